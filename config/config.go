@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
+	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/slighter12/godot-mcp-go/mcp"
 )
@@ -19,6 +23,25 @@ const (
 	maxPromptCatalogAutoReloadIntervalSeconds     = 300
 	defaultRuntimeBridgeStaleAfterSeconds         = 10
 	defaultRuntimeBridgeStaleGraceMS              = 1500
+	defaultRuntimeBridgeSnapshotHistoryLimit      = 32
+	maxRuntimeBridgeSnapshotHistoryLimit          = 512
+	defaultRuntimeBridgeScreenshotRetention       = 16
+	maxRuntimeBridgeScreenshotRetention           = 256
+	defaultRuntimeBridgeScreenshotMaxBytes        = 16 << 20
+	maxRuntimeBridgeScreenshotMaxBytes            = 128 << 20
+	defaultRuntimeBridgeLogMaxFileBytes           = 8 << 20
+	maxRuntimeBridgeLogMaxFileBytes               = 256 << 20
+	defaultRuntimeBridgeLogMaxFiles               = 5
+	maxRuntimeBridgeLogMaxFiles                   = 64
+	defaultRuntimeBridgeHangAfterSeconds          = 15
+	maxRuntimeBridgeHangAfterSeconds              = 3600
+	defaultRuntimeBridgeSessionHistoryLimit       = 50
+	maxRuntimeBridgeSessionHistoryLimit           = 1000
+	defaultAuditRetentionDays                     = 30
+	maxAuditRetentionDays                         = 3650
+	maxRateLimitMaxInFlightCommands               = 1024
+	defaultServerSSEReplayLimit                   = 256
+	maxServerSSEReplayLimit                       = 65536
 )
 
 // Config represents the MCP server configuration
@@ -27,18 +50,102 @@ type Config struct {
 	Version       string        `json:"version"`
 	Description   string        `json:"description"`
 	Server        Server        `json:"server"`
+	Auth          Auth          `json:"auth"`
 	Transports    []Transport   `json:"transports"`
 	Logging       Logging       `json:"logging"`
 	PromptCatalog PromptCatalog `json:"prompt_catalog"`
 	ToolControls  ToolControls  `json:"tool_controls"`
 	RuntimeBridge RuntimeBridge `json:"runtime_bridge"`
+	Audit         Audit         `json:"audit"`
 }
 
 // Server represents server configuration
 type Server struct {
-	Host  string `json:"host"`
-	Port  int    `json:"port"`
-	Debug bool   `json:"debug"`
+	Host       string     `json:"host"`
+	Port       int        `json:"port"`
+	Debug      bool       `json:"debug"`
+	TLS        TLS        `json:"tls"`
+	UnixSocket UnixSocket `json:"unix_socket"`
+	// SSEReplayLimit is how many SSE events per session are kept for
+	// Last-Event-ID replay; 0 disables replay.
+	SSEReplayLimit     int                `json:"sse_replay_limit"`
+	SessionPersistence SessionPersistence `json:"session_persistence"`
+}
+
+// SessionPersistence keeps Streamable HTTP session metadata in a JSON
+// snapshot so clients can keep their MCP-Session-Id across restarts.
+type SessionPersistence struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+}
+
+// TLS serves the Streamable HTTP endpoint over HTTPS.
+type TLS struct {
+	Enabled  bool   `json:"enabled"`
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// ClientCAFile enables mutual TLS: client certificates are verified
+	// against these CAs.
+	ClientCAFile string `json:"client_ca_file"`
+	// ClientAuth is "require" (default with a client CA) or "verify_if_given".
+	ClientAuth string `json:"client_auth"`
+	// MinVersion is "1.2" (default) or "1.3".
+	MinVersion string `json:"min_version"`
+}
+
+// UnixSocket adds a Unix domain socket listener next to (or instead of) TCP.
+type UnixSocket struct {
+	Enabled bool   `json:"enabled"`
+	Path    string `json:"path"`
+	// Mode is the octal file mode applied to the socket, e.g. "0600".
+	Mode string `json:"mode"`
+	// DisableTCP serves only on the socket. The editor plugin and launched
+	// games need TCP, so this is for socket-only clients.
+	DisableTCP bool `json:"disable_tcp"`
+}
+
+// Auth controls bearer token authentication on the Streamable HTTP endpoint.
+type Auth struct {
+	Enabled bool        `json:"enabled"`
+	Tokens  []AuthToken `json:"tokens"`
+	// TokensFile is a JSON file holding more tokens ({"tokens": [...]}) so
+	// secrets can live outside the main config.
+	TokensFile string `json:"tokens_file"`
+	OAuth      OAuth  `json:"oauth"`
+}
+
+// OAuth configures the OAuth 2.1 resource-server mode: JWT access tokens
+// issued by an external authorization server are accepted alongside static
+// tokens.
+type OAuth struct {
+	Enabled bool `json:"enabled"`
+	// Resource is the canonical URI of this MCP server; tokens must list it
+	// in aud. Defaults to http(s)://<host>:<port>/mcp.
+	Resource string `json:"resource"`
+	Issuer   string `json:"issuer"`
+	// AuthorizationServers is advertised in the protected resource metadata.
+	// Defaults to [issuer].
+	AuthorizationServers []string `json:"authorization_servers"`
+	// Exactly one of JWKSFile and JWKSURL locates the issuer's signing keys.
+	JWKSFile string `json:"jwks_file"`
+	JWKSURL  string `json:"jwks_url"`
+	// ReadScopes, WriteScopes, BridgeScopes and AuditScopes list the token
+	// scopes that grant the read, write, bridge and audit permissions.
+	ReadScopes       []string `json:"read_scopes"`
+	WriteScopes      []string `json:"write_scopes"`
+	BridgeScopes     []string `json:"bridge_scopes"`
+	AuditScopes      []string `json:"audit_scopes"`
+	ClockSkewSeconds int      `json:"clock_skew_seconds"`
+}
+
+// AuthToken is one accepted bearer token.
+type AuthToken struct {
+	// ID names the credential in logs and session diagnostics; it is never the secret.
+	ID     string   `json:"id"`
+	Token  string   `json:"token"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is an optional RFC 3339 timestamp after which the token is rejected.
+	ExpiresAt string `json:"expires_at,omitempty"`
 }
 
 // Transport represents a transport configuration
@@ -103,16 +210,133 @@ type ToolControls struct {
 	AllowedTools                   []string `json:"allowed_tools"`
 	EmitProgressNotifications      bool     `json:"emit_progress_notifications"`
 	AllowMutatingWithoutCapability bool     `json:"allow_mutating_without_capability"`
+	// AllowBridgeWithoutRole lets any session call godot.bridge.* tools, as
+	// before sessions were role-bound. Only meant for older plugins.
+	AllowBridgeWithoutRole bool `json:"allow_bridge_without_role"`
+	// BridgeSecretFile holds the secret editor and runtime sessions present
+	// at initialize. Empty means <project>/.godot/godot_mcp/bridge_secret.
+	BridgeSecretFile string `json:"bridge_secret_file"`
+	// PermissionProfiles are named per-session tool policies applied on top
+	// of PermissionMode.
+	PermissionProfiles map[string]PermissionProfile `json:"permission_profiles"`
+	// ProfileBindings pick a profile by client name or auth token id; the
+	// first matching binding wins.
+	ProfileBindings []PermissionProfileBinding `json:"profile_bindings"`
+	// DefaultPermissionProfile applies to sessions no binding or request
+	// selects. Empty means no profile.
+	DefaultPermissionProfile string `json:"default_permission_profile"`
+	// PathSandbox limits which project paths file-based tools may read,
+	// write or list.
+	PathSandbox PathSandbox `json:"path_sandbox"`
+	// RateLimits throttles tool calls per session and caps commands in
+	// flight per target session.
+	RateLimits RateLimits `json:"rate_limits"`
+}
+
+// RateLimits configures token buckets for tool calls. Bridge tools are never
+// throttled.
+type RateLimits struct {
+	// Session applies to every tool call of one MCP session.
+	Session RateLimit `json:"session"`
+	// Tools keys are tool names or globs such as godot.runtime.input.*; each
+	// matching tool gets its own bucket per session.
+	Tools map[string]RateLimit `json:"tools"`
+	// MaxInFlightCommands caps editor and runtime commands awaiting an ack per
+	// target session; 0 is unlimited.
+	MaxInFlightCommands int `json:"max_in_flight_commands"`
+}
+
+// RateLimit refills RatePerSecond tokens up to Burst; a zero rate disables it.
+type RateLimit struct {
+	RatePerSecond float64 `json:"rate_per_second"`
+	Burst         int     `json:"burst"`
+}
+
+// PathSandbox holds res://-relative globs; ** matches any number of
+// directories and a pattern without a slash matches a name at any depth.
+type PathSandbox struct {
+	// Include, when non-empty, lists the only paths tools may use.
+	Include []string `json:"include"`
+	// Exclude wins over Include, e.g. addons/** or *.import.
+	Exclude []string `json:"exclude"`
+}
+
+// PermissionProfile restricts which tools a session may call. Tool patterns
+// are globs such as godot.node.* matched against the tool name.
+type PermissionProfile struct {
+	// Classes limits tools to read_only and/or mutating; empty allows both.
+	Classes []string `json:"classes"`
+	// Allow, when non-empty, lists the only tools the profile may call.
+	Allow []string `json:"allow"`
+	// Deny wins over Allow.
+	Deny                []string                       `json:"deny"`
+	ArgumentConstraints []PermissionArgumentConstraint `json:"argument_constraints"`
+	// Selectable lets a client request the profile with
+	// initialize.params.capabilities.godot.permission_profile.
+	Selectable bool `json:"selectable"`
+}
+
+// PermissionArgumentConstraint limits one argument of the matching tools to
+// path prefixes (e.g. res://scenes/) and/or fixed values.
+type PermissionArgumentConstraint struct {
+	Tool         string   `json:"tool"`
+	Argument     string   `json:"argument"`
+	PathPrefixes []string `json:"path_prefixes"`
+	Values       []string `json:"values"`
+}
+
+// PermissionProfileBinding selects Profile for sessions whose client name and
+// auth token id match the globs; an empty glob matches anything.
+type PermissionProfileBinding struct {
+	Profile     string `json:"profile"`
+	ClientName  string `json:"client_name"`
+	AuthTokenID string `json:"auth_token_id"`
 }
 
 // RuntimeBridge controls stale detection and grace windows for synced snapshots.
 type RuntimeBridge struct {
 	StaleAfterSeconds int `json:"stale_after_seconds"`
 	StaleGraceMS      int `json:"stale_grace_ms"`
+	// SnapshotHistoryLimit bounds retained runtime snapshots per game session for scene tree diffs.
+	SnapshotHistoryLimit int `json:"snapshot_history_limit"`
+	// ScreenshotRetention bounds retained runtime screenshots per game session.
+	ScreenshotRetention int `json:"screenshot_retention"`
+	// ScreenshotMaxBytes rejects runtime screenshot PNGs larger than this size.
+	ScreenshotMaxBytes int `json:"screenshot_max_bytes"`
+	// ScreenshotBaselineDir stores named baselines for godot.runtime.screenshot.compare.
+	ScreenshotBaselineDir string `json:"screenshot_baseline_dir"`
+	// GodotExecutable is the Godot binary used by headless project runs; empty
+	// means godot or godot4 from PATH.
+	GodotExecutable string `json:"godot_executable"`
+	// LogPersistenceEnabled mirrors runtime log entries to JSONL files under
+	// LogDir so they survive session cleanup and can be searched later.
+	LogPersistenceEnabled bool `json:"log_persistence_enabled"`
+	// LogDir holds one rotated JSONL log per game session.
+	LogDir string `json:"log_dir"`
+	// LogMaxFileBytes rotates a session log file once it grows past this size.
+	LogMaxFileBytes int `json:"log_max_file_bytes"`
+	// LogMaxFiles bounds the files kept per game session, including the active one.
+	LogMaxFiles int `json:"log_max_files"`
+	// HangAfterSeconds marks a game session hung once its runtime snapshots
+	// keep arriving without the frame counter advancing for this long.
+	HangAfterSeconds int `json:"hang_after_seconds"`
+	// SessionHistoryLimit bounds finished and running game sessions kept for
+	// godot.runtime.session.list.
+	SessionHistoryLimit int `json:"session_history_limit"`
 	// Deprecated: public runtime tools no longer borrow the latest session implicitly.
 	AllowLatestSessionFallback bool `json:"allow_latest_session_fallback"`
 }
 
+// Audit records mutating tool calls and bridge command acknowledgements to a
+// hash-chained JSONL log.
+type Audit struct {
+	Enabled bool `json:"enabled"`
+	// Dir holds one audit file per UTC day.
+	Dir string `json:"dir"`
+	// RetentionDays deletes audit files older than this many days.
+	RetentionDays int `json:"retention_days"`
+}
+
 // NewConfig creates a new Config with default values
 func NewConfig() *Config {
 	home, err := os.UserHomeDir()
@@ -127,6 +351,20 @@ func NewConfig() *Config {
 			Host:  "localhost",
 			Port:  9080,
 			Debug: false,
+			TLS: TLS{
+				MinVersion: "1.2",
+			},
+			UnixSocket: UnixSocket{
+				Mode: "0600",
+			},
+			SSEReplayLimit: defaultServerSSEReplayLimit,
+			SessionPersistence: SessionPersistence{
+				Path: filepath.Join(home, ".godot-mcp", "sessions.json"),
+			},
+		},
+		Auth: Auth{
+			Enabled: false,
+			Tokens:  []AuthToken{},
 		},
 		Transports: []Transport{
 			{
@@ -175,12 +413,31 @@ func NewConfig() *Config {
 			AllowedTools:                   []string{},
 			EmitProgressNotifications:      true,
 			AllowMutatingWithoutCapability: false,
+			AllowBridgeWithoutRole:         false,
+			RateLimits: RateLimits{
+				Tools: map[string]RateLimit{},
+			},
 		},
 		RuntimeBridge: RuntimeBridge{
 			StaleAfterSeconds:          defaultRuntimeBridgeStaleAfterSeconds,
 			StaleGraceMS:               defaultRuntimeBridgeStaleGraceMS,
+			SnapshotHistoryLimit:       defaultRuntimeBridgeSnapshotHistoryLimit,
+			ScreenshotRetention:        defaultRuntimeBridgeScreenshotRetention,
+			ScreenshotMaxBytes:         defaultRuntimeBridgeScreenshotMaxBytes,
+			ScreenshotBaselineDir:      filepath.Join(home, ".godot-mcp", "screenshot-baselines"),
+			LogPersistenceEnabled:      false,
+			LogDir:                     filepath.Join(home, ".godot-mcp", "runtime-logs"),
+			LogMaxFileBytes:            defaultRuntimeBridgeLogMaxFileBytes,
+			LogMaxFiles:                defaultRuntimeBridgeLogMaxFiles,
+			HangAfterSeconds:           defaultRuntimeBridgeHangAfterSeconds,
+			SessionHistoryLimit:        defaultRuntimeBridgeSessionHistoryLimit,
 			AllowLatestSessionFallback: false,
 		},
+		Audit: Audit{
+			Enabled:       false,
+			Dir:           filepath.Join(home, ".godot-mcp", "audit"),
+			RetentionDays: defaultAuditRetentionDays,
+		},
 	}
 }
 
@@ -250,6 +507,44 @@ func applyEnvOverrides(cfg *Config) {
 	}
 
 	applyEnvBoolOverride("MCP_DEBUG", &cfg.Server.Debug)
+	applyEnvIntOverride("MCP_SSE_REPLAY_LIMIT", &cfg.Server.SSEReplayLimit)
+	applyEnvBoolOverride("MCP_SESSION_PERSISTENCE_ENABLED", &cfg.Server.SessionPersistence.Enabled)
+	if sessionsPath := os.Getenv("MCP_SESSION_PERSISTENCE_PATH"); sessionsPath != "" {
+		cfg.Server.SessionPersistence.Path = sessionsPath
+	}
+
+	applyEnvBoolOverride("MCP_TLS_ENABLED", &cfg.Server.TLS.Enabled)
+	if certFile := os.Getenv("MCP_TLS_CERT_FILE"); certFile != "" {
+		cfg.Server.TLS.CertFile = certFile
+	}
+	if keyFile := os.Getenv("MCP_TLS_KEY_FILE"); keyFile != "" {
+		cfg.Server.TLS.KeyFile = keyFile
+	}
+	if clientCAFile := os.Getenv("MCP_TLS_CLIENT_CA_FILE"); clientCAFile != "" {
+		cfg.Server.TLS.ClientCAFile = clientCAFile
+	}
+	applyEnvBoolOverride("MCP_UNIX_SOCKET_ENABLED", &cfg.Server.UnixSocket.Enabled)
+	if socketPath := os.Getenv("MCP_UNIX_SOCKET_PATH"); socketPath != "" {
+		cfg.Server.UnixSocket.Path = socketPath
+	}
+
+	applyEnvBoolOverride("MCP_AUTH_ENABLED", &cfg.Auth.Enabled)
+	if tokensFile := os.Getenv("MCP_AUTH_TOKENS_FILE"); tokensFile != "" {
+		cfg.Auth.TokensFile = tokensFile
+	}
+	applyEnvBoolOverride("MCP_AUTH_OAUTH_ENABLED", &cfg.Auth.OAuth.Enabled)
+	if issuer := os.Getenv("MCP_AUTH_OAUTH_ISSUER"); issuer != "" {
+		cfg.Auth.OAuth.Issuer = issuer
+	}
+	if resource := os.Getenv("MCP_AUTH_OAUTH_RESOURCE"); resource != "" {
+		cfg.Auth.OAuth.Resource = resource
+	}
+	if jwksFile := os.Getenv("MCP_AUTH_OAUTH_JWKS_FILE"); jwksFile != "" {
+		cfg.Auth.OAuth.JWKSFile = jwksFile
+	}
+	if jwksURL := os.Getenv("MCP_AUTH_OAUTH_JWKS_URL"); jwksURL != "" {
+		cfg.Auth.OAuth.JWKSURL = jwksURL
+	}
 
 	if logLevel := os.Getenv("MCP_LOG_LEVEL"); logLevel != "" {
 		cfg.Logging.Level = logLevel
@@ -289,6 +584,10 @@ func applyEnvOverrides(cfg *Config) {
 	applyEnvBoolOverride("MCP_TOOL_CONTROLS_REJECT_UNKNOWN_ARGUMENTS", &cfg.ToolControls.RejectUnknownArguments)
 	applyEnvBoolOverride("MCP_TOOL_CONTROLS_EMIT_PROGRESS_NOTIFICATIONS", &cfg.ToolControls.EmitProgressNotifications)
 	applyEnvBoolOverride("MCP_TOOL_CONTROLS_ALLOW_MUTATING_WITHOUT_CAPABILITY", &cfg.ToolControls.AllowMutatingWithoutCapability)
+	applyEnvBoolOverride("MCP_TOOL_CONTROLS_ALLOW_BRIDGE_WITHOUT_ROLE", &cfg.ToolControls.AllowBridgeWithoutRole)
+	if bridgeSecretFile := os.Getenv("MCP_TOOL_CONTROLS_BRIDGE_SECRET_FILE"); bridgeSecretFile != "" {
+		cfg.ToolControls.BridgeSecretFile = bridgeSecretFile
+	}
 
 	if permissionMode := os.Getenv("MCP_TOOL_CONTROLS_PERMISSION_MODE"); permissionMode != "" {
 		cfg.ToolControls.PermissionMode = permissionMode
@@ -296,10 +595,45 @@ func applyEnvOverrides(cfg *Config) {
 	if allowedTools := os.Getenv("MCP_TOOL_CONTROLS_ALLOWED_TOOLS"); allowedTools != "" {
 		cfg.ToolControls.AllowedTools = parseCSV(allowedTools)
 	}
+	if defaultProfile := os.Getenv("MCP_TOOL_CONTROLS_DEFAULT_PERMISSION_PROFILE"); defaultProfile != "" {
+		cfg.ToolControls.DefaultPermissionProfile = defaultProfile
+	}
+	if include := os.Getenv("MCP_TOOL_CONTROLS_PATH_SANDBOX_INCLUDE"); include != "" {
+		cfg.ToolControls.PathSandbox.Include = parseCSV(include)
+	}
+	if exclude := os.Getenv("MCP_TOOL_CONTROLS_PATH_SANDBOX_EXCLUDE"); exclude != "" {
+		cfg.ToolControls.PathSandbox.Exclude = parseCSV(exclude)
+	}
+	applyEnvFloatOverride("MCP_TOOL_CONTROLS_RATE_LIMIT_SESSION_RATE_PER_SECOND", &cfg.ToolControls.RateLimits.Session.RatePerSecond)
+	applyEnvIntOverride("MCP_TOOL_CONTROLS_RATE_LIMIT_SESSION_BURST", &cfg.ToolControls.RateLimits.Session.Burst)
+	applyEnvIntOverride("MCP_TOOL_CONTROLS_RATE_LIMIT_MAX_IN_FLIGHT_COMMANDS", &cfg.ToolControls.RateLimits.MaxInFlightCommands)
 
 	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_STALE_AFTER_SECONDS", &cfg.RuntimeBridge.StaleAfterSeconds)
 	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_STALE_GRACE_MS", &cfg.RuntimeBridge.StaleGraceMS)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_SNAPSHOT_HISTORY_LIMIT", &cfg.RuntimeBridge.SnapshotHistoryLimit)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_SCREENSHOT_RETENTION", &cfg.RuntimeBridge.ScreenshotRetention)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_SCREENSHOT_MAX_BYTES", &cfg.RuntimeBridge.ScreenshotMaxBytes)
+	if baselineDir := os.Getenv("MCP_RUNTIME_BRIDGE_SCREENSHOT_BASELINE_DIR"); baselineDir != "" {
+		cfg.RuntimeBridge.ScreenshotBaselineDir = baselineDir
+	}
+	if executable := os.Getenv("MCP_RUNTIME_BRIDGE_GODOT_EXECUTABLE"); executable != "" {
+		cfg.RuntimeBridge.GodotExecutable = executable
+	}
+	applyEnvBoolOverride("MCP_RUNTIME_BRIDGE_LOG_PERSISTENCE_ENABLED", &cfg.RuntimeBridge.LogPersistenceEnabled)
+	if logDir := os.Getenv("MCP_RUNTIME_BRIDGE_LOG_DIR"); logDir != "" {
+		cfg.RuntimeBridge.LogDir = logDir
+	}
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_LOG_MAX_FILE_BYTES", &cfg.RuntimeBridge.LogMaxFileBytes)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_LOG_MAX_FILES", &cfg.RuntimeBridge.LogMaxFiles)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_HANG_AFTER_SECONDS", &cfg.RuntimeBridge.HangAfterSeconds)
+	applyEnvIntOverride("MCP_RUNTIME_BRIDGE_SESSION_HISTORY_LIMIT", &cfg.RuntimeBridge.SessionHistoryLimit)
 	applyEnvBoolOverride("MCP_RUNTIME_BRIDGE_ALLOW_LATEST_SESSION_FALLBACK", &cfg.RuntimeBridge.AllowLatestSessionFallback)
+
+	applyEnvBoolOverride("MCP_AUDIT_ENABLED", &cfg.Audit.Enabled)
+	if auditDir := os.Getenv("MCP_AUDIT_DIR"); auditDir != "" {
+		cfg.Audit.Dir = auditDir
+	}
+	applyEnvIntOverride("MCP_AUDIT_RETENTION_DAYS", &cfg.Audit.RetentionDays)
 }
 
 func applyEnvBoolOverride(name string, target *bool) {
@@ -334,10 +668,49 @@ func applyEnvIntOverride(name string, target *int) {
 	*target = parsed
 }
 
+func applyEnvFloatOverride(name string, target *float64) {
+	if target == nil {
+		return
+	}
+	raw := os.Getenv(name)
+	if raw == "" {
+		return
+	}
+	parsed, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		log.Printf("warning: ignoring invalid %s value %q: %v", name, raw, err)
+		return
+	}
+	*target = parsed
+}
+
 // Normalize canonicalizes config values so downstream validation and runtime
 // logic operate on stable representations.
 func (c *Config) Normalize() {
 	c.Server.Host = strings.TrimSpace(c.Server.Host)
+	c.Server.TLS.CertFile = strings.TrimSpace(c.Server.TLS.CertFile)
+	c.Server.TLS.KeyFile = strings.TrimSpace(c.Server.TLS.KeyFile)
+	c.Server.TLS.ClientCAFile = strings.TrimSpace(c.Server.TLS.ClientCAFile)
+	c.Server.TLS.ClientAuth = strings.ToLower(strings.TrimSpace(c.Server.TLS.ClientAuth))
+	if c.Server.TLS.ClientAuth == "" && c.Server.TLS.ClientCAFile != "" {
+		c.Server.TLS.ClientAuth = "require"
+	}
+	c.Server.TLS.MinVersion = strings.TrimSpace(c.Server.TLS.MinVersion)
+	if c.Server.TLS.MinVersion == "" {
+		c.Server.TLS.MinVersion = "1.2"
+	}
+	c.Server.UnixSocket.Path = strings.TrimSpace(c.Server.UnixSocket.Path)
+	c.Server.UnixSocket.Mode = strings.TrimSpace(c.Server.UnixSocket.Mode)
+	if c.Server.UnixSocket.Mode == "" {
+		c.Server.UnixSocket.Mode = "0600"
+	}
+	c.Server.SessionPersistence.Path = strings.TrimSpace(c.Server.SessionPersistence.Path)
+	if c.Server.SessionPersistence.Path == "" {
+		c.Server.SessionPersistence.Path = NewConfig().Server.SessionPersistence.Path
+	}
+	c.Auth.TokensFile = strings.TrimSpace(c.Auth.TokensFile)
+	c.Auth.Tokens = NormalizeAuthTokens(c.Auth.Tokens)
+	c.Auth.OAuth.normalize(c.Server)
 	c.Logging.Level = strings.ToLower(strings.TrimSpace(c.Logging.Level))
 	c.Logging.Format = strings.ToLower(strings.TrimSpace(c.Logging.Format))
 	c.Logging.Path = strings.TrimSpace(c.Logging.Path)
@@ -360,6 +733,11 @@ func (c *Config) Normalize() {
 		c.ToolControls.PermissionMode = "allow_all"
 	}
 	c.ToolControls.AllowedTools = normalizeStringList(c.ToolControls.AllowedTools)
+	c.ToolControls.BridgeSecretFile = strings.TrimSpace(c.ToolControls.BridgeSecretFile)
+	c.ToolControls.normalizePermissionProfiles()
+	c.ToolControls.PathSandbox.Include = normalizeStringList(c.ToolControls.PathSandbox.Include)
+	c.ToolControls.PathSandbox.Exclude = normalizeStringList(c.ToolControls.PathSandbox.Exclude)
+	c.ToolControls.RateLimits.normalize()
 	for i := range c.Transports {
 		c.Transports[i].Type = strings.ToLower(strings.TrimSpace(c.Transports[i].Type))
 		c.Transports[i].URL = strings.TrimSpace(c.Transports[i].URL)
@@ -370,6 +748,43 @@ func (c *Config) Normalize() {
 	if c.RuntimeBridge.StaleGraceMS < 0 {
 		c.RuntimeBridge.StaleGraceMS = 0
 	}
+	if c.RuntimeBridge.SnapshotHistoryLimit == 0 {
+		c.RuntimeBridge.SnapshotHistoryLimit = defaultRuntimeBridgeSnapshotHistoryLimit
+	}
+	if c.RuntimeBridge.ScreenshotRetention == 0 {
+		c.RuntimeBridge.ScreenshotRetention = defaultRuntimeBridgeScreenshotRetention
+	}
+	if c.RuntimeBridge.ScreenshotMaxBytes == 0 {
+		c.RuntimeBridge.ScreenshotMaxBytes = defaultRuntimeBridgeScreenshotMaxBytes
+	}
+	c.RuntimeBridge.ScreenshotBaselineDir = strings.TrimSpace(c.RuntimeBridge.ScreenshotBaselineDir)
+	if c.RuntimeBridge.ScreenshotBaselineDir == "" {
+		c.RuntimeBridge.ScreenshotBaselineDir = NewConfig().RuntimeBridge.ScreenshotBaselineDir
+	}
+	c.RuntimeBridge.GodotExecutable = strings.TrimSpace(c.RuntimeBridge.GodotExecutable)
+	c.RuntimeBridge.LogDir = strings.TrimSpace(c.RuntimeBridge.LogDir)
+	if c.RuntimeBridge.LogDir == "" {
+		c.RuntimeBridge.LogDir = NewConfig().RuntimeBridge.LogDir
+	}
+	if c.RuntimeBridge.LogMaxFileBytes == 0 {
+		c.RuntimeBridge.LogMaxFileBytes = defaultRuntimeBridgeLogMaxFileBytes
+	}
+	if c.RuntimeBridge.LogMaxFiles == 0 {
+		c.RuntimeBridge.LogMaxFiles = defaultRuntimeBridgeLogMaxFiles
+	}
+	if c.RuntimeBridge.HangAfterSeconds == 0 {
+		c.RuntimeBridge.HangAfterSeconds = defaultRuntimeBridgeHangAfterSeconds
+	}
+	if c.RuntimeBridge.SessionHistoryLimit == 0 {
+		c.RuntimeBridge.SessionHistoryLimit = defaultRuntimeBridgeSessionHistoryLimit
+	}
+	c.Audit.Dir = strings.TrimSpace(c.Audit.Dir)
+	if c.Audit.Dir == "" {
+		c.Audit.Dir = NewConfig().Audit.Dir
+	}
+	if c.Audit.RetentionDays == 0 {
+		c.Audit.RetentionDays = defaultAuditRetentionDays
+	}
 }
 
 // Validate checks if the configuration is valid
@@ -382,6 +797,25 @@ func (c *Config) Validate() error {
 	if c.Server.Host == "" {
 		return errors.New("host cannot be empty")
 	}
+	if err := c.Server.TLS.validate(); err != nil {
+		return err
+	}
+	if err := c.Server.UnixSocket.validate(); err != nil {
+		return err
+	}
+	if c.Server.SSEReplayLimit < 0 || c.Server.SSEReplayLimit > maxServerSSEReplayLimit {
+		return fmt.Errorf("invalid server.sse_replay_limit: %d (expected range 0..%d)", c.Server.SSEReplayLimit, maxServerSSEReplayLimit)
+	}
+
+	if err := ValidateAuthTokens(c.Auth.Tokens); err != nil {
+		return err
+	}
+	if c.Auth.Enabled && len(c.Auth.Tokens) == 0 && c.Auth.TokensFile == "" && !c.Auth.OAuth.Enabled {
+		return errors.New("auth is enabled but no tokens, tokens_file or oauth are configured")
+	}
+	if err := c.Auth.OAuth.validate(); err != nil {
+		return err
+	}
 
 	// Validate logging configuration
 	validLogLevels := map[string]bool{
@@ -476,6 +910,15 @@ func (c *Config) Validate() error {
 	if !validPermissionModes[c.ToolControls.PermissionMode] {
 		return fmt.Errorf("invalid tool controls permission mode: %q (expected one of [allow_all read_only allow_list])", c.ToolControls.PermissionMode)
 	}
+	if err := c.ToolControls.validatePermissionProfiles(); err != nil {
+		return err
+	}
+	if err := c.ToolControls.PathSandbox.validate(); err != nil {
+		return err
+	}
+	if err := c.ToolControls.RateLimits.validate(); err != nil {
+		return err
+	}
 
 	if c.RuntimeBridge.StaleAfterSeconds <= 0 {
 		return fmt.Errorf("invalid runtime bridge stale_after_seconds: %d (must be > 0)", c.RuntimeBridge.StaleAfterSeconds)
@@ -483,6 +926,62 @@ func (c *Config) Validate() error {
 	if c.RuntimeBridge.StaleGraceMS < 0 {
 		return fmt.Errorf("invalid runtime bridge stale_grace_ms: %d (must be >= 0)", c.RuntimeBridge.StaleGraceMS)
 	}
+	if c.RuntimeBridge.SnapshotHistoryLimit < 1 || c.RuntimeBridge.SnapshotHistoryLimit > maxRuntimeBridgeSnapshotHistoryLimit {
+		return fmt.Errorf(
+			"invalid runtime bridge snapshot_history_limit: %d (expected range 1..%d)",
+			c.RuntimeBridge.SnapshotHistoryLimit,
+			maxRuntimeBridgeSnapshotHistoryLimit,
+		)
+	}
+	if c.RuntimeBridge.ScreenshotRetention < 1 || c.RuntimeBridge.ScreenshotRetention > maxRuntimeBridgeScreenshotRetention {
+		return fmt.Errorf(
+			"invalid runtime bridge screenshot_retention: %d (expected range 1..%d)",
+			c.RuntimeBridge.ScreenshotRetention,
+			maxRuntimeBridgeScreenshotRetention,
+		)
+	}
+	if c.RuntimeBridge.ScreenshotMaxBytes < 1 || c.RuntimeBridge.ScreenshotMaxBytes > maxRuntimeBridgeScreenshotMaxBytes {
+		return fmt.Errorf(
+			"invalid runtime bridge screenshot_max_bytes: %d (expected range 1..%d)",
+			c.RuntimeBridge.ScreenshotMaxBytes,
+			maxRuntimeBridgeScreenshotMaxBytes,
+		)
+	}
+	if c.RuntimeBridge.LogMaxFileBytes < 1 || c.RuntimeBridge.LogMaxFileBytes > maxRuntimeBridgeLogMaxFileBytes {
+		return fmt.Errorf(
+			"invalid runtime bridge log_max_file_bytes: %d (expected range 1..%d)",
+			c.RuntimeBridge.LogMaxFileBytes,
+			maxRuntimeBridgeLogMaxFileBytes,
+		)
+	}
+	if c.RuntimeBridge.LogMaxFiles < 1 || c.RuntimeBridge.LogMaxFiles > maxRuntimeBridgeLogMaxFiles {
+		return fmt.Errorf(
+			"invalid runtime bridge log_max_files: %d (expected range 1..%d)",
+			c.RuntimeBridge.LogMaxFiles,
+			maxRuntimeBridgeLogMaxFiles,
+		)
+	}
+	if c.RuntimeBridge.HangAfterSeconds < 1 || c.RuntimeBridge.HangAfterSeconds > maxRuntimeBridgeHangAfterSeconds {
+		return fmt.Errorf(
+			"invalid runtime bridge hang_after_seconds: %d (expected range 1..%d)",
+			c.RuntimeBridge.HangAfterSeconds,
+			maxRuntimeBridgeHangAfterSeconds,
+		)
+	}
+	if c.RuntimeBridge.SessionHistoryLimit < 1 || c.RuntimeBridge.SessionHistoryLimit > maxRuntimeBridgeSessionHistoryLimit {
+		return fmt.Errorf(
+			"invalid runtime bridge session_history_limit: %d (expected range 1..%d)",
+			c.RuntimeBridge.SessionHistoryLimit,
+			maxRuntimeBridgeSessionHistoryLimit,
+		)
+	}
+	if c.Audit.RetentionDays < 1 || c.Audit.RetentionDays > maxAuditRetentionDays {
+		return fmt.Errorf(
+			"invalid audit retention_days: %d (expected range 1..%d)",
+			c.Audit.RetentionDays,
+			maxAuditRetentionDays,
+		)
+	}
 
 	return nil
 }
@@ -545,6 +1044,310 @@ func GetConfigPath() (string, error) {
 	return ResolveConfigPath()
 }
 
+// NormalizeAuthTokens trims token fields and canonicalizes scopes. Tokens
+// without scopes get every scope.
+func NormalizeAuthTokens(tokens []AuthToken) []AuthToken {
+	out := make([]AuthToken, 0, len(tokens))
+	for _, token := range tokens {
+		scopes := make([]string, 0, len(token.Scopes))
+		for _, scope := range token.Scopes {
+			scopes = append(scopes, strings.ToLower(strings.TrimSpace(scope)))
+		}
+		scopes = normalizeStringList(scopes)
+		if len(scopes) == 0 {
+			scopes = []string{"*"}
+		}
+		out = append(out, AuthToken{
+			ID:        strings.TrimSpace(token.ID),
+			Token:     strings.TrimSpace(token.Token),
+			Scopes:    scopes,
+			ExpiresAt: strings.TrimSpace(token.ExpiresAt),
+		})
+	}
+	return out
+}
+
+// ValidateAuthTokens checks normalized tokens from the config or a tokens file.
+func ValidateAuthTokens(tokens []AuthToken) error {
+	validScopes := map[string]bool{
+		"*":      true,
+		"read":   true,
+		"write":  true,
+		"bridge": true,
+		"audit":  true,
+	}
+	ids := make(map[string]struct{}, len(tokens))
+	secrets := make(map[string]struct{}, len(tokens))
+	for i, token := range tokens {
+		if token.ID == "" {
+			return fmt.Errorf("auth token %d: id cannot be empty", i+1)
+		}
+		if _, exists := ids[token.ID]; exists {
+			return fmt.Errorf("auth token %q: duplicate id", token.ID)
+		}
+		ids[token.ID] = struct{}{}
+		if token.Token == "" {
+			return fmt.Errorf("auth token %q: token cannot be empty", token.ID)
+		}
+		if _, exists := secrets[token.Token]; exists {
+			return fmt.Errorf("auth token %q: token value is shared with another id", token.ID)
+		}
+		secrets[token.Token] = struct{}{}
+		for _, scope := range token.Scopes {
+			if !validScopes[scope] {
+				return fmt.Errorf("auth token %q: invalid scope %q (expected one of [* read write bridge audit])", token.ID, scope)
+			}
+		}
+		if token.ExpiresAt != "" {
+			if _, err := time.Parse(time.RFC3339, token.ExpiresAt); err != nil {
+				return fmt.Errorf("auth token %q: invalid expires_at %q (expected RFC 3339)", token.ID, token.ExpiresAt)
+			}
+		}
+	}
+	return nil
+}
+
+func (t TLS) validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.CertFile == "" || t.KeyFile == "" {
+		return errors.New("server.tls requires cert_file and key_file when enabled")
+	}
+	switch t.ClientAuth {
+	case "", "require", "verify_if_given":
+	default:
+		return fmt.Errorf("invalid server.tls.client_auth: %q (expected one of [require verify_if_given])", t.ClientAuth)
+	}
+	if t.ClientAuth != "" && t.ClientCAFile == "" {
+		return errors.New("server.tls.client_auth requires client_ca_file")
+	}
+	switch t.MinVersion {
+	case "1.2", "1.3":
+	default:
+		return fmt.Errorf("invalid server.tls.min_version: %q (expected one of [1.2 1.3])", t.MinVersion)
+	}
+	return nil
+}
+
+// FileMode parses Mode as an octal permission.
+func (u UnixSocket) FileMode() (os.FileMode, error) {
+	mode, err := strconv.ParseUint(u.Mode, 8, 32)
+	if err != nil || mode > 0o777 {
+		return 0, fmt.Errorf("invalid server.unix_socket.mode: %q (expected octal permissions like 0600)", u.Mode)
+	}
+	return os.FileMode(mode), nil
+}
+
+func (u UnixSocket) validate() error {
+	if !u.Enabled {
+		if u.DisableTCP {
+			return errors.New("server.unix_socket.disable_tcp requires the unix socket to be enabled")
+		}
+		return nil
+	}
+	if u.Path == "" {
+		return errors.New("server.unix_socket.path cannot be empty when the unix socket is enabled")
+	}
+	_, err := u.FileMode()
+	return err
+}
+
+func (t *ToolControls) normalizePermissionProfiles() {
+	for name, profile := range t.PermissionProfiles {
+		profile.Classes = normalizeStringList(profile.Classes)
+		for i := range profile.Classes {
+			profile.Classes[i] = strings.ToLower(profile.Classes[i])
+		}
+		profile.Allow = normalizeStringList(profile.Allow)
+		profile.Deny = normalizeStringList(profile.Deny)
+		for i := range profile.ArgumentConstraints {
+			constraint := &profile.ArgumentConstraints[i]
+			constraint.Tool = strings.TrimSpace(constraint.Tool)
+			constraint.Argument = strings.TrimSpace(constraint.Argument)
+			constraint.PathPrefixes = normalizeStringList(constraint.PathPrefixes)
+		}
+		t.PermissionProfiles[name] = profile
+	}
+	for i := range t.ProfileBindings {
+		binding := &t.ProfileBindings[i]
+		binding.Profile = strings.TrimSpace(binding.Profile)
+		binding.ClientName = strings.TrimSpace(binding.ClientName)
+		binding.AuthTokenID = strings.TrimSpace(binding.AuthTokenID)
+	}
+	t.DefaultPermissionProfile = strings.TrimSpace(t.DefaultPermissionProfile)
+}
+
+func (t ToolControls) validatePermissionProfiles() error {
+	validGlob := func(pattern string) bool {
+		_, err := path.Match(pattern, "")
+		return err == nil
+	}
+	for name, profile := range t.PermissionProfiles {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("tool_controls.permission_profiles: profile name cannot be empty")
+		}
+		for _, class := range profile.Classes {
+			if class != "read_only" && class != "mutating" {
+				return fmt.Errorf("permission profile %q: invalid class %q (expected one of [read_only mutating])", name, class)
+			}
+		}
+		for _, pattern := range append(slices.Clone(profile.Allow), profile.Deny...) {
+			if !validGlob(pattern) {
+				return fmt.Errorf("permission profile %q: invalid tool pattern %q", name, pattern)
+			}
+		}
+		for _, constraint := range profile.ArgumentConstraints {
+			if constraint.Tool == "" || constraint.Argument == "" {
+				return fmt.Errorf("permission profile %q: argument constraints require tool and argument", name)
+			}
+			if !validGlob(constraint.Tool) {
+				return fmt.Errorf("permission profile %q: invalid tool pattern %q", name, constraint.Tool)
+			}
+			if len(constraint.PathPrefixes) == 0 && len(constraint.Values) == 0 {
+				return fmt.Errorf("permission profile %q: constraint on %s.%s needs path_prefixes or values", name, constraint.Tool, constraint.Argument)
+			}
+		}
+	}
+	for _, binding := range t.ProfileBindings {
+		if _, ok := t.PermissionProfiles[binding.Profile]; !ok {
+			return fmt.Errorf("tool_controls.profile_bindings: unknown permission profile %q", binding.Profile)
+		}
+		if !validGlob(binding.ClientName) || !validGlob(binding.AuthTokenID) {
+			return fmt.Errorf("tool_controls.profile_bindings: invalid glob for profile %q", binding.Profile)
+		}
+	}
+	if t.DefaultPermissionProfile != "" {
+		if _, ok := t.PermissionProfiles[t.DefaultPermissionProfile]; !ok {
+			return fmt.Errorf("tool_controls.default_permission_profile: unknown permission profile %q", t.DefaultPermissionProfile)
+		}
+	}
+	return nil
+}
+
+func (p PathSandbox) validate() error {
+	for _, pattern := range append(slices.Clone(p.Include), p.Exclude...) {
+		if filepath.IsAbs(pattern) || strings.HasPrefix(pattern, "res://") {
+			return fmt.Errorf("invalid tool_controls.path_sandbox pattern %q (expected a path relative to res://)", pattern)
+		}
+		for segment := range strings.SplitSeq(strings.Trim(pattern, "/"), "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return fmt.Errorf("invalid tool_controls.path_sandbox pattern %q: %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// normalize trims tool patterns and gives a rate without a burst room for
+// one second of calls.
+func (r *RateLimits) normalize() {
+	r.Session.normalize()
+	tools := make(map[string]RateLimit, len(r.Tools))
+	for pattern, limit := range r.Tools {
+		limit.normalize()
+		tools[strings.TrimSpace(pattern)] = limit
+	}
+	r.Tools = tools
+}
+
+func (l *RateLimit) normalize() {
+	if l.RatePerSecond > 0 && l.Burst == 0 {
+		l.Burst = max(1, int(math.Ceil(l.RatePerSecond)))
+	}
+}
+
+func (r RateLimits) validate() error {
+	if err := r.Session.validate("session"); err != nil {
+		return err
+	}
+	for pattern, limit := range r.Tools {
+		if pattern == "" {
+			return errors.New("invalid tool_controls.rate_limits.tools entry: tool pattern cannot be empty")
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid tool_controls.rate_limits.tools pattern %q: %w", pattern, err)
+		}
+		if err := limit.validate("tools." + pattern); err != nil {
+			return err
+		}
+	}
+	if r.MaxInFlightCommands < 0 || r.MaxInFlightCommands > maxRateLimitMaxInFlightCommands {
+		return fmt.Errorf(
+			"invalid tool_controls.rate_limits max_in_flight_commands: %d (expected range 0..%d)",
+			r.MaxInFlightCommands,
+			maxRateLimitMaxInFlightCommands,
+		)
+	}
+	return nil
+}
+
+func (l RateLimit) validate(name string) error {
+	if l.RatePerSecond < 0 || math.IsNaN(l.RatePerSecond) || math.IsInf(l.RatePerSecond, 0) {
+		return fmt.Errorf("invalid tool_controls.rate_limits.%s rate_per_second: %v (must be >= 0)", name, l.RatePerSecond)
+	}
+	if l.Burst < 0 {
+		return fmt.Errorf("invalid tool_controls.rate_limits.%s burst: %d (must be >= 0)", name, l.Burst)
+	}
+	return nil
+}
+
+func (o *OAuth) normalize(server Server) {
+	o.Resource = strings.TrimSpace(o.Resource)
+	o.Issuer = strings.TrimSpace(o.Issuer)
+	o.JWKSFile = strings.TrimSpace(o.JWKSFile)
+	o.JWKSURL = strings.TrimSpace(o.JWKSURL)
+	o.AuthorizationServers = normalizeStringList(o.AuthorizationServers)
+	o.ReadScopes = normalizeStringList(o.ReadScopes)
+	o.WriteScopes = normalizeStringList(o.WriteScopes)
+	o.BridgeScopes = normalizeStringList(o.BridgeScopes)
+	o.AuditScopes = normalizeStringList(o.AuditScopes)
+	if !o.Enabled {
+		return
+	}
+	if o.Resource == "" {
+		scheme := "http"
+		if server.TLS.Enabled {
+			scheme = "https"
+		}
+		o.Resource = fmt.Sprintf("%s://%s:%d/mcp", scheme, server.Host, server.Port)
+	}
+	if len(o.AuthorizationServers) == 0 && o.Issuer != "" {
+		o.AuthorizationServers = []string{o.Issuer}
+	}
+	if len(o.ReadScopes) == 0 {
+		o.ReadScopes = []string{"mcp:read"}
+	}
+	if len(o.WriteScopes) == 0 {
+		o.WriteScopes = []string{"mcp:write"}
+	}
+	if len(o.BridgeScopes) == 0 {
+		o.BridgeScopes = []string{"mcp:bridge"}
+	}
+	if len(o.AuditScopes) == 0 {
+		o.AuditScopes = []string{"mcp:audit"}
+	}
+	if o.ClockSkewSeconds == 0 {
+		o.ClockSkewSeconds = 60
+	}
+}
+
+func (o OAuth) validate() error {
+	if !o.Enabled {
+		return nil
+	}
+	if o.Issuer == "" {
+		return errors.New("auth.oauth.issuer cannot be empty when oauth is enabled")
+	}
+	if (o.JWKSFile == "") == (o.JWKSURL == "") {
+		return errors.New("auth.oauth requires exactly one of jwks_file and jwks_url")
+	}
+	if o.ClockSkewSeconds < 0 || o.ClockSkewSeconds > 600 {
+		return fmt.Errorf("invalid auth.oauth.clock_skew_seconds: %d (expected range 0..600)", o.ClockSkewSeconds)
+	}
+	return nil
+}
+
 func parseCSV(raw string) []string {
 	parts := strings.Split(raw, ",")
 	out := make([]string, 0, len(parts))
@@ -575,6 +575,310 @@ func TestValidateRejectsInvalidToolControlsPermissionMode(t *testing.T) {
 	}
 }
 
+func TestPermissionProfilesNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ToolControls.PermissionProfiles = map[string]PermissionProfile{
+		"reviewer": {
+			Classes: []string{" READ_ONLY "},
+			Deny:    []string{" godot.script.* ", ""},
+			ArgumentConstraints: []PermissionArgumentConstraint{
+				{Tool: " godot.scene.* ", Argument: " path ", PathPrefixes: []string{" res://levels/ "}},
+			},
+		},
+	}
+	cfg.ToolControls.ProfileBindings = []PermissionProfileBinding{{Profile: " reviewer ", ClientName: "claude-*"}}
+	cfg.ToolControls.DefaultPermissionProfile = " reviewer "
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid permission profiles, got %v", err)
+	}
+	profile := cfg.ToolControls.PermissionProfiles["reviewer"]
+	if profile.Classes[0] != "read_only" || len(profile.Deny) != 1 || profile.ArgumentConstraints[0].Argument != "path" {
+		t.Fatalf("Unexpected normalized profile: %+v", profile)
+	}
+
+	invalid := []func(*ToolControls){
+		func(c *ToolControls) {
+			c.PermissionProfiles["reviewer"] = PermissionProfile{Classes: []string{"admin"}}
+		},
+		func(c *ToolControls) {
+			c.PermissionProfiles["reviewer"] = PermissionProfile{Allow: []string{"godot.[scene"}}
+		},
+		func(c *ToolControls) {
+			c.PermissionProfiles["reviewer"] = PermissionProfile{ArgumentConstraints: []PermissionArgumentConstraint{{Tool: "godot.*", Argument: "path"}}}
+		},
+		func(c *ToolControls) { c.ProfileBindings = []PermissionProfileBinding{{Profile: "missing"}} },
+		func(c *ToolControls) { c.DefaultPermissionProfile = "missing" },
+	}
+	for i, mutate := range invalid {
+		cfg := NewConfig()
+		cfg.ToolControls.PermissionProfiles = map[string]PermissionProfile{"reviewer": {}}
+		mutate(&cfg.ToolControls)
+		cfg.Normalize()
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("Expected validation error for invalid permission profile case %d", i)
+		}
+	}
+}
+
+func TestPathSandboxNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ToolControls.PathSandbox.Exclude = []string{" addons/** ", "*.import", "addons/**"}
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid path sandbox, got %v", err)
+	}
+	if len(cfg.ToolControls.PathSandbox.Exclude) != 2 || cfg.ToolControls.PathSandbox.Exclude[0] != "addons/**" {
+		t.Fatalf("Unexpected normalized path sandbox: %#v", cfg.ToolControls.PathSandbox.Exclude)
+	}
+
+	for _, pattern := range []string{"res://addons/**", "/etc/**", "scenes/[a"} {
+		cfg := NewConfig()
+		cfg.ToolControls.PathSandbox.Include = []string{pattern}
+		cfg.Normalize()
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("Expected validation error for path sandbox pattern %q", pattern)
+		}
+	}
+}
+
+func TestRateLimitsNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ToolControls.RateLimits.Session = RateLimit{RatePerSecond: 2.5}
+	cfg.ToolControls.RateLimits.Tools = map[string]RateLimit{" godot.runtime.input.* ": {RatePerSecond: 5, Burst: 2}}
+	cfg.ToolControls.RateLimits.MaxInFlightCommands = 4
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid rate limits, got %v", err)
+	}
+	if cfg.ToolControls.RateLimits.Session.Burst != 3 {
+		t.Fatalf("Expected burst to default to one second of calls, got %d", cfg.ToolControls.RateLimits.Session.Burst)
+	}
+	if _, ok := cfg.ToolControls.RateLimits.Tools["godot.runtime.input.*"]; !ok {
+		t.Fatalf("Expected trimmed tool pattern, got %#v", cfg.ToolControls.RateLimits.Tools)
+	}
+
+	invalid := []RateLimits{
+		{Session: RateLimit{RatePerSecond: -1}},
+		{Tools: map[string]RateLimit{"godot.[node": {RatePerSecond: 1}}},
+		{Tools: map[string]RateLimit{"godot.node.*": {RatePerSecond: 1, Burst: -1}}},
+		{MaxInFlightCommands: maxRateLimitMaxInFlightCommands + 1},
+	}
+	for _, limits := range invalid {
+		cfg := NewConfig()
+		cfg.ToolControls.RateLimits = limits
+		cfg.Normalize()
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("Expected validation error for rate limits %#v", limits)
+		}
+	}
+}
+
+func TestAuditNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Audit.Dir = "  "
+	cfg.Audit.RetentionDays = 0
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid audit config, got %v", err)
+	}
+	if cfg.Audit.Dir != NewConfig().Audit.Dir || cfg.Audit.RetentionDays != defaultAuditRetentionDays {
+		t.Fatalf("Unexpected normalized audit config: %#v", cfg.Audit)
+	}
+
+	cfg = NewConfig()
+	cfg.Audit.RetentionDays = maxAuditRetentionDays + 1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for audit retention_days")
+	}
+}
+
+func TestServerSessionSettingsNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Server.SessionPersistence.Path = "  "
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid server session config, got %v", err)
+	}
+	if cfg.Server.SessionPersistence.Path != NewConfig().Server.SessionPersistence.Path || cfg.Server.SSEReplayLimit != defaultServerSSEReplayLimit {
+		t.Fatalf("Unexpected normalized server config: %#v", cfg.Server)
+	}
+
+	for _, limit := range []int{-1, maxServerSSEReplayLimit + 1} {
+		cfg = NewConfig()
+		cfg.Server.SSEReplayLimit = limit
+		cfg.Normalize()
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("Expected validation error for sse_replay_limit %d", limit)
+		}
+	}
+}
+
+func TestAuthNormalizeAndValidate(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Auth.Enabled = true
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for auth without tokens")
+	}
+
+	cfg = NewConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.Tokens = []AuthToken{{ID: " editor ", Token: " secret ", Scopes: []string{" READ ", "read", "Bridge"}}}
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid auth config, got %v", err)
+	}
+	token := cfg.Auth.Tokens[0]
+	if token.ID != "editor" || token.Token != "secret" || len(token.Scopes) != 2 || token.Scopes[0] != "read" || token.Scopes[1] != "bridge" {
+		t.Fatalf("Unexpected normalized token: %#v", token)
+	}
+
+	for name, tokens := range map[string][]AuthToken{
+		"scope":      {{ID: "a", Token: "x", Scopes: []string{"admin"}}},
+		"expires_at": {{ID: "a", Token: "x", ExpiresAt: "tomorrow"}},
+		"duplicate":  {{ID: "a", Token: "x"}, {ID: "a", Token: "y"}},
+		"secret":     {{ID: "a", Token: ""}},
+	} {
+		cfg = NewConfig()
+		cfg.Auth.Tokens = tokens
+		cfg.Normalize()
+		if err := cfg.Validate(); err == nil {
+			t.Fatalf("Expected validation error for invalid token %s", name)
+		}
+	}
+
+	cfg = NewConfig()
+	cfg.Auth.Enabled = true
+	cfg.Auth.OAuth = OAuth{Enabled: true, Issuer: " https://gateway.example.com ", JWKSFile: "jwks.json"}
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected oauth-only auth config to be valid, got %v", err)
+	}
+	oauth := cfg.Auth.OAuth
+	if oauth.Resource != "http://localhost:9080/mcp" || len(oauth.AuthorizationServers) != 1 || oauth.AuthorizationServers[0] != "https://gateway.example.com" {
+		t.Fatalf("Unexpected oauth defaults: %#v", oauth)
+	}
+	if oauth.WriteScopes[0] != "mcp:write" || oauth.ClockSkewSeconds != 60 {
+		t.Fatalf("Unexpected oauth scope defaults: %#v", oauth)
+	}
+	cfg.Auth.OAuth.JWKSURL = "https://gateway.example.com/jwks"
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for both jwks_file and jwks_url")
+	}
+}
+
+func TestValidateRejectsInvalidRuntimeBridgeSnapshotHistoryLimit(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RuntimeBridge.SnapshotHistoryLimit = 0
+	cfg.Normalize()
+	if cfg.RuntimeBridge.SnapshotHistoryLimit != defaultRuntimeBridgeSnapshotHistoryLimit {
+		t.Fatalf("Expected zero snapshot history limit to normalize to %d, got %d", defaultRuntimeBridgeSnapshotHistoryLimit, cfg.RuntimeBridge.SnapshotHistoryLimit)
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.SnapshotHistoryLimit = maxRuntimeBridgeSnapshotHistoryLimit + 1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid snapshot history limit")
+	}
+}
+
+func TestValidateRejectsInvalidRuntimeBridgeScreenshotLimits(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RuntimeBridge.ScreenshotRetention = 0
+	cfg.RuntimeBridge.ScreenshotMaxBytes = 0
+	cfg.RuntimeBridge.ScreenshotBaselineDir = "  "
+	cfg.Normalize()
+	if cfg.RuntimeBridge.ScreenshotRetention != defaultRuntimeBridgeScreenshotRetention {
+		t.Fatalf("Expected zero screenshot retention to normalize to %d, got %d", defaultRuntimeBridgeScreenshotRetention, cfg.RuntimeBridge.ScreenshotRetention)
+	}
+	if cfg.RuntimeBridge.ScreenshotMaxBytes != defaultRuntimeBridgeScreenshotMaxBytes {
+		t.Fatalf("Expected zero screenshot max bytes to normalize to %d, got %d", defaultRuntimeBridgeScreenshotMaxBytes, cfg.RuntimeBridge.ScreenshotMaxBytes)
+	}
+	if cfg.RuntimeBridge.ScreenshotBaselineDir == "" {
+		t.Fatalf("Expected blank screenshot baseline dir to normalize to default")
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.ScreenshotRetention = maxRuntimeBridgeScreenshotRetention + 1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid screenshot retention")
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.ScreenshotMaxBytes = -1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid screenshot max bytes")
+	}
+}
+
+func TestValidateRejectsInvalidRuntimeBridgeLogPersistenceLimits(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RuntimeBridge.LogMaxFileBytes = 0
+	cfg.RuntimeBridge.LogMaxFiles = 0
+	cfg.RuntimeBridge.LogDir = "  "
+	cfg.Normalize()
+	if cfg.RuntimeBridge.LogMaxFileBytes != defaultRuntimeBridgeLogMaxFileBytes {
+		t.Fatalf("Expected zero log max file bytes to normalize to %d, got %d", defaultRuntimeBridgeLogMaxFileBytes, cfg.RuntimeBridge.LogMaxFileBytes)
+	}
+	if cfg.RuntimeBridge.LogMaxFiles != defaultRuntimeBridgeLogMaxFiles {
+		t.Fatalf("Expected zero log max files to normalize to %d, got %d", defaultRuntimeBridgeLogMaxFiles, cfg.RuntimeBridge.LogMaxFiles)
+	}
+	if cfg.RuntimeBridge.LogDir == "" {
+		t.Fatalf("Expected blank log dir to normalize to default")
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.LogMaxFiles = maxRuntimeBridgeLogMaxFiles + 1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid log max files")
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.LogMaxFileBytes = -1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid log max file bytes")
+	}
+}
+
+func TestValidateRejectsInvalidRuntimeBridgeHangAfterSeconds(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RuntimeBridge.HangAfterSeconds = 0
+	cfg.Normalize()
+	if cfg.RuntimeBridge.HangAfterSeconds != defaultRuntimeBridgeHangAfterSeconds {
+		t.Fatalf("Expected zero hang after seconds to normalize to %d, got %d", defaultRuntimeBridgeHangAfterSeconds, cfg.RuntimeBridge.HangAfterSeconds)
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.HangAfterSeconds = maxRuntimeBridgeHangAfterSeconds + 1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid hang after seconds")
+	}
+}
+
+func TestValidateRejectsInvalidRuntimeBridgeSessionHistoryLimit(t *testing.T) {
+	cfg := NewConfig()
+	cfg.RuntimeBridge.SessionHistoryLimit = 0
+	cfg.Normalize()
+	if cfg.RuntimeBridge.SessionHistoryLimit != defaultRuntimeBridgeSessionHistoryLimit {
+		t.Fatalf("Expected zero session history limit to normalize to %d, got %d", defaultRuntimeBridgeSessionHistoryLimit, cfg.RuntimeBridge.SessionHistoryLimit)
+	}
+
+	cfg = NewConfig()
+	cfg.RuntimeBridge.SessionHistoryLimit = -1
+	cfg.Normalize()
+	if err := cfg.Validate(); err == nil {
+		t.Fatalf("Expected validation error for invalid session history limit")
+	}
+}
+
 func TestLoadConfigToolControlsEnvOverrides(t *testing.T) {
 	tempDir := t.TempDir()
 	configPath := filepath.Join(tempDir, "tool_controls_env_overrides_config.json")
@@ -640,3 +944,34 @@ func TestLoadConfigToolControlsEnvOverrides(t *testing.T) {
 		t.Fatalf("Expected emit progress notifications false")
 	}
 }
+
+func TestValidateServerTLSAndUnixSocket(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Server.TLS = TLS{Enabled: true, CertFile: "server.pem", KeyFile: "server.key", ClientCAFile: " ca.pem "}
+	cfg.Server.UnixSocket = UnixSocket{Enabled: true, Path: "/tmp/godot-mcp.sock", Mode: "0660"}
+	cfg.Normalize()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected valid listener config, got %v", err)
+	}
+	if cfg.Server.TLS.ClientAuth != "require" || cfg.Server.TLS.MinVersion != "1.2" {
+		t.Fatalf("Unexpected TLS defaults: %#v", cfg.Server.TLS)
+	}
+	if mode, _ := cfg.Server.UnixSocket.FileMode(); mode != 0o660 {
+		t.Fatalf("Expected socket mode 0660, got %o", mode)
+	}
+
+	for name, mutate := range map[string]func(*Config){
+		"missing key":        func(c *Config) { c.Server.TLS.KeyFile = "" },
+		"client auth":        func(c *Config) { c.Server.TLS.ClientAuth = "optional" },
+		"min version":        func(c *Config) { c.Server.TLS.MinVersion = "1.0" },
+		"socket path":        func(c *Config) { c.Server.UnixSocket.Path = "" },
+		"socket mode":        func(c *Config) { c.Server.UnixSocket.Mode = "rw-rw----" },
+		"tcp without socket": func(c *Config) { c.Server.UnixSocket.Enabled = false; c.Server.UnixSocket.DisableTCP = true },
+	} {
+		invalid := *cfg
+		mutate(&invalid)
+		if err := invalid.Validate(); err == nil {
+			t.Fatalf("Expected validation error for %s", name)
+		}
+	}
+}
@@ -5,6 +5,7 @@ go 1.26
 require (
 	github.com/fsnotify/fsnotify v1.9.0
 	github.com/labstack/echo/v4 v4.15.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
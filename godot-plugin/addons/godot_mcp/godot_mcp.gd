@@ -29,6 +29,9 @@ var runtime_change_poll_seconds: float = DEFAULT_RUNTIME_CHANGE_POLL_SECONDS
 var active_game_session_id: String = ""
 var active_game_launch_token: String = ""
 var active_game_handshake_file: String = ""
+# Extra instances of a multi-instance project.run, keyed by game session id.
+# The editor plays instance 1; the others are separate processes.
+var extra_game_instances: Dictionary = {}
 
 func _enter_tree():
 	print("Godot MCP Plugin: Entering tree...")
@@ -129,6 +132,7 @@ func _exit_tree():
 	active_game_session_id = ""
 	active_game_launch_token = ""
 	active_game_handshake_file = ""
+	extra_game_instances.clear()
 	print("Godot MCP Plugin: Cleanup complete")
 
 func _disable_plugin() -> void:
@@ -296,6 +300,9 @@ func _handle_project_run(arguments: Dictionary, _editor_interface: EditorInterfa
 	var session_id = _resolve_project_run_session_id(arguments)
 	var launch_token = _resolve_project_run_launch_token(arguments)
 	var handshake_file = _resolve_runtime_handshake_file(arguments, session_id)
+	var instance_index = int(arguments.get("instance_index", 1))
+	if instance_index > 1:
+		return _launch_extra_game_instance(session_id, launch_token, handshake_file, instance_index)
 	var started_at = _utc_now_rfc3339()
 	var scene_path = _resolve_launch_scene_path()
 	var editor_session_id = _current_editor_session_id()
@@ -382,7 +389,93 @@ func _handle_project_run(arguments: Dictionary, _editor_interface: EditorInterfa
 		"already_running": already_running
 	})
 
+func _launch_extra_game_instance(session_id: String, launch_token: String, handshake_file: String, instance_index: int) -> Dictionary:
+	var started_at = _utc_now_rfc3339()
+	var scene_path = _resolve_launch_scene_path()
+	var editor_session_id = _current_editor_session_id()
+	var handshake_payload = {
+		"schema_version": "v1",
+		"state": "launch_requested",
+		"source": "editor_plugin",
+		"game_session_id": session_id,
+		"session_id": session_id,
+		"editor_session_id": editor_session_id,
+		"launch_token": launch_token,
+		"handshake_file": handshake_file,
+		"scene_path": scene_path,
+		"instance_index": instance_index,
+		"streamable_http_url": current_streamable_http_url,
+		"server_url": current_streamable_http_url,
+		"mcp_url": current_streamable_http_url,
+		"mcp_streamable_http_url": current_streamable_http_url,
+		"started_at": started_at
+	}
+	# Extra instances never touch the active handshake; that file belongs to
+	# the instance the editor plays.
+	var write_result = _write_runtime_handshake_file(handshake_file, handshake_payload)
+	if not VARIANT_UTILS.to_bool(write_result.get("success", false), false):
+		return _runtime_failure_result(
+			"runtime_handshake_write_failed",
+			"failed to persist runtime handshake: " + str(write_result.get("error", "unknown write error"))
+		)
+
+	var args = PackedStringArray(["--path", ProjectSettings.globalize_path("res://")])
+	OS.set_environment("GODOT_MCP_RUNTIME_HANDSHAKE_PATH", ProjectSettings.globalize_path(handshake_file))
+	var pid = OS.create_process(OS.get_executable_path(), args)
+	OS.unset_environment("GODOT_MCP_RUNTIME_HANDSHAKE_PATH")
+	if pid <= 0:
+		return _runtime_failure_result("instance_launch_failed", "failed to start game instance %d" % instance_index)
+	extra_game_instances[session_id] = {
+		"pid": pid,
+		"launch_token": launch_token,
+		"handshake_file": handshake_file
+	}
+	print("Godot MCP Plugin: extra game instance started - session_id=", session_id, " instance_index=", instance_index, " pid=", pid)
+	return _runtime_success_result({
+		"command": "godot.project.run",
+		"running": true,
+		"session_id": session_id,
+		"editor_session_id": editor_session_id,
+		"launch_token": launch_token,
+		"handshake_file": handshake_file,
+		"scene_path": scene_path,
+		"started_at": started_at,
+		"instance_index": instance_index,
+		"pid": pid,
+		"already_running": false
+	})
+
+func _stop_extra_game_instance(session_id: String) -> Dictionary:
+	var instance: Dictionary = extra_game_instances[session_id]
+	extra_game_instances.erase(session_id)
+	var stopped_at = _utc_now_rfc3339()
+	var handshake_file = str(instance.get("handshake_file", ""))
+	var teardown_payload = {
+		"schema_version": "v1",
+		"state": "stopped",
+		"source": "editor_plugin",
+		"game_session_id": session_id,
+		"launch_token": str(instance.get("launch_token", "")),
+		"handshake_file": handshake_file,
+		"stopped_at": stopped_at
+	}
+	var write_result = _write_runtime_handshake_file(handshake_file, teardown_payload)
+	var pid = int(instance.get("pid", 0))
+	if pid > 0 and OS.is_process_running(pid):
+		OS.kill(pid)
+	return _runtime_success_result({
+		"command": "godot.project.stop",
+		"running": false,
+		"session_id": session_id,
+		"handshake_file": handshake_file,
+		"stopped_at": stopped_at,
+		"teardown_written": VARIANT_UTILS.to_bool(write_result.get("success", false), false)
+	})
+
 func _handle_project_stop(arguments: Dictionary, _editor_interface: EditorInterface) -> Dictionary:
+	var requested_session_id = _extract_non_empty_string(arguments, ["session_id", "game_session_id"])
+	if extra_game_instances.has(requested_session_id):
+		return _stop_extra_game_instance(requested_session_id)
 	if not ClassDB.class_has_method("EditorInterface", "stop_playing_scene"):
 		return _runtime_failure_result("stop_playing_scene_unavailable", "stop_playing_scene is not available")
 
@@ -373,6 +373,10 @@ func _handle_runtime_tool_result(result: Variant, invalid_payload_message: Strin
 	var result_dict: Dictionary = result
 	if VARIANT_UTILS.to_bool(result_dict.get("isError", false), false):
 		emit_signal("runtime_sync_failed", _extract_tool_error_message(result_dict))
+		# A session restored after a server restart comes back without its
+		# bridge role; initialize again to present the bridge secret.
+		if _as_dictionary(result_dict.get("error", {})).get("reason", "") == "bridge_role_required" and mcp_client != null and mcp_client.has_method("reinitialize_session"):
+			mcp_client.reinitialize_session()
 
 func ack_runtime_command(command_id: String, success: bool, result: Dictionary = {}, error_message: String = "", reason: String = "", retryable: Variant = null, schema_version: String = "v1") -> void:
 	if mcp_client == null:
@@ -5,6 +5,8 @@ const CONFIG_PATH := "res://addons/godot_mcp/config.cfg"
 const TOOL_RUNTIME_REGISTER := "godot.bridge.runtime.register"
 const TOOL_RUNTIME_SNAPSHOT_PUSH := "godot.bridge.runtime.snapshot.push"
 const TOOL_RUNTIME_LOG_PUSH := "godot.bridge.runtime.log.push"
+const TOOL_RUNTIME_WATCH_PUSH := "godot.bridge.runtime.watch.push"
+const TOOL_RUNTIME_PERFORMANCE_PUSH := "godot.bridge.runtime.performance.push"
 const TOOL_COMMAND_ACK := "godot.bridge.command.ack"
 const PROPERTY_WHITELIST := {
 	"position": true,
@@ -19,6 +21,50 @@ const PROPERTY_WHITELIST := {
 	"zoom": true
 }
 
+# Monitor key -> [Performance monitor, scale]. Times are reported in
+# milliseconds rather than Godot's seconds.
+const PERFORMANCE_MONITORS := {
+	"fps": [Performance.TIME_FPS, 1.0],
+	"frame_time_ms": [Performance.TIME_PROCESS, 1000.0],
+	"physics_time_ms": [Performance.TIME_PHYSICS_PROCESS, 1000.0],
+	"navigation_time_ms": [Performance.TIME_NAVIGATION_PROCESS, 1000.0],
+	"memory_static_bytes": [Performance.MEMORY_STATIC, 1.0],
+	"memory_static_max_bytes": [Performance.MEMORY_STATIC_MAX, 1.0],
+	"object_count": [Performance.OBJECT_COUNT, 1.0],
+	"resource_count": [Performance.OBJECT_RESOURCE_COUNT, 1.0],
+	"node_count": [Performance.OBJECT_NODE_COUNT, 1.0],
+	"orphan_node_count": [Performance.OBJECT_ORPHAN_NODE_COUNT, 1.0],
+	"draw_calls": [Performance.RENDER_TOTAL_DRAW_CALLS_IN_FRAME, 1.0],
+	"render_objects": [Performance.RENDER_TOTAL_OBJECTS_IN_FRAME, 1.0],
+	"render_primitives": [Performance.RENDER_TOTAL_PRIMITIVES_IN_FRAME, 1.0],
+	"video_memory_bytes": [Performance.RENDER_VIDEO_MEM_USED, 1.0],
+	"physics_2d_active_objects": [Performance.PHYSICS_2D_ACTIVE_OBJECTS, 1.0],
+	"physics_3d_active_objects": [Performance.PHYSICS_3D_ACTIVE_OBJECTS, 1.0]
+}
+
+const INPUT_EVENT_TYPES := [
+	"mouse_button",
+	"mouse_motion",
+	"mouse_scroll",
+	"mouse_drag",
+	"touch",
+	"touch_drag",
+	"magnify_gesture",
+	"pan_gesture",
+	"joypad_button",
+	"joypad_motion"
+]
+const MOUSE_BUTTONS := {
+	"left": MOUSE_BUTTON_LEFT,
+	"right": MOUSE_BUTTON_RIGHT,
+	"middle": MOUSE_BUTTON_MIDDLE,
+	"wheel_up": MOUSE_BUTTON_WHEEL_UP,
+	"wheel_down": MOUSE_BUTTON_WHEEL_DOWN,
+	"wheel_left": MOUSE_BUTTON_WHEEL_LEFT,
+	"wheel_right": MOUSE_BUTTON_WHEEL_RIGHT,
+	"xbutton1": MOUSE_BUTTON_XBUTTON1,
+	"xbutton2": MOUSE_BUTTON_XBUTTON2
+}
 var mcp_client: RuntimeStreamableHTTPClient
 var mcp_interface: RuntimeMCPProtocolAdapter
 var snapshot_collector := RuntimeSnapshotCollector.new()
@@ -26,10 +72,12 @@ var snapshot_collector := RuntimeSnapshotCollector.new()
 var bootstrap_timer: Timer
 var snapshot_timer: Timer
 var log_flush_timer: Timer
+var performance_timer: Timer
 
 var streamable_http_url := "http://localhost:9080/mcp"
 var handshake_path := "user://godot_mcp/runtime/active_handshake.json"
-var handshake_scan_dir := "/tmp/godot-mcp"
+# Empty scans the per-user directory the server writes headless handshakes to.
+var handshake_scan_dir := ""
 var bootstrap_poll_seconds := 0.5
 var snapshot_interval_seconds := 0.1
 var log_flush_seconds := 1.0
@@ -49,6 +97,14 @@ var log_sequence := 0
 var pending_log_entries: Array[Dictionary] = []
 var log_push_in_flight := false
 var pending_log_flush_batch: Array[Dictionary] = []
+var watches: Dictionary = {}
+var pending_watch_samples: Array[Dictionary] = []
+var watch_push_in_flight := false
+var pending_watch_flush_batch: Array[Dictionary] = []
+var performance_monitors: Array[String] = []
+var pending_performance_samples: Array[Dictionary] = []
+var performance_push_in_flight := false
+var pending_performance_flush_batch: Array[Dictionary] = []
 
 var _last_bootstrap_state := ""
 var _last_handshake_scan_report := ""
@@ -68,6 +124,7 @@ func _ready() -> void:
 	if Engine.is_editor_hint():
 		return
 
+	set_process(false)
 	_load_config()
 	_setup_bridge_nodes()
 	_setup_timers()
@@ -82,6 +139,15 @@ func _exit_tree() -> void:
 		snapshot_timer.stop()
 	if log_flush_timer != null:
 		log_flush_timer.stop()
+	if performance_timer != null:
+		performance_timer.stop()
+
+func _process(_delta: float) -> void:
+	if watches.is_empty():
+		set_process(false)
+		return
+	_sample_watches()
+	_flush_watch_samples()
 
 func _load_config() -> void:
 	var cfg = ConfigFile.new()
@@ -138,6 +204,13 @@ func _setup_timers() -> void:
 	add_child(log_flush_timer)
 	log_flush_timer.start()
 
+	performance_timer = Timer.new()
+	performance_timer.one_shot = false
+	performance_timer.timeout.connect(_on_performance_timeout)
+	add_child(performance_timer)
+	# Started by godot.runtime.performance.sample.
+	performance_timer.stop()
+
 func _on_bootstrap_timeout() -> void:
 	var has_register_tool := mcp_interface != null and mcp_interface.has_tool(TOOL_RUNTIME_REGISTER)
 	var state_str := "handshake=%s connected=%s connecting=%s registered=%s has_register_tool=%s" % [
@@ -173,6 +246,16 @@ func _on_snapshot_timeout() -> void:
 func _on_log_flush_timeout() -> void:
 	_flush_logs()
 
+func _on_performance_timeout() -> void:
+	pending_performance_samples.append({
+		"time": _now_rfc3339(),
+		"frame": int(Engine.get_process_frames()),
+		"monitors": _read_performance_monitors(performance_monitors)
+	})
+	while pending_performance_samples.size() > 600:
+		pending_performance_samples.pop_front()
+	_flush_performance_samples()
+
 func _on_mcp_connected() -> void:
 	_append_log("info", "connected to MCP server", "runtime_companion", {
 		"url": streamable_http_url
@@ -213,6 +296,14 @@ func _on_tool_result(tool_name: String, result: Dictionary) -> void:
 		log_push_in_flight = false
 		pending_log_flush_batch.clear()
 		return
+	if tool_name == TOOL_RUNTIME_WATCH_PUSH:
+		watch_push_in_flight = false
+		pending_watch_flush_batch.clear()
+		return
+	if tool_name == TOOL_RUNTIME_PERFORMANCE_PUSH:
+		performance_push_in_flight = false
+		pending_performance_flush_batch.clear()
+		return
 	if tool_name == TOOL_RUNTIME_SNAPSHOT_PUSH:
 		return
 	if tool_name == TOOL_COMMAND_ACK:
@@ -246,6 +337,22 @@ func _on_tool_error(tool_name: String, error_message: String) -> void:
 		_append_diagnostic_failure("runtime log push failed", "runtime_companion", "log_push_failed", error_message)
 		return
 
+	if tool_name == TOOL_RUNTIME_WATCH_PUSH:
+		watch_push_in_flight = false
+		var failed_samples = pending_watch_flush_batch.duplicate(true)
+		pending_watch_flush_batch.clear()
+		_restore_pending_watch_samples(failed_samples)
+		_append_diagnostic_failure("runtime watch push failed", "runtime_companion", "watch_push_failed", error_message)
+		return
+
+	if tool_name == TOOL_RUNTIME_PERFORMANCE_PUSH:
+		performance_push_in_flight = false
+		var failed_performance = pending_performance_flush_batch.duplicate(true)
+		pending_performance_flush_batch.clear()
+		_restore_pending_performance_samples(failed_performance)
+		_append_diagnostic_failure("runtime performance push failed", "runtime_companion", "performance_push_failed", error_message)
+		return
+
 	if tool_name == TOOL_COMMAND_ACK:
 		_append_diagnostic_failure("runtime command ack failed", "runtime_companion", "command_ack_failed", error_message)
 
@@ -278,8 +385,10 @@ func _attempt_runtime_register() -> void:
 			"log_push": true,
 			"command_ack": true,
 			"input": true,
+			"input_events": true,
 			"screenshot": true,
-			"node_properties": true
+			"node_properties": true,
+			"watches": true
 		},
 		"runtime": {
 			"engine": Engine.get_version_info(),
@@ -343,6 +452,14 @@ func _dispatch_runtime_command(command_name: String, arguments: Dictionary) -> D
 			return _handle_log_clear()
 		"godot.runtime.screenshot.get":
 			return _handle_screenshot_get(arguments)
+		"godot.runtime.watch.add":
+			return _handle_watch_add(arguments)
+		"godot.runtime.watch.remove":
+			return _handle_watch_remove(arguments)
+		"godot.runtime.performance.get":
+			return _handle_performance_get(arguments)
+		"godot.runtime.performance.sample":
+			return _handle_performance_sample(arguments)
 		_:
 			return _runtime_command_failure(command_name, "command_not_supported", "unsupported runtime command: %s" % command_name)
 
@@ -391,13 +508,22 @@ func _handle_node_properties_get(arguments: Dictionary) -> Dictionary:
 	})
 
 func _handle_input_tap(arguments: Dictionary) -> Dictionary:
-	var parsed = _parse_input_descriptor(str(arguments.get("input", "")))
+	var parsed = _parse_input_request(arguments)
 	if not bool(parsed.get("ok", false)):
 		return _runtime_command_failure("godot.runtime.input.tap", "input_not_supported", str(parsed.get("error", "input not supported")))
 
 	var duration_ms = int(arguments.get("duration_ms", 120))
 	duration_ms = clampi(duration_ms, 0, 5000)
 
+	if str(parsed.get("kind", "")) == "event" and not _input_event_has_press_state(parsed.get("event", {})):
+		_play_input_gesture(parsed.get("event", {}), duration_ms)
+		return _runtime_success_result({
+			"input": parsed.get("input", ""),
+			"duration_ms": duration_ms,
+			"frame": int(Engine.get_process_frames()),
+			"timestamp": _now_rfc3339()
+		})
+
 	_send_parsed_input(parsed, true)
 	if duration_ms > 0:
 		var release_timer = get_tree().create_timer(float(duration_ms) / 1000.0)
@@ -417,7 +543,7 @@ func _handle_input_tap(arguments: Dictionary) -> Dictionary:
 	})
 
 func _handle_input_press(arguments: Dictionary) -> Dictionary:
-	var parsed = _parse_input_descriptor(str(arguments.get("input", "")))
+	var parsed = _parse_input_request(arguments)
 	if not bool(parsed.get("ok", false)):
 		return _runtime_command_failure("godot.runtime.input.press", "input_not_supported", str(parsed.get("error", "input not supported")))
 
@@ -429,7 +555,7 @@ func _handle_input_press(arguments: Dictionary) -> Dictionary:
 	})
 
 func _handle_input_release(arguments: Dictionary) -> Dictionary:
-	var parsed = _parse_input_descriptor(str(arguments.get("input", "")))
+	var parsed = _parse_input_request(arguments)
 	if not bool(parsed.get("ok", false)):
 		return _runtime_command_failure("godot.runtime.input.release", "input_not_supported", str(parsed.get("error", "input not supported")))
 
@@ -466,27 +592,259 @@ func _handle_screenshot_get(arguments: Dictionary) -> Dictionary:
 	if image == null:
 		return _runtime_command_failure("godot.runtime.screenshot.get", "command_failed", "failed to read viewport image")
 
-	var base_dir = "user://godot-mcp-runtime/%s" % game_session_id
-	var absolute_dir = ProjectSettings.globalize_path(base_dir)
-	var mk_err = DirAccess.make_dir_recursive_absolute(absolute_dir)
-	if mk_err != OK:
-		return _runtime_command_failure("godot.runtime.screenshot.get", "command_failed", "failed to prepare screenshot directory")
+	# The server only reads screenshots from the private directory it hands out.
+	var output_dir = str(arguments.get("output_dir", "")).strip_edges()
+	if output_dir == "" or not DirAccess.dir_exists_absolute(output_dir):
+		return _runtime_command_failure("godot.runtime.screenshot.get", "command_failed", "screenshot output_dir is unavailable")
 
 	var frame = int(Engine.get_process_frames())
-	var relative_path = "%s/frame_%08d.png" % [base_dir, frame]
-	var save_err = image.save_png(relative_path)
+	var output_path = output_dir.path_join("frame_%08d.png" % frame)
+	var save_err = image.save_png(output_path)
 	if save_err != OK:
 		return _runtime_command_failure("godot.runtime.screenshot.get", "command_failed", "failed to save screenshot")
 
 	return _runtime_success_result({
 		"session_id": game_session_id,
-		"path": ProjectSettings.globalize_path(relative_path),
+		"path": output_path,
 		"width": image.get_width(),
 		"height": image.get_height(),
 		"frame": frame,
 		"timestamp": _now_rfc3339()
 	})
 
+func _handle_watch_add(arguments: Dictionary) -> Dictionary:
+	var watch_id = str(arguments.get("watch_id", "")).strip_edges()
+	if watch_id == "":
+		return _runtime_command_failure("godot.runtime.watch.add", "watch_not_found", "watch_id is required")
+	var node_query = str(arguments.get("node", "")).strip_edges()
+	var target = snapshot_collector.resolve_node(node_query)
+	if target == null:
+		return _runtime_command_failure("godot.runtime.watch.add", "node_not_found", "node not found: %s" % node_query)
+	var property_path = str(arguments.get("property", "")).strip_edges()
+	var base_property = property_path.split(":")[0]
+	if base_property == "" or not _node_has_property(target, base_property):
+		return _runtime_command_failure("godot.runtime.watch.add", "property_not_supported", "property unavailable on node: %s" % property_path)
+
+	var value = _normalize_variant(target.get_indexed(NodePath(property_path)))
+	var interval_ms = clampi(int(arguments.get("interval_ms", 100)), 16, 60000)
+	watches[watch_id] = {
+		"node_path": target.get_path(),
+		"property": property_path,
+		"interval_ms": interval_ms,
+		"next_sample_msec": 0,
+		"has_value": false,
+		"last_value": null
+	}
+	set_process(true)
+	return _runtime_success_result({
+		"watch_id": watch_id,
+		"node": str(target.get_path()),
+		"type": str(target.get_class()),
+		"value": value,
+		"frame": int(Engine.get_process_frames()),
+		"updated_at": _now_rfc3339()
+	})
+
+func _handle_watch_remove(arguments: Dictionary) -> Dictionary:
+	var watch_id = str(arguments.get("watch_id", "")).strip_edges()
+	var removed = watches.erase(watch_id)
+	var kept: Array[Dictionary] = []
+	for sample in pending_watch_samples:
+		if str(sample.get("watch_id", "")) != watch_id:
+			kept.append(sample)
+	pending_watch_samples = kept
+	return _runtime_success_result({
+		"watch_id": watch_id,
+		"removed": removed,
+		"timestamp": _now_rfc3339()
+	})
+
+# Samples due watches and queues a sample only when the value changed, so the
+# server receives one entry per change rather than per tick.
+func _sample_watches() -> void:
+	var tree := get_tree()
+	if tree == null:
+		return
+	var now_ms = Time.get_ticks_msec()
+	for watch_id in watches.keys():
+		var watch: Dictionary = watches[watch_id]
+		if now_ms < int(watch.get("next_sample_msec", 0)):
+			continue
+		watch["next_sample_msec"] = now_ms + int(watch.get("interval_ms", 100))
+
+		var value: Variant = null
+		var target = tree.root.get_node_or_null(watch.get("node_path", NodePath()))
+		if target != null:
+			value = _normalize_variant(target.get_indexed(NodePath(str(watch.get("property", "")))))
+		var last_value = watch.get("last_value")
+		if bool(watch.get("has_value", false)) and typeof(value) == typeof(last_value) and value == last_value:
+			continue
+		watch["has_value"] = true
+		watch["last_value"] = value
+		pending_watch_samples.append({
+			"watch_id": watch_id,
+			"time": _now_rfc3339(),
+			"frame": int(Engine.get_process_frames()),
+			"value": value
+		})
+	while pending_watch_samples.size() > 1000:
+		pending_watch_samples.pop_front()
+
+func _flush_watch_samples() -> void:
+	if not is_registered:
+		return
+	if mcp_interface == null:
+		return
+	if watch_push_in_flight:
+		return
+	if pending_watch_samples.is_empty():
+		return
+	if not mcp_interface.has_tool(TOOL_RUNTIME_WATCH_PUSH):
+		return
+
+	var samples = pending_watch_samples.duplicate(true)
+	pending_watch_samples.clear()
+	pending_watch_flush_batch = samples.duplicate(true)
+	watch_push_in_flight = true
+
+	mcp_interface.call_tool(TOOL_RUNTIME_WATCH_PUSH, {
+		"game_session_id": game_session_id,
+		"session_id": game_session_id,
+		"launch_token": launch_token,
+		"samples": samples
+	})
+
+func _restore_pending_watch_samples(samples: Array[Dictionary]) -> void:
+	if samples.is_empty():
+		return
+	var restored: Array[Dictionary] = []
+	for sample in samples:
+		restored.append(sample)
+	for sample in pending_watch_samples:
+		restored.append(sample)
+	pending_watch_samples = restored
+	while pending_watch_samples.size() > 1000:
+		pending_watch_samples.pop_front()
+
+func _handle_performance_get(arguments: Dictionary) -> Dictionary:
+	return _runtime_success_result({
+		"session_id": game_session_id,
+		"frame": int(Engine.get_process_frames()),
+		"timestamp": _now_rfc3339(),
+		"monitors": _read_performance_monitors(_performance_monitor_names(arguments.get("monitors", null)))
+	})
+
+func _handle_performance_sample(arguments: Dictionary) -> Dictionary:
+	if not bool(arguments.get("enabled", false)):
+		performance_timer.stop()
+		# Flush before the ack so queued samples land while the server still
+		# accepts them.
+		_flush_performance_samples()
+		return _runtime_success_result({
+			"enabled": false,
+			"timestamp": _now_rfc3339()
+		})
+
+	var interval_ms = clampi(int(arguments.get("interval_ms", 1000)), 100, 60000)
+	performance_monitors = _performance_monitor_names(arguments.get("monitors", null))
+	performance_timer.wait_time = float(interval_ms) / 1000.0
+	performance_timer.start()
+	return _runtime_success_result({
+		"enabled": true,
+		"interval_ms": interval_ms,
+		"monitors": performance_monitors,
+		"timestamp": _now_rfc3339()
+	})
+
+func _performance_monitor_names(raw: Variant) -> Array[String]:
+	var names: Array[String] = []
+	if raw is Array:
+		for item in raw:
+			var name = str(item).strip_edges()
+			if PERFORMANCE_MONITORS.has(name):
+				names.append(name)
+	return names
+
+# Reads the named monitors, or every known monitor when names is empty.
+func _read_performance_monitors(names: Array[String]) -> Dictionary:
+	var keys: Array = names
+	if keys.is_empty():
+		keys = PERFORMANCE_MONITORS.keys()
+	var values := {}
+	for key in keys:
+		var monitor: Array = PERFORMANCE_MONITORS[key]
+		values[key] = float(Performance.get_monitor(monitor[0])) * float(monitor[1])
+	return values
+
+func _flush_performance_samples() -> void:
+	if not is_registered:
+		return
+	if mcp_interface == null:
+		return
+	if performance_push_in_flight:
+		return
+	if pending_performance_samples.is_empty():
+		return
+	if not mcp_interface.has_tool(TOOL_RUNTIME_PERFORMANCE_PUSH):
+		return
+
+	var samples = pending_performance_samples.duplicate(true)
+	pending_performance_samples.clear()
+	pending_performance_flush_batch = samples.duplicate(true)
+	performance_push_in_flight = true
+
+	mcp_interface.call_tool(TOOL_RUNTIME_PERFORMANCE_PUSH, {
+		"game_session_id": game_session_id,
+		"session_id": game_session_id,
+		"launch_token": launch_token,
+		"samples": samples
+	})
+
+func _restore_pending_performance_samples(samples: Array[Dictionary]) -> void:
+	if samples.is_empty():
+		return
+	var restored: Array[Dictionary] = []
+	for sample in samples:
+		restored.append(sample)
+	for sample in pending_performance_samples:
+		restored.append(sample)
+	pending_performance_samples = restored
+	while pending_performance_samples.size() > 600:
+		pending_performance_samples.pop_front()
+
+func _parse_input_request(arguments: Dictionary) -> Dictionary:
+	var raw_event = arguments.get("event", null)
+	if raw_event is Dictionary:
+		return _parse_input_event(raw_event)
+	return _parse_input_descriptor(str(arguments.get("input", "")))
+
+func _parse_input_event(raw_event: Dictionary) -> Dictionary:
+	var event_type = str(raw_event.get("type", "")).strip_edges().to_lower()
+	if event_type == "":
+		return {
+			"ok": false,
+			"error": "event.type is required"
+		}
+	if not (event_type in INPUT_EVENT_TYPES):
+		return {
+			"ok": false,
+			"error": "unsupported event type: %s" % event_type
+		}
+	if event_type in ["mouse_button", "mouse_drag"] and not MOUSE_BUTTONS.has(str(raw_event.get("button", "left"))):
+		return {
+			"ok": false,
+			"error": "unsupported mouse button: %s" % str(raw_event.get("button", ""))
+		}
+	return {
+		"ok": true,
+		"kind": "event",
+		"event": raw_event,
+		"input": event_type
+	}
+
+func _input_event_has_press_state(event: Dictionary) -> bool:
+	return str(event.get("type", "")) in ["mouse_button", "touch", "joypad_button", "joypad_motion"]
+
 func _parse_input_descriptor(raw_input: String) -> Dictionary:
 	var input_name = raw_input.strip_edges()
 	if input_name == "":
@@ -562,6 +920,121 @@ func _send_parsed_input(parsed: Dictionary, pressed: bool) -> void:
 		key_event.physical_keycode = keycode
 		key_event.pressed = pressed
 		Input.parse_input_event(key_event)
+		return
+
+	if kind == "event":
+		_send_typed_input(parsed.get("event", {}), pressed)
+
+func _send_typed_input(event: Dictionary, pressed: bool) -> void:
+	match str(event.get("type", "")):
+		"mouse_button":
+			var position = _input_event_point(event, "position")
+			_send_mouse_button(_mouse_button_index(event), position, pressed, bool(event.get("double_click", false)))
+		"touch":
+			_send_screen_touch(int(event.get("index", 0)), _input_event_point(event, "position"), pressed)
+		"joypad_button":
+			var button_event = InputEventJoypadButton.new()
+			button_event.device = int(event.get("device", 0))
+			button_event.button_index = int(event.get("button", 0))
+			button_event.pressure = float(event.get("pressure", 1.0)) if pressed else 0.0
+			button_event.pressed = pressed
+			Input.parse_input_event(button_event)
+		"joypad_motion":
+			var motion_event = InputEventJoypadMotion.new()
+			motion_event.device = int(event.get("device", 0))
+			motion_event.axis = int(event.get("axis", 0))
+			motion_event.axis_value = float(event.get("value", 0.0)) if pressed else 0.0
+			Input.parse_input_event(motion_event)
+
+# Plays one complete pointer gesture. Drags are spread across duration_ms so
+# physics/process callbacks observe intermediate positions.
+func _play_input_gesture(event: Dictionary, duration_ms: int) -> void:
+	var event_type = str(event.get("type", ""))
+	match event_type:
+		"mouse_motion":
+			var motion = InputEventMouseMotion.new()
+			motion.position = _input_event_point(event, "position")
+			motion.global_position = motion.position
+			motion.relative = _input_event_point(event, "relative")
+			Input.parse_input_event(motion)
+		"mouse_scroll":
+			var wheel_button = MOUSE_BUTTONS.get("wheel_%s" % str(event.get("direction", "down")), MOUSE_BUTTON_WHEEL_DOWN)
+			var scroll_position = _input_event_point(event, "position")
+			for _step in range(max(1, int(event.get("steps", 1)))):
+				_send_mouse_button(wheel_button, scroll_position, true, false)
+				_send_mouse_button(wheel_button, scroll_position, false, false)
+		"magnify_gesture":
+			var magnify = InputEventMagnifyGesture.new()
+			magnify.position = _input_event_point(event, "position")
+			magnify.factor = float(event.get("factor", 1.0))
+			Input.parse_input_event(magnify)
+		"pan_gesture":
+			var pan = InputEventPanGesture.new()
+			pan.position = _input_event_point(event, "position")
+			pan.delta = _input_event_point(event, "delta")
+			Input.parse_input_event(pan)
+		"mouse_drag", "touch_drag":
+			var from_point = _input_event_point(event, "from")
+			var to_point = _input_event_point(event, "to")
+			var steps = max(1, int(event.get("steps", 8)))
+			var step_delay = float(duration_ms) / 1000.0 / float(steps)
+			var is_touch = event_type == "touch_drag"
+			var touch_index = int(event.get("index", 0))
+			var button_index = _mouse_button_index(event)
+			if is_touch:
+				_send_screen_touch(touch_index, from_point, true)
+			else:
+				_send_mouse_button(button_index, from_point, true, false)
+			var previous = from_point
+			for step in range(1, steps + 1):
+				if step_delay > 0.0:
+					await get_tree().create_timer(step_delay).timeout
+				var current = from_point.lerp(to_point, float(step) / float(steps))
+				if is_touch:
+					var drag = InputEventScreenDrag.new()
+					drag.index = touch_index
+					drag.position = current
+					drag.relative = current - previous
+					Input.parse_input_event(drag)
+				else:
+					var drag_motion = InputEventMouseMotion.new()
+					drag_motion.position = current
+					drag_motion.global_position = current
+					drag_motion.relative = current - previous
+					drag_motion.button_mask = 1 << (button_index - 1)
+					Input.parse_input_event(drag_motion)
+				previous = current
+			if is_touch:
+				_send_screen_touch(touch_index, to_point, false)
+			else:
+				_send_mouse_button(button_index, to_point, false, false)
+
+func _send_mouse_button(button_index: MouseButton, position: Vector2, pressed: bool, double_click: bool) -> void:
+	var mouse_event = InputEventMouseButton.new()
+	mouse_event.button_index = button_index
+	mouse_event.position = position
+	mouse_event.global_position = position
+	mouse_event.pressed = pressed
+	mouse_event.double_click = double_click and pressed
+	if pressed:
+		mouse_event.button_mask = 1 << (button_index - 1)
+	Input.parse_input_event(mouse_event)
+
+func _send_screen_touch(index: int, position: Vector2, pressed: bool) -> void:
+	var touch_event = InputEventScreenTouch.new()
+	touch_event.index = index
+	touch_event.position = position
+	touch_event.pressed = pressed
+	Input.parse_input_event(touch_event)
+
+func _mouse_button_index(event: Dictionary) -> MouseButton:
+	return MOUSE_BUTTONS.get(str(event.get("button", "left")), MOUSE_BUTTON_LEFT)
+
+func _input_event_point(event: Dictionary, key: String) -> Vector2:
+	var raw_point = event.get(key, {})
+	if not (raw_point is Dictionary):
+		return Vector2.ZERO
+	return Vector2(float(raw_point.get("x", 0.0)), float(raw_point.get("y", 0.0)))
 
 func _ack_runtime_command(command_id: String, payload: Dictionary) -> void:
 	if mcp_interface == null:
@@ -761,13 +1234,22 @@ func _debug_candidate_report(candidates: Array[String]) -> String:
 		parts.append("%s(exists=%s,size=%d,state=%s)" % [candidate, str(exists), size, state])
 	return " | ".join(parts)
 
+# Matches DefaultGameProcessHandshakeDir on the server: the user cache
+# directory, which both Go and Godot resolve the same way.
+func _default_handshake_scan_dir() -> String:
+	var cache_dir = OS.get_cache_dir().strip_edges()
+	if cache_dir == "":
+		return ""
+	return cache_dir.path_join("godot-mcp").path_join("handshakes")
+
 func _discover_latest_handshake_path() -> String:
 	var scan_dirs: Array[String] = []
 	var env_dir = OS.get_environment("GODOT_MCP_RUNTIME_HANDSHAKE_DIR").strip_edges()
 	if env_dir != "":
 		scan_dirs.append(env_dir)
-	if handshake_scan_dir != "":
-		scan_dirs.append(handshake_scan_dir)
+	var configured_dir = handshake_scan_dir if handshake_scan_dir != "" else _default_handshake_scan_dir()
+	if configured_dir != "":
+		scan_dirs.append(configured_dir)
 
 	var latest_path := ""
 	var latest_modified := 0
@@ -855,6 +1337,16 @@ func _apply_handshake_payload(payload: Dictionary, source_path: String) -> bool:
 	])
 	streamable_http_url = candidate_url
 	active_handshake_path = source_path
+	var candidate_auth_token = _pick_first_string(payload, [
+		"auth_token"
+	])
+	if candidate_auth_token != "" and mcp_client != null:
+		mcp_client.auth_token = candidate_auth_token
+	var candidate_bridge_secret = _pick_first_string(payload, [
+		"bridge_secret"
+	])
+	if candidate_bridge_secret != "" and mcp_client != null:
+		mcp_client.bridge_secret = candidate_bridge_secret
 
 	if payload.has("snapshot_hz"):
 		var hz = max(1.0, float(payload.get("snapshot_hz", 10.0)))
@@ -13,8 +13,14 @@ signal message_received(message: Dictionary)
 const DEFAULT_PROTOCOL_VERSION := "2025-11-25"
 const PLUGIN_CLIENT_VERSION := "0.2.0"
 const SSE_RECONNECT_DELAY_MS: int = 1500
+const BRIDGE_SECRET_PATH := "res://.godot/godot_mcp/bridge_secret"
 
 var streamable_http_url: String = "http://localhost:9080/mcp"
+# Bearer token sent when the server has auth enabled; empty sends none.
+var auth_token: String = ""
+# Role claimed at initialize; the bridge secret proves it to the server.
+var bridge_role: String = "runtime"
+var bridge_secret: String = ""
 var is_connecting: bool = false
 var post_http_connection: HTTPRequest
 var session_id: String = ""
@@ -55,14 +61,29 @@ func load_settings() -> void:
 	var err = config.load("res://addons/godot_mcp/config.cfg")
 	if err == OK:
 		streamable_http_url = str(config.get_value("mcp_runtime", "streamable_http_url", streamable_http_url)).strip_edges()
+		auth_token = str(config.get_value("mcp_runtime", "auth_token", auth_token)).strip_edges()
 		print("MCP Client: Settings loaded - type: streamable_http, url: ", streamable_http_url)
 	else:
 		print("MCP Client: Failed to load settings, using defaults")
 
+# Uses the handshake's bridge secret when set, else the one the editor
+# plugin wrote into the project.
+func _resolve_bridge_secret() -> String:
+	if bridge_secret == "" and FileAccess.file_exists(BRIDGE_SECRET_PATH):
+		bridge_secret = FileAccess.get_file_as_string(BRIDGE_SECRET_PATH).strip_edges()
+	return bridge_secret
+
 func connect_to_server() -> void:
 	print("MCP Client: Attempting to connect...")
 	connect_streamable_http(streamable_http_url)
 
+# Drops the current MCP session and initializes again, presenting the bridge
+# secret so the new session gets its editor/runtime role.
+func reinitialize_session() -> void:
+	print("MCP Client: Bridge role missing, reinitializing Streamable HTTP session")
+	session_id = ""
+	connect_streamable_http(streamable_http_url)
+
 func connect_streamable_http(url: String) -> void:
 	if ignore_post_result_once:
 		pending_connect_url = url
@@ -98,7 +119,11 @@ func connect_streamable_http(url: String) -> void:
 			"protocolVersion": negotiated_protocol_version,
 			"capabilities": {
 				"godot": {
-					"mutating": true
+					"mutating": true,
+					"bridge": {
+						"role": bridge_role,
+						"secret": _resolve_bridge_secret()
+					}
 				}
 			},
 			"clientInfo": {
@@ -253,6 +278,8 @@ func _send_raw_message(message: Dictionary) -> bool:
 	]
 	if session_id != "":
 		headers.append("MCP-Session-Id: " + session_id)
+	if auth_token != "":
+		headers.append("Authorization: Bearer " + auth_token)
 
 	request_in_flight = true
 	ignore_post_result_once = false
@@ -476,6 +503,8 @@ func _send_sse_get_request() -> void:
 	])
 	if session_id != "":
 		headers.append("MCP-Session-Id: " + session_id)
+	if auth_token != "":
+		headers.append("Authorization: Bearer " + auth_token)
 
 	var request_err = sse_http_connection.request(HTTPClient.METHOD_GET, sse_request_path, headers)
 	if request_err != OK:
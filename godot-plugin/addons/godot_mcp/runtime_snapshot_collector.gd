@@ -264,11 +264,19 @@ func _build_runtime_tree(node: Node, depth: int, max_depth: int, max_nodes: int,
 	if script_ref != null and script_ref is Resource:
 		script_path = str(script_ref.resource_path)
 
+	var groups: Array[String] = []
+	for group_name in node.get_groups():
+		var group := str(group_name)
+		if not group.begins_with("_"):
+			groups.append(group)
+
 	var item: Dictionary = {
 		"path": str(node.get_path()),
 		"name": str(node.name),
 		"type": str(node.get_class()),
+		"instance_id": node.get_instance_id(),
 		"script_path": script_path,
+		"groups": groups,
 		"child_count": int(node.get_child_count())
 	}
 
@@ -0,0 +1,90 @@
+package toolpipeline
+
+import (
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/internal/infra/audit"
+	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const bridgeCommandAckTool = "godot.bridge.command.ack"
+
+// deniedReasons are the semantic error reasons the pipeline uses when a call
+// is refused before the tool runs.
+var deniedReasons = map[string]struct{}{
+	"permission_denied":            {},
+	"profile_denied":               {},
+	"scope_denied":                 {},
+	"bridge_role_required":         {},
+	"mutating_capability_required": {},
+}
+
+func shouldAuditTool(toolName string) bool {
+	return toolspec.IsMutatingTool(toolName) || toolName == bridgeCommandAckTool
+}
+
+func recordAudit(auditLog *audit.Log, toolName string, arguments map[string]any, callContext ToolCallContext, startedAt time.Time, response *jsonrpc.Response) {
+	record := audit.Record{
+		Kind:          audit.KindToolCall,
+		SessionID:     strings.TrimSpace(callContext.SessionID),
+		SessionRole:   strings.TrimSpace(callContext.SessionRole),
+		ClientName:    callContext.ClientName,
+		ClientVersion: callContext.ClientVersion,
+		AuthTokenID:   callContext.AuthTokenID,
+		Tool:          toolName,
+		Arguments:     audit.SanitizeArguments(arguments),
+		Outcome:       audit.OutcomeSuccess,
+		DurationMS:    time.Since(startedAt).Milliseconds(),
+	}
+	applyAuditOutcome(&record, response)
+	if toolName == bridgeCommandAckTool {
+		record.Kind = audit.KindBridgeAck
+		record.CommandID, _ = arguments["command_id"].(string)
+		record.CommandID = strings.TrimSpace(record.CommandID)
+		ack := map[string]any{"success": true}
+		if success, ok := arguments["success"].(bool); ok {
+			ack["success"] = success
+		}
+		for _, key := range []string{"error", "reason"} {
+			if value, ok := arguments[key].(string); ok && strings.TrimSpace(value) != "" {
+				ack[key] = strings.TrimSpace(value)
+			}
+		}
+		record.Ack = ack
+	}
+	auditLog.Append(record)
+}
+
+func applyAuditOutcome(record *audit.Record, response *jsonrpc.Response) {
+	if response == nil {
+		record.Outcome = audit.OutcomeError
+		return
+	}
+	if response.Error != nil {
+		record.Outcome = audit.OutcomeError
+		record.Reason = response.Error.Message
+		return
+	}
+	result, _ := response.Result.(map[string]any)
+	if isError, _ := result["isError"].(bool); isError {
+		record.Outcome = audit.OutcomeError
+		errorPayload, _ := result["error"].(map[string]any)
+		record.ErrorKind, _ = errorPayload["kind"].(string)
+		record.Reason, _ = errorPayload["reason"].(string)
+		if _, denied := deniedReasons[record.Reason]; denied || record.ErrorKind == tooltypes.SemanticKindPathForbidden || record.ErrorKind == tooltypes.SemanticKindRateLimited {
+			record.Outcome = audit.OutcomeDenied
+		}
+		if commandID, ok := errorPayload["command_id"].(string); ok {
+			record.CommandID = commandID
+		}
+		return
+	}
+	if toolResult, ok := result["result"].(map[string]any); ok {
+		if commandID, ok := toolResult["command_id"].(string); ok {
+			record.CommandID = commandID
+		}
+	}
+}
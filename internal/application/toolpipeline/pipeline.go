@@ -2,6 +2,7 @@ package toolpipeline
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"maps"
 	"sort"
@@ -9,10 +10,12 @@ import (
 	"time"
 
 	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/internal/infra/audit"
 	"github.com/slighter12/godot-mcp-go/internal/infra/notifications"
 	"github.com/slighter12/godot-mcp-go/logger"
 	"github.com/slighter12/godot-mcp-go/mcp"
 	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
 	"github.com/slighter12/godot-mcp-go/tools"
 	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
 )
@@ -25,6 +28,20 @@ type ToolCallContext struct {
 	RuntimeCommandSessionID string
 	SessionInitialized      bool
 	MutatingAllowed         bool
+	// AuthRequired is set when the caller authenticated with a bearer token;
+	// AuthScopes then limits which tools it may call.
+	AuthRequired bool
+	AuthScopes   []string
+	// SessionRole is the caller's negotiated role (editor, runtime or agent).
+	SessionRole string
+	// PermissionProfile is the session's selected profile; nil applies no
+	// per-session restrictions.
+	PermissionProfile *toolspec.PermissionProfile
+	// ClientName, ClientVersion and AuthTokenID identify the caller in the
+	// audit log.
+	ClientName    string
+	ClientVersion string
+	AuthTokenID   string
 }
 
 type ToolCallOptions struct {
@@ -33,6 +50,9 @@ type ToolCallOptions struct {
 	PermissionMode            string
 	AllowedTools              []string
 	EmitProgressNotifications bool
+	// BridgeRoleRequired limits godot.bridge.* tools to editor and runtime
+	// sessions.
+	BridgeRoleRequired bool
 }
 
 type ExecuteInput struct {
@@ -43,7 +63,7 @@ type ExecuteInput struct {
 	Options      ToolCallOptions
 }
 
-func Execute(input ExecuteInput) *jsonrpc.Response {
+func Execute(input ExecuteInput) (response *jsonrpc.Response) {
 	var toolCall struct {
 		Name      string         `json:"name"`
 		Tool      string         `json:"tool"`
@@ -86,12 +106,8 @@ func Execute(input ExecuteInput) *jsonrpc.Response {
 	}
 
 	if strings.HasPrefix(toolName, "godot://") {
-		if !toolspec.IsToolAllowed(toolName, input.Options.PermissionMode, input.Options.AllowedTools) {
-			return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(toolName, tooltypes.NewSemanticError(
-				tooltypes.SemanticKindNotSupported,
-				"Tool call is blocked by permission policy",
-				map[string]any{"reason": "permission_denied", "permission_mode": input.Options.PermissionMode},
-			)))
+		if denied := resourceReadDenied(toolName, arguments, input.Context, input.Options); denied != nil {
+			return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(toolName, denied))
 		}
 		if input.ReadResource == nil {
 			return jsonrpc.NewErrorResponse(input.Message.ID, int(jsonrpc.ErrInvalidParams), "Resource handler is not configured", nil)
@@ -113,7 +129,27 @@ func Execute(input ExecuteInput) *jsonrpc.Response {
 	if !toolspec.ValidateToolName(canonicalToolName) {
 		return jsonrpc.NewErrorResponse(input.Message.ID, int(jsonrpc.ErrInvalidParams), "Invalid tool name", nil)
 	}
+	if auditLog := audit.Default(); shouldAuditTool(canonicalToolName) && auditLog.Enabled() {
+		auditArguments := arguments
+		defer func() {
+			recordAudit(auditLog, canonicalToolName, auditArguments, input.Context, startedAt, response)
+		}()
+	}
 	isInternalBridgeTool := toolspec.IsInternalBridgeTool(canonicalToolName)
+	if denied := authScopeDenied(canonicalToolName, input.Context); denied != nil {
+		return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, denied))
+	}
+	if isInternalBridgeTool && input.Options.BridgeRoleRequired && !toolspec.IsBridgeToolAllowedForRole(canonicalToolName, input.Context.SessionRole) {
+		role := strings.TrimSpace(input.Context.SessionRole)
+		if role == "" {
+			role = toolspec.SessionRoleAgent
+		}
+		return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, tooltypes.NewSemanticError(
+			tooltypes.SemanticKindNotSupported,
+			"Bridge tools are reserved for editor and runtime sessions",
+			map[string]any{"reason": "bridge_role_required", "session_role": role, "allowed_roles": toolspec.BridgeToolRoles(canonicalToolName)},
+		)))
+	}
 
 	if found && tool != nil {
 		if !isInternalBridgeTool && !toolspec.IsToolAllowed(canonicalToolName, input.Options.PermissionMode, input.Options.AllowedTools) {
@@ -123,6 +159,9 @@ func Execute(input ExecuteInput) *jsonrpc.Response {
 				map[string]any{"reason": "permission_denied", "permission_mode": input.Options.PermissionMode},
 			)))
 		}
+		if denied := permissionProfileDenied(canonicalToolName, arguments, input.Context); denied != nil {
+			return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, denied))
+		}
 		if toolspec.IsMutatingTool(canonicalToolName) && !input.Context.MutatingAllowed {
 			return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, tooltypes.NewSemanticError(
 				tooltypes.SemanticKindNotSupported,
@@ -135,9 +174,17 @@ func Execute(input ExecuteInput) *jsonrpc.Response {
 				return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, err))
 			}
 		}
+		if !isInternalBridgeTool {
+			if limited := rateLimited(canonicalToolName, input.Context); limited != nil {
+				return jsonrpc.NewResponse(input.Message.ID, buildToolSemanticErrorResult(canonicalToolName, limited))
+			}
+		}
 	}
 
+	callID, release := tooltypes.RegisterNestedToolCaller(nestedToolCaller(input))
+	defer release()
 	arguments = enrichToolCallArguments(arguments, input.Context, input.Options, progressToken, hasProgressToken)
+	arguments["_mcp"].(map[string]any)["call_id"] = callID
 	result, err := input.ToolManager.CallTool(canonicalToolName, arguments)
 	if err != nil {
 		if semanticErr, ok := tooltypes.AsSemanticError(err); ok {
@@ -152,12 +199,53 @@ func Execute(input ExecuteInput) *jsonrpc.Response {
 	return jsonrpc.NewResponse(input.Message.ID, BuildToolSuccessResult(canonicalToolName, result))
 }
 
+// nestedToolCaller sends tool calls made by a running tool back through
+// Execute with the outer call context, so a scenario step cannot reach a tool
+// the caller's scopes, profile, rate limits or audit log would have stopped.
+func nestedToolCaller(input ExecuteInput) tooltypes.NestedToolCaller {
+	return func(toolName string, arguments map[string]any) ([]byte, error) {
+		params, err := json.Marshal(map[string]any{"name": toolName, "arguments": tooltypes.StripMCPContext(arguments)})
+		if err != nil {
+			return nil, err
+		}
+		nested := input
+		nested.Message.Params = params
+		response := Execute(nested)
+		if response.Error != nil {
+			return nil, fmt.Errorf("%s: %s", toolName, response.Error.Message)
+		}
+		result, _ := response.Result.(map[string]any)
+		if isError, _ := result["isError"].(bool); isError {
+			return nil, nestedToolError(result)
+		}
+		return json.Marshal(result["result"])
+	}
+}
+
+// nestedToolError rebuilds the semantic error from an isError tool result.
+func nestedToolError(result map[string]any) error {
+	message := toolExecutionErrorMessage
+	if content, ok := result["content"].([]map[string]any); ok && len(content) > 0 {
+		if text, ok := content[0]["text"].(string); ok {
+			message = text
+		}
+	}
+	data := map[string]any{}
+	if payload, ok := result["error"].(map[string]any); ok {
+		maps.Copy(data, payload)
+	}
+	kind, _ := data["kind"].(string)
+	delete(data, "kind")
+	return tooltypes.NewSemanticError(kind, message, data)
+}
+
 func BuildToolSuccessResult(toolName string, result any) map[string]any {
+	result, images := tooltypes.SplitToolResultImages(result)
 	return map[string]any{
 		"type":              string(mcp.TypeResult),
 		"tool":              toolName,
 		"result":            result,
-		"content":           ToolContentFromResult(result),
+		"content":           append(ToolContentFromResult(result), images...),
 		"structuredContent": result,
 		"isError":           false,
 	}
@@ -206,6 +294,83 @@ func buildToolSemanticErrorResult(toolName string, semanticErr *tooltypes.Semant
 	}
 }
 
+func authScopeDenied(toolName string, callContext ToolCallContext) *tooltypes.SemanticError {
+	if !callContext.AuthRequired {
+		return nil
+	}
+	required := toolspec.RequiredAuthScope(toolName)
+	if toolspec.HasAuthScope(callContext.AuthScopes, required) {
+		return nil
+	}
+	return tooltypes.NewSemanticError(
+		tooltypes.SemanticKindNotSupported,
+		"Tool call is not allowed for this credential",
+		map[string]any{"reason": "scope_denied", "required_scope": required},
+	)
+}
+
+// ResourceReadDenied applies the scope, permission mode and permission profile
+// checks of the tool backing uri to a resources/read. Resources without a
+// backing tool are not gated.
+func ResourceReadDenied(uri string, callContext ToolCallContext, options ToolCallOptions) *tooltypes.SemanticError {
+	if _, ok := runtimebridge.ParseRuntimeLogResourceURI(uri); !ok {
+		return nil
+	}
+	return resourceReadDenied(uri, map[string]any{}, callContext, options)
+}
+
+// resourceReadDenied gates a resource like the tool it shares data with, so
+// a resource is never a way around a denied tool. Runtime log exports are
+// godot.runtime.log.get for their session.
+func resourceReadDenied(uri string, arguments map[string]any, callContext ToolCallContext, options ToolCallOptions) *tooltypes.SemanticError {
+	toolName := uri
+	if sessionID, ok := runtimebridge.ParseRuntimeLogResourceURI(uri); ok {
+		toolName = "godot.runtime.log.get"
+		arguments = map[string]any{"session_id": sessionID}
+	}
+	if denied := authScopeDenied(toolName, callContext); denied != nil {
+		return denied
+	}
+	if !toolspec.IsToolAllowed(toolName, options.PermissionMode, options.AllowedTools) {
+		return tooltypes.NewSemanticError(
+			tooltypes.SemanticKindNotSupported,
+			"Tool call is blocked by permission policy",
+			map[string]any{"reason": "permission_denied", "permission_mode": options.PermissionMode},
+		)
+	}
+	return permissionProfileDenied(toolName, arguments, callContext)
+}
+
+func permissionProfileDenied(toolName string, arguments map[string]any, callContext ToolCallContext) *tooltypes.SemanticError {
+	denial := callContext.PermissionProfile.Check(toolName, arguments)
+	if denial == nil {
+		return nil
+	}
+	data := map[string]any{"reason": "profile_denied", "profile": callContext.PermissionProfile.Name, "rule": denial.Rule}
+	maps.Copy(data, denial.Detail)
+	return tooltypes.NewSemanticError(tooltypes.SemanticKindNotSupported, "Tool call is blocked by permission profile", data)
+}
+
+// rateLimited applies the per-session and per-tool token buckets. Bridge
+// tools are exempt so a throttled agent cannot stall editor or runtime pushes.
+func rateLimited(toolName string, callContext ToolCallContext) *tooltypes.SemanticError {
+	decision := runtimebridge.DefaultRateLimiter().Allow(strings.TrimSpace(callContext.SessionID), toolName, time.Now())
+	if decision.Allowed {
+		return nil
+	}
+	data := map[string]any{
+		"reason":          tooltypes.RateLimitReasonSession,
+		"scope":           decision.Scope,
+		"rate_per_second": decision.Limit.RatePerSecond,
+		"burst":           decision.Limit.Burst,
+	}
+	if decision.Scope == runtimebridge.RateLimitScopeTool {
+		data["reason"] = tooltypes.RateLimitReasonTool
+		data["pattern"] = decision.Pattern
+	}
+	return tooltypes.NewRateLimitedError("Tool call rate limit exceeded", decision.RetryAfter, data)
+}
+
 func enrichToolCallArguments(arguments map[string]any, callContext ToolCallContext, options ToolCallOptions, progressToken any, hasProgressToken bool) map[string]any {
 	enriched := make(map[string]any, len(arguments)+1)
 	maps.Copy(enriched, arguments)
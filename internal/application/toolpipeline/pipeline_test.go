@@ -5,8 +5,11 @@ import (
 	"os"
 	"testing"
 
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/internal/infra/audit"
 	"github.com/slighter12/godot-mcp-go/logger"
 	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
 	"github.com/slighter12/godot-mcp-go/tools"
 	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
 )
@@ -154,6 +157,235 @@ func TestExecute_MutatingToolRequiresMutatingCapability(t *testing.T) {
 	}
 }
 
+func TestExecute_RecordsMutatingCallsInAuditLog(t *testing.T) {
+	audit.ResetDefaultForTests(t.TempDir(), 30)
+	t.Cleanup(func() { audit.ResetDefaultForTests("", 30) })
+	manager := tools.NewManager()
+	manager.RegisterDefaultTools()
+
+	call := func(tool string, arguments map[string]any) {
+		t.Helper()
+		resp := Execute(ExecuteInput{
+			Message: jsonrpc.Request{
+				JSONRPC: jsonrpc.Version,
+				ID:      tool,
+				Method:  "tools/call",
+				Params:  mustMarshalParams(t, map[string]any{"name": tool, "arguments": arguments}),
+			},
+			ToolManager: manager,
+			Context: ToolCallContext{
+				SessionID:          "session-audit",
+				SessionInitialized: true,
+				SessionRole:        toolspec.SessionRoleAgent,
+				ClientName:         "test-client",
+				AuthTokenID:        "agent-token",
+			},
+			Options: ToolCallOptions{PermissionMode: "allow_all"},
+		})
+		if resp.Error != nil {
+			t.Fatalf("expected JSON-RPC success for %s, got %+v", tool, resp.Error)
+		}
+	}
+	call("godot.scene.list", map[string]any{})
+	call("godot.node.create", map[string]any{"parent": "/root", "type": "Node2D", "api_key": "secret"})
+	call("godot.bridge.command.ack", map[string]any{"command_id": "cmd-7", "success": false, "error": "boom"})
+
+	result, err := audit.Default().Query(audit.Query{})
+	if err != nil {
+		t.Fatalf("query audit log: %v", err)
+	}
+	if len(result.Records) != 2 {
+		t.Fatalf("expected only mutating and ack calls to be audited, got %#v", result.Records)
+	}
+	ack, create := result.Records[0], result.Records[1]
+	if create.Tool != "godot.node.create" || create.Outcome != audit.OutcomeDenied || create.Reason != "mutating_capability_required" {
+		t.Fatalf("unexpected create record %#v", create)
+	}
+	if create.ClientName != "test-client" || create.AuthTokenID != "agent-token" || create.Arguments["api_key"] != "[redacted]" {
+		t.Fatalf("expected caller identity and sanitized arguments, got %#v", create)
+	}
+	if ack.Kind != audit.KindBridgeAck || ack.CommandID != "cmd-7" || ack.Ack["success"] != false || ack.Ack["error"] != "boom" {
+		t.Fatalf("unexpected ack record %#v", ack)
+	}
+	if _, ok := ack.Arguments["_mcp"]; ok {
+		t.Fatalf("expected enriched transport context to stay out of the audit log")
+	}
+}
+
+func TestExecute_RateLimitsToolCallsPerSession(t *testing.T) {
+	runtimebridge.ResetDefaultRateLimiterForTests(runtimebridge.RateLimitPolicy{
+		Tools: map[string]runtimebridge.RateLimit{"godot.scene.*": {RatePerSecond: 0.5, Burst: 1}},
+	})
+	t.Cleanup(func() { runtimebridge.ResetDefaultRateLimiterForTests(runtimebridge.RateLimitPolicy{}) })
+	manager := tools.NewManager()
+	manager.RegisterDefaultTools()
+
+	call := func(sessionID string) map[string]any {
+		t.Helper()
+		resp := Execute(ExecuteInput{
+			Message: jsonrpc.Request{
+				JSONRPC: jsonrpc.Version,
+				ID:      "rate",
+				Method:  "tools/call",
+				Params:  mustMarshalParams(t, map[string]any{"name": "godot.scene.list", "arguments": map[string]any{}}),
+			},
+			ToolManager: manager,
+			Context:     ToolCallContext{SessionID: sessionID, SessionInitialized: true},
+			Options:     ToolCallOptions{PermissionMode: "allow_all"},
+		})
+		if resp.Error != nil {
+			t.Fatalf("expected JSON-RPC success, got %+v", resp.Error)
+		}
+		return mustMap(t, resp.Result)
+	}
+
+	if result := call("session-a"); result["isError"] == true {
+		t.Fatalf("expected first call to pass, got %#v", result)
+	}
+	result := call("session-a")
+	errPayload := mustMap(t, result["error"])
+	if result["isError"] != true || errPayload["kind"] != tooltypes.SemanticKindRateLimited || errPayload["reason"] != tooltypes.RateLimitReasonTool {
+		t.Fatalf("expected rate_limited error, got %#v", result)
+	}
+	if retryAfter, _ := errPayload["retry_after_ms"].(int64); errPayload["pattern"] != "godot.scene.*" || retryAfter < 1000 || retryAfter > 2000 {
+		t.Fatalf("expected pattern and retry_after_ms, got %#v", errPayload)
+	}
+	if result := call("session-b"); result["isError"] == true {
+		t.Fatalf("expected other sessions to keep their own budget, got %#v", result)
+	}
+}
+
+func TestExecute_PermissionProfileDeniesByRule(t *testing.T) {
+	manager := tools.NewManager()
+	manager.RegisterDefaultTools()
+	profile := &toolspec.PermissionProfile{
+		Name:    "reviewer",
+		Classes: []string{toolspec.ToolClassReadOnly},
+		Deny:    []string{"godot.script.*"},
+		ArgumentConstraints: []toolspec.ArgumentConstraint{
+			{Tool: "godot.scene.*", Argument: "path", PathPrefixes: []string{"res://levels/"}},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		tool      string
+		arguments map[string]any
+		rule      string
+	}{
+		{name: "deny glob", tool: "godot.script.read", arguments: map[string]any{"path": "res://main.gd"}, rule: "deny"},
+		{name: "mutating class", tool: "godot.node.create", arguments: map[string]any{}, rule: "class"},
+		{name: "path outside prefix", tool: "godot.scene.read", arguments: map[string]any{"path": "res://menus/Main.tscn"}, rule: "argument"},
+		{name: "dot-dot escape", tool: "godot.scene.read", arguments: map[string]any{"path": "res://levels/../menus/Main.tscn"}, rule: "argument"},
+		{name: "path inside prefix", tool: "godot.scene.read", arguments: map[string]any{"path": "res://levels/missing.tscn"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := Execute(ExecuteInput{
+				Message: jsonrpc.Request{
+					JSONRPC: jsonrpc.Version,
+					ID:      "profile",
+					Method:  "tools/call",
+					Params:  mustMarshalParams(t, map[string]any{"name": tc.tool, "arguments": tc.arguments}),
+				},
+				ToolManager: manager,
+				Context: ToolCallContext{
+					SessionID:          "session-profile",
+					SessionInitialized: true,
+					MutatingAllowed:    true,
+					PermissionProfile:  profile,
+				},
+				Options: ToolCallOptions{PermissionMode: "allow_all"},
+			})
+			if resp.Error != nil {
+				t.Fatalf("expected JSON-RPC success, got %+v", resp.Error)
+			}
+			result := mustMap(t, resp.Result)
+			errPayload, _ := result["error"].(map[string]any)
+			if tc.rule == "" {
+				if errPayload != nil && errPayload["reason"] == "profile_denied" {
+					t.Fatalf("expected profile to allow call, got %v", errPayload)
+				}
+				return
+			}
+			if errPayload == nil || errPayload["reason"] != "profile_denied" || errPayload["rule"] != tc.rule || errPayload["profile"] != "reviewer" {
+				t.Fatalf("expected profile_denied by %s, got %v", tc.rule, result["error"])
+			}
+		})
+	}
+}
+
+func TestExecute_NestedScenarioStepsPassThePermissionProfile(t *testing.T) {
+	manager := tools.NewManager()
+	manager.RegisterDefaultTools()
+	sent := 0
+	runtimebridge.SetNotificationSender(func(string, map[string]any) bool {
+		sent++
+		return false
+	})
+	t.Cleanup(func() { runtimebridge.SetNotificationSender(nil) })
+
+	resp := Execute(ExecuteInput{
+		Message: jsonrpc.Request{
+			JSONRPC: jsonrpc.Version,
+			ID:      "scenario",
+			Method:  "tools/call",
+			Params: mustMarshalParams(t, map[string]any{
+				"name": "godot.test.scenario.run",
+				"arguments": map[string]any{
+					"session_id": "game_1",
+					"scenario":   map[string]any{"steps": []any{map[string]any{"action": "tap", "input": "ui_right"}}},
+				},
+			}),
+		},
+		ToolManager: manager,
+		Context: ToolCallContext{
+			SessionID:          "session-scenario",
+			SessionInitialized: true,
+			MutatingAllowed:    true,
+			PermissionProfile:  &toolspec.PermissionProfile{Name: "no-input", Deny: []string{"godot.runtime.input.*"}},
+		},
+		Options: ToolCallOptions{PermissionMode: "allow_all", SchemaValidationEnabled: true},
+	})
+	if resp.Error != nil {
+		t.Fatalf("expected JSON-RPC success, got %+v", resp.Error)
+	}
+	result := mustMap(t, resp.Result)
+	report, _ := result["result"].(map[string]any)
+	steps, _ := report["steps"].([]any)
+	if len(steps) != 1 {
+		t.Fatalf("expected one step result, got %v", result)
+	}
+	step, _ := steps[0].(map[string]any)
+	details, _ := step["details"].(map[string]any)
+	if step["status"] != "error" || details["reason"] != "profile_denied" || details["profile"] != "no-input" {
+		t.Fatalf("expected tap step to be denied by the profile, got %v", step)
+	}
+	if sent != 0 {
+		t.Fatalf("expected no runtime command to be sent, got %d", sent)
+	}
+}
+
+func TestBuildToolSuccessResult_MovesImagesIntoContentBlocks(t *testing.T) {
+	result := BuildToolSuccessResult("godot.runtime.screenshot.get", map[string]any{
+		"width": float64(2),
+		tooltypes.ToolResultImagesKey: []any{
+			map[string]any{"data": "iVBORw0KGgo=", "mimeType": "image/png"},
+		},
+	})
+	structured := mustMap(t, result["structuredContent"])
+	if _, ok := structured[tooltypes.ToolResultImagesKey]; ok {
+		t.Fatalf("expected image payload to be stripped from structured content, got %v", structured)
+	}
+	content, ok := result["content"].([]map[string]any)
+	if !ok || len(content) != 2 {
+		t.Fatalf("expected text and image content blocks, got %#v", result["content"])
+	}
+	if content[0]["type"] != "text" || content[1]["type"] != "image" || content[1]["mimeType"] != "image/png" || content[1]["data"] != "iVBORw0KGgo=" {
+		t.Fatalf("unexpected content blocks: %v", content)
+	}
+}
+
 func mustMarshalParams(t *testing.T, payload map[string]any) json.RawMessage {
 	t.Helper()
 	raw, err := json.Marshal(payload)
@@ -0,0 +1,154 @@
+// Package imagediff compares two images pixel by pixel and renders a diff
+// image highlighting the pixels that differ.
+//
+// Pixel mode compares the largest per-channel RGBA delta. Perceptual mode uses
+// the YIQ color distance, which weights luma over chroma and ignores most
+// differences the eye would not notice (e.g. slight dithering).
+package imagediff
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+)
+
+const (
+	ModePixel      = "pixel"
+	ModePerceptual = "perceptual"
+)
+
+// maxYIQDelta is the YIQ distance between black and white, used to scale
+// perceptual deltas to 0..1.
+const maxYIQDelta = 35215.0
+
+var diffColor = color.RGBA{R: 255, A: 255}
+
+// Options configures a comparison. Threshold is the per-pixel delta in 0..1
+// above which a pixel counts as different.
+type Options struct {
+	Threshold float64
+	Mode      string
+}
+
+// Result summarizes a comparison.
+type Result struct {
+	Width       int
+	Height      int
+	DiffPixels  int
+	TotalPixels int
+	DiffRatio   float64
+	MaxDelta    float64
+	// Diff shows matching pixels as faded grayscale and differing pixels in red.
+	Diff *image.RGBA
+}
+
+// SizeMismatchError reports images with different bounds.
+type SizeMismatchError struct {
+	Baseline  image.Point
+	Candidate image.Point
+}
+
+func (e *SizeMismatchError) Error() string {
+	return fmt.Sprintf("image size mismatch: baseline %dx%d, candidate %dx%d",
+		e.Baseline.X, e.Baseline.Y, e.Candidate.X, e.Candidate.Y)
+}
+
+// Compare diffs candidate against baseline. Both images must have the same size.
+func Compare(baseline image.Image, candidate image.Image, options Options) (Result, error) {
+	mode := options.Mode
+	if mode == "" {
+		mode = ModePerceptual
+	}
+	if mode != ModePixel && mode != ModePerceptual {
+		return Result{}, fmt.Errorf("unknown diff mode %q (expected %s or %s)", mode, ModePixel, ModePerceptual)
+	}
+	if options.Threshold < 0 || options.Threshold > 1 {
+		return Result{}, fmt.Errorf("threshold %v out of range 0..1", options.Threshold)
+	}
+	bb := baseline.Bounds()
+	cb := candidate.Bounds()
+	if bb.Dx() != cb.Dx() || bb.Dy() != cb.Dy() {
+		return Result{}, &SizeMismatchError{Baseline: bb.Size(), Candidate: cb.Size()}
+	}
+
+	width, height := bb.Dx(), bb.Dy()
+	result := Result{
+		Width:       width,
+		Height:      height,
+		TotalPixels: width * height,
+		Diff:        image.NewRGBA(image.Rect(0, 0, width, height)),
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			a := color.NRGBA64Model.Convert(baseline.At(bb.Min.X+x, bb.Min.Y+y)).(color.NRGBA64)
+			b := color.NRGBA64Model.Convert(candidate.At(cb.Min.X+x, cb.Min.Y+y)).(color.NRGBA64)
+			var delta float64
+			if mode == ModePixel {
+				delta = pixelDelta(a, b)
+			} else {
+				delta = perceptualDelta(a, b)
+			}
+			result.MaxDelta = max(result.MaxDelta, delta)
+			if delta > options.Threshold {
+				result.DiffPixels++
+				result.Diff.SetRGBA(x, y, diffColor)
+				continue
+			}
+			result.Diff.SetRGBA(x, y, fadedGray(a))
+		}
+	}
+	if result.TotalPixels > 0 {
+		result.DiffRatio = float64(result.DiffPixels) / float64(result.TotalPixels)
+	}
+	return result, nil
+}
+
+func pixelDelta(a color.NRGBA64, b color.NRGBA64) float64 {
+	delta := max(
+		channelDelta(a.R, b.R),
+		channelDelta(a.G, b.G),
+		channelDelta(a.B, b.B),
+		channelDelta(a.A, b.A),
+	)
+	return float64(delta) / 0xffff
+}
+
+func channelDelta(a uint16, b uint16) uint16 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// perceptualDelta returns the YIQ distance of both colors blended over white,
+// scaled to 0..1 so black versus white is 1.
+func perceptualDelta(a color.NRGBA64, b color.NRGBA64) float64 {
+	ar, ag, ab := blendOverWhite(a)
+	br, bg, bb := blendOverWhite(b)
+	dy := rgbToY(ar, ag, ab) - rgbToY(br, bg, bb)
+	di := rgbToI(ar, ag, ab) - rgbToI(br, bg, bb)
+	dq := rgbToQ(ar, ag, ab) - rgbToQ(br, bg, bb)
+	distance := 0.5053*dy*dy + 0.299*di*di + 0.1957*dq*dq
+	return math.Min(1, math.Sqrt(distance/maxYIQDelta))
+}
+
+func blendOverWhite(c color.NRGBA64) (float64, float64, float64) {
+	alpha := float64(c.A) / 0xffff
+	blend := func(channel uint16) float64 {
+		return 255 + (float64(channel)/0xffff*255-255)*alpha
+	}
+	return blend(c.R), blend(c.G), blend(c.B)
+}
+
+func rgbToY(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgbToI(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgbToQ(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+func fadedGray(c color.NRGBA64) color.RGBA {
+	r, g, b := blendOverWhite(c)
+	luma := rgbToY(r, g, b)
+	// Fade toward white so red diff pixels stand out.
+	value := uint8(math.Round(255 - (255-luma)*0.25))
+	return color.RGBA{R: value, G: value, B: value, A: 255}
+}
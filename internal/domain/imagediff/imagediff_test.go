@@ -0,0 +1,75 @@
+package imagediff
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func solidImage(width int, height int, c color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompare_CountsDifferingPixelsAndMarksDiffImage(t *testing.T) {
+	baseline := solidImage(4, 4, color.NRGBA{R: 40, G: 80, B: 120, A: 255})
+	candidate := solidImage(4, 4, color.NRGBA{R: 40, G: 80, B: 120, A: 255})
+	candidate.Set(1, 2, color.NRGBA{R: 255, G: 255, B: 255, A: 255})
+	candidate.Set(3, 3, color.NRGBA{R: 0, G: 0, B: 0, A: 255})
+
+	for _, mode := range []string{ModePixel, ModePerceptual} {
+		result, err := Compare(baseline, candidate, Options{Threshold: 0.1, Mode: mode})
+		if err != nil {
+			t.Fatalf("%s: compare: %v", mode, err)
+		}
+		if result.DiffPixels != 2 || result.TotalPixels != 16 || result.DiffRatio != 0.125 {
+			t.Fatalf("%s: unexpected result %+v", mode, result)
+		}
+		if got := result.Diff.RGBAAt(1, 2); got != diffColor {
+			t.Fatalf("%s: expected diff pixel to be red, got %v", mode, got)
+		}
+		if got := result.Diff.RGBAAt(0, 0); got.R != got.G || got.R == 255 && got.G == 0 {
+			t.Fatalf("%s: expected matching pixel to be gray, got %v", mode, got)
+		}
+	}
+}
+
+func TestCompare_PerceptualModeIgnoresSmallColorShifts(t *testing.T) {
+	baseline := solidImage(2, 2, color.NRGBA{R: 100, G: 100, B: 100, A: 255})
+	candidate := solidImage(2, 2, color.NRGBA{R: 104, G: 100, B: 100, A: 255})
+
+	perceptual, err := Compare(baseline, candidate, Options{Threshold: 0.01, Mode: ModePerceptual})
+	if err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if perceptual.DiffPixels != 0 {
+		t.Fatalf("expected perceptual mode to ignore a small red shift, got %d diff pixels", perceptual.DiffPixels)
+	}
+	pixel, err := Compare(baseline, candidate, Options{Threshold: 0.01, Mode: ModePixel})
+	if err != nil {
+		t.Fatalf("compare: %v", err)
+	}
+	if pixel.DiffPixels != 4 {
+		t.Fatalf("expected pixel mode to flag every pixel, got %d", pixel.DiffPixels)
+	}
+}
+
+func TestCompare_RejectsSizeMismatchAndInvalidOptions(t *testing.T) {
+	_, err := Compare(solidImage(2, 2, color.White), solidImage(3, 2, color.White), Options{})
+	var mismatch *SizeMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Candidate.X != 3 {
+		t.Fatalf("expected size mismatch error, got %v", err)
+	}
+	if _, err := Compare(solidImage(1, 1, color.White), solidImage(1, 1, color.White), Options{Mode: "fuzzy"}); err == nil {
+		t.Fatalf("expected unknown mode error")
+	}
+	if _, err := Compare(solidImage(1, 1, color.White), solidImage(1, 1, color.White), Options{Threshold: 2}); err == nil {
+		t.Fatalf("expected threshold range error")
+	}
+}
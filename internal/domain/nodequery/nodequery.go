@@ -0,0 +1,367 @@
+// Package nodequery implements a small CSS-like selector language for Godot
+// scene trees.
+//
+// Grammar:
+//
+//	query      := selector (',' selector)*
+//	selector   := compound (combinator compound)*
+//	combinator := '>' (direct child) | whitespace (any descendant)
+//	compound   := (Type | '*')? attribute*
+//	attribute  := '[' key ('=' | '!=') value ']'
+//	key        := name | type | group | script | path
+//
+// Attribute values may be quoted and are matched as path.Match globs, e.g.
+// `Node2D > CharacterBody2D[group=enemies][name=Goblin*]`.
+package nodequery
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Node is one scene tree node as seen by the query engine.
+type Node struct {
+	Path     string
+	Name     string
+	Type     string
+	Script   string
+	Groups   []string
+	Children []Node
+}
+
+// Match is one node selected by a query.
+type Match struct {
+	Path   string   `json:"path"`
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Script string   `json:"script,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	Depth  int      `json:"depth"`
+}
+
+// SyntaxError reports an invalid selector with the byte offset of the problem.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid selector at %d: %s", e.Pos, e.Msg)
+}
+
+// Query is a parsed selector list.
+type Query struct {
+	selectors []selector
+}
+
+type selector struct {
+	compounds []compound
+	// childOf[i] reports whether compounds[i+1] must be a direct child of compounds[i].
+	childOf []bool
+}
+
+type compound struct {
+	typeName   string
+	attributes []attribute
+}
+
+type attribute struct {
+	key    string
+	negate bool
+	value  string
+}
+
+var attributeKeys = map[string]struct{}{
+	"name":   {},
+	"type":   {},
+	"group":  {},
+	"script": {},
+	"path":   {},
+}
+
+// Parse compiles a selector expression.
+func Parse(expr string) (Query, error) {
+	p := &parser{input: expr}
+	query, err := p.parseQuery()
+	if err != nil {
+		return Query{}, err
+	}
+	return query, nil
+}
+
+// Evaluate returns nodes matching the query in depth-first order. Nodes deeper
+// than maxDepth (root depth is 0) are not visited; maxDepth <= 0 means unlimited.
+func (q Query) Evaluate(root Node, maxDepth int) []Match {
+	matches := []Match{}
+	ancestors := []Node{}
+	var walk func(node Node, depth int)
+	walk = func(node Node, depth int) {
+		if maxDepth > 0 && depth > maxDepth {
+			return
+		}
+		if strings.TrimSpace(node.Path) == "" && node.Name == "" {
+			return
+		}
+		for _, sel := range q.selectors {
+			if sel.matches(node, ancestors) {
+				matches = append(matches, Match{
+					Path:   node.Path,
+					Name:   node.Name,
+					Type:   node.Type,
+					Script: node.Script,
+					Groups: node.Groups,
+					Depth:  depth,
+				})
+				break
+			}
+		}
+		ancestors = append(ancestors, node)
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+		ancestors = ancestors[:len(ancestors)-1]
+	}
+	walk(root, 0)
+	return matches
+}
+
+func (s selector) matches(node Node, ancestors []Node) bool {
+	last := len(s.compounds) - 1
+	if !s.compounds[last].matches(node) {
+		return false
+	}
+	return s.matchAncestors(last-1, ancestors)
+}
+
+// matchAncestors checks compounds[0..index] against the ancestor chain, where
+// the last ancestor is the parent of the node matched by compounds[index+1].
+func (s selector) matchAncestors(index int, ancestors []Node) bool {
+	if index < 0 {
+		return true
+	}
+	if s.childOf[index] {
+		if len(ancestors) == 0 {
+			return false
+		}
+		parent := ancestors[len(ancestors)-1]
+		return s.compounds[index].matches(parent) && s.matchAncestors(index-1, ancestors[:len(ancestors)-1])
+	}
+	for i := len(ancestors) - 1; i >= 0; i-- {
+		if s.compounds[index].matches(ancestors[i]) && s.matchAncestors(index-1, ancestors[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c compound) matches(node Node) bool {
+	if c.typeName != "" && c.typeName != "*" && c.typeName != node.Type {
+		return false
+	}
+	for _, attr := range c.attributes {
+		if attr.matches(node) == attr.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (a attribute) matches(node Node) bool {
+	switch a.key {
+	case "name":
+		return globMatch(a.value, node.Name)
+	case "type":
+		return globMatch(a.value, node.Type)
+	case "script":
+		return globMatch(a.value, node.Script)
+	case "path":
+		return globMatch(a.value, node.Path)
+	case "group":
+		for _, group := range node.Groups {
+			if globMatch(a.value, group) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func globMatch(pattern string, value string) bool {
+	matched, err := path.Match(pattern, value)
+	if err != nil {
+		return pattern == value
+	}
+	return matched
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) parseQuery() (Query, error) {
+	query := Query{}
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return Query{}, err
+		}
+		query.selectors = append(query.selectors, sel)
+		p.skipSpaces()
+		if p.eof() {
+			return query, nil
+		}
+		if p.peek() != ',' {
+			return Query{}, p.errorf("unexpected %q", p.peek())
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	sel := selector{}
+	first, err := p.parseCompound()
+	if err != nil {
+		return selector{}, err
+	}
+	sel.compounds = append(sel.compounds, first)
+	for {
+		hadSpace := p.skipSpaces()
+		if p.eof() || p.peek() == ',' {
+			return sel, nil
+		}
+		childOf := false
+		if p.peek() == '>' {
+			childOf = true
+			p.pos++
+			p.skipSpaces()
+		} else if !hadSpace {
+			return selector{}, p.errorf("unexpected %q", p.peek())
+		}
+		next, err := p.parseCompound()
+		if err != nil {
+			return selector{}, err
+		}
+		sel.compounds = append(sel.compounds, next)
+		sel.childOf = append(sel.childOf, childOf)
+	}
+}
+
+func (p *parser) parseCompound() (compound, error) {
+	start := p.pos
+	out := compound{}
+	if !p.eof() && p.peek() == '*' {
+		out.typeName = "*"
+		p.pos++
+	} else {
+		out.typeName = p.readIdentifier()
+	}
+	for !p.eof() && p.peek() == '[' {
+		attr, err := p.parseAttribute()
+		if err != nil {
+			return compound{}, err
+		}
+		out.attributes = append(out.attributes, attr)
+	}
+	if p.pos == start {
+		if p.eof() {
+			return compound{}, p.errorf("expected type, '*' or attribute")
+		}
+		return compound{}, p.errorf("unexpected %q", p.peek())
+	}
+	return out, nil
+}
+
+func (p *parser) parseAttribute() (attribute, error) {
+	p.pos++ // '['
+	p.skipSpaces()
+	keyPos := p.pos
+	key := strings.ToLower(p.readIdentifier())
+	if _, ok := attributeKeys[key]; !ok {
+		p.pos = keyPos
+		return attribute{}, p.errorf("unknown attribute %q (expected name, type, group, script or path)", key)
+	}
+	p.skipSpaces()
+	attr := attribute{key: key}
+	switch {
+	case strings.HasPrefix(p.input[p.pos:], "!="):
+		attr.negate = true
+		p.pos += 2
+	case strings.HasPrefix(p.input[p.pos:], "="):
+		p.pos++
+	default:
+		return attribute{}, p.errorf("expected '=' or '!=' after %s", key)
+	}
+	p.skipSpaces()
+	value, err := p.readValue()
+	if err != nil {
+		return attribute{}, err
+	}
+	attr.value = value
+	p.skipSpaces()
+	if p.eof() || p.peek() != ']' {
+		return attribute{}, p.errorf("expected ']'")
+	}
+	p.pos++
+	return attr, nil
+}
+
+func (p *parser) readValue() (string, error) {
+	if p.eof() {
+		return "", p.errorf("expected attribute value")
+	}
+	if quote := p.peek(); quote == '"' || quote == '\'' {
+		start := p.pos
+		p.pos++
+		end := strings.IndexByte(p.input[p.pos:], quote)
+		if end < 0 {
+			p.pos = start
+			return "", p.errorf("unterminated quoted value")
+		}
+		value := p.input[p.pos : p.pos+end]
+		p.pos += end + 1
+		return value, nil
+	}
+	start := p.pos
+	for !p.eof() && p.peek() != ']' {
+		p.pos++
+	}
+	value := strings.TrimSpace(p.input[start:p.pos])
+	if value == "" {
+		return "", p.errorf("expected attribute value")
+	}
+	return value, nil
+}
+
+func (p *parser) readIdentifier() string {
+	start := p.pos
+	for !p.eof() {
+		c := p.peek()
+		if c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) skipSpaces() bool {
+	start := p.pos
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t' || p.peek() == '\n') {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+func (p *parser) peek() byte { return p.input[p.pos] }
+
+func (p *parser) eof() bool { return p.pos >= len(p.input) }
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
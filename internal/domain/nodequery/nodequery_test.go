@@ -0,0 +1,72 @@
+package nodequery
+
+import (
+	"errors"
+	"testing"
+)
+
+func sampleTree() Node {
+	return Node{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []Node{
+		{Path: "/root/Main/Player", Name: "Player", Type: "CharacterBody2D", Script: "res://player/player.gd", Groups: []string{"players"}},
+		{Path: "/root/Main/Enemies", Name: "Enemies", Type: "Node2D", Children: []Node{
+			{Path: "/root/Main/Enemies/Goblin1", Name: "Goblin1", Type: "CharacterBody2D", Script: "res://enemies/goblin.gd", Groups: []string{"enemies"}},
+			{Path: "/root/Main/Enemies/Goblin2", Name: "Goblin2", Type: "CharacterBody2D", Script: "res://enemies/goblin.gd", Groups: []string{"enemies"}, Children: []Node{
+				{Path: "/root/Main/Enemies/Goblin2/Sprite", Name: "Sprite", Type: "Sprite2D"},
+			}},
+		}},
+	}}
+}
+
+func evaluatePaths(t *testing.T, expr string, maxDepth int) []string {
+	t.Helper()
+	query, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("parse %q: %v", expr, err)
+	}
+	paths := []string{}
+	for _, match := range query.Evaluate(sampleTree(), maxDepth) {
+		paths = append(paths, match.Path)
+	}
+	return paths
+}
+
+func TestEvaluate_MatchesTypeAndAttributes(t *testing.T) {
+	cases := map[string][]string{
+		"CharacterBody2D[group=enemies]":        {"/root/Main/Enemies/Goblin1", "/root/Main/Enemies/Goblin2"},
+		"CharacterBody2D[group!=enemies]":       {"/root/Main/Player"},
+		"*[name=Goblin*][name!=Goblin1]":        {"/root/Main/Enemies/Goblin2"},
+		`[script="res://player/*.gd"]`:          {"/root/Main/Player"},
+		"[name=Main] > CharacterBody2D":         {"/root/Main/Player"},
+		"[name=Enemies] Sprite2D":               {"/root/Main/Enemies/Goblin2/Sprite"},
+		"[name=Enemies] > Sprite2D":             {},
+		"Sprite2D, CharacterBody2D[name=Play*]": {"/root/Main/Player", "/root/Main/Enemies/Goblin2/Sprite"},
+	}
+	for expr, want := range cases {
+		got := evaluatePaths(t, expr, 0)
+		if len(got) != len(want) {
+			t.Fatalf("%q: expected %v, got %v", expr, want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%q: expected %v, got %v", expr, want, got)
+			}
+		}
+	}
+}
+
+func TestEvaluate_RespectsMaxDepth(t *testing.T) {
+	got := evaluatePaths(t, "*", 1)
+	if len(got) != 3 {
+		t.Fatalf("expected root and two depth-1 nodes, got %v", got)
+	}
+}
+
+func TestParse_ReportsSyntaxErrors(t *testing.T) {
+	for _, expr := range []string{"", "Node2D[", "Node2D[owner=x]", "Node2D[name=\"x]", "Node2D >", "Node2D,,"} {
+		_, err := Parse(expr)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("%q: expected syntax error, got %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Package testreport turns the output of Godot unit test runners into one
+// structured report.
+//
+// JUnit XML, written by both GUT (-gjunit_xml_file) and GdUnit4 (results.xml
+// in its report directory), is the primary source. The console parsers are a
+// best-effort fallback for runs that exited before writing the XML file.
+package testreport
+
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	StatusPassed  = "passed"
+	StatusFailed  = "failed"
+	StatusError   = "error"
+	StatusSkipped = "skipped"
+)
+
+// Case is one test case result.
+type Case struct {
+	Suite      string  `json:"suite"`
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	DurationMS float64 `json:"duration_ms"`
+	Message    string  `json:"message,omitempty"`
+	Details    string  `json:"details,omitempty"`
+	File       string  `json:"file,omitempty"`
+	Line       int     `json:"line,omitempty"`
+}
+
+// Suite aggregates the cases of one test script.
+type Suite struct {
+	Name       string  `json:"name"`
+	File       string  `json:"file,omitempty"`
+	Tests      int     `json:"tests"`
+	Passed     int     `json:"passed"`
+	Failed     int     `json:"failed"`
+	Errors     int     `json:"errors"`
+	Skipped    int     `json:"skipped"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+// Summary counts cases by status.
+type Summary struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Errors  int `json:"errors"`
+	Skipped int `json:"skipped"`
+}
+
+// Report is a parsed test run.
+type Report struct {
+	Suites  []Suite `json:"suites"`
+	Cases   []Case  `json:"cases"`
+	Summary Summary `json:"summary"`
+}
+
+// OK reports whether at least one test ran and none failed or errored.
+func (r Report) OK() bool {
+	return r.Summary.Total > 0 && r.Summary.Failed == 0 && r.Summary.Errors == 0
+}
+
+var (
+	scriptLocationPattern = regexp.MustCompile(`(res://[^\s:'"()\[\]]+\.gd)(?::(\d+))?`)
+	linePattern           = regexp.MustCompile(`(?i)\bline:?\s+(\d+)`)
+	ansiPattern           = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+)
+
+type junitSuites struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name    string       `xml:"name,attr"`
+	Package string       `xml:"package,attr"`
+	File    string       `xml:"file,attr"`
+	Cases   []junitCase  `xml:"testcase"`
+	Suites  []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Line      string        `xml:"line,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *junitProblem `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// ParseJUnitXML parses a <testsuites> or <testsuite> document.
+func ParseJUnitXML(data []byte) (Report, error) {
+	var root struct {
+		XMLName xml.Name
+		junitSuite
+		Suites []junitSuite `xml:"testsuite"`
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return Report{}, fmt.Errorf("invalid junit xml: %w", err)
+	}
+	var suites []junitSuite
+	switch root.XMLName.Local {
+	case "testsuites":
+		suites = root.Suites
+	case "testsuite":
+		suite := root.junitSuite
+		suite.Suites = root.Suites
+		suites = []junitSuite{suite}
+	default:
+		return Report{}, fmt.Errorf("invalid junit xml: unexpected root element <%s>", root.XMLName.Local)
+	}
+
+	builder := newReportBuilder()
+	var walk func(suite junitSuite)
+	walk = func(suite junitSuite) {
+		suiteFile := junitSuiteFile(suite)
+		builder.suite(suite.Name, suiteFile)
+		for _, item := range suite.Cases {
+			result := Case{
+				Suite:  suite.Name,
+				Name:   strings.TrimSpace(item.Name),
+				Status: StatusPassed,
+				File:   firstNonEmpty(strings.TrimSpace(item.File), suiteFile),
+			}
+			if seconds, err := strconv.ParseFloat(strings.TrimSpace(item.Time), 64); err == nil {
+				result.DurationMS = seconds * 1000
+			}
+			if line, err := strconv.Atoi(strings.TrimSpace(item.Line)); err == nil {
+				result.Line = line
+			}
+			problem := item.Failure
+			switch {
+			case item.Failure != nil:
+				result.Status = StatusFailed
+			case item.Error != nil:
+				result.Status = StatusError
+				problem = item.Error
+			case item.Skipped != nil:
+				result.Status = StatusSkipped
+				problem = item.Skipped
+			}
+			if problem != nil {
+				result.Message = strings.TrimSpace(problem.Message)
+				result.Details = strings.TrimSpace(problem.Body)
+				if result.Message == "" {
+					result.Message = firstLine(result.Details)
+				}
+				locateCase(&result, result.Message+"\n"+result.Details)
+			}
+			builder.add(result)
+		}
+		for _, nested := range suite.Suites {
+			walk(nested)
+		}
+	}
+	for _, suite := range suites {
+		walk(suite)
+	}
+	return builder.report(), nil
+}
+
+// ParseGUTConsole reads GUT's console log: a res:// script line starts a
+// suite, "* name" starts a test, and [Failed]/[Pending] lines with a
+// following "at line N" describe its outcome.
+func ParseGUTConsole(output string) Report {
+	builder := newReportBuilder()
+	suite := ""
+	var current *Case
+	flush := func() {
+		if current != nil {
+			builder.add(*current)
+			current = nil
+		}
+	}
+	for _, raw := range strings.Split(stripANSI(output), "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(line, "res://") && strings.Contains(line, ".gd"):
+			flush()
+			suite = line
+			builder.suite(suite, scriptFile(suite))
+		case strings.HasPrefix(line, "* ") && suite != "":
+			flush()
+			current = &Case{Suite: suite, Name: strings.TrimSpace(line[2:]), Status: StatusPassed, File: scriptFile(suite)}
+		case current == nil:
+		case strings.HasPrefix(line, "[Failed]"):
+			current.Status = StatusFailed
+			appendMessage(current, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "[Failed]"), ":")))
+		case strings.HasPrefix(line, "[Pending]"):
+			if current.Status == StatusPassed {
+				current.Status = StatusSkipped
+			}
+			appendMessage(current, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "[Pending]"), ":")))
+		case strings.HasPrefix(line, "[ERROR]"):
+			if current.Status == StatusPassed {
+				current.Status = StatusError
+			}
+			appendMessage(current, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(line, "[ERROR]"), ":")))
+		case strings.HasPrefix(line, "at line"):
+			if current.Line == 0 {
+				locateCase(current, line)
+			}
+		}
+	}
+	flush()
+	return builder.report()
+}
+
+var gdunitCasePattern = regexp.MustCompile(`^(\S+)\s+>\s+(\S+)\s+(PASSED|FAILED|ERROR|ERRORS|SKIPPED|FLAKY)\b(?:\s+(\d+)\s*ms)?`)
+
+// ParseGdUnitConsole reads GdUnit4's console log: "Run Test Suite: res://..."
+// starts a suite and "suite > test STATUS 12ms" lines report each test, with
+// "line N: message" report lines following a failure.
+func ParseGdUnitConsole(output string) Report {
+	builder := newReportBuilder()
+	suite := ""
+	var failedCase *Case
+	flush := func() {
+		if failedCase != nil {
+			builder.add(*failedCase)
+			failedCase = nil
+		}
+	}
+	for _, raw := range strings.Split(stripANSI(output), "\n") {
+		line := strings.TrimSpace(raw)
+		if after, ok := strings.CutPrefix(line, "Run Test Suite:"); ok {
+			flush()
+			suite = strings.TrimSpace(after)
+			builder.suite(suite, scriptFile(suite))
+			continue
+		}
+		if match := gdunitCasePattern.FindStringSubmatch(line); match != nil {
+			flush()
+			result := Case{Suite: firstNonEmpty(suite, match[1]), Name: match[2], File: scriptFile(suite)}
+			if suite == "" {
+				builder.suite(result.Suite, "")
+			}
+			if ms, err := strconv.ParseFloat(match[4], 64); err == nil {
+				result.DurationMS = ms
+			}
+			switch match[3] {
+			case "PASSED", "FLAKY":
+				result.Status = StatusPassed
+			case "SKIPPED":
+				result.Status = StatusSkipped
+			case "FAILED":
+				result.Status = StatusFailed
+			default:
+				result.Status = StatusError
+			}
+			if result.Status == StatusFailed || result.Status == StatusError {
+				failedCase = &result
+				continue
+			}
+			builder.add(result)
+			continue
+		}
+		if failedCase != nil && linePattern.MatchString(line) {
+			if failedCase.Line == 0 {
+				locateCase(failedCase, line)
+			}
+			appendMessage(failedCase, line)
+		}
+	}
+	flush()
+	return builder.report()
+}
+
+type reportBuilder struct {
+	suites []Suite
+	index  map[string]int
+	cases  []Case
+}
+
+func newReportBuilder() *reportBuilder {
+	return &reportBuilder{index: make(map[string]int)}
+}
+
+func (b *reportBuilder) suite(name string, file string) {
+	if _, ok := b.index[name]; ok {
+		return
+	}
+	b.index[name] = len(b.suites)
+	b.suites = append(b.suites, Suite{Name: name, File: file})
+}
+
+func (b *reportBuilder) add(result Case) {
+	b.suite(result.Suite, result.File)
+	suite := &b.suites[b.index[result.Suite]]
+	suite.Tests++
+	suite.DurationMS += result.DurationMS
+	switch result.Status {
+	case StatusPassed:
+		suite.Passed++
+	case StatusFailed:
+		suite.Failed++
+	case StatusError:
+		suite.Errors++
+	case StatusSkipped:
+		suite.Skipped++
+	}
+	b.cases = append(b.cases, result)
+}
+
+func (b *reportBuilder) report() Report {
+	report := Report{Suites: b.suites, Cases: b.cases}
+	if report.Suites == nil {
+		report.Suites = []Suite{}
+	}
+	if report.Cases == nil {
+		report.Cases = []Case{}
+	}
+	for _, suite := range report.Suites {
+		report.Summary.Total += suite.Tests
+		report.Summary.Passed += suite.Passed
+		report.Summary.Failed += suite.Failed
+		report.Summary.Errors += suite.Errors
+		report.Summary.Skipped += suite.Skipped
+	}
+	return report
+}
+
+// locateCase fills File/Line from a res:// script location, or from a
+// "line N" mention relative to the case's script.
+func locateCase(result *Case, text string) {
+	if match := scriptLocationPattern.FindStringSubmatch(text); match != nil {
+		result.File = match[1]
+		if line, err := strconv.Atoi(match[2]); err == nil {
+			result.Line = line
+			return
+		}
+	}
+	if match := linePattern.FindStringSubmatch(text); match != nil {
+		result.Line, _ = strconv.Atoi(match[1])
+	}
+}
+
+func appendMessage(result *Case, message string) {
+	if message == "" {
+		return
+	}
+	if result.Message == "" {
+		result.Message = message
+		return
+	}
+	result.Details = strings.TrimSpace(result.Details + "\n" + message)
+}
+
+// junitSuiteFile derives the script of a suite from its file attribute, a
+// res:// suite name (GUT), or package plus name (GdUnit4).
+func junitSuiteFile(suite junitSuite) string {
+	if file := strings.TrimSpace(suite.File); file != "" {
+		return file
+	}
+	if file := scriptFile(suite.Name); file != "" {
+		return file
+	}
+	pkg := strings.TrimSuffix(strings.TrimSpace(suite.Package), "/")
+	if strings.HasPrefix(pkg, "res://") && suite.Name != "" {
+		return pkg + "/" + strings.TrimSpace(suite.Name) + ".gd"
+	}
+	return ""
+}
+
+// scriptFile returns the res:// script in value, dropping GUT inner-class
+// suffixes such as "res://test_player.gd.TestJump".
+func scriptFile(value string) string {
+	if match := scriptLocationPattern.FindStringSubmatch(value); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+func stripANSI(value string) string {
+	return ansiPattern.ReplaceAllString(value, "")
+}
+
+func firstLine(value string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(value), "\n")
+	return strings.TrimSpace(line)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
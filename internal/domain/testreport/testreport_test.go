@@ -0,0 +1,74 @@
+package testreport
+
+import "testing"
+
+func TestParseJUnitXML_ReadsGdUnitReport(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites id="2024" name="report_1" tests="3" failures="1" skipped="1" time="0.120">
+	<testsuite id="0" name="player_test" package="res://test" tests="3" failures="1" skipped="1" time="0.120">
+		<testcase name="test_moves" classname="player_test" time="0.050"></testcase>
+		<testcase name="test_jumps" classname="player_test" time="0.070">
+			<failure message="FAILED: res://test/player_test.gd:42" type="FAILURE"><![CDATA[line 42: Expecting: '3' but was '2']]></failure>
+		</testcase>
+		<testcase name="test_later" classname="player_test" time="0"><skipped message="SKIPPED"/></testcase>
+	</testsuite>
+</testsuites>`)
+	report, err := ParseJUnitXML(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if report.Summary != (Summary{Total: 3, Passed: 1, Failed: 1, Skipped: 1}) || report.OK() {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+	if len(report.Suites) != 1 || report.Suites[0].File != "res://test/player_test.gd" {
+		t.Fatalf("unexpected suites: %+v", report.Suites)
+	}
+	failed := report.Cases[1]
+	if failed.Status != StatusFailed || failed.File != "res://test/player_test.gd" || failed.Line != 42 || failed.Details != "line 42: Expecting: '3' but was '2'" || failed.DurationMS != 70 {
+		t.Fatalf("unexpected failed case: %+v", failed)
+	}
+}
+
+func TestParseGUTConsole_ReadsFailuresAndPending(t *testing.T) {
+	output := "---  Gut  ---\n" +
+		"res://test/unit/test_inventory.gd\n" +
+		"* test_add_item\n" +
+		"* test_remove_item\n" +
+		"    [Failed]:  [1] expected to equal [0]\n" +
+		"      at line 27\n" +
+		"* test_sort\n" +
+		"    [Pending]:  not written yet\n" +
+		"\x1b[32mres://test/unit/test_shop.gd.TestBuy\x1b[0m\n" +
+		"* test_buy\n" +
+		"2/4 passed.\n"
+	report := ParseGUTConsole(output)
+	if report.Summary != (Summary{Total: 4, Passed: 2, Failed: 1, Skipped: 1}) {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+	failed := report.Cases[1]
+	if failed.Name != "test_remove_item" || failed.Line != 27 || failed.File != "res://test/unit/test_inventory.gd" || failed.Message != "[1] expected to equal [0]" {
+		t.Fatalf("unexpected failed case: %+v", failed)
+	}
+	if report.Suites[1].File != "res://test/unit/test_shop.gd" {
+		t.Fatalf("expected inner class suite to map to its script, got %+v", report.Suites[1])
+	}
+}
+
+func TestParseGdUnitConsole_ReadsStatusesAndReportLines(t *testing.T) {
+	output := "Run Test Suite: res://test/enemy_test.gd\n" +
+		"  enemy_test > test_spawn STARTED\n" +
+		"  enemy_test > test_spawn PASSED 12ms\n" +
+		"  enemy_test > test_die STARTED\n" +
+		"  enemy_test > test_die FAILED 30ms\n" +
+		"    Report:\n" +
+		"      line 18: Expecting: 'true' but is 'false'\n" +
+		"Statistics: 2 tests cases | 0 errors | 1 failures\n"
+	report := ParseGdUnitConsole(output)
+	if report.Summary != (Summary{Total: 2, Passed: 1, Failed: 1}) {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+	failed := report.Cases[1]
+	if failed.Line != 18 || failed.File != "res://test/enemy_test.gd" || failed.DurationMS != 30 || failed.Message != "line 18: Expecting: 'true' but is 'false'" {
+		t.Fatalf("unexpected failed case: %+v", failed)
+	}
+}
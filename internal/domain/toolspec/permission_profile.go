@@ -0,0 +1,206 @@
+package toolspec
+
+import (
+	"errors"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Tool classes a permission profile can allow.
+const (
+	ToolClassReadOnly = "read_only"
+	ToolClassMutating = "mutating"
+)
+
+// ErrPermissionProfileNotSelectable rejects a client request for a profile
+// that does not exist or is not marked selectable.
+var ErrPermissionProfileNotSelectable = errors.New("permission profile is unknown or not selectable")
+
+// PermissionProfile is a named tool policy applied to one session on top of
+// the global permission mode. Tool patterns are globs such as godot.node.*.
+type PermissionProfile struct {
+	Name string
+	// Classes limits tools to read_only and/or mutating; empty allows both.
+	Classes []string
+	// Allow, when non-empty, lists the only tools the profile may call.
+	Allow []string
+	// Deny wins over Allow.
+	Deny                []string
+	ArgumentConstraints []ArgumentConstraint
+	// Selectable lets a client request the profile at initialize.
+	Selectable bool
+}
+
+// ArgumentConstraint restricts one argument of the tools matching Tool. A
+// call that omits the argument is not constrained.
+type ArgumentConstraint struct {
+	Tool     string
+	Argument string
+	// PathPrefixes requires the (cleaned) value to be inside one of these
+	// paths, e.g. res://scenes/.
+	PathPrefixes []string
+	// Values requires the value to be one of these strings.
+	Values []string
+}
+
+// PermissionBinding selects a profile for sessions whose client name and
+// auth token id match the given globs; empty fields match anything.
+type PermissionBinding struct {
+	Profile     string
+	ClientName  string
+	AuthTokenID string
+}
+
+// PermissionPolicy holds the configured profiles and how sessions pick one.
+type PermissionPolicy struct {
+	Profiles       map[string]PermissionProfile
+	Bindings       []PermissionBinding
+	DefaultProfile string
+}
+
+// PermissionDenial explains why a profile rejected a tool call.
+type PermissionDenial struct {
+	Rule   string
+	Detail map[string]any
+}
+
+// Select resolves a session's profile. A matching binding wins over a
+// requested profile, which wins over the default; nil means no profile.
+func (p PermissionPolicy) Select(clientName string, authTokenID string, requested string) (*PermissionProfile, error) {
+	for _, binding := range p.Bindings {
+		if !globMatches(binding.ClientName, clientName) || !globMatches(binding.AuthTokenID, authTokenID) {
+			continue
+		}
+		if profile, ok := p.Profiles[binding.Profile]; ok {
+			return &profile, nil
+		}
+	}
+	if requested = strings.TrimSpace(requested); requested != "" {
+		profile, ok := p.Profiles[requested]
+		if !ok || !profile.Selectable {
+			return nil, ErrPermissionProfileNotSelectable
+		}
+		return &profile, nil
+	}
+	if profile, ok := p.Profiles[p.DefaultProfile]; ok {
+		return &profile, nil
+	}
+	return nil, nil
+}
+
+func globMatches(pattern string, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
+
+func matchesAnyTool(patterns []string, toolName string) (string, bool) {
+	for _, pattern := range patterns {
+		if globMatches(strings.ToLower(pattern), toolName) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// ToolClass returns read_only for read-only tools and resources and mutating
+// for everything else.
+func ToolClass(name string) string {
+	if IsReadOnlyTool(name) {
+		return ToolClassReadOnly
+	}
+	return ToolClassMutating
+}
+
+// Check reports whether the profile allows calling toolName with arguments.
+// Internal bridge tools are governed by session roles and always pass.
+func (p *PermissionProfile) Check(toolName string, arguments map[string]any) *PermissionDenial {
+	if p == nil {
+		return nil
+	}
+	name := strings.ToLower(strings.TrimSpace(toolName))
+	if IsInternalBridgeTool(name) {
+		return nil
+	}
+	if pattern, denied := matchesAnyTool(p.Deny, name); denied {
+		return &PermissionDenial{Rule: "deny", Detail: map[string]any{"pattern": pattern}}
+	}
+	if len(p.Allow) > 0 {
+		if _, allowed := matchesAnyTool(p.Allow, name); !allowed {
+			return &PermissionDenial{Rule: "allow", Detail: map[string]any{}}
+		}
+	}
+	if class := ToolClass(name); len(p.Classes) > 0 && !slices.Contains(p.Classes, class) {
+		return &PermissionDenial{Rule: "class", Detail: map[string]any{"tool_class": class, "allowed_classes": p.Classes}}
+	}
+	for _, constraint := range p.ArgumentConstraints {
+		if !globMatches(strings.ToLower(constraint.Tool), name) {
+			continue
+		}
+		raw, present := arguments[constraint.Argument]
+		if !present || raw == nil {
+			continue
+		}
+		if !constraint.allows(raw) {
+			return &PermissionDenial{Rule: "argument", Detail: map[string]any{
+				"argument":      constraint.Argument,
+				"path_prefixes": constraint.PathPrefixes,
+				"values":        constraint.Values,
+			}}
+		}
+	}
+	return nil
+}
+
+// allows checks a string argument, or every element of a string list.
+func (c ArgumentConstraint) allows(raw any) bool {
+	var values []string
+	switch value := raw.(type) {
+	case string:
+		values = []string{value}
+	case []any:
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return false
+			}
+			values = append(values, text)
+		}
+	default:
+		return false
+	}
+	for _, value := range values {
+		if len(c.Values) > 0 && !slices.Contains(c.Values, value) {
+			return false
+		}
+		if len(c.PathPrefixes) > 0 && !hasPathPrefix(value, c.PathPrefixes) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasPathPrefix cleans value (so res://a/../b cannot escape a prefix) and
+// checks it is a prefix itself or lies below one.
+func hasPathPrefix(value string, prefixes []string) bool {
+	cleaned := cleanResourcePath(value)
+	for _, prefix := range prefixes {
+		prefix = cleanResourcePath(prefix)
+		if cleaned == prefix || strings.HasPrefix(cleaned, strings.TrimSuffix(prefix, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanResourcePath(value string) string {
+	scheme, rest, found := strings.Cut(strings.TrimSpace(value), "://")
+	if !found {
+		return path.Clean(scheme)
+	}
+	cleaned := path.Clean("/" + rest)
+	return scheme + ":/" + cleaned
+}
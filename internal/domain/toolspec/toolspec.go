@@ -12,6 +12,24 @@ const (
 	ToolPermissionAllowList = "allow_list"
 )
 
+// Bearer token scopes. Write implies read; the wildcard grants every scope.
+// Audit is only granted explicitly, since the audit log covers every session.
+const (
+	AuthScopeAll    = "*"
+	AuthScopeRead   = "read"
+	AuthScopeWrite  = "write"
+	AuthScopeBridge = "bridge"
+	AuthScopeAudit  = "audit"
+)
+
+// Session roles. Editor and runtime sessions prove their role with the
+// plugin's bridge secret; every other session is an agent.
+const (
+	SessionRoleAgent   = "agent"
+	SessionRoleEditor  = "editor"
+	SessionRoleRuntime = "runtime"
+)
+
 var toolNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
 
 var readOnlyToolNames = map[string]struct{}{
@@ -23,43 +41,66 @@ var readOnlyToolNames = map[string]struct{}{
 	"godot.editor.state.get":            {},
 	"godot.project.is_running":          {},
 	"godot.runtime.session.get_active":  {},
+	"godot.runtime.session.list":        {},
+	"godot.runtime.session.get":         {},
 	"godot.runtime.await_snapshot":      {},
 	"godot.runtime.scene_tree.get":      {},
+	"godot.runtime.scene_tree.diff":     {},
 	"godot.runtime.node_properties.get": {},
 	"godot.runtime.log.get":             {},
+	"godot.runtime.log.search":          {},
+	"godot.runtime.log.tail.start":      {},
+	"godot.runtime.log.tail.stop":       {},
 	"godot.runtime.screenshot.get":      {},
+	"godot.runtime.screenshot.compare":  {},
+	"godot.runtime.watch.list":          {},
+	"godot.runtime.watch.get":           {},
+	"godot.runtime.performance.get":     {},
 	"godot.scene.list":                  {},
 	"godot.scene.read":                  {},
+	"godot.node.query":                  {},
 	"godot.script.list":                 {},
 	"godot.script.read":                 {},
 	"godot.script.analyze":              {},
+	"godot.test.result.get":             {},
+	"godot.audit.query":                 {},
 }
 
 var mutatingToolNames = map[string]struct{}{
-	"godot.project.run":           {},
-	"godot.project.stop":          {},
-	"godot.runtime.sync_now":      {},
-	"godot.runtime.input.tap":     {},
-	"godot.runtime.input.press":   {},
-	"godot.runtime.input.release": {},
-	"godot.runtime.log.clear":     {},
-	"godot.editor.scene.apply":    {},
-	"godot.scene.create":          {},
-	"godot.scene.save":            {},
-	"godot.node.create":           {},
-	"godot.node.delete":           {},
-	"godot.node.modify":           {},
-	"godot.script.create":         {},
-	"godot.script.modify":         {},
+	"godot.project.run":                      {},
+	"godot.project.stop":                     {},
+	"godot.runtime.sync_now":                 {},
+	"godot.runtime.input.tap":                {},
+	"godot.runtime.input.press":              {},
+	"godot.runtime.input.release":            {},
+	"godot.runtime.log.clear":                {},
+	"godot.runtime.watch.add":                {},
+	"godot.runtime.watch.remove":             {},
+	"godot.runtime.performance.sample":       {},
+	"godot.runtime.screenshot.baseline.save": {},
+	"godot.editor.scene.apply":               {},
+	"godot.scene.create":                     {},
+	"godot.scene.save":                       {},
+	"godot.node.create":                      {},
+	"godot.node.delete":                      {},
+	"godot.node.modify":                      {},
+	"godot.script.create":                    {},
+	"godot.script.modify":                    {},
+	"godot.test.scenario.run":                {},
+	"godot.test.run":                         {},
 }
 
-var internalBridgeToolNames = map[string]struct{}{
-	"godot.bridge.editor.sync":           {},
-	"godot.bridge.editor.ping":           {},
-	"godot.bridge.runtime.register":      {},
-	"godot.bridge.runtime.snapshot.push": {},
-	"godot.bridge.runtime.log.push":      {},
-	"godot.bridge.command.ack":           {},
+// internalBridgeToolNames maps each bridge tool to the session roles that
+// may call it.
+var internalBridgeToolNames = map[string][]string{
+	"godot.bridge.editor.sync":              {SessionRoleEditor},
+	"godot.bridge.editor.ping":              {SessionRoleEditor},
+	"godot.bridge.runtime.register":         {SessionRoleRuntime},
+	"godot.bridge.runtime.snapshot.push":    {SessionRoleRuntime},
+	"godot.bridge.runtime.log.push":         {SessionRoleRuntime},
+	"godot.bridge.runtime.watch.push":       {SessionRoleRuntime},
+	"godot.bridge.runtime.performance.push": {SessionRoleRuntime},
+	"godot.bridge.command.ack":              {SessionRoleEditor, SessionRoleRuntime},
 }
 
 func ValidateToolName(name string) bool {
@@ -137,3 +178,51 @@ func IsInternalBridgeTool(name string) bool {
 	_, ok := internalBridgeToolNames[trimmed]
 	return ok
 }
+
+// BridgeToolRoles returns the session roles allowed to call a bridge tool,
+// or nil for other tools.
+func BridgeToolRoles(name string) []string {
+	return internalBridgeToolNames[strings.ToLower(strings.TrimSpace(name))]
+}
+
+// IsBridgeToolAllowedForRole reports whether a session with role may call
+// the bridge tool name.
+func IsBridgeToolAllowedForRole(name string, role string) bool {
+	return slices.Contains(BridgeToolRoles(name), strings.ToLower(strings.TrimSpace(role)))
+}
+
+// auditToolNames need the audit scope: they expose other sessions' ids,
+// token ids and tool arguments.
+var auditToolNames = map[string]struct{}{
+	"godot.audit.query": {},
+}
+
+// RequiredAuthScope returns the token scope a tool call needs: bridge for
+// internal bridge tools, audit for audit log tools, read for read-only tools
+// and resources, and write for everything else.
+func RequiredAuthScope(name string) string {
+	_, isAuditTool := auditToolNames[strings.ToLower(strings.TrimSpace(name))]
+	switch {
+	case IsInternalBridgeTool(name):
+		return AuthScopeBridge
+	case isAuditTool:
+		return AuthScopeAudit
+	case IsReadOnlyTool(name):
+		return AuthScopeRead
+	default:
+		return AuthScopeWrite
+	}
+}
+
+func HasAuthScope(scopes []string, required string) bool {
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if scope == AuthScopeAll || scope == required {
+			return true
+		}
+		if scope == AuthScopeWrite && required == AuthScopeRead {
+			return true
+		}
+	}
+	return false
+}
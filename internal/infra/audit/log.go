@@ -0,0 +1,459 @@
+// Package audit keeps an append-only, hash-chained JSONL record of mutating
+// tool calls and bridge command acknowledgements.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultRetentionDays = 30
+	filePrefix           = "audit-"
+	fileSuffix           = ".jsonl"
+	fileDateLayout       = "2006-01-02"
+	maxRecordBytes       = 4 << 20
+	defaultQueryLimit    = 100
+	maxQueryLimit        = 1000
+)
+
+// Outcomes recorded for a call.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+	OutcomeDenied  = "denied"
+)
+
+// Record kinds.
+const (
+	KindToolCall  = "tool_call"
+	KindBridgeAck = "bridge_ack"
+)
+
+// emptyHashSuffix ends every marshalled record; Hash is the last struct field so
+// the chain hash can be computed over the exact bytes written.
+const emptyHashSuffix = `"hash":""}`
+
+var defaultLog atomic.Pointer[Log]
+
+func init() {
+	defaultLog.Store(NewLog("", DefaultRetentionDays))
+}
+
+// Record is one audit log line.
+type Record struct {
+	Seq           int64          `json:"seq"`
+	Time          time.Time      `json:"time"`
+	Kind          string         `json:"kind"`
+	SessionID     string         `json:"session_id,omitempty"`
+	SessionRole   string         `json:"session_role,omitempty"`
+	ClientName    string         `json:"client_name,omitempty"`
+	ClientVersion string         `json:"client_version,omitempty"`
+	AuthTokenID   string         `json:"auth_token_id,omitempty"`
+	Tool          string         `json:"tool"`
+	Arguments     map[string]any `json:"arguments,omitempty"`
+	Outcome       string         `json:"outcome"`
+	ErrorKind     string         `json:"error_kind,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+	CommandID     string         `json:"command_id,omitempty"`
+	Ack           map[string]any `json:"ack,omitempty"`
+	DurationMS    int64          `json:"duration_ms"`
+	PrevHash      string         `json:"prev_hash"`
+	Hash          string         `json:"hash"`
+}
+
+// Query filters audit records. Zero values disable the corresponding filter.
+type Query struct {
+	SessionID string
+	// Tool is matched with path.Match, so "godot.node.*" selects node tools.
+	Tool    string
+	Outcome string
+	Since   time.Time
+	Until   time.Time
+	Limit   int
+}
+
+// QueryResult holds the newest matching records first.
+type QueryResult struct {
+	Records   []Record
+	Truncated bool
+}
+
+// Verification reports whether the retained chain is intact. The first
+// retained record's prev_hash is trusted because older files may have been
+// pruned by retention.
+type Verification struct {
+	Valid    bool   `json:"valid"`
+	Records  int    `json:"records"`
+	BrokenAt int64  `json:"broken_at,omitempty"`
+	Problem  string `json:"problem,omitempty"`
+}
+
+// Log writes one JSONL file per UTC day under dir and deletes files older
+// than retentionDays. An empty dir disables the log.
+type Log struct {
+	mu            sync.Mutex
+	dir           string
+	retentionDays int
+	loaded        bool
+	seq           int64
+	lastHash      string
+	prunedDay     string
+	writeErrors   int64
+	lastError     string
+	now           func() time.Time
+}
+
+func NewLog(dir string, retentionDays int) *Log {
+	log := &Log{now: time.Now}
+	log.Configure(dir, retentionDays)
+	return log
+}
+
+func Default() *Log {
+	return defaultLog.Load()
+}
+
+func ResetDefaultForTests(dir string, retentionDays int) {
+	defaultLog.Store(NewLog(dir, retentionDays))
+}
+
+// Configure sets the directory and retention; the chain state is reloaded
+// from disk on the next append.
+func (l *Log) Configure(dir string, retentionDays int) {
+	if l == nil {
+		return
+	}
+	if retentionDays <= 0 {
+		retentionDays = DefaultRetentionDays
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dir = strings.TrimSpace(dir)
+	l.retentionDays = retentionDays
+	l.loaded = false
+	l.prunedDay = ""
+}
+
+func (l *Log) Enabled() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dir != ""
+}
+
+// Append chains record onto the log. Write failures are counted in Health
+// rather than failing the tool call that produced the record.
+func (l *Log) Append(record Record) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dir == "" {
+		return
+	}
+	if err := l.appendLocked(record); err != nil {
+		l.writeErrors++
+		l.lastError = err.Error()
+	}
+}
+
+func (l *Log) appendLocked(record Record) error {
+	if err := os.MkdirAll(l.dir, 0o700); err != nil {
+		return err
+	}
+	if !l.loaded {
+		if err := l.loadChainLocked(); err != nil {
+			return err
+		}
+	}
+	now := l.now().UTC()
+	if day := now.Format(fileDateLayout); day != l.prunedDay {
+		l.pruneLocked(now)
+		l.prunedDay = day
+	}
+
+	record.Seq = l.seq + 1
+	record.Time = now
+	record.PrevHash = l.lastHash
+	record.Hash = ""
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	hash := chainHash(record.PrevHash, line)
+	line = append(line[:len(line)-len(emptyHashSuffix)], []byte(`"hash":"`+hash+`"}`+"\n")...)
+
+	file, err := os.OpenFile(filepath.Join(l.dir, fileName(now)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(line); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	l.seq = record.Seq
+	l.lastHash = hash
+	return nil
+}
+
+// loadChainLocked resumes the chain from the newest record on disk.
+func (l *Log) loadChainLocked() error {
+	files, err := l.filesLocked()
+	if err != nil {
+		return err
+	}
+	l.seq, l.lastHash = 0, ""
+	for index := len(files) - 1; index >= 0; index-- {
+		var last *Record
+		if err := scanFile(files[index], func(record Record, _ []byte) bool {
+			last = &record
+			return true
+		}); err != nil {
+			return err
+		}
+		if last != nil {
+			l.seq, l.lastHash = last.Seq, last.Hash
+			break
+		}
+	}
+	l.loaded = true
+	return nil
+}
+
+func (l *Log) pruneLocked(now time.Time) {
+	cutoff := now.AddDate(0, 0, -l.retentionDays).Format(fileDateLayout)
+	files, err := l.filesLocked()
+	if err != nil {
+		return
+	}
+	for _, name := range files {
+		if fileDay(name) < cutoff {
+			_ = os.Remove(name)
+		}
+	}
+}
+
+// filesLocked lists audit files oldest first.
+func (l *Log) filesLocked() ([]string, error) {
+	items, err := os.ReadDir(l.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(items))
+	for _, item := range items {
+		if !item.IsDir() && fileDay(item.Name()) != "" {
+			files = append(files, filepath.Join(l.dir, item.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Query returns matching records, newest first.
+func (l *Log) Query(query Query) (QueryResult, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	limit = min(limit, maxQueryLimit)
+	var matches []Record
+	err := l.scan(func(record Record, _ []byte) bool {
+		if query.matches(record) {
+			matches = append(matches, record)
+		}
+		return true
+	})
+	if err != nil {
+		return QueryResult{}, err
+	}
+	result := QueryResult{Records: make([]Record, 0, min(limit, len(matches)))}
+	for index := len(matches) - 1; index >= 0 && len(result.Records) < limit; index-- {
+		result.Records = append(result.Records, matches[index])
+	}
+	result.Truncated = len(matches) > limit
+	return result, nil
+}
+
+func (q Query) matches(record Record) bool {
+	if q.SessionID != "" && record.SessionID != q.SessionID {
+		return false
+	}
+	if q.Tool != "" {
+		if matched, err := path.Match(q.Tool, record.Tool); err != nil || !matched {
+			return false
+		}
+	}
+	if q.Outcome != "" && record.Outcome != q.Outcome {
+		return false
+	}
+	if !q.Since.IsZero() && record.Time.Before(q.Since) {
+		return false
+	}
+	if !q.Until.IsZero() && record.Time.After(q.Until) {
+		return false
+	}
+	return true
+}
+
+// Verify recomputes the hash chain over every retained record.
+func (l *Log) Verify() (Verification, error) {
+	verification := Verification{Valid: true}
+	prevHash := ""
+	err := l.scan(func(record Record, line []byte) bool {
+		verification.Records++
+		problem := ""
+		switch {
+		case verification.Records > 1 && record.PrevHash != prevHash:
+			problem = "prev_hash does not match the previous record"
+		case !verifyLine(record, line):
+			problem = "hash does not match the record"
+		}
+		if problem != "" {
+			verification.Valid = false
+			verification.BrokenAt = record.Seq
+			verification.Problem = problem
+			return false
+		}
+		prevHash = record.Hash
+		return true
+	})
+	return verification, err
+}
+
+func (l *Log) scan(visit func(Record, []byte) bool) error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.dir == "" {
+		return nil
+	}
+	files, err := l.filesLocked()
+	if err != nil {
+		return err
+	}
+	for _, name := range files {
+		stop := false
+		if err := scanFile(name, func(record Record, line []byte) bool {
+			if !visit(record, line) {
+				stop = true
+				return false
+			}
+			return true
+		}); err != nil {
+			return err
+		}
+		if stop {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *Log) Health() map[string]any {
+	if l == nil {
+		return map[string]any{"enabled": false}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return map[string]any{
+		"enabled":        l.dir != "",
+		"dir":            l.dir,
+		"retention_days": l.retentionDays,
+		"last_seq":       l.seq,
+		"write_errors":   l.writeErrors,
+		"last_error":     l.lastError,
+	}
+}
+
+// scanFile visits records in order; a line that does not decode is passed
+// as a zero record with its seq unset so Verify flags it.
+func scanFile(name string, visit func(Record, []byte) bool) error {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxRecordBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		var record Record
+		_ = json.Unmarshal(line, &record)
+		if !visit(record, line) {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read %s: %w", filepath.Base(name), err)
+	}
+	return nil
+}
+
+func verifyLine(record Record, line []byte) bool {
+	suffix := []byte(`"hash":"` + record.Hash + `"}`)
+	if record.Hash == "" || !bytes.HasSuffix(line, suffix) {
+		return false
+	}
+	unsigned := append(bytes.Clone(line[:len(line)-len(suffix)]), emptyHashSuffix...)
+	return chainHash(record.PrevHash, unsigned) == record.Hash
+}
+
+func chainHash(prevHash string, unsigned []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(prevHash))
+	sum.Write([]byte{'\n'})
+	sum.Write(unsigned)
+	return hex.EncodeToString(sum.Sum(nil))
+}
+
+func fileName(now time.Time) string {
+	return filePrefix + now.Format(fileDateLayout) + fileSuffix
+}
+
+// fileDay returns the YYYY-MM-DD part of an audit file name, or "".
+func fileDay(name string) string {
+	base := filepath.Base(name)
+	day, ok := strings.CutPrefix(base, filePrefix)
+	if !ok {
+		return ""
+	}
+	day, ok = strings.CutSuffix(day, fileSuffix)
+	if !ok {
+		return ""
+	}
+	if _, err := time.Parse(fileDateLayout, day); err != nil {
+		return ""
+	}
+	return day
+}
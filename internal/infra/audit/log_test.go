@@ -0,0 +1,129 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogAppendChainsAndVerifies(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLog(dir, 30)
+	log.Append(Record{Kind: KindToolCall, SessionID: "s1", Tool: "godot.node.create", Outcome: OutcomeSuccess})
+	log.Append(Record{Kind: KindBridgeAck, SessionID: "s2", Tool: "godot.bridge.command.ack", Outcome: OutcomeSuccess, CommandID: "cmd-1"})
+	log.Append(Record{Kind: KindToolCall, SessionID: "s1", Tool: "godot.script.modify", Outcome: OutcomeDenied})
+
+	result, err := log.Query(Query{})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	if len(result.Records) != 3 || result.Records[0].Seq != 3 || result.Records[2].Seq != 1 {
+		t.Fatalf("expected newest-first records, got %#v", result.Records)
+	}
+	if result.Records[0].PrevHash != result.Records[1].Hash || result.Records[2].PrevHash != "" {
+		t.Fatalf("expected hash chain, got %#v", result.Records)
+	}
+
+	filtered, err := log.Query(Query{SessionID: "s1", Tool: "godot.node.*"})
+	if err != nil || len(filtered.Records) != 1 || filtered.Records[0].Tool != "godot.node.create" {
+		t.Fatalf("unexpected filtered query %#v err=%v", filtered, err)
+	}
+
+	verification, err := log.Verify()
+	if err != nil || !verification.Valid || verification.Records != 3 {
+		t.Fatalf("expected intact chain, got %#v err=%v", verification, err)
+	}
+
+	// A reopened log continues the chain from disk.
+	lastHash := result.Records[0].Hash
+	reopened := NewLog(dir, 30)
+	reopened.Append(Record{Kind: KindToolCall, Tool: "godot.scene.save", Outcome: OutcomeError})
+	result, _ = reopened.Query(Query{Limit: 1})
+	if result.Records[0].Seq != 4 || result.Records[0].PrevHash != lastHash {
+		t.Fatalf("expected chain to resume, got %#v", result.Records[0])
+	}
+}
+
+func TestLogVerifyDetectsTampering(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLog(dir, 30)
+	log.Append(Record{Kind: KindToolCall, Tool: "godot.node.delete", Outcome: OutcomeSuccess})
+	log.Append(Record{Kind: KindToolCall, Tool: "godot.node.delete", Outcome: OutcomeSuccess})
+
+	files, _ := filepath.Glob(filepath.Join(dir, "audit-*.jsonl"))
+	if len(files) != 1 {
+		t.Fatalf("expected one audit file, got %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	tampered := strings.Replace(string(data), `"outcome":"success"`, `"outcome":"error"`, 1)
+	if err := os.WriteFile(files[0], []byte(tampered), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	verification, err := log.Verify()
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if verification.Valid || verification.BrokenAt != 1 {
+		t.Fatalf("expected tampering at seq 1, got %#v", verification)
+	}
+}
+
+func TestLogPrunesFilesPastRetention(t *testing.T) {
+	dir := t.TempDir()
+	stale := filepath.Join(dir, "audit-2000-01-01.jsonl")
+	if err := os.WriteFile(stale, []byte("{}\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	log := NewLog(dir, 7)
+	log.Append(Record{Kind: KindToolCall, Tool: "godot.project.run", Outcome: OutcomeSuccess})
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Fatalf("expected stale audit file to be pruned, stat err=%v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, fileName(time.Now().UTC()))); err != nil {
+		t.Fatalf("expected current audit file: %v", err)
+	}
+}
+
+func TestLogDisabledWithoutDir(t *testing.T) {
+	log := NewLog("", 30)
+	log.Append(Record{Tool: "godot.node.create"})
+	if log.Enabled() {
+		t.Fatalf("expected log without dir to be disabled")
+	}
+	result, err := log.Query(Query{})
+	if err != nil || len(result.Records) != 0 {
+		t.Fatalf("expected empty query, got %#v err=%v", result, err)
+	}
+}
+
+func TestSanitizeArguments(t *testing.T) {
+	sanitized := SanitizeArguments(map[string]any{
+		"path":      "res://player.gd",
+		"content":   strings.Repeat("x", 300),
+		"api_token": "abc",
+		"_mcp":      map[string]any{"session_id": "s1"},
+		"properties": map[string]any{
+			"password": "hunter2",
+			"speed":    float64(3),
+		},
+	})
+	if _, ok := sanitized["_mcp"]; ok {
+		t.Fatalf("expected transport context to be dropped")
+	}
+	if sanitized["path"] != "res://player.gd" || sanitized["api_token"] != redactedValue {
+		t.Fatalf("unexpected sanitized arguments %#v", sanitized)
+	}
+	if content, _ := sanitized["content"].(string); !strings.HasPrefix(content, "[300 bytes sha256:") {
+		t.Fatalf("expected long string digest, got %q", content)
+	}
+	properties, _ := sanitized["properties"].(map[string]any)
+	if properties["password"] != redactedValue || properties["speed"] != float64(3) {
+		t.Fatalf("expected nested redaction, got %#v", properties)
+	}
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxArgumentStringBytes = 256
+	redactedValue          = "[redacted]"
+)
+
+var sensitiveArgumentKeys = []string{"secret", "token", "password", "authorization", "api_key", "apikey"}
+
+// SanitizeArguments copies tool arguments for the audit log. Keys starting
+// with "_" carry transport context and are dropped, secret-looking keys are
+// redacted, and long strings are replaced by their size and digest so script
+// bodies and screenshots do not bloat the log.
+func SanitizeArguments(arguments map[string]any) map[string]any {
+	if len(arguments) == 0 {
+		return nil
+	}
+	sanitized := make(map[string]any, len(arguments))
+	for key, value := range arguments {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if isSensitiveArgumentKey(key) {
+			sanitized[key] = redactedValue
+			continue
+		}
+		sanitized[key] = sanitizeValue(value)
+	}
+	return sanitized
+}
+
+func sanitizeValue(value any) any {
+	switch typed := value.(type) {
+	case string:
+		if len(typed) <= maxArgumentStringBytes {
+			return typed
+		}
+		sum := sha256.Sum256([]byte(typed))
+		return fmt.Sprintf("[%d bytes sha256:%s]", len(typed), hex.EncodeToString(sum[:8]))
+	case map[string]any:
+		nested := SanitizeArguments(typed)
+		if nested == nil {
+			return map[string]any{}
+		}
+		return nested
+	case []any:
+		items := make([]any, len(typed))
+		for index, item := range typed {
+			items[index] = sanitizeValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func isSensitiveArgumentKey(key string) bool {
+	lower := strings.ToLower(key)
+	for _, sensitive := range sensitiveArgumentKeys {
+		if strings.Contains(lower, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,279 @@
+// Package oauth validates OAuth 2.1 access tokens issued as signed JWTs, for
+// the HTTP transport's resource-server mode.
+package oauth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key is one verification key from a JWKS document. Exactly one of RSA, EC
+// and Secret is set.
+type Key struct {
+	ID     string
+	Alg    string
+	RSA    *rsa.PublicKey
+	EC     *ecdsa.PublicKey
+	Secret []byte
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+// ParseJWKS decodes a JWKS document ({"keys": [...]}). RSA, EC (P-256,
+// P-384) and oct keys are supported; encryption keys are skipped.
+func ParseJWKS(data []byte) ([]Key, error) {
+	var document struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+	keys := make([]Key, 0, len(document.Keys))
+	for i, raw := range document.Keys {
+		if raw.Use != "" && raw.Use != "sig" {
+			continue
+		}
+		key := Key{ID: raw.Kid, Alg: raw.Alg}
+		switch raw.Kty {
+		case "RSA":
+			n, err := decodeBigInt(raw.N)
+			if err != nil {
+				return nil, fmt.Errorf("jwks key %d: invalid n: %w", i, err)
+			}
+			e, err := decodeBigInt(raw.E)
+			if err != nil || !e.IsInt64() {
+				return nil, fmt.Errorf("jwks key %d: invalid e", i)
+			}
+			key.RSA = &rsa.PublicKey{N: n, E: int(e.Int64())}
+		case "EC":
+			var curve elliptic.Curve
+			switch raw.Crv {
+			case "P-256":
+				curve = elliptic.P256()
+			case "P-384":
+				curve = elliptic.P384()
+			default:
+				return nil, fmt.Errorf("jwks key %d: unsupported curve %q", i, raw.Crv)
+			}
+			x, err := decodeBigInt(raw.X)
+			if err != nil {
+				return nil, fmt.Errorf("jwks key %d: invalid x: %w", i, err)
+			}
+			y, err := decodeBigInt(raw.Y)
+			if err != nil {
+				return nil, fmt.Errorf("jwks key %d: invalid y: %w", i, err)
+			}
+			key.EC = &ecdsa.PublicKey{Curve: curve, X: x, Y: y}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(raw.K, "="))
+			if err != nil || len(secret) == 0 {
+				return nil, fmt.Errorf("jwks key %d: invalid k", i)
+			}
+			key.Secret = secret
+		default:
+			return nil, fmt.Errorf("jwks key %d: unsupported kty %q", i, raw.Kty)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no signing keys")
+	}
+	return keys, nil
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errors.New("empty value")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// KeyFile is a JWKS file that is re-read when its modification time changes,
+// so keys can be rotated without restarting the server.
+type KeyFile struct {
+	path    string
+	mu      sync.Mutex
+	modTime time.Time
+	keys    []Key
+}
+
+// LoadKeyFile reads and parses the JWKS file at path.
+func LoadKeyFile(path string) (*KeyFile, error) {
+	file := &KeyFile{path: path}
+	if _, err := file.Keys(); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// Keys returns the current keys. A file that turns unreadable or invalid
+// after a successful load keeps serving the last good keys.
+func (f *KeyFile) Keys() ([]Key, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if f.keys != nil {
+			return f.keys, nil
+		}
+		return nil, fmt.Errorf("read jwks file: %w", err)
+	}
+	if f.keys != nil && info.ModTime().Equal(f.modTime) {
+		return f.keys, nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err == nil {
+		var keys []Key
+		if keys, err = ParseJWKS(data); err == nil {
+			f.keys = keys
+			f.modTime = info.ModTime()
+			return keys, nil
+		}
+	}
+	if f.keys != nil {
+		return f.keys, nil
+	}
+	return nil, fmt.Errorf("load jwks file %s: %w", f.path, err)
+}
+
+// RemoteKeySet fetches a JWKS document from an issuer's jwks_uri and caches
+// it for RefreshInterval. A token signed with an unknown kid triggers an
+// early refresh, rate limited to once per MinRefreshInterval. Fetches run
+// outside the lock, one at a time, and cached keys are served while one is
+// in flight.
+type RemoteKeySet struct {
+	URL                string
+	Client             *http.Client
+	RefreshInterval    time.Duration
+	MinRefreshInterval time.Duration
+
+	mu        sync.Mutex
+	keys      []Key
+	fetchedAt time.Time
+	// failedAt and failure record the last failed fetch; no new fetch is
+	// attempted for MinRefreshInterval after it.
+	failedAt time.Time
+	failure  error
+	// inflight is closed when the running fetch finishes; nil when idle.
+	inflight chan struct{}
+}
+
+// NewRemoteKeySet returns a key set for url with default refresh intervals.
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	return &RemoteKeySet{
+		URL:                url,
+		Client:             &http.Client{Timeout: 10 * time.Second},
+		RefreshInterval:    10 * time.Minute,
+		MinRefreshInterval: 30 * time.Second,
+	}
+}
+
+// Keys returns cached keys, refreshing them when the cache is stale. A failed
+// refresh keeps serving the last good keys and backs off for
+// MinRefreshInterval.
+func (r *RemoteKeySet) Keys() ([]Key, error) {
+	return r.refresh(r.RefreshInterval)
+}
+
+// Refresh re-fetches the key set unless it was fetched very recently.
+func (r *RemoteKeySet) Refresh() ([]Key, error) {
+	return r.refresh(r.MinRefreshInterval)
+}
+
+// refresh fetches the key set when the cached keys are older than maxAge.
+// Only the first caller fetches; later callers get the cached keys, or wait
+// for that fetch when there are none yet.
+func (r *RemoteKeySet) refresh(maxAge time.Duration) ([]Key, error) {
+	r.mu.Lock()
+	if r.keys != nil && time.Since(r.fetchedAt) < maxAge {
+		defer r.mu.Unlock()
+		return r.keys, nil
+	}
+	if !r.failedAt.IsZero() && time.Since(r.failedAt) < r.MinRefreshInterval {
+		defer r.mu.Unlock()
+		return r.cachedLocked()
+	}
+	if inflight := r.inflight; inflight != nil {
+		if r.keys != nil {
+			defer r.mu.Unlock()
+			return r.keys, nil
+		}
+		r.mu.Unlock()
+		<-inflight
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.cachedLocked()
+	}
+	inflight := make(chan struct{})
+	r.inflight = inflight
+	r.mu.Unlock()
+
+	keys, err := r.fetch()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inflight = nil
+	close(inflight)
+	if err != nil {
+		r.failedAt = time.Now()
+		r.failure = err
+		return r.cachedLocked()
+	}
+	r.keys = keys
+	r.fetchedAt = time.Now()
+	r.failedAt = time.Time{}
+	r.failure = nil
+	return keys, nil
+}
+
+// cachedLocked returns the last good keys, or the last fetch error when
+// there are none.
+func (r *RemoteKeySet) cachedLocked() ([]Key, error) {
+	if r.keys != nil {
+		return r.keys, nil
+	}
+	return nil, r.failure
+}
+
+func (r *RemoteKeySet) fetch() ([]Key, error) {
+	resp, err := r.Client.Get(r.URL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch jwks %s: unexpected status %d", r.URL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read jwks: %w", err)
+	}
+	return ParseJWKS(data)
+}
@@ -0,0 +1,229 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformedToken = errors.New("malformed access token")
+	ErrSignature      = errors.New("access token signature is invalid")
+	ErrExpired        = errors.New("access token expired")
+	ErrClaims         = errors.New("access token claims are invalid")
+)
+
+// Claims are the validated access token claims the server uses.
+type Claims struct {
+	Issuer    string
+	Subject   string
+	ClientID  string
+	Audience  []string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// Validator checks JWT access tokens against a key set, the expected issuer
+// and the resource (audience) they must be issued for.
+type Validator struct {
+	Keys func() ([]Key, error)
+	// Refresh, when set, is called once to re-fetch keys after a signature
+	// check fails, so rotated keys are picked up before their cache expires.
+	Refresh  func() ([]Key, error)
+	Issuer   string
+	Audience string
+	// ClockSkew tolerates small clock differences for exp and nbf.
+	ClockSkew time.Duration
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	Issuer    string          `json:"iss"`
+	Subject   string          `json:"sub"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *float64        `json:"exp"`
+	NotBefore *float64        `json:"nbf"`
+	Scope     string          `json:"scope"`
+	Scp       json.RawMessage `json:"scp"`
+	ClientID  string          `json:"client_id"`
+	AZP       string          `json:"azp"`
+}
+
+// Validate verifies the token signature and registered claims.
+func (v *Validator) Validate(token string, now time.Time) (Claims, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrMalformedToken
+	}
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	keys, err := v.Keys()
+	if err != nil {
+		return Claims{}, err
+	}
+	signingInput := []byte(parts[0] + "." + parts[1])
+	if !verifySignature(header, keys, signingInput, signature) {
+		if v.Refresh == nil {
+			return Claims{}, ErrSignature
+		}
+		if keys, err = v.Refresh(); err != nil || !verifySignature(header, keys, signingInput, signature) {
+			return Claims{}, ErrSignature
+		}
+	}
+
+	var raw jwtClaims
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return Claims{}, ErrMalformedToken
+	}
+	claims := Claims{
+		Issuer:   raw.Issuer,
+		Subject:  raw.Subject,
+		ClientID: firstNonEmpty(raw.ClientID, raw.AZP),
+		Audience: stringOrList(raw.Audience),
+		Scopes:   strings.Fields(raw.Scope),
+	}
+	if len(claims.Scopes) == 0 {
+		claims.Scopes = stringOrList(raw.Scp)
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return Claims{}, fmt.Errorf("%w: unexpected issuer %q", ErrClaims, claims.Issuer)
+	}
+	if v.Audience != "" && !slices.Contains(claims.Audience, v.Audience) {
+		return Claims{}, fmt.Errorf("%w: token is not issued for %s", ErrClaims, v.Audience)
+	}
+	if raw.ExpiresAt == nil {
+		return Claims{}, fmt.Errorf("%w: exp is required", ErrClaims)
+	}
+	claims.ExpiresAt = time.Unix(int64(*raw.ExpiresAt), 0).UTC()
+	if !now.Before(claims.ExpiresAt.Add(v.ClockSkew)) {
+		return Claims{}, ErrExpired
+	}
+	if raw.NotBefore != nil && now.Add(v.ClockSkew).Before(time.Unix(int64(*raw.NotBefore), 0)) {
+		return Claims{}, fmt.Errorf("%w: token is not valid yet", ErrClaims)
+	}
+	if claims.Subject == "" && claims.ClientID == "" {
+		return Claims{}, fmt.Errorf("%w: sub or client_id is required", ErrClaims)
+	}
+	return claims, nil
+}
+
+func verifySignature(header jwtHeader, keys []Key, signingInput []byte, signature []byte) bool {
+	hashFunc, hashID, ok := algorithmHash(header.Alg)
+	if !ok {
+		return false
+	}
+	for _, key := range keys {
+		if header.Kid != "" && key.ID != "" && key.ID != header.Kid {
+			continue
+		}
+		if key.Alg != "" && key.Alg != header.Alg {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(header.Alg, "RS") && key.RSA != nil:
+			digest := sum(hashFunc, signingInput)
+			if rsa.VerifyPKCS1v15(key.RSA, hashID, digest, signature) == nil {
+				return true
+			}
+		case strings.HasPrefix(header.Alg, "PS") && key.RSA != nil:
+			digest := sum(hashFunc, signingInput)
+			if rsa.VerifyPSS(key.RSA, hashID, digest, signature, nil) == nil {
+				return true
+			}
+		case strings.HasPrefix(header.Alg, "ES") && key.EC != nil:
+			size := (key.EC.Curve.Params().BitSize + 7) / 8
+			if len(signature) != 2*size {
+				continue
+			}
+			r := new(big.Int).SetBytes(signature[:size])
+			s := new(big.Int).SetBytes(signature[size:])
+			if ecdsa.Verify(key.EC, sum(hashFunc, signingInput), r, s) {
+				return true
+			}
+		case strings.HasPrefix(header.Alg, "HS") && key.Secret != nil:
+			mac := hmac.New(hashFunc, key.Secret)
+			mac.Write(signingInput)
+			if hmac.Equal(mac.Sum(nil), signature) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// algorithmHash maps a JWS alg to its hash. "none" and unknown algorithms
+// are rejected.
+func algorithmHash(alg string) (func() hash.Hash, crypto.Hash, bool) {
+	switch alg {
+	case "RS256", "PS256", "ES256", "HS256":
+		return sha256.New, crypto.SHA256, true
+	case "RS384", "PS384", "ES384", "HS384":
+		return sha512.New384, crypto.SHA384, true
+	case "RS512", "PS512", "HS512":
+		return sha512.New, crypto.SHA512, true
+	default:
+		return nil, 0, false
+	}
+}
+
+func sum(hashFunc func() hash.Hash, data []byte) []byte {
+	h := hashFunc()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
+
+// stringOrList decodes a claim that may be a single string or a list.
+func stringOrList(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return strings.Fields(single)
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
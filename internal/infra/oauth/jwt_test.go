@@ -0,0 +1,212 @@
+package oauth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func signToken(t *testing.T, header map[string]any, claims map[string]any, sign func([]byte) []byte) string {
+	t.Helper()
+	encode := func(value any) string {
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func TestValidator_RS256FromKeyFile(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	jwks, _ := json.Marshal(map[string]any{"keys": []map[string]any{{
+		"kty": "RSA",
+		"kid": "k1",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(privateKey.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(privateKey.E)).Bytes()),
+	}}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, jwks, 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+	keyFile, err := LoadKeyFile(path)
+	if err != nil {
+		t.Fatalf("load key file: %v", err)
+	}
+	validator := &Validator{Keys: keyFile.Keys, Issuer: "https://auth.example.com", Audience: "https://mcp.example.com/mcp"}
+	now := time.Unix(1_800_000_000, 0)
+	sign := func(input []byte) []byte {
+		digest := sha256.Sum256(input)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return signature
+	}
+	claims := map[string]any{
+		"iss":   "https://auth.example.com",
+		"sub":   "teammate",
+		"aud":   []string{"https://mcp.example.com/mcp"},
+		"exp":   now.Add(time.Hour).Unix(),
+		"scope": "mcp:read mcp:write",
+	}
+
+	got, err := validator.Validate(signToken(t, map[string]any{"alg": "RS256", "kid": "k1"}, claims, sign), now)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if got.Subject != "teammate" || len(got.Scopes) != 2 || got.Scopes[1] != "mcp:write" {
+		t.Fatalf("unexpected claims: %+v", got)
+	}
+
+	claims["aud"] = "https://other.example.com/mcp"
+	if _, err := validator.Validate(signToken(t, map[string]any{"alg": "RS256", "kid": "k1"}, claims, sign), now); !errors.Is(err, ErrClaims) {
+		t.Fatalf("expected audience rejection, got %v", err)
+	}
+	claims["aud"] = "https://mcp.example.com/mcp"
+	claims["exp"] = now.Add(-time.Minute).Unix()
+	if _, err := validator.Validate(signToken(t, map[string]any{"alg": "RS256", "kid": "k1"}, claims, sign), now); !errors.Is(err, ErrExpired) {
+		t.Fatalf("expected expiry rejection, got %v", err)
+	}
+}
+
+func TestValidator_RejectsNoneAndForgedSignatures(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	validator := &Validator{
+		Keys:   func() ([]Key, error) { return []Key{{ID: "hs", Secret: secret}}, nil },
+		Issuer: "https://auth.example.com",
+	}
+	now := time.Unix(1_800_000_000, 0)
+	claims := map[string]any{"iss": "https://auth.example.com", "client_id": "ci", "exp": now.Add(time.Hour).Unix(), "scp": []string{"mcp:read"}}
+	hmacSign := func(key []byte) func([]byte) []byte {
+		return func(input []byte) []byte {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(input)
+			return mac.Sum(nil)
+		}
+	}
+
+	got, err := validator.Validate(signToken(t, map[string]any{"alg": "HS256"}, claims, hmacSign(secret)), now)
+	if err != nil || got.ClientID != "ci" || len(got.Scopes) != 1 {
+		t.Fatalf("expected valid token, got %+v err=%v", got, err)
+	}
+	if _, err := validator.Validate(signToken(t, map[string]any{"alg": "HS256"}, claims, hmacSign([]byte("wrong"))), now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("expected forged signature rejection, got %v", err)
+	}
+	if _, err := validator.Validate(signToken(t, map[string]any{"alg": "none"}, claims, func([]byte) []byte { return nil }), now); !errors.Is(err, ErrSignature) {
+		t.Fatalf("expected alg none rejection, got %v", err)
+	}
+}
+
+func TestRemoteKeySet_BacksOffAfterFailedFetch(t *testing.T) {
+	fetches := 0
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches++
+		if !healthy {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"keys":[{"kty":"oct","kid":"k1","k":"c2VjcmV0"}]}`))
+	}))
+	defer server.Close()
+
+	keySet := NewRemoteKeySet(server.URL)
+	if keys, err := keySet.Keys(); err != nil || len(keys) != 1 {
+		t.Fatalf("expected initial keys, got %v %v", keys, err)
+	}
+	healthy = false
+	keySet.fetchedAt = time.Now().Add(-keySet.RefreshInterval)
+	for range 3 {
+		if keys, err := keySet.Keys(); err != nil || len(keys) != 1 {
+			t.Fatalf("expected stale keys while the issuer fails, got %v %v", keys, err)
+		}
+		if _, err := keySet.Refresh(); err != nil {
+			t.Fatalf("expected stale keys from Refresh, got %v", err)
+		}
+	}
+	if fetches != 2 {
+		t.Fatalf("expected one failed refetch inside MinRefreshInterval, got %d fetches", fetches)
+	}
+
+	keySet.failedAt = time.Now().Add(-keySet.MinRefreshInterval)
+	healthy = true
+	if _, err := keySet.Keys(); err != nil || fetches != 3 || !keySet.failedAt.IsZero() {
+		t.Fatalf("expected a retry after the backoff, got fetches=%d err=%v", fetches, err)
+	}
+
+	empty := NewRemoteKeySet(server.URL)
+	healthy = false
+	if _, err := empty.Keys(); err == nil {
+		t.Fatalf("expected an error without cached keys")
+	}
+	if _, err := empty.Keys(); err == nil || fetches != 4 {
+		t.Fatalf("expected the cached error without a refetch, got fetches=%d err=%v", fetches, err)
+	}
+}
+
+func TestRemoteKeySet_ServesCachedKeysWhileRefreshIsInFlight(t *testing.T) {
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if fetches.Add(1) > 1 {
+			<-release
+		}
+		_, _ = w.Write([]byte(`{"keys":[{"kty":"oct","kid":"k1","k":"c2VjcmV0"}]}`))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	keySet := NewRemoteKeySet(server.URL)
+	if keys, err := keySet.Keys(); err != nil || len(keys) != 1 {
+		t.Fatalf("expected initial keys, got %v %v", keys, err)
+	}
+	keySet.mu.Lock()
+	keySet.fetchedAt = time.Now().Add(-keySet.RefreshInterval)
+	keySet.mu.Unlock()
+
+	slow := make(chan struct{})
+	go func() {
+		defer close(slow)
+		_, _ = keySet.Keys()
+	}()
+	for fetches.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := keySet.Refresh()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("expected cached keys during the refresh, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("key lookup blocked behind an in-flight JWKS fetch")
+	}
+	if fetches.Load() != 2 {
+		t.Fatalf("expected a single in-flight fetch, got %d", fetches.Load())
+	}
+}
@@ -10,7 +10,12 @@ import (
 	"time"
 )
 
-const defaultCommandTimeout = 8 * time.Second
+const (
+	defaultCommandTimeout = 8 * time.Second
+	// CommandReasonInFlightLimited is returned by DispatchAndWait when the
+	// target session already has the maximum number of commands in flight.
+	CommandReasonInFlightLimited = "command_in_flight_limited"
+)
 
 var (
 	defaultCommandBroker atomic.Pointer[CommandBroker]
@@ -97,6 +102,10 @@ type CommandBrokerMetrics struct {
 	FailureReasons      map[string]uint64 `json:"failure_reasons"`
 	AvgLatencyMS        float64           `json:"avg_latency_ms"`
 	MaxLatencyMS        int64             `json:"max_latency_ms"`
+	// MaxInFlightPerSession is the in-flight quota per target session; 0 is unlimited.
+	MaxInFlightPerSession int            `json:"max_in_flight_per_session"`
+	InFlight              map[string]int `json:"in_flight"`
+	InFlightLimitedTotal  uint64         `json:"in_flight_limited_total"`
 }
 
 type commandBrokerMetricsState struct {
@@ -105,6 +114,7 @@ type commandBrokerMetricsState struct {
 	ackedTotal          uint64
 	timeoutTotal        uint64
 	transportErrorTotal uint64
+	inFlightLimited     uint64
 	totalLatencyMS      int64
 	maxLatencyMS        int64
 	failureReasons      map[string]uint64
@@ -114,6 +124,7 @@ type commandBrokerMetricsState struct {
 type CommandBroker struct {
 	mu             sync.Mutex
 	defaultTimeout time.Duration
+	maxInFlight    int
 	pending        map[string]pendingCommand
 	metrics        commandBrokerMetricsState
 }
@@ -146,6 +157,27 @@ func ResetDefaultCommandBrokerForTests(defaultTimeout time.Duration) {
 	defaultCommandBroker.Store(NewCommandBroker(defaultTimeout))
 }
 
+// ConfigureMaxInFlight caps concurrent commands awaiting an ack per target
+// session; 0 removes the cap.
+func (b *CommandBroker) ConfigureMaxInFlight(maxInFlight int) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.maxInFlight = max(maxInFlight, 0)
+}
+
+// MaxInFlight returns the per-session in-flight command quota.
+func (b *CommandBroker) MaxInFlight() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.maxInFlight
+}
+
 func (b *CommandBroker) DispatchAndWait(sessionID string, commandName string, arguments map[string]any, timeout time.Duration) (CommandAck, bool, string) {
 	if b == nil {
 		return CommandAck{}, false, "command_broker_unavailable"
@@ -170,6 +202,11 @@ func (b *CommandBroker) DispatchAndWait(sessionID string, commandName string, ar
 	}
 
 	b.mu.Lock()
+	if b.maxInFlight > 0 && b.inFlightLocked(sessionID) >= b.maxInFlight {
+		b.mu.Unlock()
+		b.metricsFailure(CommandReasonInFlightLimited)
+		return CommandAck{}, false, CommandReasonInFlightLimited
+	}
 	b.pending[commandID] = waiter
 	b.mu.Unlock()
 
@@ -242,6 +279,16 @@ func (b *CommandBroker) Ack(sessionID string, ack CommandAck) bool {
 	}
 }
 
+func (b *CommandBroker) inFlightLocked(sessionID string) int {
+	count := 0
+	for _, pending := range b.pending {
+		if pending.sessionID == sessionID {
+			count++
+		}
+	}
+	return count
+}
+
 func (b *CommandBroker) remove(commandID string) {
 	b.mu.Lock()
 	delete(b.pending, commandID)
@@ -257,20 +304,32 @@ func (b *CommandBroker) Metrics() CommandBrokerMetrics {
 	if b == nil {
 		return CommandBrokerMetrics{
 			FailureReasons: map[string]uint64{},
+			InFlight:       map[string]int{},
 		}
 	}
 
+	b.mu.Lock()
+	maxInFlight := b.maxInFlight
+	inFlight := make(map[string]int)
+	for _, pending := range b.pending {
+		inFlight[pending.sessionID]++
+	}
+	b.mu.Unlock()
+
 	b.metrics.mu.Lock()
 	defer b.metrics.mu.Unlock()
 
 	out := CommandBrokerMetrics{
-		DispatchTotal:       b.metrics.dispatchTotal,
-		AckedTotal:          b.metrics.ackedTotal,
-		TimeoutTotal:        b.metrics.timeoutTotal,
-		TransportErrorTotal: b.metrics.transportErrorTotal,
-		FailureReasons:      make(map[string]uint64, len(b.metrics.failureReasons)),
-		AvgLatencyMS:        0,
-		MaxLatencyMS:        b.metrics.maxLatencyMS,
+		DispatchTotal:         b.metrics.dispatchTotal,
+		AckedTotal:            b.metrics.ackedTotal,
+		TimeoutTotal:          b.metrics.timeoutTotal,
+		TransportErrorTotal:   b.metrics.transportErrorTotal,
+		FailureReasons:        make(map[string]uint64, len(b.metrics.failureReasons)),
+		AvgLatencyMS:          0,
+		MaxLatencyMS:          b.metrics.maxLatencyMS,
+		MaxInFlightPerSession: maxInFlight,
+		InFlight:              inFlight,
+		InFlightLimitedTotal:  b.metrics.inFlightLimited,
 	}
 	for reason, count := range b.metrics.failureReasons {
 		out.FailureReasons[reason] = count
@@ -314,5 +373,7 @@ func (b *CommandBroker) metricsFailure(reason string) {
 		b.metrics.transportErrorTotal = b.metrics.transportErrorTotal + 1
 	case "command_ack_timeout":
 		b.metrics.timeoutTotal = b.metrics.timeoutTotal + 1
+	case CommandReasonInFlightLimited:
+		b.metrics.inFlightLimited = b.metrics.inFlightLimited + 1
 	}
 }
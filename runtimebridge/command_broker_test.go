@@ -217,3 +217,49 @@ func TestDefaultCommandBrokerReset_ConcurrentLoadAndReset(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestCommandBrokerLimitsInFlightCommandsPerSession(t *testing.T) {
+	ResetDefaultCommandBrokerForTests(2 * time.Second)
+	broker := DefaultCommandBroker()
+	broker.ConfigureMaxInFlight(1)
+	dispatched := make(chan string, 4)
+	SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		dispatched <- commandID
+		return true
+	})
+	defer SetNotificationSender(nil)
+
+	done := make(chan bool, 1)
+	go func() {
+		_, ok, _ := broker.DispatchAndWait("session-1", "godot.runtime.input.tap", map[string]any{}, 2*time.Second)
+		done <- ok
+	}()
+	firstCommandID := <-dispatched
+
+	if _, ok, reason := broker.DispatchAndWait("session-1", "godot.runtime.input.tap", map[string]any{}, time.Second); ok || reason != CommandReasonInFlightLimited {
+		t.Fatalf("expected %s while a command is in flight, ok=%t reason=%s", CommandReasonInFlightLimited, ok, reason)
+	}
+	metrics := broker.Metrics()
+	if metrics.InFlight["session-1"] != 1 || metrics.MaxInFlightPerSession != 1 || metrics.InFlightLimitedTotal != 1 {
+		t.Fatalf("unexpected in-flight metrics %+v", metrics)
+	}
+
+	// Other target sessions keep their own quota.
+	go func() {
+		commandID := <-dispatched
+		broker.Ack("session-2", CommandAck{CommandID: commandID, Success: true})
+	}()
+	if _, ok, reason := broker.DispatchAndWait("session-2", "godot.runtime.input.tap", map[string]any{}, time.Second); !ok {
+		t.Fatalf("expected other session to dispatch, reason=%s", reason)
+	}
+
+	broker.Ack("session-1", CommandAck{CommandID: firstCommandID, Success: true})
+	if ok := <-done; !ok {
+		t.Fatal("expected first command to be acknowledged")
+	}
+	if metrics := broker.Metrics(); metrics.InFlight["session-1"] != 0 {
+		t.Fatalf("expected no commands in flight after ack, got %+v", metrics.InFlight)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !windows
+
+package runtimebridge
+
+import (
+	"os"
+	"syscall"
+)
+
+// privateToCurrentUser reports whether info belongs to the effective uid and
+// grants nothing to group or others.
+func privateToCurrentUser(info os.FileInfo) bool {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	return ok && int(stat.Uid) == os.Geteuid() && info.Mode().Perm()&0o077 == 0
+}
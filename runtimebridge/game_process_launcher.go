@@ -0,0 +1,653 @@
+package runtimebridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	gameProcessStdoutSource  = "process_stdout"
+	gameProcessStderrSource  = "process_stderr"
+	gameProcessMaxLineBytes  = 256 << 10
+	defaultGameProcessStopIn = 3 * time.Second
+	// gameProcessPipeCloseWait is how long Stop waits after a kill before it
+	// closes the output pipes a surviving grandchild may still hold open.
+	gameProcessPipeCloseWait = time.Second
+	// gameProcessContinuationWait bounds how long an error header waits for
+	// its "at:" and backtrace lines before it is appended on its own.
+	gameProcessContinuationWait = 100 * time.Millisecond
+)
+
+var (
+	ErrGodotExecutableMissing = errors.New("godot executable not found")
+	ErrGameProcessRunning     = errors.New("game session already has a running process")
+	ErrInvalidGameSessionID   = errors.New("game session id must be 1-64 letters, digits, '_' or '-'")
+	ErrInvalidScenePath       = errors.New("scene path must not start with '-'")
+	ErrUnsafePrivateDir       = errors.New("directory must be owned by the current user with mode 0700")
+
+	// gameSessionIDPattern matches the ids project.run generates; ids name
+	// handshake files, so nothing else may reach the filesystem.
+	gameSessionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+	defaultGameProcessLauncher atomic.Pointer[GameProcessLauncher]
+)
+
+func init() {
+	defaultGameProcessLauncher.Store(NewGameProcessLauncher("", "", ""))
+}
+
+// GameProcessLaunch describes one Godot child process started by the server.
+type GameProcessLaunch struct {
+	SessionID   string
+	LaunchToken string
+	ProjectDir  string
+	ScenePath   string
+	Headless    bool
+	UserArgs    []string
+	StartedAt   time.Time
+}
+
+// GameProcessInfo is the observable state of a managed game process.
+type GameProcessInfo struct {
+	SessionID  string   `json:"session_id"`
+	PID        int      `json:"pid"`
+	Executable string   `json:"executable"`
+	Args       []string `json:"args"`
+	ProjectDir string   `json:"project_dir"`
+	StartedAt  string   `json:"started_at"`
+	Running    bool     `json:"running"`
+	ExitCode   *int     `json:"exit_code,omitempty"`
+	ExitedAt   string   `json:"exited_at,omitempty"`
+}
+
+// GameProcessLauncher spawns Godot as a managed child process, hands it a
+// runtime handshake through GODOT_MCP_RUNTIME_HANDSHAKE_PATH, and pipes its
+// stdout/stderr into the runtime log stream of its game session.
+type GameProcessLauncher struct {
+	mu           sync.Mutex
+	executable   string
+	serverURL    string
+	handshakeDir string
+	// authToken is the bearer token handed to launched games when the
+	// server requires authentication.
+	authToken string
+	// bridgeSecret lets launched games' runtime sessions claim the runtime
+	// role.
+	bridgeSecret string
+	processes    map[string]*gameProcess
+}
+
+type gameProcess struct {
+	info          GameProcessInfo
+	cmd           *exec.Cmd
+	done          chan struct{}
+	handshakePath string
+	// pipes are the read ends of stdout and stderr.
+	pipes []io.Closer
+	// stopRequested marks exits caused by Stop rather than by the game.
+	stopRequested bool
+}
+
+func NewGameProcessLauncher(executable string, serverURL string, handshakeDir string) *GameProcessLauncher {
+	launcher := &GameProcessLauncher{processes: make(map[string]*gameProcess)}
+	launcher.Configure(executable, serverURL, handshakeDir)
+	return launcher
+}
+
+func DefaultGameProcessLauncher() *GameProcessLauncher {
+	if launcher := defaultGameProcessLauncher.Load(); launcher != nil {
+		return launcher
+	}
+	launcher := NewGameProcessLauncher("", "", "")
+	if defaultGameProcessLauncher.CompareAndSwap(nil, launcher) {
+		return launcher
+	}
+	return defaultGameProcessLauncher.Load()
+}
+
+func ResetDefaultGameProcessLauncherForTests(executable string, serverURL string, handshakeDir string) {
+	if previous := defaultGameProcessLauncher.Load(); previous != nil {
+		previous.StopAll()
+	}
+	defaultGameProcessLauncher.Store(NewGameProcessLauncher(executable, serverURL, handshakeDir))
+}
+
+// DefaultGameProcessHandshakeDir is the per-user directory handshake files
+// are written to, or empty when the platform has no user cache directory.
+// The runtime companion scans the same directory by default.
+func DefaultGameProcessHandshakeDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil || strings.TrimSpace(dir) == "" {
+		return ""
+	}
+	return filepath.Join(dir, "godot-mcp", "handshakes")
+}
+
+// Configure sets the Godot executable, the streamable HTTP URL written into
+// handshakes, and where handshake files are written. An empty handshakeDir
+// uses DefaultGameProcessHandshakeDir, falling back to a private temporary
+// directory created on first launch.
+func (l *GameProcessLauncher) Configure(executable string, serverURL string, handshakeDir string) {
+	if l == nil {
+		return
+	}
+	handshakeDir = strings.TrimSpace(handshakeDir)
+	if handshakeDir == "" {
+		handshakeDir = DefaultGameProcessHandshakeDir()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.executable = strings.TrimSpace(executable)
+	l.serverURL = strings.TrimSpace(serverURL)
+	l.handshakeDir = handshakeDir
+}
+
+// SetBridgeSecret sets the bridge secret written into handshakes; empty
+// omits it.
+func (l *GameProcessLauncher) SetBridgeSecret(secret string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bridgeSecret = strings.TrimSpace(secret)
+}
+
+// SetAuthToken sets the bearer token written into handshakes; empty omits it.
+func (l *GameProcessLauncher) SetAuthToken(token string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.authToken = strings.TrimSpace(token)
+}
+
+// ResolveExecutable returns the configured Godot binary, or godot/godot4 from PATH.
+func (l *GameProcessLauncher) ResolveExecutable() (string, error) {
+	if l == nil {
+		return "", ErrGodotExecutableMissing
+	}
+	l.mu.Lock()
+	configured := l.executable
+	l.mu.Unlock()
+	candidates := []string{"godot", "godot4"}
+	if configured != "" {
+		candidates = []string{configured}
+	}
+	for _, candidate := range candidates {
+		if path, err := exec.LookPath(candidate); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrGodotExecutableMissing, strings.Join(candidates, ", "))
+}
+
+// ValidGameSessionID reports whether id is safe to use as a game session id.
+func ValidGameSessionID(id string) bool {
+	return gameSessionIDPattern.MatchString(id)
+}
+
+// Launch starts a Godot process for an already registered game session.
+func (l *GameProcessLauncher) Launch(launch GameProcessLaunch) (GameProcessInfo, error) {
+	if l == nil || strings.TrimSpace(launch.SessionID) == "" {
+		return GameProcessInfo{}, errors.New("game process launch requires a session id")
+	}
+	if launch.StartedAt.IsZero() {
+		launch.StartedAt = time.Now().UTC()
+	}
+	sessionID := strings.TrimSpace(launch.SessionID)
+	if !ValidGameSessionID(sessionID) {
+		return GameProcessInfo{}, ErrInvalidGameSessionID
+	}
+	if strings.HasPrefix(strings.TrimSpace(launch.ScenePath), "-") {
+		return GameProcessInfo{}, ErrInvalidScenePath
+	}
+	executable, err := l.ResolveExecutable()
+	if err != nil {
+		return GameProcessInfo{}, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if existing, ok := l.processes[sessionID]; ok && existing.info.Running {
+		return GameProcessInfo{}, ErrGameProcessRunning
+	}
+
+	args := []string{}
+	if launch.Headless {
+		args = append(args, "--headless")
+	}
+	args = append(args, "--path", launch.ProjectDir)
+	if scenePath := strings.TrimSpace(launch.ScenePath); scenePath != "" {
+		args = append(args, scenePath)
+	}
+	if len(launch.UserArgs) > 0 {
+		args = append(args, "--")
+		args = append(args, launch.UserArgs...)
+	}
+
+	handshakePath, err := l.writeHandshakeLocked(sessionID, launch)
+	if err != nil {
+		return GameProcessInfo{}, err
+	}
+	cmd := exec.Command(executable, args...)
+	cmd.Dir = launch.ProjectDir
+	cmd.Env = append(os.Environ(), "GODOT_MCP_RUNTIME_HANDSHAKE_PATH="+handshakePath)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		_ = os.Remove(handshakePath)
+		return GameProcessInfo{}, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		_ = os.Remove(handshakePath)
+		return GameProcessInfo{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		_ = os.Remove(handshakePath)
+		return GameProcessInfo{}, err
+	}
+
+	process := &gameProcess{
+		info: GameProcessInfo{
+			SessionID:  sessionID,
+			PID:        cmd.Process.Pid,
+			Executable: executable,
+			Args:       args,
+			ProjectDir: launch.ProjectDir,
+			StartedAt:  launch.StartedAt.UTC().Format(time.RFC3339Nano),
+			Running:    true,
+		},
+		cmd:           cmd,
+		done:          make(chan struct{}),
+		handshakePath: handshakePath,
+		pipes:         []io.Closer{stdout, stderr},
+	}
+	l.processes[sessionID] = process
+
+	var readers sync.WaitGroup
+	readers.Add(2)
+	go func() {
+		defer readers.Done()
+		captureGameProcessOutput(sessionID, stdout, gameProcessStdoutSource, "info")
+	}()
+	go func() {
+		defer readers.Done()
+		captureGameProcessOutput(sessionID, stderr, gameProcessStderrSource, "error")
+	}()
+	go l.waitForExit(process, &readers)
+	return process.info, nil
+}
+
+func (l *GameProcessLauncher) writeHandshakeLocked(sessionID string, launch GameProcessLaunch) (string, error) {
+	if l.handshakeDir == "" {
+		dir, err := os.MkdirTemp("", "godot-mcp-handshakes-")
+		if err != nil {
+			return "", err
+		}
+		l.handshakeDir = dir
+	}
+	if err := ensurePrivateDir(l.handshakeDir); err != nil {
+		return "", err
+	}
+	handshake := map[string]any{
+		"state":               "running",
+		"game_session_id":     sessionID,
+		"launch_token":        launch.LaunchToken,
+		"streamable_http_url": l.serverURL,
+		"scene_path":          launch.ScenePath,
+		"started_at":          launch.StartedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if l.authToken != "" {
+		handshake["auth_token"] = l.authToken
+	}
+	if l.bridgeSecret != "" {
+		handshake["bridge_secret"] = l.bridgeSecret
+	}
+	payload, err := json.Marshal(handshake)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(l.handshakeDir, sessionID+".json")
+	// O_EXCL refuses an existing file, including a planted symlink; a stale
+	// handshake of an earlier run of this session id is only ever a regular
+	// file in the private directory.
+	if info, err := os.Lstat(path); err == nil && info.Mode().IsRegular() {
+		_ = os.Remove(path)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Write(payload); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// ensurePrivateDir creates dir with mode 0700 and rejects it unless it is a
+// real directory owned by the current user that nobody else can reach, since
+// MkdirAll leaves a directory created by someone else as is.
+func ensurePrivateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() || !privateToCurrentUser(info) {
+		return fmt.Errorf("%w: %s", ErrUnsafePrivateDir, dir)
+	}
+	return nil
+}
+
+func (l *GameProcessLauncher) waitForExit(process *gameProcess, readers *sync.WaitGroup) {
+	// Pipes must be drained before Wait closes them.
+	readers.Wait()
+	err := process.cmd.Wait()
+	exitCode := 0
+	if process.cmd.ProcessState != nil {
+		exitCode = process.cmd.ProcessState.ExitCode()
+	} else if err != nil {
+		exitCode = -1
+	}
+	now := time.Now().UTC()
+
+	l.mu.Lock()
+	process.info.Running = false
+	process.info.ExitCode = &exitCode
+	process.info.ExitedAt = now.Format(time.RFC3339Nano)
+	reason := GameSessionExitProcessExit
+	if process.stopRequested {
+		reason = GameSessionExitProjectStop
+	}
+	l.mu.Unlock()
+	_ = os.Remove(process.handshakePath)
+
+	level := "info"
+	if exitCode != 0 {
+		level = "error"
+	}
+	DefaultRuntimeLogStore().Append(process.info.SessionID, []RuntimeLogAppendEntry{{
+		Level:   level,
+		Message: fmt.Sprintf("game process exited with code %d", exitCode),
+		Source:  "runtime_lifecycle",
+	}}, now)
+	DefaultGameSessionHistory().RecordExitCode(process.info.SessionID, exitCode)
+	DefaultGameSessionRegistry().StopSessionWithReason(process.info.SessionID, reason, now)
+	close(process.done)
+}
+
+// Process returns the managed process for a game session.
+func (l *GameProcessLauncher) Process(sessionID string) (GameProcessInfo, bool) {
+	if l == nil {
+		return GameProcessInfo{}, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	process, ok := l.processes[strings.TrimSpace(sessionID)]
+	if !ok {
+		return GameProcessInfo{}, false
+	}
+	return process.info, true
+}
+
+// Done returns a channel closed when the session's process has exited.
+func (l *GameProcessLauncher) Done(sessionID string) (<-chan struct{}, bool) {
+	if l == nil {
+		return nil, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	process, ok := l.processes[strings.TrimSpace(sessionID)]
+	if !ok {
+		return nil, false
+	}
+	return process.done, true
+}
+
+// Stop interrupts the session's process, killing it if it has not exited
+// within grace. It returns false when the session has no running process.
+func (l *GameProcessLauncher) Stop(sessionID string, grace time.Duration) bool {
+	if l == nil {
+		return false
+	}
+	if grace <= 0 {
+		grace = defaultGameProcessStopIn
+	}
+	l.mu.Lock()
+	process, ok := l.processes[strings.TrimSpace(sessionID)]
+	if !ok || !process.info.Running {
+		l.mu.Unlock()
+		return false
+	}
+	process.stopRequested = true
+	l.mu.Unlock()
+
+	if err := process.cmd.Process.Signal(os.Interrupt); err != nil {
+		_ = process.cmd.Process.Kill()
+	}
+	select {
+	case <-process.done:
+	case <-time.After(grace):
+		_ = process.cmd.Process.Kill()
+		select {
+		case <-process.done:
+		case <-time.After(gameProcessPipeCloseWait):
+			// A grandchild that inherited stdout/stderr keeps the output
+			// readers, and so waitForExit, blocked; closing our ends frees
+			// them.
+			for _, pipe := range process.pipes {
+				_ = pipe.Close()
+			}
+			<-process.done
+		}
+	}
+	return true
+}
+
+// StopAll stops every running process, used on server shutdown and in tests.
+func (l *GameProcessLauncher) StopAll() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	sessionIDs := make([]string, 0, len(l.processes))
+	for sessionID, process := range l.processes {
+		if process.info.Running {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	l.mu.Unlock()
+	for _, sessionID := range sessionIDs {
+		l.Stop(sessionID, defaultGameProcessStopIn)
+	}
+}
+
+// RemoveSession stops the session's process, if any, and forgets it.
+func (l *GameProcessLauncher) RemoveSession(sessionID string) {
+	if l == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	l.Stop(sessionID, defaultGameProcessStopIn)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.processes, strings.TrimSpace(sessionID))
+}
+
+func (l *GameProcessLauncher) Health() map[string]any {
+	if l == nil {
+		return map[string]any{
+			"processes": 0,
+			"running":   0,
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	running := 0
+	for _, process := range l.processes {
+		if process.info.Running {
+			running++
+		}
+	}
+	return map[string]any{
+		"processes": len(l.processes),
+		"running":   running,
+	}
+}
+
+// captureGameProcessOutput appends each output line to the session's runtime
+// log, detecting Godot's ERROR/WARNING prefixes. Continuation lines of an
+// error or warning ("at:" locations and GDScript backtrace frames) are folded
+// into that entry's stack trace; other indented lines keep the previous level.
+func captureGameProcessOutput(sessionID string, reader io.Reader, source string, fallbackLevel string) {
+	lines := make(chan gameProcessLine, 64)
+	go func() {
+		defer close(lines)
+		buffered := bufio.NewReaderSize(reader, 64<<10)
+		for {
+			line, dropped, err := readGameProcessLine(buffered, gameProcessMaxLineBytes)
+			if line != "" {
+				lines <- gameProcessLine{text: line}
+			}
+			if dropped > 0 {
+				lines <- gameProcessLine{text: line, dropped: dropped}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var pending *RuntimeLogAppendEntry
+	var continuation []string
+	flush := func() {
+		if pending == nil {
+			return
+		}
+		pending.StackTrace = strings.Join(continuation, "\n")
+		DefaultRuntimeLogStore().Append(sessionID, []RuntimeLogAppendEntry{*pending}, time.Now().UTC())
+		pending = nil
+		continuation = nil
+	}
+	// An error header is held briefly so its continuation lines can join it.
+	idle := time.NewTimer(gameProcessContinuationWait)
+	idle.Stop()
+	defer idle.Stop()
+
+	previous := ""
+	for {
+		select {
+		case item, ok := <-lines:
+			if !ok {
+				flush()
+				return
+			}
+			if item.dropped > 0 {
+				flush()
+				DefaultRuntimeLogStore().Append(sessionID, []RuntimeLogAppendEntry{{
+					Level:   "warning",
+					Message: fmt.Sprintf("Process output line truncated to %d bytes, %d bytes dropped", len(item.text), item.dropped),
+					Source:  source,
+				}}, time.Now().UTC())
+				continue
+			}
+			line := item.text
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if pending != nil && IsGodotLogContinuation(line) {
+				continuation = append(continuation, strings.TrimSpace(line))
+				idle.Reset(gameProcessContinuationWait)
+				continue
+			}
+			flush()
+			level := DetectGameProcessLogLevel(line, previous, fallbackLevel)
+			previous = level
+			entry := RuntimeLogAppendEntry{
+				Level:   level,
+				Message: line,
+				Source:  source,
+			}
+			if level == "error" || level == "warning" {
+				pending = &entry
+				idle.Reset(gameProcessContinuationWait)
+				continue
+			}
+			DefaultRuntimeLogStore().Append(sessionID, []RuntimeLogAppendEntry{entry}, time.Now().UTC())
+		case <-idle.C:
+			flush()
+		}
+	}
+}
+
+// gameProcessLine is one captured output line, or with dropped set, the
+// notice that the preceding line was cut.
+type gameProcessLine struct {
+	text    string
+	dropped int
+}
+
+// readGameProcessLine reads one line, keeping at most maxBytes of it and
+// discarding the rest up to the newline so one huge line never stops capture.
+func readGameProcessLine(reader *bufio.Reader, maxBytes int) (string, int, error) {
+	var line []byte
+	dropped := 0
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		keep := min(len(chunk), maxBytes-len(line))
+		line = append(line, chunk[:keep]...)
+		dropped += len(chunk) - keep
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue
+		}
+		if dropped > 0 && len(chunk) > 0 && chunk[len(chunk)-1] == '\n' {
+			dropped--
+		} else {
+			line = bytes.TrimSuffix(line, []byte("\n"))
+		}
+		return strings.TrimRight(string(line), "\r"), dropped, err
+	}
+}
+
+// DetectGameProcessLogLevel maps one Godot output line to a runtime log level.
+func DetectGameProcessLogLevel(line string, previousLevel string, fallbackLevel string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "SCRIPT ERROR:"),
+		strings.HasPrefix(trimmed, "USER SCRIPT ERROR:"),
+		strings.HasPrefix(trimmed, "USER ERROR:"),
+		strings.HasPrefix(trimmed, "SHADER ERROR:"),
+		strings.HasPrefix(trimmed, "ERROR:"),
+		strings.HasPrefix(trimmed, "Parse Error:"):
+		return "error"
+	case strings.HasPrefix(trimmed, "USER WARNING:"),
+		strings.HasPrefix(trimmed, "SCRIPT WARNING:"),
+		strings.HasPrefix(trimmed, "WARNING:"):
+		return "warning"
+	}
+	if previousLevel != "" && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+		return previousLevel
+	}
+	return fallbackLevel
+}
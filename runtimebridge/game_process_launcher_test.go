@@ -0,0 +1,194 @@
+package runtimebridge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGameProcessLauncher_CapturesOutputWithLevelsAndRecordsExit(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the godot executable")
+	}
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultGameSessionRegistryForTests()
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "godot")
+	script := `#!/bin/sh
+test -f "$GODOT_MCP_RUNTIME_HANDSHAKE_PATH" || exit 9
+echo "Godot Engine v4.3.stable"
+echo "SCRIPT ERROR: Invalid call. Nonexistent function 'jump'." >&2
+echo "   at: _ready (res://player.gd:12)" >&2
+echo "WARNING: texture not found" >&2
+echo "args: $*"
+exit 3
+`
+	if err := os.WriteFile(executable, []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake godot: %v", err)
+	}
+	launcher := NewGameProcessLauncher(executable, "http://localhost:9080/mcp", filepath.Join(dir, "handshakes"))
+	DefaultGameSessionRegistry().UpsertFromRun("game_1", "", "", "launch-token", time.Now().UTC())
+
+	info, err := launcher.Launch(GameProcessLaunch{
+		SessionID:   "game_1",
+		LaunchToken: "launch-token",
+		ProjectDir:  dir,
+		ScenePath:   "res://Main.tscn",
+		Headless:    true,
+	})
+	if err != nil {
+		t.Fatalf("launch: %v", err)
+	}
+	if info.PID == 0 || info.Args[0] != "--headless" {
+		t.Fatalf("unexpected process info: %+v", info)
+	}
+	done, _ := launcher.Done("game_1")
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for process exit")
+	}
+
+	exited, _ := launcher.Process("game_1")
+	if exited.Running || exited.ExitCode == nil || *exited.ExitCode != 3 {
+		t.Fatalf("expected exit code 3, got %+v", exited)
+	}
+	if DefaultGameSessionRegistry().IsRunning("game_1") {
+		t.Fatal("expected game session to be stopped after process exit")
+	}
+	levels := map[string]string{}
+	for _, entry := range DefaultRuntimeLogStore().Get("game_1", "", 100, 0) {
+		levels[entry.Message] = entry.Level
+		if entry.ErrorKind == "script_error" {
+			if entry.StackTrace != "at: _ready (res://player.gd:12)" || entry.File != "res://player.gd" || entry.Line != 12 || entry.Function != "_ready" {
+				t.Fatalf("expected at: line folded into the script error, got %+v", entry)
+			}
+		}
+	}
+	if _, ok := levels["at: _ready (res://player.gd:12)"]; ok {
+		t.Fatalf("expected at: line not to be logged separately, got %v", levels)
+	}
+	expected := map[string]string{
+		"Godot Engine v4.3.stable":                                 "info",
+		"SCRIPT ERROR: Invalid call. Nonexistent function 'jump'.": "error",
+		"WARNING: texture not found":                               "warning",
+		"args: --headless --path " + dir + " res://Main.tscn":      "info",
+		"game process exited with code 3":                          "error",
+	}
+	for message, level := range expected {
+		if levels[message] != level {
+			t.Fatalf("expected %q at level %q, got %q (all=%v)", message, level, levels[message], levels)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "handshakes", "game_1.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected handshake file to be removed after exit, got %v", err)
+	}
+}
+
+func TestGameProcessLauncher_StopReturnsWhenGrandchildHoldsPipes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the godot executable")
+	}
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultGameSessionRegistryForTests()
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "godot")
+	script := `#!/bin/sh
+sleep 10 &
+echo started
+wait
+`
+	if err := os.WriteFile(executable, []byte(script), 0o755); err != nil {
+		t.Fatalf("write fake godot: %v", err)
+	}
+	launcher := NewGameProcessLauncher(executable, "http://localhost:9080/mcp", filepath.Join(dir, "handshakes"))
+	DefaultGameSessionRegistry().UpsertFromRun("game_1", "", "", "launch-token", time.Now().UTC())
+	if _, err := launcher.Launch(GameProcessLaunch{SessionID: "game_1", LaunchToken: "launch-token", ProjectDir: dir}); err != nil {
+		t.Fatalf("launch: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(DefaultRuntimeLogStore().Get("game_1", "", 10, 0)) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	startedAt := time.Now()
+	if !launcher.Stop("game_1", 100*time.Millisecond) {
+		t.Fatal("expected a running process to stop")
+	}
+	if elapsed := time.Since(startedAt); elapsed > 5*time.Second {
+		t.Fatalf("expected Stop to return once the pipes are closed, took %s", elapsed)
+	}
+	if process, _ := launcher.Process("game_1"); process.Running {
+		t.Fatalf("expected process to be recorded as exited, got %+v", process)
+	}
+}
+
+func TestCaptureGameProcessOutput_TruncatesLongLinesAndKeepsReading(t *testing.T) {
+	ResetDefaultRuntimeLogStoreForTests(100)
+	long := strings.Repeat("x", gameProcessMaxLineBytes+10)
+	captureGameProcessOutput("game_long", strings.NewReader("before\n"+long+"\nafter\n"), gameProcessStdoutSource, "info")
+
+	entries := DefaultRuntimeLogStore().Get("game_long", "", 100, 0)
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+	messages := map[string]string{}
+	for _, entry := range entries {
+		messages[entry.Message] = entry.Level
+	}
+	if _, ok := messages[long[:gameProcessMaxLineBytes]]; !ok {
+		t.Fatalf("expected the kept prefix of the long line")
+	}
+	if messages["Process output line truncated to 262144 bytes, 10 bytes dropped"] != "warning" || messages["before"] != "info" || messages["after"] != "info" {
+		t.Fatalf("expected truncation warning and surrounding lines, got %d messages", len(messages))
+	}
+}
+
+func TestGameProcessLauncher_RefusesSharedHandshakeDirAndPlantedFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the godot executable and POSIX modes")
+	}
+	ResetDefaultGameSessionRegistryForTests()
+
+	dir := t.TempDir()
+	executable := filepath.Join(dir, "godot")
+	if err := os.WriteFile(executable, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("write fake godot: %v", err)
+	}
+	launch := GameProcessLaunch{SessionID: "game_1", ProjectDir: dir, Headless: true}
+
+	shared := filepath.Join(dir, "shared")
+	if err := os.Mkdir(shared, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.Chmod(shared, 0o755); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	if _, err := NewGameProcessLauncher(executable, "http://localhost:9080/mcp", shared).Launch(launch); !errors.Is(err, ErrUnsafePrivateDir) {
+		t.Fatalf("expected unsafe handshake dir error, got %v", err)
+	}
+
+	private := filepath.Join(dir, "private")
+	if err := os.Mkdir(private, 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	target := filepath.Join(dir, "target")
+	if err := os.WriteFile(target, []byte("keep"), 0o600); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+	if err := os.Symlink(target, filepath.Join(private, "game_1.json")); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	if _, err := NewGameProcessLauncher(executable, "http://localhost:9080/mcp", private).Launch(launch); err == nil {
+		t.Fatal("expected launch to refuse a planted handshake symlink")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "keep" {
+		t.Fatalf("symlink target was overwritten: %q", data)
+	}
+}
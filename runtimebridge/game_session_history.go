@@ -0,0 +1,387 @@
+package runtimebridge
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	GameSessionExitProjectStop    = "project_stop"
+	GameSessionExitProcessExit    = "process_exit"
+	GameSessionExitReplaced       = "replaced"
+	GameSessionExitEditorStop     = "editor_stop"
+	GameSessionExitSessionRemoved = "session_removed"
+
+	DefaultGameSessionHistoryLimit = 50
+	maxGameSessionHistoryEvents    = 32
+)
+
+// GameSessionEvent is one lifecycle transition of a game session.
+type GameSessionEvent struct {
+	Time   string `json:"time"`
+	Event  string `json:"event"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// GameSessionRecord summarises one game session run. It outlives the
+// registry entry so finished runs can be compared.
+type GameSessionRecord struct {
+	SessionID       string             `json:"session_id"`
+	EditorSessionID string             `json:"editor_session_id,omitempty"`
+	ScenePath       string             `json:"scene_path,omitempty"`
+	InstanceIndex   int                `json:"instance_index,omitempty"`
+	StartedAt       string             `json:"started_at"`
+	EndedAt         string             `json:"ended_at,omitempty"`
+	DurationMS      int64              `json:"duration_ms"`
+	Outcome         string             `json:"outcome"`
+	ExitReason      string             `json:"exit_reason,omitempty"`
+	ExitCode        *int               `json:"exit_code,omitempty"`
+	LogCount        int                `json:"log_count"`
+	ErrorCount      int                `json:"error_count"`
+	WarningCount    int                `json:"warning_count"`
+	SnapshotCount   int                `json:"snapshot_count"`
+	LastFrame       int64              `json:"last_frame,omitempty"`
+	LastLogLines    []RuntimeLogEntry  `json:"last_log_lines,omitempty"`
+	Events          []GameSessionEvent `json:"events,omitempty"`
+
+	startedAt time.Time
+	endedAt   time.Time
+}
+
+// GameSessionHistoryQuery filters GameSessionHistory.List.
+type GameSessionHistoryQuery struct {
+	Outcome   string
+	ScenePath string
+	Limit     int
+}
+
+var defaultGameSessionHistory atomic.Pointer[GameSessionHistory]
+
+func init() {
+	defaultGameSessionHistory.Store(NewGameSessionHistory(DefaultGameSessionHistoryLimit))
+}
+
+// GameSessionHistory keeps a bounded record of recent game sessions. The
+// registry feeds lifecycle transitions and the log and snapshot stores feed
+// counters, so records survive session cleanup.
+type GameSessionHistory struct {
+	mu      sync.Mutex
+	limit   int
+	records map[string]*GameSessionRecord
+	order   []string
+	evicted int64
+}
+
+func NewGameSessionHistory(limit int) *GameSessionHistory {
+	if limit <= 0 {
+		limit = DefaultGameSessionHistoryLimit
+	}
+	return &GameSessionHistory{
+		limit:   limit,
+		records: make(map[string]*GameSessionRecord),
+		order:   make([]string, 0),
+	}
+}
+
+func DefaultGameSessionHistory() *GameSessionHistory {
+	if history := defaultGameSessionHistory.Load(); history != nil {
+		return history
+	}
+	history := NewGameSessionHistory(DefaultGameSessionHistoryLimit)
+	if defaultGameSessionHistory.CompareAndSwap(nil, history) {
+		return history
+	}
+	return defaultGameSessionHistory.Load()
+}
+
+func ResetDefaultGameSessionHistoryForTests(limit int) {
+	defaultGameSessionHistory.Store(NewGameSessionHistory(limit))
+}
+
+func (h *GameSessionHistory) Configure(limit int) {
+	if h == nil || limit <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.limit = limit
+	h.evictLocked()
+}
+
+// begin opens a record for a run. Repeated calls while the run is open only
+// refresh its editor and scene; a finished record is replaced by a new run.
+func (h *GameSessionHistory) begin(session GameSession, at time.Time) {
+	if h == nil || session.SessionID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if record, ok := h.records[session.SessionID]; ok && record.endedAt.IsZero() {
+		record.EditorSessionID = session.EditorSessionID
+		record.ScenePath = session.ScenePath
+		record.InstanceIndex = session.InstanceIndex
+		return
+	}
+	if _, ok := h.records[session.SessionID]; ok {
+		h.removeLocked(session.SessionID)
+	}
+	record := &GameSessionRecord{
+		SessionID:       session.SessionID,
+		EditorSessionID: session.EditorSessionID,
+		ScenePath:       session.ScenePath,
+		InstanceIndex:   session.InstanceIndex,
+		StartedAt:       at.UTC().Format(time.RFC3339Nano),
+		Outcome:         GameSessionStateRunning,
+		startedAt:       at.UTC(),
+	}
+	appendGameSessionEvent(record, "started", "", at)
+	h.records[session.SessionID] = record
+	h.order = append(h.order, session.SessionID)
+	h.evictLocked()
+}
+
+func (h *GameSessionHistory) event(sessionID string, event string, reason string, at time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if record, ok := h.records[sessionID]; ok {
+		appendGameSessionEvent(record, event, reason, at)
+	}
+}
+
+func (h *GameSessionHistory) setInstanceIndex(sessionID string, instanceIndex int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if record, ok := h.records[sessionID]; ok {
+		record.InstanceIndex = instanceIndex
+	}
+}
+
+// setOutcome records a transition of an open run, such as hung or responsive.
+func (h *GameSessionHistory) setOutcome(sessionID string, outcome string, event string, reason string, at time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record, ok := h.records[sessionID]
+	if !ok || !record.endedAt.IsZero() {
+		return
+	}
+	record.Outcome = outcome
+	appendGameSessionEvent(record, event, reason, at)
+}
+
+// end closes an open run. Only the first end counts, so a process exit that
+// follows godot.project.stop keeps the project_stop reason.
+func (h *GameSessionHistory) end(sessionID string, outcome string, reason string, lastLogLines []RuntimeLogEntry, at time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record, ok := h.records[sessionID]
+	if !ok || !record.endedAt.IsZero() {
+		return
+	}
+	record.Outcome = outcome
+	record.ExitReason = reason
+	record.EndedAt = at.UTC().Format(time.RFC3339Nano)
+	record.endedAt = at.UTC()
+	if lastLogLines != nil {
+		record.LastLogLines = lastLogLines
+	}
+	appendGameSessionEvent(record, outcome, reason, at)
+}
+
+// reopen resumes a run that ended as crashed but started pushing snapshots
+// again.
+func (h *GameSessionHistory) reopen(sessionID string, at time.Time) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record, ok := h.records[sessionID]
+	if !ok || record.endedAt.IsZero() {
+		return
+	}
+	record.Outcome = GameSessionStateRunning
+	record.ExitReason = ""
+	record.EndedAt = ""
+	record.endedAt = time.Time{}
+	appendGameSessionEvent(record, "revived", "", at)
+}
+
+// RecordExitCode attaches the exit code of a server-managed game process.
+func (h *GameSessionHistory) RecordExitCode(sessionID string, exitCode int) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if record, ok := h.records[strings.TrimSpace(sessionID)]; ok {
+		code := exitCode
+		record.ExitCode = &code
+	}
+}
+
+func (h *GameSessionHistory) observeLogs(sessionID string, entries []RuntimeLogEntry) {
+	if h == nil || len(entries) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record, ok := h.records[sessionID]
+	if !ok {
+		return
+	}
+	for _, entry := range entries {
+		record.LogCount++
+		switch entry.Level {
+		case "error":
+			record.ErrorCount++
+		case "warning":
+			record.WarningCount++
+		}
+	}
+}
+
+func (h *GameSessionHistory) observeSnapshot(sessionID string, frame int64) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if record, ok := h.records[sessionID]; ok {
+		record.SnapshotCount++
+		record.LastFrame = frame
+	}
+}
+
+// List returns matching records, most recently started first.
+func (h *GameSessionHistory) List(query GameSessionHistoryQuery, now time.Time) []GameSessionRecord {
+	if h == nil {
+		return nil
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	outcome := strings.TrimSpace(query.Outcome)
+	scenePath := strings.TrimSpace(query.ScenePath)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]GameSessionRecord, 0)
+	for i := len(h.order) - 1; i >= 0; i-- {
+		record := h.records[h.order[i]]
+		if outcome != "" && record.Outcome != outcome {
+			continue
+		}
+		if scenePath != "" && record.ScenePath != scenePath {
+			continue
+		}
+		out = append(out, snapshotGameSessionRecord(record, now))
+		if query.Limit > 0 && len(out) >= query.Limit {
+			break
+		}
+	}
+	return out
+}
+
+func (h *GameSessionHistory) Get(sessionID string, now time.Time) (GameSessionRecord, bool) {
+	if h == nil || strings.TrimSpace(sessionID) == "" {
+		return GameSessionRecord{}, false
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	record, ok := h.records[strings.TrimSpace(sessionID)]
+	if !ok {
+		return GameSessionRecord{}, false
+	}
+	return snapshotGameSessionRecord(record, now), true
+}
+
+func (h *GameSessionHistory) Health() map[string]any {
+	if h == nil {
+		return map[string]any{
+			"records": 0,
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	outcomes := map[string]int{}
+	for _, record := range h.records {
+		outcomes[record.Outcome]++
+	}
+	return map[string]any{
+		"records":  len(h.records),
+		"limit":    h.limit,
+		"outcomes": outcomes,
+		"evicted":  h.evicted,
+	}
+}
+
+// evictLocked drops the oldest finished records first, then the oldest open
+// ones, until the history fits its limit.
+func (h *GameSessionHistory) evictLocked() {
+	for len(h.order) > h.limit {
+		victim := h.order[0]
+		for _, sessionID := range h.order {
+			if !h.records[sessionID].endedAt.IsZero() {
+				victim = sessionID
+				break
+			}
+		}
+		h.removeLocked(victim)
+		h.evicted++
+	}
+}
+
+func (h *GameSessionHistory) removeLocked(sessionID string) {
+	delete(h.records, sessionID)
+	for i, id := range h.order {
+		if id == sessionID {
+			h.order = append(h.order[:i], h.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func snapshotGameSessionRecord(record *GameSessionRecord, now time.Time) GameSessionRecord {
+	out := *record
+	end := record.endedAt
+	if end.IsZero() {
+		end = now
+	}
+	if duration := end.Sub(record.startedAt); duration > 0 {
+		out.DurationMS = duration.Milliseconds()
+	}
+	out.LastLogLines = append([]RuntimeLogEntry(nil), record.LastLogLines...)
+	out.Events = append([]GameSessionEvent(nil), record.Events...)
+	return out
+}
+
+func appendGameSessionEvent(record *GameSessionRecord, event string, reason string, at time.Time) {
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	record.Events = append(record.Events, GameSessionEvent{
+		Time:   at.UTC().Format(time.RFC3339Nano),
+		Event:  event,
+		Reason: reason,
+	})
+	if len(record.Events) > maxGameSessionHistoryEvents {
+		// Keep the start event so the run's origin stays visible.
+		record.Events = append(record.Events[:1], record.Events[len(record.Events)-maxGameSessionHistoryEvents+1:]...)
+	}
+}
@@ -0,0 +1,87 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGameSessionHistory_RecordsRunOutcomeAndCounters(t *testing.T) {
+	ResetDefaultGameSessionHistoryForTests(10)
+	ResetDefaultGameSessionRegistryForTests()
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultRuntimeSnapshotStoreForTests(10*time.Second, 0)
+
+	start := time.Now().UTC().Add(-time.Minute)
+	registry := DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game_1", "editor_1", "res://Main.tscn", "token_1", start)
+	registry.RegisterRuntimeTransport("game_1", "runtime_1", "editor_1", "res://Main.tscn", start, "token_1")
+	DefaultRuntimeSnapshotStore().Upsert("game_1", RuntimeSnapshot{Frame: 40}, start.Add(time.Second))
+	registry.MarkSnapshotReceived("game_1", start.Add(time.Second))
+	DefaultRuntimeSnapshotStore().Upsert("game_1", RuntimeSnapshot{Frame: 90}, start.Add(2*time.Second))
+	registry.MarkSnapshotReceived("game_1", start.Add(2*time.Second))
+	DefaultRuntimeLogStore().Append("game_1", []RuntimeLogAppendEntry{
+		{Level: "error", Message: "SCRIPT ERROR: boom"},
+		{Level: "warning", Message: "WARNING: careful"},
+		{Level: "info", Message: "hello"},
+	}, start.Add(3*time.Second))
+	registry.StopSession("game_1", start.Add(30*time.Second))
+	// Cleanup after the stop must not erase the record or its counters.
+	DefaultRuntimeLogStore().RemoveSession("game_1")
+	registry.RemoveSession("game_1")
+
+	record, ok := DefaultGameSessionHistory().Get("game_1", time.Now().UTC())
+	if !ok {
+		t.Fatal("expected history record after session removal")
+	}
+	if record.Outcome != GameSessionStateStopped || record.ExitReason != GameSessionExitProjectStop {
+		t.Fatalf("expected stopped by project_stop, got outcome=%q reason=%q", record.Outcome, record.ExitReason)
+	}
+	if record.DurationMS != 30000 {
+		t.Fatalf("expected 30s duration, got %dms", record.DurationMS)
+	}
+	if record.ErrorCount != 1 || record.WarningCount != 1 || record.LogCount != 3 {
+		t.Fatalf("unexpected log counters: %+v", record)
+	}
+	if record.SnapshotCount != 2 || record.LastFrame != 90 {
+		t.Fatalf("unexpected snapshot counters: %+v", record)
+	}
+	events := make([]string, 0, len(record.Events))
+	for _, event := range record.Events {
+		events = append(events, event.Event)
+	}
+	want := []string{"started", "runtime_registered", "first_snapshot", "stopped"}
+	if len(events) != len(want) {
+		t.Fatalf("expected events %v, got %v", want, events)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Fatalf("expected events %v, got %v", want, events)
+		}
+	}
+}
+
+func TestGameSessionHistory_ListsNewestFirstAndEvictsFinishedRuns(t *testing.T) {
+	ResetDefaultGameSessionHistoryForTests(2)
+	ResetDefaultGameSessionRegistryForTests()
+
+	now := time.Now().UTC()
+	registry := DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game_running", "editor_1", "res://A.tscn", "", now)
+	registry.UpsertFromRun("game_crashed", "editor_2", "res://B.tscn", "", now.Add(time.Second))
+	registry.MarkCrashed("game_crashed", GameSessionReasonSnapshotTimeout, []RuntimeLogEntry{{Sequence: 3, Message: "last"}}, now.Add(2*time.Second))
+	registry.UpsertFromRun("game_new", "editor_3", "res://C.tscn", "", now.Add(3*time.Second))
+
+	records := DefaultGameSessionHistory().List(GameSessionHistoryQuery{}, now.Add(4*time.Second))
+	if len(records) != 2 || records[0].SessionID != "game_new" || records[1].SessionID != "game_running" {
+		t.Fatalf("expected finished run evicted and newest first, got %+v", records)
+	}
+
+	ResetDefaultGameSessionHistoryForTests(10)
+	registry.UpsertFromRun("game_a", "editor_1", "res://A.tscn", "", now)
+	registry.UpsertFromRun("game_b", "editor_2", "res://A.tscn", "", now)
+	registry.MarkCrashed("game_b", GameSessionReasonTransportClosed, nil, now.Add(time.Second))
+	crashed := DefaultGameSessionHistory().List(GameSessionHistoryQuery{Outcome: GameSessionStateCrashed}, now)
+	if len(crashed) != 1 || crashed[0].SessionID != "game_b" || crashed[0].ExitReason != GameSessionReasonTransportClosed {
+		t.Fatalf("expected crashed filter to return game_b, got %+v", crashed)
+	}
+}
@@ -1,6 +1,7 @@
 package runtimebridge
 
 import (
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -14,17 +15,19 @@ func init() {
 }
 
 // GameSessionRegistry tracks editor session <-> game session <-> runtime transport mapping.
+// One editor session may own several game sessions, such as the instances of
+// a multi-instance run; byEditorSessionID keeps them in launch order.
 type GameSessionRegistry struct {
 	mu                sync.RWMutex
 	bySessionID       map[string]GameSession
-	byEditorSessionID map[string]string
+	byEditorSessionID map[string][]string
 	byRuntimeSession  map[string]string
 }
 
 func NewGameSessionRegistry() *GameSessionRegistry {
 	return &GameSessionRegistry{
 		bySessionID:       make(map[string]GameSession),
-		byEditorSessionID: make(map[string]string),
+		byEditorSessionID: make(map[string][]string),
 		byRuntimeSession:  make(map[string]string),
 	}
 }
@@ -60,12 +63,11 @@ func (r *GameSessionRegistry) UpsertFromRun(sessionID string, editorSessionID st
 	session.SessionID = sessionID
 	if editorSessionID != "" {
 		if oldEditorSessionID := strings.TrimSpace(session.EditorSessionID); oldEditorSessionID != "" &&
-			oldEditorSessionID != editorSessionID &&
-			r.byEditorSessionID[oldEditorSessionID] == sessionID {
-			delete(r.byEditorSessionID, oldEditorSessionID)
+			oldEditorSessionID != editorSessionID {
+			r.unlinkEditorLocked(oldEditorSessionID, sessionID)
 		}
 		session.EditorSessionID = editorSessionID
-		r.byEditorSessionID[editorSessionID] = sessionID
+		r.linkEditorLocked(editorSessionID, sessionID)
 	}
 	if strings.TrimSpace(scenePath) != "" {
 		session.ScenePath = strings.TrimSpace(scenePath)
@@ -76,7 +78,9 @@ func (r *GameSessionRegistry) UpsertFromRun(sessionID string, editorSessionID st
 	session.Running = true
 	session.StoppedAt = ""
 	session.StartedAt = startedAt.UTC().Format(time.RFC3339Nano)
+	setGameSessionState(&session, GameSessionStateRunning, "", nil, startedAt)
 	r.bySessionID[sessionID] = session
+	DefaultGameSessionHistory().begin(session, startedAt)
 }
 
 func (r *GameSessionRegistry) RegisterRuntimeTransport(sessionID string, runtimeSessionID string, editorSessionID string, scenePath string, startedAt time.Time, launchToken string) {
@@ -96,12 +100,11 @@ func (r *GameSessionRegistry) RegisterRuntimeTransport(sessionID string, runtime
 	session.SessionID = sessionID
 	if editorSessionID != "" {
 		if oldEditorSessionID := strings.TrimSpace(session.EditorSessionID); oldEditorSessionID != "" &&
-			oldEditorSessionID != editorSessionID &&
-			r.byEditorSessionID[oldEditorSessionID] == sessionID {
-			delete(r.byEditorSessionID, oldEditorSessionID)
+			oldEditorSessionID != editorSessionID {
+			r.unlinkEditorLocked(oldEditorSessionID, sessionID)
 		}
 		session.EditorSessionID = editorSessionID
-		r.byEditorSessionID[editorSessionID] = sessionID
+		r.linkEditorLocked(editorSessionID, sessionID)
 	}
 	if scenePath = strings.TrimSpace(scenePath); scenePath != "" {
 		session.ScenePath = scenePath
@@ -133,7 +136,10 @@ func (r *GameSessionRegistry) RegisterRuntimeTransport(sessionID string, runtime
 	if session.StartedAt == "" {
 		session.StartedAt = startedAt.UTC().Format(time.RFC3339Nano)
 	}
+	setGameSessionState(&session, GameSessionStateRunning, "", nil, time.Now().UTC())
 	r.bySessionID[sessionID] = session
+	DefaultGameSessionHistory().begin(session, startedAt)
+	DefaultGameSessionHistory().event(sessionID, "runtime_registered", "", time.Now().UTC())
 }
 
 func (r *GameSessionRegistry) MarkSnapshotReceived(sessionID string, at time.Time) {
@@ -150,13 +156,27 @@ func (r *GameSessionRegistry) MarkSnapshotReceived(sessionID string, at time.Tim
 	if !ok {
 		return
 	}
+	if !session.HasSnapshot {
+		DefaultGameSessionHistory().event(session.SessionID, "first_snapshot", "", at)
+	}
 	session.HasSnapshot = true
 	session.LastSnapshotAt = at.UTC().Format(time.RFC3339Nano)
 	session.Running = true
+	if session.State == GameSessionStateCrashed {
+		// Snapshots resumed, so the companion was only silent, not gone.
+		setGameSessionState(&session, GameSessionStateRunning, "", nil, at)
+		DefaultGameSessionHistory().reopen(session.SessionID, at)
+	}
 	r.bySessionID[session.SessionID] = session
 }
 
 func (r *GameSessionRegistry) StopSession(sessionID string, stoppedAt time.Time) {
+	r.StopSessionWithReason(sessionID, GameSessionExitProjectStop, stoppedAt)
+}
+
+// StopSessionWithReason stops a game session and records why it ended in the
+// session history.
+func (r *GameSessionRegistry) StopSessionWithReason(sessionID string, reason string, stoppedAt time.Time) {
 	if r == nil || strings.TrimSpace(sessionID) == "" {
 		return
 	}
@@ -167,16 +187,20 @@ func (r *GameSessionRegistry) StopSession(sessionID string, stoppedAt time.Time)
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.stopSessionLocked(sessionID, stoppedAt)
+	r.stopSessionLocked(sessionID, reason, stoppedAt)
 }
 
-func (r *GameSessionRegistry) stopSessionLocked(sessionID string, stoppedAt time.Time) bool {
+func (r *GameSessionRegistry) stopSessionLocked(sessionID string, reason string, stoppedAt time.Time) bool {
 	session, ok := r.bySessionID[sessionID]
 	if !ok {
 		return false
 	}
 	session.Running = false
 	session.StoppedAt = stoppedAt.UTC().Format(time.RFC3339Nano)
+	if session.State != GameSessionStateCrashed {
+		setGameSessionState(&session, GameSessionStateStopped, "", nil, stoppedAt)
+	}
+	DefaultGameSessionHistory().end(sessionID, GameSessionStateStopped, reason, nil, stoppedAt)
 	if strings.TrimSpace(session.RuntimeSessionID) != "" {
 		delete(r.byRuntimeSession, session.RuntimeSessionID)
 		session.RuntimeSessionID = ""
@@ -185,9 +209,11 @@ func (r *GameSessionRegistry) stopSessionLocked(sessionID string, stoppedAt time
 	return true
 }
 
-func (r *GameSessionRegistry) StopByEditorSession(editorSessionID string, stoppedAt time.Time) (string, bool) {
+// StopByEditorSession stops every running game session owned by one editor
+// session and returns their ids in launch order.
+func (r *GameSessionRegistry) StopByEditorSession(editorSessionID string, stoppedAt time.Time) ([]string, bool) {
 	if r == nil || strings.TrimSpace(editorSessionID) == "" {
-		return "", false
+		return nil, false
 	}
 	if stoppedAt.IsZero() {
 		stoppedAt = time.Now().UTC()
@@ -196,15 +222,20 @@ func (r *GameSessionRegistry) StopByEditorSession(editorSessionID string, stoppe
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	sessionID := strings.TrimSpace(r.byEditorSessionID[editorSessionID])
-	if strings.TrimSpace(sessionID) == "" {
-		return "", false
-	}
-	if !r.stopSessionLocked(sessionID, stoppedAt) {
-		delete(r.byEditorSessionID, editorSessionID)
-		return "", false
+	stopped := make([]string, 0)
+	for _, sessionID := range append([]string(nil), r.byEditorSessionID[editorSessionID]...) {
+		session, ok := r.bySessionID[sessionID]
+		if !ok {
+			r.unlinkEditorLocked(editorSessionID, sessionID)
+			continue
+		}
+		if !session.Running {
+			continue
+		}
+		r.stopSessionLocked(sessionID, GameSessionExitEditorStop, stoppedAt)
+		stopped = append(stopped, sessionID)
 	}
-	return sessionID, true
+	return stopped, len(stopped) > 0
 }
 
 func (r *GameSessionRegistry) Session(sessionID string) (GameSession, bool) {
@@ -217,22 +248,68 @@ func (r *GameSessionRegistry) Session(sessionID string) (GameSession, bool) {
 	return session, ok
 }
 
+// ActiveForEditor returns the most recently started running game session of
+// one editor session. Instances of one run share a start time, so the lowest
+// instance wins the tie.
 func (r *GameSessionRegistry) ActiveForEditor(editorSessionID string) (GameSession, bool) {
-	if r == nil || strings.TrimSpace(editorSessionID) == "" {
+	sessions := r.RunningForEditor(editorSessionID)
+	if len(sessions) == 0 {
 		return GameSession{}, false
 	}
+	best := sessions[0]
+	for _, session := range sessions[1:] {
+		if session.StartedAt > best.StartedAt {
+			best = session
+		}
+	}
+	return best, true
+}
+
+// RunningForEditor returns every running game session of one editor session,
+// ordered by start time and instance index.
+func (r *GameSessionRegistry) RunningForEditor(editorSessionID string) []GameSession {
+	if r == nil || strings.TrimSpace(editorSessionID) == "" {
+		return nil
+	}
 	editorSessionID = strings.TrimSpace(editorSessionID)
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	sessionID := strings.TrimSpace(r.byEditorSessionID[editorSessionID])
-	if sessionID == "" {
-		return GameSession{}, false
+	sessions := make([]GameSession, 0, len(r.byEditorSessionID[editorSessionID]))
+	for _, sessionID := range r.byEditorSessionID[editorSessionID] {
+		if session, ok := r.bySessionID[sessionID]; ok && session.Running {
+			sessions = append(sessions, session)
+		}
 	}
-	session, ok := r.bySessionID[sessionID]
-	if !ok || !session.Running {
-		return GameSession{}, false
+	sortGameSessions(sessions)
+	return sessions
+}
+
+// SessionIDsForEditor returns every game session id, running or not, that one
+// editor session owns, in launch order.
+func (r *GameSessionRegistry) SessionIDsForEditor(editorSessionID string) []string {
+	if r == nil || strings.TrimSpace(editorSessionID) == "" {
+		return nil
 	}
-	return session, true
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string(nil), r.byEditorSessionID[strings.TrimSpace(editorSessionID)]...)
+}
+
+// SetInstanceIndex records the position of a game session within a
+// multi-instance run.
+func (r *GameSessionRegistry) SetInstanceIndex(sessionID string, instanceIndex int) {
+	if r == nil || strings.TrimSpace(sessionID) == "" || instanceIndex <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.bySessionID[strings.TrimSpace(sessionID)]
+	if !ok {
+		return
+	}
+	session.InstanceIndex = instanceIndex
+	r.bySessionID[session.SessionID] = session
+	DefaultGameSessionHistory().setInstanceIndex(session.SessionID, instanceIndex)
 }
 
 func (r *GameSessionRegistry) RuntimeSessionID(gameSessionID string) (string, bool) {
@@ -308,8 +385,9 @@ func (r *GameSessionRegistry) removeSessionLocked(sessionID string) bool {
 		return false
 	}
 	delete(r.bySessionID, sessionID)
-	if strings.TrimSpace(session.EditorSessionID) != "" && r.byEditorSessionID[session.EditorSessionID] == sessionID {
-		delete(r.byEditorSessionID, session.EditorSessionID)
+	DefaultGameSessionHistory().end(sessionID, GameSessionStateStopped, GameSessionExitSessionRemoved, nil, time.Now().UTC())
+	if strings.TrimSpace(session.EditorSessionID) != "" {
+		r.unlinkEditorLocked(session.EditorSessionID, sessionID)
 	}
 	if strings.TrimSpace(session.RuntimeSessionID) != "" && r.byRuntimeSession[session.RuntimeSessionID] == sessionID {
 		delete(r.byRuntimeSession, session.RuntimeSessionID)
@@ -317,6 +395,7 @@ func (r *GameSessionRegistry) removeSessionLocked(sessionID string) bool {
 	return true
 }
 
+// RemoveByEditorSession removes every game session one editor session owns.
 func (r *GameSessionRegistry) RemoveByEditorSession(editorSessionID string) {
 	if r == nil || strings.TrimSpace(editorSessionID) == "" {
 		return
@@ -324,13 +403,44 @@ func (r *GameSessionRegistry) RemoveByEditorSession(editorSessionID string) {
 	editorSessionID = strings.TrimSpace(editorSessionID)
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	sessionID := strings.TrimSpace(r.byEditorSessionID[editorSessionID])
-	if sessionID == "" {
-		return
+	for _, sessionID := range append([]string(nil), r.byEditorSessionID[editorSessionID]...) {
+		r.removeSessionLocked(sessionID)
+	}
+	delete(r.byEditorSessionID, editorSessionID)
+}
+
+func (r *GameSessionRegistry) linkEditorLocked(editorSessionID string, sessionID string) {
+	for _, id := range r.byEditorSessionID[editorSessionID] {
+		if id == sessionID {
+			return
+		}
+	}
+	r.byEditorSessionID[editorSessionID] = append(r.byEditorSessionID[editorSessionID], sessionID)
+}
+
+func (r *GameSessionRegistry) unlinkEditorLocked(editorSessionID string, sessionID string) {
+	ids := r.byEditorSessionID[editorSessionID]
+	for i, id := range ids {
+		if id != sessionID {
+			continue
+		}
+		ids = append(ids[:i:i], ids[i+1:]...)
+		break
 	}
-	if !r.removeSessionLocked(sessionID) {
+	if len(ids) == 0 {
 		delete(r.byEditorSessionID, editorSessionID)
+		return
 	}
+	r.byEditorSessionID[editorSessionID] = ids
+}
+
+func sortGameSessions(sessions []GameSession) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		if sessions[i].StartedAt != sessions[j].StartedAt {
+			return sessions[i].StartedAt < sessions[j].StartedAt
+		}
+		return sessions[i].InstanceIndex < sessions[j].InstanceIndex
+	})
 }
 
 // StopStaleForEditor stops all running game sessions for one editor session
@@ -364,7 +474,7 @@ func (r *GameSessionRegistry) StopStaleForEditor(editorSessionID string, exclude
 		if strings.TrimSpace(session.RuntimeSessionID) != "" {
 			continue
 		}
-		r.stopSessionLocked(id, stoppedAt)
+		r.stopSessionLocked(id, GameSessionExitReplaced, stoppedAt)
 		stopped++
 	}
 	return stopped
@@ -383,6 +493,63 @@ func (r *GameSessionRegistry) LatestRunning() (GameSession, bool) {
 		if !session.Running {
 			continue
 		}
+		if !found || session.StartedAt > best.StartedAt ||
+			(session.StartedAt == best.StartedAt && session.InstanceIndex < best.InstanceIndex) {
+			best = session
+			found = true
+		}
+	}
+	return best, found
+}
+
+// RunningSessions returns a copy of every running game session, ordered by
+// start time and instance index.
+func (r *GameSessionRegistry) RunningSessions() []GameSession {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sessions := make([]GameSession, 0, len(r.bySessionID))
+	for _, session := range r.bySessionID {
+		if session.Running {
+			sessions = append(sessions, session)
+		}
+	}
+	sortGameSessions(sessions)
+	return sessions
+}
+
+// LastForEditor returns the game session most recently launched for one
+// editor session, whether or not it is still running.
+func (r *GameSessionRegistry) LastForEditor(editorSessionID string) (GameSession, bool) {
+	if r == nil || strings.TrimSpace(editorSessionID) == "" {
+		return GameSession{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ids := r.byEditorSessionID[strings.TrimSpace(editorSessionID)]
+	if len(ids) == 0 {
+		return GameSession{}, false
+	}
+	session, ok := r.bySessionID[ids[len(ids)-1]]
+	return session, ok
+}
+
+// LatestCrashed returns the most recently started game session the watchdog
+// marked crashed.
+func (r *GameSessionRegistry) LatestCrashed() (GameSession, bool) {
+	if r == nil {
+		return GameSession{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var best GameSession
+	found := false
+	for _, session := range r.bySessionID {
+		if session.State != GameSessionStateCrashed {
+			continue
+		}
 		if !found || session.StartedAt > best.StartedAt {
 			best = session
 			found = true
@@ -391,6 +558,80 @@ func (r *GameSessionRegistry) LatestRunning() (GameSession, bool) {
 	return best, found
 }
 
+// MarkCrashed stops a running game session that vanished without
+// godot.project.stop. The runtime transport mapping is kept so a companion
+// that resumes pushing snapshots revives the session.
+func (r *GameSessionRegistry) MarkCrashed(sessionID string, reason string, lastLogLines []RuntimeLogEntry, at time.Time) bool {
+	if r == nil || strings.TrimSpace(sessionID) == "" {
+		return false
+	}
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.bySessionID[strings.TrimSpace(sessionID)]
+	if !ok || !session.Running {
+		return false
+	}
+	session.Running = false
+	session.StoppedAt = at.UTC().Format(time.RFC3339Nano)
+	setGameSessionState(&session, GameSessionStateCrashed, reason, lastLogLines, at)
+	r.bySessionID[session.SessionID] = session
+	DefaultGameSessionHistory().end(session.SessionID, GameSessionStateCrashed, reason, lastLogLines, at)
+	return true
+}
+
+// MarkHung flags a running game session whose frame counter stopped
+// advancing. The session stays running so commands can still be attempted.
+func (r *GameSessionRegistry) MarkHung(sessionID string, reason string, lastLogLines []RuntimeLogEntry, at time.Time) bool {
+	if r == nil || strings.TrimSpace(sessionID) == "" {
+		return false
+	}
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.bySessionID[strings.TrimSpace(sessionID)]
+	if !ok || !session.Running || session.State == GameSessionStateHung {
+		return false
+	}
+	setGameSessionState(&session, GameSessionStateHung, reason, lastLogLines, at)
+	r.bySessionID[session.SessionID] = session
+	DefaultGameSessionHistory().setOutcome(session.SessionID, GameSessionStateHung, GameSessionStateHung, reason, at)
+	return true
+}
+
+// MarkResponsive clears the hung state once the frame counter advances again.
+func (r *GameSessionRegistry) MarkResponsive(sessionID string, at time.Time) bool {
+	if r == nil || strings.TrimSpace(sessionID) == "" {
+		return false
+	}
+	if at.IsZero() {
+		at = time.Now().UTC()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session, ok := r.bySessionID[strings.TrimSpace(sessionID)]
+	if !ok || session.State != GameSessionStateHung {
+		return false
+	}
+	setGameSessionState(&session, GameSessionStateRunning, "", nil, at)
+	r.bySessionID[session.SessionID] = session
+	DefaultGameSessionHistory().setOutcome(session.SessionID, GameSessionStateRunning, "responsive", "", at)
+	return true
+}
+
+func setGameSessionState(session *GameSession, state string, reason string, lastLogLines []RuntimeLogEntry, at time.Time) {
+	if session.State != state {
+		session.StateChangedAt = at.UTC().Format(time.RFC3339Nano)
+	}
+	session.State = state
+	session.StateReason = reason
+	session.LastLogLines = lastLogLines
+}
+
 func (r *GameSessionRegistry) Health() map[string]any {
 	if r == nil {
 		return map[string]any{
@@ -400,13 +641,23 @@ func (r *GameSessionRegistry) Health() map[string]any {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	running := 0
+	crashed := 0
+	hung := 0
 	for _, session := range r.bySessionID {
 		if session.Running {
 			running++
 		}
+		switch session.State {
+		case GameSessionStateCrashed:
+			crashed++
+		case GameSessionStateHung:
+			hung++
+		}
 	}
 	return map[string]any{
 		"sessions": len(r.bySessionID),
 		"running":  running,
+		"crashed":  crashed,
+		"hung":     hung,
 	}
 }
@@ -13,12 +13,12 @@ func TestGameSessionRegistryStopByEditorSession_StopsMappedSession(t *testing.T)
 	registry.UpsertFromRun("game_1", "editor_1", "res://Main.tscn", "launch_token", now)
 	registry.RegisterRuntimeTransport("game_1", "runtime_1", "editor_1", "res://Main.tscn", now, "launch_token")
 
-	sessionID, stopped := registry.StopByEditorSession("editor_1", now.Add(time.Second))
+	sessionIDs, stopped := registry.StopByEditorSession("editor_1", now.Add(time.Second))
 	if !stopped {
 		t.Fatal("expected stop by editor session to succeed")
 	}
-	if sessionID != "game_1" {
-		t.Fatalf("expected session_id game_1, got %q", sessionID)
+	if len(sessionIDs) != 1 || sessionIDs[0] != "game_1" {
+		t.Fatalf("expected session_id game_1, got %v", sessionIDs)
 	}
 
 	session, ok := registry.Session("game_1")
@@ -41,10 +41,10 @@ func TestGameSessionRegistryStopByEditorSession_StopsMappedSession(t *testing.T)
 
 func TestGameSessionRegistryStopByEditorSession_RejectsStaleMappingWithoutSession(t *testing.T) {
 	registry := NewGameSessionRegistry()
-	registry.byEditorSessionID["editor_orphan"] = "game_missing"
+	registry.byEditorSessionID["editor_orphan"] = []string{"game_missing"}
 
-	if sessionID, stopped := registry.StopByEditorSession("editor_orphan", time.Now().UTC()); stopped {
-		t.Fatalf("expected stop to fail for stale mapping, got session=%q", sessionID)
+	if sessionIDs, stopped := registry.StopByEditorSession("editor_orphan", time.Now().UTC()); stopped {
+		t.Fatalf("expected stop to fail for stale mapping, got sessions=%v", sessionIDs)
 	}
 	if _, ok := registry.byEditorSessionID["editor_orphan"]; ok {
 		t.Fatal("expected stale editor mapping to self-heal after failed stop")
@@ -73,7 +73,7 @@ func TestGameSessionRegistryRemoveByEditorSession_RemovesSessionAndIndexes(t *te
 
 func TestGameSessionRegistryRemoveByEditorSession_HealsStaleMappingWithoutSession(t *testing.T) {
 	registry := NewGameSessionRegistry()
-	registry.byEditorSessionID["editor_orphan"] = "game_missing"
+	registry.byEditorSessionID["editor_orphan"] = []string{"game_missing"}
 
 	registry.RemoveByEditorSession("editor_orphan")
 
@@ -92,8 +92,8 @@ func TestGameSessionRegistryUpsertFromRun_RebindEditorSessionRemovesOldMapping(t
 	if _, ok := registry.byEditorSessionID["editor_old"]; ok {
 		t.Fatal("expected old editor mapping to be removed during rebind")
 	}
-	if mapped := registry.byEditorSessionID["editor_new"]; mapped != "game_1" {
-		t.Fatalf("expected editor_new to map game_1, got %q", mapped)
+	if mapped := registry.byEditorSessionID["editor_new"]; len(mapped) != 1 || mapped[0] != "game_1" {
+		t.Fatalf("expected editor_new to map game_1, got %v", mapped)
 	}
 }
 
@@ -107,8 +107,8 @@ func TestGameSessionRegistryRegisterRuntimeTransport_RebindEditorSessionRemovesO
 	if _, ok := registry.byEditorSessionID["editor_old"]; ok {
 		t.Fatal("expected old editor mapping to be removed during runtime transport rebind")
 	}
-	if mapped := registry.byEditorSessionID["editor_new"]; mapped != "game_1" {
-		t.Fatalf("expected editor_new to map game_1, got %q", mapped)
+	if mapped := registry.byEditorSessionID["editor_new"]; len(mapped) != 1 || mapped[0] != "game_1" {
+		t.Fatalf("expected editor_new to map game_1, got %v", mapped)
 	}
 }
 
@@ -139,6 +139,52 @@ func TestGameSessionRegistryRegisterRuntimeTransport_RebindRuntimeSessionEvictsP
 	}
 }
 
+func TestGameSessionRegistry_TracksSeveralRunningSessionsPerEditor(t *testing.T) {
+	registry := NewGameSessionRegistry()
+	now := time.Now().UTC()
+
+	registry.UpsertFromRun("game_1", "editor_1", "res://Main.tscn", "launch_1", now)
+	registry.SetInstanceIndex("game_1", 1)
+	registry.UpsertFromRun("game_2", "editor_1", "res://Main.tscn", "launch_2", now)
+	registry.SetInstanceIndex("game_2", 2)
+	registry.UpsertFromRun("game_3", "editor_1", "res://Main.tscn", "launch_3", now)
+	registry.SetInstanceIndex("game_3", 3)
+	registry.RegisterRuntimeTransport("game_2", "runtime_2", "editor_1", "res://Main.tscn", now, "launch_2")
+
+	running := registry.RunningForEditor("editor_1")
+	if len(running) != 3 || running[0].SessionID != "game_1" || running[2].SessionID != "game_3" {
+		t.Fatalf("expected three running instances in order, got %+v", running)
+	}
+	if active, ok := registry.ActiveForEditor("editor_1"); !ok || active.SessionID != "game_1" {
+		t.Fatalf("expected first instance as active session, got %+v ok=%v", active, ok)
+	}
+
+	registry.StopSession("game_2", now.Add(time.Second))
+	running = registry.RunningForEditor("editor_1")
+	if len(running) != 2 || running[0].SessionID != "game_1" || running[1].SessionID != "game_3" {
+		t.Fatalf("expected stopping one instance to keep its siblings, got %+v", running)
+	}
+	if session, _ := registry.Session("game_3"); session.InstanceIndex != 3 {
+		t.Fatalf("expected instance index 3, got %d", session.InstanceIndex)
+	}
+
+	stopped, ok := registry.StopByEditorSession("editor_1", now.Add(2*time.Second))
+	if !ok || len(stopped) != 2 || stopped[0] != "game_1" || stopped[1] != "game_3" {
+		t.Fatalf("expected editor stop to stop remaining instances, got %v ok=%v", stopped, ok)
+	}
+	if _, ok := registry.ActiveForEditor("editor_1"); ok {
+		t.Fatal("expected no active session after editor stop")
+	}
+
+	registry.RemoveByEditorSession("editor_1")
+	if ids := registry.SessionIDsForEditor("editor_1"); len(ids) != 0 {
+		t.Fatalf("expected all instances removed, got %v", ids)
+	}
+	if _, ok := registry.Session("game_2"); ok {
+		t.Fatal("expected stopped instance removed with its editor")
+	}
+}
+
 func TestDefaultGameSessionRegistryReset_ReplacesInstance(t *testing.T) {
 	ResetDefaultGameSessionRegistryForTests()
 	first := DefaultGameSessionRegistry()
@@ -0,0 +1,314 @@
+package runtimebridge
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	GameSessionStateRunning = "running"
+	GameSessionStateStopped = "stopped"
+	GameSessionStateCrashed = "crashed"
+	GameSessionStateHung    = "hung"
+
+	GameSessionReasonSnapshotTimeout = "snapshot_timeout"
+	GameSessionReasonTransportClosed = "transport_closed"
+	GameSessionReasonFrameStalled    = "frame_stalled"
+
+	// DefaultGameSessionWatchdogInterval is how often the server runs Check.
+	DefaultGameSessionWatchdogInterval = time.Second
+
+	defaultGameSessionSilenceAfter = 10*time.Second + 1500*time.Millisecond
+	defaultGameSessionHangAfter    = 15 * time.Second
+	gameSessionWatchdogLogLines    = 20
+)
+
+// RuntimeTransportProbe reports whether an MCP session still has an open
+// notification stream.
+type RuntimeTransportProbe func(sessionID string) bool
+
+var (
+	runtimeTransportProbeMu sync.RWMutex
+	runtimeTransportProbe   RuntimeTransportProbe
+)
+
+func SetRuntimeTransportProbe(probe RuntimeTransportProbe) {
+	runtimeTransportProbeMu.Lock()
+	defer runtimeTransportProbeMu.Unlock()
+	runtimeTransportProbe = probe
+}
+
+// runtimeTransportOpen returns known=false when no transport can be probed,
+// such as in stdio mode.
+func runtimeTransportOpen(sessionID string) (open bool, known bool) {
+	runtimeTransportProbeMu.RLock()
+	probe := runtimeTransportProbe
+	runtimeTransportProbeMu.RUnlock()
+	if probe == nil {
+		return false, false
+	}
+	return probe(sessionID), true
+}
+
+// GameSessionIncident is one crashed or hung transition raised by Check.
+type GameSessionIncident struct {
+	SessionID string `json:"session_id"`
+	State     string `json:"state"`
+	Reason    string `json:"reason"`
+	At        string `json:"at"`
+}
+
+type gameSessionProgress struct {
+	frame      int64
+	advancedAt time.Time
+	seenAt     time.Time
+}
+
+var defaultGameSessionWatchdog atomic.Pointer[GameSessionWatchdog]
+
+func init() {
+	defaultGameSessionWatchdog.Store(NewGameSessionWatchdog(defaultGameSessionSilenceAfter, defaultGameSessionHangAfter))
+}
+
+// GameSessionWatchdog notices registered game sessions that went away without
+// godot.project.stop. A session whose runtime snapshots stop, or whose runtime
+// transport stays closed, for longer than silenceAfter is marked crashed. A
+// session whose snapshots keep arriving with the same frame for hangAfter,
+// while not paused, is marked hung.
+type GameSessionWatchdog struct {
+	mu              sync.Mutex
+	silenceAfter    time.Duration
+	hangAfter       time.Duration
+	progress        map[string]gameSessionProgress
+	transportLostAt map[string]time.Time
+	checks          int64
+	incidents       map[string]int
+}
+
+func NewGameSessionWatchdog(silenceAfter time.Duration, hangAfter time.Duration) *GameSessionWatchdog {
+	if silenceAfter <= 0 {
+		silenceAfter = defaultGameSessionSilenceAfter
+	}
+	if hangAfter <= 0 {
+		hangAfter = defaultGameSessionHangAfter
+	}
+	return &GameSessionWatchdog{
+		silenceAfter:    silenceAfter,
+		hangAfter:       hangAfter,
+		progress:        make(map[string]gameSessionProgress),
+		transportLostAt: make(map[string]time.Time),
+		incidents:       make(map[string]int),
+	}
+}
+
+func DefaultGameSessionWatchdog() *GameSessionWatchdog {
+	if watchdog := defaultGameSessionWatchdog.Load(); watchdog != nil {
+		return watchdog
+	}
+	watchdog := NewGameSessionWatchdog(defaultGameSessionSilenceAfter, defaultGameSessionHangAfter)
+	if defaultGameSessionWatchdog.CompareAndSwap(nil, watchdog) {
+		return watchdog
+	}
+	return defaultGameSessionWatchdog.Load()
+}
+
+func ResetDefaultGameSessionWatchdogForTests(silenceAfter time.Duration, hangAfter time.Duration) {
+	defaultGameSessionWatchdog.Store(NewGameSessionWatchdog(silenceAfter, hangAfter))
+}
+
+// Configure sets the silence window (stale_after plus stale_grace) and the
+// frame stall window.
+func (w *GameSessionWatchdog) Configure(silenceAfter time.Duration, hangAfter time.Duration) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if silenceAfter > 0 {
+		w.silenceAfter = silenceAfter
+	}
+	if hangAfter > 0 {
+		w.hangAfter = hangAfter
+	}
+}
+
+// ObserveSnapshot records frame progress for one accepted runtime snapshot and
+// clears a hung state once the frame advances again.
+func (w *GameSessionWatchdog) ObserveSnapshot(sessionID string, snapshot RuntimeSnapshot, now time.Time) {
+	if w == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+
+	w.mu.Lock()
+	previous, ok := w.progress[sessionID]
+	advanced := !ok || snapshot.Frame != previous.frame || snapshot.Paused
+	if advanced {
+		previous = gameSessionProgress{frame: snapshot.Frame, advancedAt: now}
+	}
+	previous.seenAt = now
+	w.progress[sessionID] = previous
+	w.mu.Unlock()
+
+	if advanced {
+		DefaultGameSessionRegistry().MarkResponsive(sessionID, now)
+	}
+}
+
+// Check evaluates every running game session with a registered runtime
+// transport and marks the ones that crashed or hung. Sessions still waiting
+// for runtime.register are left to the bootstrap diagnostics.
+func (w *GameSessionWatchdog) Check(now time.Time) []GameSessionIncident {
+	if w == nil {
+		return nil
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessions := DefaultGameSessionRegistry().RunningSessions()
+	running := make(map[string]bool, len(sessions))
+	pending := make([]GameSessionIncident, 0)
+	// Probe transports before taking the watchdog lock; session cleanup calls
+	// RemoveSession while holding the transport's session lock.
+	transportClosed := make(map[string]bool, len(sessions))
+	for _, session := range sessions {
+		if strings.TrimSpace(session.RuntimeSessionID) == "" {
+			continue
+		}
+		if open, known := runtimeTransportOpen(session.RuntimeSessionID); known && !open {
+			transportClosed[session.SessionID] = true
+		}
+	}
+
+	w.mu.Lock()
+	w.checks++
+	for _, session := range sessions {
+		running[session.SessionID] = true
+		if strings.TrimSpace(session.RuntimeSessionID) == "" {
+			continue
+		}
+		state, reason := w.evaluateLocked(session, transportClosed[session.SessionID], now)
+		if state != "" {
+			pending = append(pending, GameSessionIncident{
+				SessionID: session.SessionID,
+				State:     state,
+				Reason:    reason,
+				At:        now.UTC().Format(time.RFC3339Nano),
+			})
+		}
+	}
+	for sessionID := range w.progress {
+		if !running[sessionID] {
+			delete(w.progress, sessionID)
+		}
+	}
+	for sessionID := range w.transportLostAt {
+		if !running[sessionID] {
+			delete(w.transportLostAt, sessionID)
+		}
+	}
+	w.mu.Unlock()
+
+	incidents := make([]GameSessionIncident, 0, len(pending))
+	for _, incident := range pending {
+		lastLogLines := lastRuntimeLogLines(incident.SessionID, gameSessionWatchdogLogLines)
+		var marked bool
+		level := "error"
+		if incident.State == GameSessionStateCrashed {
+			marked = DefaultGameSessionRegistry().MarkCrashed(incident.SessionID, incident.Reason, lastLogLines, now)
+		} else {
+			marked = DefaultGameSessionRegistry().MarkHung(incident.SessionID, incident.Reason, lastLogLines, now)
+			level = "warning"
+		}
+		if !marked {
+			continue
+		}
+		DefaultRuntimeLogStore().Append(incident.SessionID, []RuntimeLogAppendEntry{{
+			Level:   level,
+			Source:  "runtime_lifecycle",
+			Message: "game session " + incident.State + ": " + incident.Reason,
+		}}, now)
+		w.mu.Lock()
+		w.incidents[incident.State+":"+incident.Reason]++
+		w.mu.Unlock()
+		incidents = append(incidents, incident)
+	}
+	return incidents
+}
+
+func (w *GameSessionWatchdog) evaluateLocked(session GameSession, transportClosed bool, now time.Time) (string, string) {
+	if transportClosed {
+		lostAt, ok := w.transportLostAt[session.SessionID]
+		if !ok {
+			lostAt = now
+			w.transportLostAt[session.SessionID] = now
+		}
+		// The companion reconnects its stream on its own, so only a closure
+		// outlasting the silence window counts as a crash.
+		if now.Sub(lostAt) > w.silenceAfter {
+			return GameSessionStateCrashed, GameSessionReasonTransportClosed
+		}
+	} else {
+		delete(w.transportLostAt, session.SessionID)
+	}
+	if !session.HasSnapshot {
+		return "", ""
+	}
+	if lastSnapshotAt, err := time.Parse(time.RFC3339Nano, session.LastSnapshotAt); err == nil && now.Sub(lastSnapshotAt) > w.silenceAfter {
+		return GameSessionStateCrashed, GameSessionReasonSnapshotTimeout
+	}
+	if session.State == GameSessionStateHung {
+		return "", ""
+	}
+	// Only snapshots that keep repeating the same frame count as a hang; a
+	// companion that went quiet is left to the snapshot timeout.
+	if progress, ok := w.progress[session.SessionID]; ok && progress.seenAt.Sub(progress.advancedAt) >= w.hangAfter {
+		return GameSessionStateHung, GameSessionReasonFrameStalled
+	}
+	return "", ""
+}
+
+func (w *GameSessionWatchdog) RemoveSession(sessionID string) {
+	if w == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.progress, sessionID)
+	delete(w.transportLostAt, sessionID)
+}
+
+func (w *GameSessionWatchdog) Health() map[string]any {
+	if w == nil {
+		return map[string]any{
+			"tracked": 0,
+		}
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	incidents := make(map[string]int, len(w.incidents))
+	for key, count := range w.incidents {
+		incidents[key] = count
+	}
+	return map[string]any{
+		"tracked":          len(w.progress),
+		"checks":           w.checks,
+		"silence_after_ms": w.silenceAfter.Milliseconds(),
+		"hang_after_ms":    w.hangAfter.Milliseconds(),
+		"incidents":        incidents,
+	}
+}
+
+func lastRuntimeLogLines(sessionID string, limit int) []RuntimeLogEntry {
+	entries := DefaultRuntimeLogStore().Last(sessionID, limit)
+	if len(entries) == 0 {
+		return nil
+	}
+	return entries
+}
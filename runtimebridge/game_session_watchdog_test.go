@@ -0,0 +1,125 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func resetGameSessionWatchdogForTest(t *testing.T) time.Time {
+	t.Helper()
+	ResetDefaultGameSessionRegistryForTests()
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultGameSessionWatchdogForTests(10*time.Second, 5*time.Second)
+	SetRuntimeTransportProbe(nil)
+	t.Cleanup(func() { SetRuntimeTransportProbe(nil) })
+
+	now := time.Now().UTC()
+	registry := DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game_1", "editor_1", "res://Main.tscn", "token_1", now)
+	registry.RegisterRuntimeTransport("game_1", "runtime_1", "editor_1", "res://Main.tscn", now, "token_1")
+	registry.MarkSnapshotReceived("game_1", now)
+	DefaultGameSessionWatchdog().ObserveSnapshot("game_1", RuntimeSnapshot{Frame: 10}, now)
+	return now
+}
+
+func TestGameSessionWatchdog_MarksSilentSessionCrashedWithLastLogLines(t *testing.T) {
+	now := resetGameSessionWatchdogForTest(t)
+	for i := 0; i < 25; i++ {
+		DefaultRuntimeLogStore().Append("game_1", []RuntimeLogAppendEntry{{Level: "info", Message: "tick"}}, now)
+	}
+	DefaultRuntimeLogStore().Append("game_1", []RuntimeLogAppendEntry{{Level: "error", Message: "SCRIPT ERROR: boom"}}, now)
+
+	if incidents := DefaultGameSessionWatchdog().Check(now.Add(9 * time.Second)); len(incidents) != 0 {
+		t.Fatalf("expected no incident inside silence window, got %+v", incidents)
+	}
+	incidents := DefaultGameSessionWatchdog().Check(now.Add(11 * time.Second))
+	if len(incidents) != 1 || incidents[0].State != GameSessionStateCrashed || incidents[0].Reason != GameSessionReasonSnapshotTimeout {
+		t.Fatalf("expected snapshot_timeout crash, got %+v", incidents)
+	}
+
+	session, _ := DefaultGameSessionRegistry().Session("game_1")
+	if session.Running || session.State != GameSessionStateCrashed || session.StoppedAt == "" {
+		t.Fatalf("expected crashed stopped session, got %+v", session)
+	}
+	if len(session.LastLogLines) != gameSessionWatchdogLogLines {
+		t.Fatalf("expected %d last log lines, got %d", gameSessionWatchdogLogLines, len(session.LastLogLines))
+	}
+	if last := session.LastLogLines[len(session.LastLogLines)-1]; last.Message != "SCRIPT ERROR: boom" {
+		t.Fatalf("expected newest log line last, got %+v", last)
+	}
+	logs := DefaultRuntimeLogStore().Last("game_1", 1)
+	if len(logs) != 1 || logs[0].Source != "runtime_lifecycle" || logs[0].Level != "error" {
+		t.Fatalf("expected runtime_lifecycle log entry, got %+v", logs)
+	}
+	if incidents := DefaultGameSessionWatchdog().Check(now.Add(12 * time.Second)); len(incidents) != 0 {
+		t.Fatalf("expected crash to be reported once, got %+v", incidents)
+	}
+
+	// A companion that resumes pushing snapshots revives the session.
+	DefaultGameSessionRegistry().MarkSnapshotReceived("game_1", now.Add(13*time.Second))
+	session, _ = DefaultGameSessionRegistry().Session("game_1")
+	if !session.Running || session.State != GameSessionStateRunning || len(session.LastLogLines) != 0 {
+		t.Fatalf("expected revived running session, got %+v", session)
+	}
+}
+
+func TestGameSessionWatchdog_MarksClosedTransportCrashed(t *testing.T) {
+	now := resetGameSessionWatchdogForTest(t)
+	open := true
+	SetRuntimeTransportProbe(func(sessionID string) bool {
+		return sessionID == "runtime_1" && open
+	})
+
+	DefaultGameSessionWatchdog().Check(now.Add(time.Second))
+	open = false
+	DefaultGameSessionRegistry().MarkSnapshotReceived("game_1", now.Add(2*time.Second))
+	if incidents := DefaultGameSessionWatchdog().Check(now.Add(2 * time.Second)); len(incidents) != 0 {
+		t.Fatalf("expected a reconnect window before crash, got %+v", incidents)
+	}
+	DefaultGameSessionRegistry().MarkSnapshotReceived("game_1", now.Add(12*time.Second))
+	DefaultGameSessionWatchdog().ObserveSnapshot("game_1", RuntimeSnapshot{Frame: 20}, now.Add(12*time.Second))
+	incidents := DefaultGameSessionWatchdog().Check(now.Add(13 * time.Second))
+	if len(incidents) != 1 || incidents[0].Reason != GameSessionReasonTransportClosed {
+		t.Fatalf("expected transport_closed crash, got %+v", incidents)
+	}
+	if DefaultGameSessionRegistry().IsRunning("game_1") {
+		t.Fatal("expected crashed session to stop running")
+	}
+}
+
+func TestGameSessionWatchdog_MarksStalledFrameHungUntilItAdvances(t *testing.T) {
+	now := resetGameSessionWatchdogForTest(t)
+
+	for i := 1; i <= 6; i++ {
+		at := now.Add(time.Duration(i) * time.Second)
+		DefaultGameSessionRegistry().MarkSnapshotReceived("game_1", at)
+		DefaultGameSessionWatchdog().ObserveSnapshot("game_1", RuntimeSnapshot{Frame: 10}, at)
+	}
+	incidents := DefaultGameSessionWatchdog().Check(now.Add(6 * time.Second))
+	if len(incidents) != 1 || incidents[0].State != GameSessionStateHung || incidents[0].Reason != GameSessionReasonFrameStalled {
+		t.Fatalf("expected frame_stalled hang, got %+v", incidents)
+	}
+	session, _ := DefaultGameSessionRegistry().Session("game_1")
+	if !session.Running || session.State != GameSessionStateHung {
+		t.Fatalf("expected hung session to keep running, got %+v", session)
+	}
+
+	DefaultGameSessionWatchdog().ObserveSnapshot("game_1", RuntimeSnapshot{Frame: 11}, now.Add(7*time.Second))
+	session, _ = DefaultGameSessionRegistry().Session("game_1")
+	if session.State != GameSessionStateRunning {
+		t.Fatalf("expected advancing frame to clear hang, got %+v", session)
+	}
+}
+
+func TestGameSessionWatchdog_PausedGameIsNotHung(t *testing.T) {
+	now := resetGameSessionWatchdogForTest(t)
+
+	for i := 1; i <= 6; i++ {
+		at := now.Add(time.Duration(i) * time.Second)
+		DefaultGameSessionRegistry().MarkSnapshotReceived("game_1", at)
+		DefaultGameSessionWatchdog().ObserveSnapshot("game_1", RuntimeSnapshot{Frame: 10, Paused: true}, at)
+	}
+	if incidents := DefaultGameSessionWatchdog().Check(now.Add(6 * time.Second)); len(incidents) != 0 {
+		t.Fatalf("expected paused game to stay healthy, got %+v", incidents)
+	}
+}
@@ -0,0 +1,265 @@
+package runtimebridge
+
+import (
+	"maps"
+	"math"
+	"path"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	RateLimitScopeSession = "session"
+	RateLimitScopeTool    = "tool"
+	// rateLimiterSweepEvery bounds how often idle, refilled buckets are dropped.
+	rateLimiterSweepEvery = 256
+)
+
+var defaultRateLimiter atomic.Pointer[RateLimiter]
+
+func init() {
+	defaultRateLimiter.Store(NewRateLimiter(RateLimitPolicy{}))
+}
+
+// RateLimit is a token bucket: RatePerSecond tokens refill continuously up to
+// Burst. A zero RatePerSecond disables the limit.
+type RateLimit struct {
+	RatePerSecond float64 `json:"rate_per_second"`
+	Burst         int     `json:"burst"`
+}
+
+func (l RateLimit) enabled() bool {
+	return l.RatePerSecond > 0 && l.Burst > 0
+}
+
+// RateLimitPolicy limits tool calls per MCP session. Session applies to every
+// call of a session; Tools keys are tool names or path.Match globs, the exact
+// name winning over globs and globs tried in sorted order, and each matching
+// tool gets its own bucket per session.
+type RateLimitPolicy struct {
+	Session RateLimit            `json:"session"`
+	Tools   map[string]RateLimit `json:"tools"`
+}
+
+// RateLimitDecision reports a refused call and when a token will be available.
+type RateLimitDecision struct {
+	Allowed    bool
+	Scope      string
+	Pattern    string
+	Limit      RateLimit
+	RetryAfter time.Duration
+}
+
+type tokenBucket struct {
+	limit   RateLimit
+	tokens  float64
+	updated time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(float64(b.limit.Burst), b.tokens+elapsed*b.limit.RatePerSecond)
+		b.updated = now
+	}
+}
+
+func (b *tokenBucket) retryAfter() time.Duration {
+	missing := 1 - b.tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(missing / b.limit.RatePerSecond * float64(time.Second)))
+}
+
+type rateLimitKey struct {
+	sessionID string
+	pattern   string
+}
+
+// RateLimiter holds per-session and per-session-tool token buckets.
+type RateLimiter struct {
+	mu             sync.Mutex
+	policy         RateLimitPolicy
+	toolPatterns   []string
+	buckets        map[rateLimitKey]*tokenBucket
+	calls          uint64
+	limitedTotal   uint64
+	limitedByScope map[string]uint64
+	limitedByTool  map[string]uint64
+}
+
+func NewRateLimiter(policy RateLimitPolicy) *RateLimiter {
+	limiter := &RateLimiter{}
+	limiter.Configure(policy)
+	return limiter
+}
+
+func DefaultRateLimiter() *RateLimiter {
+	return defaultRateLimiter.Load()
+}
+
+func ResetDefaultRateLimiterForTests(policy RateLimitPolicy) {
+	defaultRateLimiter.Store(NewRateLimiter(policy))
+}
+
+// Configure replaces the policy and drops existing buckets.
+func (r *RateLimiter) Configure(policy RateLimitPolicy) {
+	if r == nil {
+		return
+	}
+	tools := make(map[string]RateLimit, len(policy.Tools))
+	patterns := make([]string, 0, len(policy.Tools))
+	for pattern, limit := range policy.Tools {
+		if limit.enabled() {
+			tools[pattern] = limit
+			patterns = append(patterns, pattern)
+		}
+	}
+	sort.Strings(patterns)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy = RateLimitPolicy{Session: policy.Session, Tools: tools}
+	r.toolPatterns = patterns
+	r.buckets = make(map[rateLimitKey]*tokenBucket)
+	r.limitedTotal = 0
+	r.limitedByScope = make(map[string]uint64)
+	r.limitedByTool = make(map[string]uint64)
+}
+
+// Allow takes one token from the session bucket and from the tool's bucket.
+// Tokens are only taken when both buckets have one, so a call refused by the
+// tool limit does not drain the session budget.
+func (r *RateLimiter) Allow(sessionID string, toolName string, now time.Time) RateLimitDecision {
+	if r == nil {
+		return RateLimitDecision{Allowed: true}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if r.calls%rateLimiterSweepEvery == 0 {
+		r.sweepLocked(now)
+	}
+
+	var sessionBucket, toolBucket *tokenBucket
+	if r.policy.Session.enabled() {
+		sessionBucket = r.bucketLocked(rateLimitKey{sessionID: sessionID}, r.policy.Session, now)
+		if sessionBucket.tokens < 1 {
+			return r.limitedLocked(RateLimitScopeSession, "", toolName, sessionBucket)
+		}
+	}
+	if pattern, limit, ok := r.toolLimitLocked(toolName); ok {
+		toolBucket = r.bucketLocked(rateLimitKey{sessionID: sessionID, pattern: pattern}, limit, now)
+		if toolBucket.tokens < 1 {
+			return r.limitedLocked(RateLimitScopeTool, pattern, toolName, toolBucket)
+		}
+	}
+	if sessionBucket != nil {
+		sessionBucket.tokens--
+	}
+	if toolBucket != nil {
+		toolBucket.tokens--
+	}
+	return RateLimitDecision{Allowed: true}
+}
+
+func (r *RateLimiter) toolLimitLocked(toolName string) (string, RateLimit, bool) {
+	if limit, ok := r.policy.Tools[toolName]; ok {
+		return toolName, limit, true
+	}
+	for _, pattern := range r.toolPatterns {
+		if matched, err := path.Match(pattern, toolName); err == nil && matched {
+			return pattern, r.policy.Tools[pattern], true
+		}
+	}
+	return "", RateLimit{}, false
+}
+
+func (r *RateLimiter) bucketLocked(key rateLimitKey, limit RateLimit, now time.Time) *tokenBucket {
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{limit: limit, tokens: float64(limit.Burst), updated: now}
+		r.buckets[key] = bucket
+		return bucket
+	}
+	bucket.refill(now)
+	return bucket
+}
+
+func (r *RateLimiter) limitedLocked(scope string, pattern string, toolName string, bucket *tokenBucket) RateLimitDecision {
+	r.limitedTotal++
+	r.limitedByScope[scope]++
+	r.limitedByTool[toolName]++
+	return RateLimitDecision{
+		Scope:      scope,
+		Pattern:    pattern,
+		Limit:      bucket.limit,
+		RetryAfter: bucket.retryAfter(),
+	}
+}
+
+// sweepLocked drops buckets that have refilled completely; recreating them
+// later yields the same state.
+func (r *RateLimiter) sweepLocked(now time.Time) {
+	for key, bucket := range r.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= float64(bucket.limit.Burst) {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+// RemoveSession drops a closed MCP session's buckets.
+func (r *RateLimiter) RemoveSession(sessionID string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.buckets {
+		if key.sessionID == sessionID {
+			delete(r.buckets, key)
+		}
+	}
+}
+
+func (r *RateLimiter) Health(now time.Time) map[string]any {
+	if r == nil {
+		return map[string]any{"enabled": false}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := map[string]map[string]any{}
+	for key, bucket := range r.buckets {
+		bucket.refill(now)
+		entry, ok := sessions[key.sessionID]
+		if !ok {
+			entry = map[string]any{}
+			sessions[key.sessionID] = entry
+		}
+		if key.pattern == "" {
+			entry["session_tokens"] = math.Floor(bucket.tokens*100) / 100
+			continue
+		}
+		tools, _ := entry["tool_tokens"].(map[string]float64)
+		if tools == nil {
+			tools = map[string]float64{}
+			entry["tool_tokens"] = tools
+		}
+		tools[key.pattern] = math.Floor(bucket.tokens*100) / 100
+	}
+	return map[string]any{
+		"enabled":          r.policy.Session.enabled() || len(r.policy.Tools) > 0,
+		"session_limit":    r.policy.Session,
+		"tool_limits":      maps.Clone(r.policy.Tools),
+		"buckets":          len(r.buckets),
+		"sessions":         sessions,
+		"limited_total":    r.limitedTotal,
+		"limited_by_scope": maps.Clone(r.limitedByScope),
+		"limited_by_tool":  maps.Clone(r.limitedByTool),
+	}
+}
@@ -0,0 +1,75 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSessionBucketRefills(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitPolicy{Session: RateLimit{RatePerSecond: 2, Burst: 2}})
+	now := time.Unix(1000, 0)
+
+	for i := range 2 {
+		if decision := limiter.Allow("s1", "godot.node.query", now); !decision.Allowed {
+			t.Fatalf("expected call %d within burst to pass", i)
+		}
+	}
+	decision := limiter.Allow("s1", "godot.node.query", now)
+	if decision.Allowed || decision.Scope != RateLimitScopeSession || decision.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("expected session limit with 500ms retry, got %+v", decision)
+	}
+	if decision := limiter.Allow("s2", "godot.node.query", now); !decision.Allowed {
+		t.Fatal("expected other sessions to have their own bucket")
+	}
+	if decision := limiter.Allow("s1", "godot.node.query", now.Add(500*time.Millisecond)); !decision.Allowed {
+		t.Fatal("expected bucket to refill one token after 500ms")
+	}
+}
+
+func TestRateLimiterToolBucketDoesNotDrainSession(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitPolicy{
+		Session: RateLimit{RatePerSecond: 1, Burst: 3},
+		Tools: map[string]RateLimit{
+			"godot.runtime.input.*":    {RatePerSecond: 1, Burst: 1},
+			"godot.runtime.input.tap":  {RatePerSecond: 10, Burst: 10},
+			"godot.runtime.screenshot": {},
+		},
+	})
+	now := time.Unix(1000, 0)
+
+	if decision := limiter.Allow("s1", "godot.runtime.input.press", now); !decision.Allowed {
+		t.Fatal("expected first press to pass")
+	}
+	decision := limiter.Allow("s1", "godot.runtime.input.press", now)
+	if decision.Allowed || decision.Scope != RateLimitScopeTool || decision.Pattern != "godot.runtime.input.*" {
+		t.Fatalf("expected glob tool limit, got %+v", decision)
+	}
+	// The exact tool name has its own bucket, and the refused press above
+	// did not take a session token.
+	for i := range 2 {
+		if decision := limiter.Allow("s1", "godot.runtime.input.tap", now); !decision.Allowed {
+			t.Fatalf("expected tap %d to pass, got %+v", i, decision)
+		}
+	}
+	if decision := limiter.Allow("s1", "godot.runtime.input.tap", now); decision.Allowed || decision.Scope != RateLimitScopeSession {
+		t.Fatalf("expected session budget to be exhausted, got %+v", decision)
+	}
+
+	health := limiter.Health(now)
+	if health["limited_total"] != uint64(2) || health["enabled"] != true {
+		t.Fatalf("unexpected limiter health %#v", health)
+	}
+	limiter.RemoveSession("s1")
+	if health := limiter.Health(now); health["buckets"] != 0 {
+		t.Fatalf("expected session buckets to be removed, got %#v", health)
+	}
+}
+
+func TestRateLimiterDisabledByDefault(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitPolicy{})
+	for range 100 {
+		if decision := limiter.Allow("s1", "godot.node.query", time.Now()); !decision.Allowed {
+			t.Fatal("expected an empty policy to allow every call")
+		}
+	}
+}
@@ -0,0 +1,480 @@
+package runtimebridge
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultRuntimeLogMaxFileBytes = 8 << 20
+	DefaultRuntimeLogMaxFiles     = 5
+	// RuntimeLogResourcePrefix is the resources/read URI prefix for session log exports.
+	RuntimeLogResourcePrefix = "godot://runtime/logs/"
+	runtimeLogFileSuffix     = ".jsonl"
+	runtimeLogMaxRecordBytes = 1 << 20
+)
+
+var (
+	ErrRuntimeLogMissing = errors.New("runtime log does not exist")
+
+	defaultRuntimeLogArchive atomic.Pointer[RuntimeLogArchive]
+)
+
+func init() {
+	defaultRuntimeLogArchive.Store(NewRuntimeLogArchive("", DefaultRuntimeLogMaxFileBytes, DefaultRuntimeLogMaxFiles))
+}
+
+// RuntimeLogRecord is one persisted runtime log entry with its game session.
+type RuntimeLogRecord struct {
+	SessionID string `json:"session_id"`
+	RuntimeLogEntry
+}
+
+// RuntimeLogSearch filters runtime log records across game sessions. Zero
+// values disable the corresponding filter.
+type RuntimeLogSearch struct {
+	SessionID string
+	Pattern   *regexp.Regexp
+	Level     string
+	// Source is matched with path.Match, so "runtime_command:*" selects every command source.
+	Source string
+	Since  time.Time
+	Until  time.Time
+	Limit  int
+}
+
+// RuntimeLogSearchResult holds the newest matching records first.
+type RuntimeLogSearchResult struct {
+	Records         []RuntimeLogRecord
+	SessionsScanned int
+	Truncated       bool
+}
+
+// RuntimeLogArchive mirrors runtime log entries to one JSONL file per game
+// session. A file that grows past maxFileBytes is rotated to "<name>.jsonl.1",
+// shifting older files up and dropping those beyond maxFiles. An empty
+// directory disables persistence.
+type RuntimeLogArchive struct {
+	mu           sync.Mutex
+	dir          string
+	maxFileBytes int64
+	maxFiles     int
+	writeErrors  int64
+	lastError    string
+}
+
+func NewRuntimeLogArchive(dir string, maxFileBytes int, maxFiles int) *RuntimeLogArchive {
+	archive := &RuntimeLogArchive{}
+	archive.Configure(dir, maxFileBytes, maxFiles)
+	return archive
+}
+
+func DefaultRuntimeLogArchive() *RuntimeLogArchive {
+	if archive := defaultRuntimeLogArchive.Load(); archive != nil {
+		return archive
+	}
+	archive := NewRuntimeLogArchive("", DefaultRuntimeLogMaxFileBytes, DefaultRuntimeLogMaxFiles)
+	if defaultRuntimeLogArchive.CompareAndSwap(nil, archive) {
+		return archive
+	}
+	return defaultRuntimeLogArchive.Load()
+}
+
+func ResetDefaultRuntimeLogArchiveForTests(dir string, maxFileBytes int, maxFiles int) {
+	defaultRuntimeLogArchive.Store(NewRuntimeLogArchive(dir, maxFileBytes, maxFiles))
+}
+
+// Configure sets the archive directory and rotation limits.
+func (a *RuntimeLogArchive) Configure(dir string, maxFileBytes int, maxFiles int) {
+	if a == nil {
+		return
+	}
+	if maxFileBytes <= 0 {
+		maxFileBytes = DefaultRuntimeLogMaxFileBytes
+	}
+	if maxFiles <= 0 {
+		maxFiles = DefaultRuntimeLogMaxFiles
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.dir = strings.TrimSpace(dir)
+	a.maxFileBytes = int64(maxFileBytes)
+	a.maxFiles = maxFiles
+}
+
+func (a *RuntimeLogArchive) Enabled() bool {
+	if a == nil {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.dir != ""
+}
+
+// Append writes entries to the session's active file. Write failures are
+// counted in Health rather than surfaced, so persistence never blocks the
+// in-memory log stream.
+func (a *RuntimeLogArchive) Append(sessionID string, entries []RuntimeLogEntry) {
+	if a == nil || strings.TrimSpace(sessionID) == "" || len(entries) == 0 {
+		return
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(RuntimeLogRecord{SessionID: sessionID, RuntimeLogEntry: entry})
+		if err != nil {
+			continue
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.dir == "" {
+		return
+	}
+	if err := a.appendLocked(sessionID, buf.Bytes()); err != nil {
+		a.writeErrors++
+		a.lastError = err.Error()
+	}
+}
+
+func (a *RuntimeLogArchive) appendLocked(sessionID string, payload []byte) error {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return err
+	}
+	active := a.filePathLocked(sessionID, 0)
+	if info, err := os.Stat(active); err == nil && info.Size() > 0 && info.Size()+int64(len(payload)) > a.maxFileBytes {
+		if err := a.rotateLocked(sessionID); err != nil {
+			return err
+		}
+	}
+	file, err := os.OpenFile(active, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(payload); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (a *RuntimeLogArchive) rotateLocked(sessionID string) error {
+	oldest := a.filePathLocked(sessionID, a.maxFiles-1)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for index := a.maxFiles - 2; index >= 0; index-- {
+		from := a.filePathLocked(sessionID, index)
+		if err := os.Rename(from, a.filePathLocked(sessionID, index+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// filePathLocked returns the active file for index 0 and rotated files above.
+// Session ids are path-escaped so any id maps to a single file name.
+func (a *RuntimeLogArchive) filePathLocked(sessionID string, index int) string {
+	name := url.PathEscape(sessionID) + runtimeLogFileSuffix
+	if index > 0 {
+		name += "." + strconv.Itoa(index)
+	}
+	return filepath.Join(a.dir, name)
+}
+
+// Sessions lists archived game sessions, most recently written first.
+func (a *RuntimeLogArchive) Sessions() []string {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	dir := a.dir
+	a.mu.Unlock()
+	if dir == "" {
+		return []string{}
+	}
+	items, err := os.ReadDir(dir)
+	if err != nil {
+		return []string{}
+	}
+	modified := map[string]time.Time{}
+	for _, item := range items {
+		if item.IsDir() {
+			continue
+		}
+		name := item.Name()
+		stem, _, _ := strings.Cut(name, runtimeLogFileSuffix)
+		if stem == name || stem == "" {
+			continue
+		}
+		sessionID, err := url.PathUnescape(stem)
+		if err != nil {
+			continue
+		}
+		info, err := item.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(modified[sessionID]) {
+			modified[sessionID] = info.ModTime()
+		}
+	}
+	sessions := make([]string, 0, len(modified))
+	for sessionID := range modified {
+		sessions = append(sessions, sessionID)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !modified[sessions[i]].Equal(modified[sessions[j]]) {
+			return modified[sessions[i]].After(modified[sessions[j]])
+		}
+		return sessions[i] < sessions[j]
+	})
+	return sessions
+}
+
+// Export returns the session's archived JSONL content, oldest file first.
+func (a *RuntimeLogArchive) Export(sessionID string) ([]byte, error) {
+	files := a.sessionFiles(sessionID)
+	if len(files) == 0 {
+		return nil, ErrRuntimeLogMissing
+	}
+	var buf bytes.Buffer
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// sessionFiles returns existing files for a session, oldest rotation first.
+func (a *RuntimeLogArchive) sessionFiles(sessionID string) []string {
+	if a == nil || strings.TrimSpace(sessionID) == "" {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.dir == "" {
+		return nil
+	}
+	files := make([]string, 0, a.maxFiles)
+	for index := a.maxFiles - 1; index >= 0; index-- {
+		name := a.filePathLocked(strings.TrimSpace(sessionID), index)
+		if _, err := os.Stat(name); err == nil {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+func (a *RuntimeLogArchive) records(sessionID string, visit func(RuntimeLogRecord)) error {
+	for _, name := range a.sessionFiles(sessionID) {
+		file, err := os.Open(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 0, 64<<10), runtimeLogMaxRecordBytes)
+		for scanner.Scan() {
+			var record RuntimeLogRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				continue
+			}
+			visit(record)
+		}
+		err = scanner.Err()
+		_ = file.Close()
+		if err != nil {
+			return fmt.Errorf("read %s: %w", filepath.Base(name), err)
+		}
+	}
+	return nil
+}
+
+func (a *RuntimeLogArchive) Health() map[string]any {
+	if a == nil {
+		return map[string]any{
+			"enabled": false,
+		}
+	}
+	sessions := len(a.Sessions())
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return map[string]any{
+		"enabled":        a.dir != "",
+		"sessions":       sessions,
+		"max_file_bytes": a.maxFileBytes,
+		"max_files":      a.maxFiles,
+		"write_errors":   a.writeErrors,
+		"last_error":     a.lastError,
+	}
+}
+
+// SearchRuntimeLogs scans archived sessions and, for sessions without an
+// archive, the in-memory log store.
+func SearchRuntimeLogs(search RuntimeLogSearch) (RuntimeLogSearchResult, error) {
+	limit := clampLogLimit(search.Limit)
+	level := normalizeLogLevel(search.Level)
+	if level == "all" {
+		level = ""
+	}
+	matches := func(record RuntimeLogRecord) bool {
+		if level != "" && normalizeLogLevel(record.Level) != level {
+			return false
+		}
+		if search.Source != "" {
+			if ok, _ := path.Match(search.Source, record.Source); !ok {
+				return false
+			}
+		}
+		if !search.Since.IsZero() || !search.Until.IsZero() {
+			at, err := time.Parse(time.RFC3339Nano, record.Time)
+			if err != nil {
+				return false
+			}
+			if !search.Since.IsZero() && at.Before(search.Since) {
+				return false
+			}
+			if !search.Until.IsZero() && at.After(search.Until) {
+				return false
+			}
+		}
+		if search.Pattern != nil && !search.Pattern.MatchString(record.Message) && !search.Pattern.MatchString(record.StackTrace) {
+			return false
+		}
+		return true
+	}
+
+	archive := DefaultRuntimeLogArchive()
+	store := DefaultRuntimeLogStore()
+	result := RuntimeLogSearchResult{Records: []RuntimeLogRecord{}}
+	for _, sessionID := range RuntimeLogSessions() {
+		if search.SessionID != "" && sessionID != search.SessionID {
+			continue
+		}
+		result.SessionsScanned++
+		visit := func(record RuntimeLogRecord) {
+			if matches(record) {
+				result.Records = append(result.Records, record)
+			}
+		}
+		if len(archive.sessionFiles(sessionID)) > 0 {
+			if err := archive.records(sessionID, visit); err != nil {
+				return RuntimeLogSearchResult{}, err
+			}
+			continue
+		}
+		for _, entry := range store.matching(sessionID, RuntimeLogQuery{}) {
+			visit(RuntimeLogRecord{SessionID: sessionID, RuntimeLogEntry: entry})
+		}
+	}
+
+	sort.SliceStable(result.Records, func(i, j int) bool {
+		left, right := result.Records[i], result.Records[j]
+		if left.Time != right.Time {
+			return runtimeLogTimeAfter(left.Time, right.Time)
+		}
+		if left.SessionID != right.SessionID {
+			return left.SessionID < right.SessionID
+		}
+		return left.Sequence > right.Sequence
+	})
+	if len(result.Records) > limit {
+		result.Records = result.Records[:limit]
+		result.Truncated = true
+	}
+	return result, nil
+}
+
+func runtimeLogTimeAfter(left string, right string) bool {
+	leftAt, leftErr := time.Parse(time.RFC3339Nano, left)
+	rightAt, rightErr := time.Parse(time.RFC3339Nano, right)
+	if leftErr != nil || rightErr != nil {
+		return left > right
+	}
+	return leftAt.After(rightAt)
+}
+
+// RuntimeLogSessions lists game sessions with archived or in-memory logs,
+// archived sessions first in most recently written order.
+func RuntimeLogSessions() []string {
+	sessions := DefaultRuntimeLogArchive().Sessions()
+	seen := make(map[string]bool, len(sessions))
+	for _, sessionID := range sessions {
+		seen[sessionID] = true
+	}
+	for _, sessionID := range DefaultRuntimeLogStore().Sessions() {
+		if !seen[sessionID] {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	return sessions
+}
+
+// ExportRuntimeLog returns one session's log as JSONL records. Archived
+// sessions export their files; others export the in-memory buffer.
+func ExportRuntimeLog(sessionID string) ([]byte, error) {
+	sessionID = strings.TrimSpace(sessionID)
+	if data, err := DefaultRuntimeLogArchive().Export(sessionID); err == nil {
+		return data, nil
+	} else if !errors.Is(err, ErrRuntimeLogMissing) {
+		return nil, err
+	}
+	entries := DefaultRuntimeLogStore().matching(sessionID, RuntimeLogQuery{})
+	if len(entries) == 0 {
+		return nil, ErrRuntimeLogMissing
+	}
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(RuntimeLogRecord{SessionID: sessionID, RuntimeLogEntry: entry})
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// RuntimeLogResourceURI returns the resources/read URI for a session log export.
+func RuntimeLogResourceURI(sessionID string) string {
+	return RuntimeLogResourcePrefix + url.PathEscape(sessionID)
+}
+
+// ParseRuntimeLogResourceURI extracts the session id from a log export URI.
+func ParseRuntimeLogResourceURI(uri string) (string, bool) {
+	rest, ok := strings.CutPrefix(uri, RuntimeLogResourcePrefix)
+	if !ok || rest == "" {
+		return "", false
+	}
+	sessionID, err := url.PathUnescape(rest)
+	if err != nil || strings.TrimSpace(sessionID) == "" {
+		return "", false
+	}
+	return sessionID, true
+}
@@ -0,0 +1,118 @@
+package runtimebridge
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRuntimeLogArchive_RotatesAndExportsOldestFirst(t *testing.T) {
+	dir := t.TempDir()
+	archive := NewRuntimeLogArchive(dir, 200, 2)
+	for i := range 6 {
+		archive.Append("game_1", []RuntimeLogEntry{{
+			Sequence: int64(i + 1),
+			Time:     "2026-01-01T00:00:00Z",
+			Level:    "info",
+			Message:  strings.Repeat("x", 40),
+		}})
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "game_1.jsonl.1")); err != nil {
+		t.Fatalf("expected rotated file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "game_1.jsonl.2")); !os.IsNotExist(err) {
+		t.Fatalf("expected rotation beyond max_files to be dropped, got %v", err)
+	}
+	data, err := archive.Export("game_1")
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	if len(lines) == 0 || len(lines) >= 6 {
+		t.Fatalf("expected rotation to drop the oldest records, got %d lines", len(lines))
+	}
+	if !bytes.Contains(lines[len(lines)-1], []byte(`"sequence":6`)) || !bytes.Contains(lines[0], []byte(`"session_id":"game_1"`)) {
+		t.Fatalf("expected records in sequence order with session ids, got %s", data)
+	}
+	if _, err := archive.Export("game_2"); !errors.Is(err, ErrRuntimeLogMissing) {
+		t.Fatalf("expected ErrRuntimeLogMissing, got %v", err)
+	}
+}
+
+func TestSearchRuntimeLogs_SpansArchivedAndLiveSessions(t *testing.T) {
+	dir := t.TempDir()
+	ResetDefaultRuntimeLogArchiveForTests(dir, DefaultRuntimeLogMaxFileBytes, DefaultRuntimeLogMaxFiles)
+	ResetDefaultRuntimeLogStoreForTests(100)
+	t.Cleanup(func() {
+		ResetDefaultRuntimeLogArchiveForTests("", DefaultRuntimeLogMaxFileBytes, DefaultRuntimeLogMaxFiles)
+	})
+
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := DefaultRuntimeLogStore()
+	store.Append("game_old", []RuntimeLogAppendEntry{
+		{Time: base.Format(time.RFC3339Nano), Level: "error", Message: "SCRIPT ERROR: Invalid call. Nonexistent function 'jump'.", Source: "process_stderr"},
+		{Time: base.Add(time.Second).Format(time.RFC3339Nano), Level: "info", Message: "jump pressed", Source: "process_stdout"},
+	}, base)
+	store.RemoveSession("game_old")
+	store.Append("game_new", []RuntimeLogAppendEntry{
+		{Time: base.Add(time.Hour).Format(time.RFC3339Nano), Level: "error", Message: "jump failed", Source: "runtime_command:godot.runtime.input.tap"},
+	}, base)
+
+	result, err := SearchRuntimeLogs(RuntimeLogSearch{Pattern: regexp.MustCompile(`(?i)jump`)})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if result.SessionsScanned != 2 || len(result.Records) != 3 {
+		t.Fatalf("expected 3 matches across 2 sessions, got %+v", result)
+	}
+	if result.Records[0].SessionID != "game_new" {
+		t.Fatalf("expected newest match first, got %+v", result.Records[0])
+	}
+
+	result, err = SearchRuntimeLogs(RuntimeLogSearch{Source: "process_*", Until: base.Add(time.Minute), Level: "error"})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(result.Records) != 1 || result.Records[0].SessionID != "game_old" || result.Records[0].ErrorKind != "script_error" {
+		t.Fatalf("expected archived script error from removed session, got %+v", result.Records)
+	}
+
+	result, err = SearchRuntimeLogs(RuntimeLogSearch{Since: base.Add(time.Minute), Limit: 1})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(result.Records) != 1 || result.Records[0].Message != "jump failed" || result.Truncated {
+		t.Fatalf("expected one untruncated match since the range start, got %+v", result)
+	}
+}
+
+func TestExportRuntimeLog_FallsBackToMemoryWithoutArchive(t *testing.T) {
+	ResetDefaultRuntimeLogArchiveForTests("", DefaultRuntimeLogMaxFileBytes, DefaultRuntimeLogMaxFiles)
+	ResetDefaultRuntimeLogStoreForTests(100)
+	DefaultRuntimeLogStore().Append("game/1", []RuntimeLogAppendEntry{{Level: "info", Message: "boot"}}, time.Now().UTC())
+
+	uri := RuntimeLogResourceURI("game/1")
+	if uri != "godot://runtime/logs/game%2F1" {
+		t.Fatalf("unexpected resource uri %q", uri)
+	}
+	sessionID, ok := ParseRuntimeLogResourceURI(uri)
+	if !ok || sessionID != "game/1" {
+		t.Fatalf("expected to parse session id, got %q %v", sessionID, ok)
+	}
+	data, err := ExportRuntimeLog(sessionID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"message":"boot"`)) {
+		t.Fatalf("expected in-memory entry in export, got %s", data)
+	}
+	if _, err := ExportRuntimeLog("game_missing"); !errors.Is(err, ErrRuntimeLogMissing) {
+		t.Fatalf("expected ErrRuntimeLogMissing, got %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+package runtimebridge
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// RuntimeLogFrame is one script or engine location parsed from a log entry.
+type RuntimeLogFrame struct {
+	Index    int    `json:"index"`
+	Function string `json:"function,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+}
+
+// GodotLogDetails is the structured form of one Godot error or warning.
+type GodotLogDetails struct {
+	ErrorKind string
+	File      string
+	Line      int
+	Function  string
+	Frames    []RuntimeLogFrame
+}
+
+// godotLogKinds maps Godot's console prefixes to error kinds. Longer prefixes
+// come first so "USER ERROR:" never matches as "ERROR:".
+var godotLogKinds = []struct {
+	prefix string
+	kind   string
+}{
+	{prefix: "SCRIPT ERROR: Parse Error:", kind: "parse_error"},
+	{prefix: "Parse Error:", kind: "parse_error"},
+	{prefix: "USER SCRIPT ERROR:", kind: "user_error"},
+	{prefix: "USER ERROR:", kind: "user_error"},
+	{prefix: "SCRIPT ERROR:", kind: "script_error"},
+	{prefix: "SHADER ERROR:", kind: "shader_error"},
+	{prefix: "ERROR:", kind: "error"},
+	{prefix: "USER SCRIPT WARNING:", kind: "user_warning"},
+	{prefix: "USER WARNING:", kind: "user_warning"},
+	{prefix: "SCRIPT WARNING:", kind: "script_warning"},
+	{prefix: "WARNING:", kind: "warning"},
+}
+
+var (
+	// "at: _ready (res://main.gd:5)" (Godot 4).
+	godotAtCallPattern = regexp.MustCompile(`^(?i:at):\s*(.*?)\s*\((.+):(\d+)\)$`)
+	// "At: res://main.gd:5:_ready() - ..." (Godot 3).
+	godotAtPathPattern = regexp.MustCompile(`^(?i:at):\s*([^\s:]+(?:://)?[^\s:]*):(\d+)(?::\s*([^\s(]+)\(\))?`)
+	// "[0] _ready (res://main.gd:5)" from a GDScript backtrace.
+	godotBacktracePattern = regexp.MustCompile(`^\[(\d+)\]\s*(.*?)\s*\((.+):(\d+)\)$`)
+	// "Frame 0 - res://main.gd:5 in function '_ready'" from print_stack().
+	godotStackFramePattern = regexp.MustCompile(`^Frame\s+(\d+)\s*-\s*(.+):(\d+)\s+in function\s+'([^']*)'`)
+)
+
+// ParseGodotLog extracts the error kind, primary location and GDScript frames
+// from a Godot console message and its optional stack trace. The primary
+// location is the "at:" line unless it points into engine sources and a
+// backtrace names a res:// script.
+func ParseGodotLog(message string, stackTrace string) GodotLogDetails {
+	details := GodotLogDetails{}
+	lines := make([]string, 0, 4)
+	for _, text := range []string{message, stackTrace} {
+		for line := range strings.SplitSeq(text, "\n") {
+			line = strings.TrimSpace(strings.TrimRight(line, "\r"))
+			if line != "" {
+				lines = append(lines, line)
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return details
+	}
+	for _, candidate := range godotLogKinds {
+		if strings.HasPrefix(lines[0], candidate.prefix) {
+			details.ErrorKind = candidate.kind
+			break
+		}
+	}
+
+	var at *RuntimeLogFrame
+	frames := make([]RuntimeLogFrame, 0)
+	for _, line := range lines {
+		if at == nil {
+			if frame, ok := parseGodotAtLine(line); ok {
+				at = &frame
+				continue
+			}
+		}
+		if match := godotBacktracePattern.FindStringSubmatch(line); match != nil {
+			frames = append(frames, RuntimeLogFrame{
+				Index:    atoiOrZero(match[1]),
+				Function: match[2],
+				File:     match[3],
+				Line:     atoiOrZero(match[4]),
+			})
+			continue
+		}
+		if match := godotStackFramePattern.FindStringSubmatch(line); match != nil {
+			frames = append(frames, RuntimeLogFrame{
+				Index:    atoiOrZero(match[1]),
+				Function: match[4],
+				File:     strings.TrimSpace(match[2]),
+				Line:     atoiOrZero(match[3]),
+			})
+		}
+	}
+
+	primary := at
+	if primary == nil || !strings.HasPrefix(primary.File, "res://") {
+		for i := range frames {
+			if strings.HasPrefix(frames[i].File, "res://") {
+				primary = &frames[i]
+				break
+			}
+		}
+	}
+	if len(frames) == 0 && at != nil {
+		frames = append(frames, *at)
+	}
+	if primary != nil {
+		details.File = primary.File
+		details.Line = primary.Line
+		details.Function = primary.Function
+	}
+	if len(frames) > 0 {
+		details.Frames = frames
+	}
+	return details
+}
+
+func parseGodotAtLine(line string) (RuntimeLogFrame, bool) {
+	if match := godotAtCallPattern.FindStringSubmatch(line); match != nil {
+		return RuntimeLogFrame{
+			Function: match[1],
+			File:     match[2],
+			Line:     atoiOrZero(match[3]),
+		}, true
+	}
+	if match := godotAtPathPattern.FindStringSubmatch(line); match != nil {
+		return RuntimeLogFrame{
+			Function: match[3],
+			File:     match[1],
+			Line:     atoiOrZero(match[2]),
+		}, true
+	}
+	return RuntimeLogFrame{}, false
+}
+
+// applyGodotLogDetails fills the structured fields of entry from its message
+// and stack trace. Entries without a recognised prefix still get a kind when
+// they are errors or warnings carrying a location.
+func applyGodotLogDetails(entry *RuntimeLogEntry) {
+	details := ParseGodotLog(entry.Message, entry.StackTrace)
+	if details.ErrorKind == "" && details.File != "" && (entry.Level == "error" || entry.Level == "warning") {
+		details.ErrorKind = entry.Level
+	}
+	entry.ErrorKind = details.ErrorKind
+	entry.File = details.File
+	entry.Line = details.Line
+	entry.Function = details.Function
+	entry.Frames = details.Frames
+}
+
+// IsGodotLogContinuation reports whether line continues the previous error
+// header, such as an "at:" location or a GDScript backtrace frame.
+func IsGodotLogContinuation(line string) bool {
+	if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+		return false
+	}
+	trimmed := strings.TrimSpace(line)
+	if _, ok := parseGodotAtLine(trimmed); ok {
+		return true
+	}
+	return strings.HasPrefix(trimmed, "GDScript backtrace") ||
+		godotBacktracePattern.MatchString(trimmed) ||
+		godotStackFramePattern.MatchString(trimmed)
+}
+
+func atoiOrZero(value string) int {
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
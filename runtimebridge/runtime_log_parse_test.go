@@ -0,0 +1,77 @@
+package runtimebridge
+
+import "testing"
+
+func TestParseGodotLog_ScriptErrorWithAtLine(t *testing.T) {
+	details := ParseGodotLog("SCRIPT ERROR: Invalid call. Nonexistent function 'jump' in base 'Node2D'.", "at: _ready (res://player.gd:12)")
+	if details.ErrorKind != "script_error" {
+		t.Fatalf("expected script_error, got %q", details.ErrorKind)
+	}
+	if details.File != "res://player.gd" || details.Line != 12 || details.Function != "_ready" {
+		t.Fatalf("unexpected location: %+v", details)
+	}
+	if len(details.Frames) != 1 || details.Frames[0].File != "res://player.gd" {
+		t.Fatalf("expected the at: location as the only frame, got %+v", details.Frames)
+	}
+}
+
+func TestParseGodotLog_PrefersScriptFrameOverEngineLocation(t *testing.T) {
+	stack := "at: add_child (scene/main/node.cpp:1634)\n" +
+		"GDScript backtrace (most recent call first):\n" +
+		"    [0] spawn (res://enemies/spawner.gd:40)\n" +
+		"    [1] _ready (res://main.gd:8)"
+	details := ParseGodotLog(`ERROR: Condition "p_child->data.parent" is true.`, stack)
+	if details.ErrorKind != "error" {
+		t.Fatalf("expected error kind, got %q", details.ErrorKind)
+	}
+	if details.File != "res://enemies/spawner.gd" || details.Line != 40 || details.Function != "spawn" {
+		t.Fatalf("expected first script frame as location, got %+v", details)
+	}
+	if len(details.Frames) != 2 || details.Frames[1].Index != 1 || details.Frames[1].Function != "_ready" {
+		t.Fatalf("unexpected frames: %+v", details.Frames)
+	}
+}
+
+func TestParseGodotLog_KindsAndAlternateFormats(t *testing.T) {
+	cases := []struct {
+		message  string
+		stack    string
+		kind     string
+		file     string
+		line     int
+		function string
+	}{
+		{message: "USER ERROR: health below zero", stack: "at: push_error (core/variant/variant_utility.cpp:1098)", kind: "user_error", file: "core/variant/variant_utility.cpp", line: 1098, function: "push_error"},
+		{message: "SCRIPT ERROR: Parse Error: Identifier \"foo\" not declared.", stack: "at: GDScript::reload (res://ui/hud.gd:3)", kind: "parse_error", file: "res://ui/hud.gd", line: 3, function: "GDScript::reload"},
+		{message: "WARNING: texture not found", kind: "warning"},
+		{message: "SCRIPT ERROR: Invalid get index 'x'.\n   At: res://player.gd:21:_physics_process() - Invalid get index 'x'.", kind: "script_error", file: "res://player.gd", line: 21, function: "_physics_process"},
+		{message: "boom", stack: "Frame 0 - res://main.gd:5 in function '_ready'\nFrame 1 - res://game.gd:9 in function 'start'", file: "res://main.gd", line: 5, function: "_ready"},
+		{message: "Godot Engine v4.3.stable"},
+	}
+	for _, tc := range cases {
+		details := ParseGodotLog(tc.message, tc.stack)
+		if details.ErrorKind != tc.kind || details.File != tc.file || details.Line != tc.line || details.Function != tc.function {
+			t.Fatalf("parse %q: got %+v", tc.message, details)
+		}
+	}
+}
+
+func TestIsGodotLogContinuation(t *testing.T) {
+	for _, line := range []string{
+		"   at: _ready (res://main.gd:4)",
+		"   GDScript backtrace (most recent call first):",
+		"       [0] _ready (res://main.gd:4)",
+	} {
+		if !IsGodotLogContinuation(line) {
+			t.Fatalf("expected %q to be a continuation line", line)
+		}
+	}
+	for _, line := range []string{
+		"at: _ready (res://main.gd:4)",
+		"   loading level 2",
+	} {
+		if IsGodotLogContinuation(line) {
+			t.Fatalf("expected %q not to be a continuation line", line)
+		}
+	}
+}
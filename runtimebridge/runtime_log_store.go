@@ -2,6 +2,7 @@ package runtimebridge
 
 import (
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -86,6 +87,7 @@ func (s *RuntimeLogStore) Append(sessionID string, entries []RuntimeLogAppendEnt
 			Source:     strings.TrimSpace(in.Source),
 			StackTrace: strings.TrimSpace(in.StackTrace),
 		}
+		applyGodotLogDetails(&entry)
 		seq++
 		current = append(current, entry)
 		out = append(out, entry)
@@ -95,23 +97,96 @@ func (s *RuntimeLogStore) Append(sessionID string, entries []RuntimeLogAppendEnt
 	}
 	s.bySess[sessionID] = current
 	s.nextSeq[sessionID] = seq
+	// Archive and fan out while holding the lock so persisted and tailed order
+	// matches sequence order.
+	DefaultRuntimeLogArchive().Append(sessionID, out)
+	DefaultRuntimeLogTailHub().publish(sessionID, out)
+	DefaultGameSessionHistory().observeLogs(sessionID, out)
 	return out
 }
 
 func (s *RuntimeLogStore) Get(sessionID string, level string, limit int, sinceSequence int64) []RuntimeLogEntry {
+	return s.Query(sessionID, RuntimeLogQuery{
+		Level:         level,
+		Limit:         limit,
+		SinceSequence: sinceSequence,
+	})
+}
+
+// Query returns the oldest entries matching query, up to its limit.
+func (s *RuntimeLogStore) Query(sessionID string, query RuntimeLogQuery) []RuntimeLogEntry {
 	if s == nil || strings.TrimSpace(sessionID) == "" {
 		return nil
 	}
-	if limit <= 0 {
-		limit = 50
+	limit := clampLogLimit(query.Limit)
+	filtered := s.matching(sessionID, query)
+	if len(filtered) > limit {
+		filtered = filtered[:limit]
 	}
-	if limit > 500 {
-		limit = 500
+	return filtered
+}
+
+// Last returns the newest limit entries of one session in sequence order.
+func (s *RuntimeLogStore) Last(sessionID string, limit int) []RuntimeLogEntry {
+	if s == nil || strings.TrimSpace(sessionID) == "" || limit <= 0 {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := s.bySess[strings.TrimSpace(sessionID)]
+	if len(items) > limit {
+		items = items[len(items)-limit:]
+	}
+	return append([]RuntimeLogEntry(nil), items...)
+}
+
+// Group folds matching entries with the same level, kind, message and
+// location into one group. Groups are ordered by first occurrence and the
+// limit applies to groups rather than entries.
+func (s *RuntimeLogStore) Group(sessionID string, query RuntimeLogQuery) []RuntimeLogGroup {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return nil
+	}
+	limit := clampLogLimit(query.Limit)
+	groups := make([]RuntimeLogGroup, 0)
+	index := map[string]int{}
+	for _, entry := range s.matching(sessionID, query) {
+		key := strings.Join([]string{entry.Level, entry.ErrorKind, entry.Message, entry.File, strconv.Itoa(entry.Line), entry.Function}, "\x00")
+		if i, ok := index[key]; ok {
+			groups[i].Count++
+			groups[i].LastSequence = entry.Sequence
+			groups[i].LastTime = entry.Time
+			continue
+		}
+		index[key] = len(groups)
+		groups = append(groups, RuntimeLogGroup{
+			Level:         entry.Level,
+			ErrorKind:     entry.ErrorKind,
+			Message:       entry.Message,
+			File:          entry.File,
+			Line:          entry.Line,
+			Function:      entry.Function,
+			Frames:        entry.Frames,
+			Count:         1,
+			FirstSequence: entry.Sequence,
+			LastSequence:  entry.Sequence,
+			FirstTime:     entry.Time,
+			LastTime:      entry.Time,
+		})
+	}
+	if len(groups) > limit {
+		groups = groups[:limit]
 	}
-	level = normalizeLogLevel(level)
+	return groups
+}
+
+func (s *RuntimeLogStore) matching(sessionID string, query RuntimeLogQuery) []RuntimeLogEntry {
+	level := normalizeLogLevel(query.Level)
 	if level == "" {
 		level = "all"
 	}
+	file := strings.TrimSpace(query.File)
+	function := strings.TrimSpace(query.Function)
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -120,28 +195,93 @@ func (s *RuntimeLogStore) Get(sessionID string, level string, limit int, sinceSe
 		return []RuntimeLogEntry{}
 	}
 
-	filtered := make([]RuntimeLogEntry, 0, min(limit, len(items)))
+	filtered := make([]RuntimeLogEntry, 0, len(items))
 	for _, entry := range items {
-		if entry.Sequence <= sinceSequence {
+		if entry.Sequence <= query.SinceSequence {
 			continue
 		}
 		if level != "all" && normalizeLogLevel(entry.Level) != level {
 			continue
 		}
+		if file != "" && !runtimeLogEntryMatchesFile(entry, file) {
+			continue
+		}
+		if function != "" && !runtimeLogEntryMatchesFunction(entry, function) {
+			continue
+		}
 		filtered = append(filtered, entry)
 	}
-	if len(filtered) == 0 {
-		return []RuntimeLogEntry{}
-	}
 	sort.Slice(filtered, func(i, j int) bool {
 		return filtered[i].Sequence < filtered[j].Sequence
 	})
-	if len(filtered) > limit {
-		filtered = filtered[:limit]
-	}
 	return filtered
 }
 
+func clampLogLimit(limit int) int {
+	if limit <= 0 {
+		return 50
+	}
+	if limit > 500 {
+		return 500
+	}
+	return limit
+}
+
+func runtimeLogEntryMatchesFile(entry RuntimeLogEntry, file string) bool {
+	if runtimeLogFileMatches(entry.File, file) {
+		return true
+	}
+	for _, frame := range entry.Frames {
+		if runtimeLogFileMatches(frame.File, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// runtimeLogFileMatches accepts an exact path or a suffix on a path boundary,
+// so "player.gd" and "scripts/player.gd" both match "res://scripts/player.gd".
+func runtimeLogFileMatches(path string, filter string) bool {
+	if path == "" {
+		return false
+	}
+	if path == filter {
+		return true
+	}
+	filter = strings.TrimPrefix(filter, "res://")
+	trimmed := strings.TrimPrefix(path, "res://")
+	return trimmed == filter || strings.HasSuffix(trimmed, "/"+filter)
+}
+
+func runtimeLogEntryMatchesFunction(entry RuntimeLogEntry, function string) bool {
+	if entry.Function == function {
+		return true
+	}
+	for _, frame := range entry.Frames {
+		if frame.Function == function {
+			return true
+		}
+	}
+	return false
+}
+
+// Sessions lists game sessions with buffered log entries.
+func (s *RuntimeLogStore) Sessions() []string {
+	if s == nil {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sessions := make([]string, 0, len(s.bySess))
+	for sessionID, items := range s.bySess {
+		if len(items) > 0 {
+			sessions = append(sessions, sessionID)
+		}
+	}
+	sort.Strings(sessions)
+	return sessions
+}
+
 func (s *RuntimeLogStore) Clear(sessionID string) int {
 	if s == nil || strings.TrimSpace(sessionID) == "" {
 		return 0
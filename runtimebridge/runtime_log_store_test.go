@@ -102,3 +102,54 @@ func TestDefaultRuntimeLogStoreReset_ConcurrentLoadAndReset(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRuntimeLogStoreQuery_FiltersByFileAndFunction(t *testing.T) {
+	store := NewRuntimeLogStore(10)
+	now := time.Now().UTC()
+	store.Append("game_1", []RuntimeLogAppendEntry{
+		{Level: "error", Message: "SCRIPT ERROR: boom", StackTrace: "at: _ready (res://scripts/player.gd:12)"},
+		{Level: "error", Message: "SCRIPT ERROR: bang", StackTrace: "at: _process (res://scripts/enemy.gd:3)"},
+		{Level: "info", Message: "player.gd loaded"},
+	}, now)
+
+	byFile := store.Query("game_1", RuntimeLogQuery{File: "player.gd"})
+	if len(byFile) != 1 || byFile[0].Message != "SCRIPT ERROR: boom" {
+		t.Fatalf("expected one entry for player.gd, got %#v", byFile)
+	}
+	if got := store.Query("game_1", RuntimeLogQuery{File: "res://scripts/enemy.gd"}); len(got) != 1 {
+		t.Fatalf("expected exact res:// path to match, got %#v", got)
+	}
+	if got := store.Query("game_1", RuntimeLogQuery{File: "layer.gd"}); len(got) != 0 {
+		t.Fatalf("expected suffix match on path boundaries only, got %#v", got)
+	}
+	byFunction := store.Query("game_1", RuntimeLogQuery{Function: "_process"})
+	if len(byFunction) != 1 || byFunction[0].File != "res://scripts/enemy.gd" {
+		t.Fatalf("expected one entry for _process, got %#v", byFunction)
+	}
+}
+
+func TestRuntimeLogStoreGroup_CountsIdenticalErrors(t *testing.T) {
+	store := NewRuntimeLogStore(10)
+	now := time.Now().UTC()
+	store.Append("game_1", []RuntimeLogAppendEntry{
+		{Level: "error", Message: "SCRIPT ERROR: boom", StackTrace: "at: _ready (res://main.gd:4)"},
+		{Level: "error", Message: "SCRIPT ERROR: boom", StackTrace: "at: _process (res://main.gd:9)"},
+		{Level: "error", Message: "SCRIPT ERROR: boom", StackTrace: "at: _ready (res://main.gd:4)"},
+		{Level: "warning", Message: "WARNING: slow frame"},
+	}, now)
+
+	groups := store.Group("game_1", RuntimeLogQuery{Level: "error"})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %#v", groups)
+	}
+	first := groups[0]
+	if first.Count != 2 || first.Function != "_ready" || first.FirstSequence != 1 || first.LastSequence != 3 {
+		t.Fatalf("unexpected first group: %#v", first)
+	}
+	if groups[1].Count != 1 || groups[1].Line != 9 {
+		t.Fatalf("unexpected second group: %#v", groups[1])
+	}
+	if limited := store.Group("game_1", RuntimeLogQuery{Limit: 1}); len(limited) != 1 || limited[0].Count != 2 {
+		t.Fatalf("expected limit to apply to groups, got %#v", limited)
+	}
+}
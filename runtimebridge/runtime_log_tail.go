@@ -0,0 +1,419 @@
+package runtimebridge
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// RuntimeLogTailMethodName is the notification carrying tailed log batches.
+	RuntimeLogTailMethodName = "notifications/godot/runtime_log"
+	// DefaultRuntimeLogTailQueue bounds undelivered entries per tail before new
+	// entries are dropped and reported as a gap.
+	DefaultRuntimeLogTailQueue  = 1000
+	runtimeLogTailBatchSize     = 100
+	maxRuntimeLogTailsPerNotify = 16
+)
+
+var (
+	ErrRuntimeLogTailLimit = errors.New("too many runtime log tails for this MCP session")
+
+	defaultRuntimeLogTailHub atomic.Pointer[RuntimeLogTailHub]
+)
+
+func init() {
+	defaultRuntimeLogTailHub.Store(NewRuntimeLogTailHub(DefaultRuntimeLogTailQueue))
+}
+
+// RuntimeLogGap reports runtime log entries a tail dropped, by sequence range.
+type RuntimeLogGap struct {
+	FromSequence int64 `json:"from_sequence"`
+	ToSequence   int64 `json:"to_sequence"`
+	Dropped      int   `json:"dropped"`
+}
+
+// RuntimeLogTail is one subscription streaming a game session's log entries
+// to an MCP session as notifications.
+type RuntimeLogTail struct {
+	TailID          string `json:"tail_id"`
+	SessionID       string `json:"session_id"`
+	NotifySessionID string `json:"-"`
+	Level           string `json:"level"`
+	CreatedAt       string `json:"created_at"`
+	Delivered       int64  `json:"delivered"`
+	Dropped         int64  `json:"dropped"`
+}
+
+type runtimeLogTailBatch struct {
+	gap     *RuntimeLogGap
+	entries []RuntimeLogEntry
+}
+
+type runtimeLogTail struct {
+	info    RuntimeLogTail
+	queue   []runtimeLogTailBatch
+	queued  int
+	openGap *RuntimeLogGap
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// RuntimeLogTailHub fans appended runtime log entries out to tails. Each tail
+// has a bounded queue drained by its own goroutine, so a slow or missing SSE
+// stream never blocks log ingestion; overflow and failed deliveries surface
+// as gaps on the next notification.
+type RuntimeLogTailHub struct {
+	mu        sync.Mutex
+	queueSize int
+	tails     map[string]*runtimeLogTail
+	nextID    int64
+}
+
+func NewRuntimeLogTailHub(queueSize int) *RuntimeLogTailHub {
+	if queueSize <= 0 {
+		queueSize = DefaultRuntimeLogTailQueue
+	}
+	return &RuntimeLogTailHub{
+		queueSize: queueSize,
+		tails:     make(map[string]*runtimeLogTail),
+	}
+}
+
+func DefaultRuntimeLogTailHub() *RuntimeLogTailHub {
+	if hub := defaultRuntimeLogTailHub.Load(); hub != nil {
+		return hub
+	}
+	hub := NewRuntimeLogTailHub(DefaultRuntimeLogTailQueue)
+	if defaultRuntimeLogTailHub.CompareAndSwap(nil, hub) {
+		return hub
+	}
+	return defaultRuntimeLogTailHub.Load()
+}
+
+func ResetDefaultRuntimeLogTailHubForTests(queueSize int) {
+	if previous := defaultRuntimeLogTailHub.Swap(NewRuntimeLogTailHub(queueSize)); previous != nil {
+		previous.stopAll()
+	}
+}
+
+// StartTail subscribes notifySessionID to new entries of a game session. With
+// replay set, buffered entries after sinceSequence are queued first; the store
+// lock makes the replay and the subscription atomic with respect to Append.
+func (s *RuntimeLogStore) StartTail(sessionID string, notifySessionID string, level string, replay bool, sinceSequence int64, now time.Time) (RuntimeLogTail, error) {
+	if s == nil || strings.TrimSpace(sessionID) == "" || strings.TrimSpace(notifySessionID) == "" {
+		return RuntimeLogTail{}, errors.New("session_id and notify session are required")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var backlog []RuntimeLogEntry
+	if replay {
+		for _, entry := range s.bySess[strings.TrimSpace(sessionID)] {
+			if entry.Sequence > sinceSequence {
+				backlog = append(backlog, entry)
+			}
+		}
+	}
+	return DefaultRuntimeLogTailHub().start(strings.TrimSpace(sessionID), strings.TrimSpace(notifySessionID), level, backlog, now)
+}
+
+func (h *RuntimeLogTailHub) start(sessionID string, notifySessionID string, level string, backlog []RuntimeLogEntry, now time.Time) (RuntimeLogTail, error) {
+	if h == nil {
+		return RuntimeLogTail{}, errors.New("runtime log tail hub is unavailable")
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	level = normalizeLogLevel(level)
+	if level == "" {
+		level = "all"
+	}
+
+	h.mu.Lock()
+	owned := 0
+	for _, tail := range h.tails {
+		if tail.info.NotifySessionID == notifySessionID {
+			owned++
+		}
+	}
+	if owned >= maxRuntimeLogTailsPerNotify {
+		h.mu.Unlock()
+		return RuntimeLogTail{}, ErrRuntimeLogTailLimit
+	}
+	h.nextID++
+	tail := &runtimeLogTail{
+		info: RuntimeLogTail{
+			TailID:          fmt.Sprintf("tail_%d", h.nextID),
+			SessionID:       sessionID,
+			NotifySessionID: notifySessionID,
+			Level:           level,
+			CreatedAt:       now.Format(time.RFC3339Nano),
+		},
+		wake: make(chan struct{}, 1),
+		done: make(chan struct{}),
+	}
+	h.tails[tail.info.TailID] = tail
+	h.enqueueLocked(tail, backlog)
+	info := tail.info
+	h.mu.Unlock()
+
+	go h.deliver(tail)
+	return info, nil
+}
+
+// Stop ends one tail owned by notifySessionID and returns its final counters.
+func (h *RuntimeLogTailHub) Stop(tailID string, notifySessionID string) (RuntimeLogTail, bool) {
+	if h == nil {
+		return RuntimeLogTail{}, false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tail, ok := h.tails[strings.TrimSpace(tailID)]
+	if !ok || tail.info.NotifySessionID != strings.TrimSpace(notifySessionID) {
+		return RuntimeLogTail{}, false
+	}
+	h.removeLocked(tail)
+	return tail.info, true
+}
+
+// List returns tails owned by notifySessionID.
+func (h *RuntimeLogTailHub) List(notifySessionID string) []RuntimeLogTail {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	out := make([]RuntimeLogTail, 0)
+	for _, tail := range h.tails {
+		if tail.info.NotifySessionID == strings.TrimSpace(notifySessionID) {
+			out = append(out, tail.info)
+		}
+	}
+	return out
+}
+
+// publish queues entries for every tail of the game session.
+func (h *RuntimeLogTailHub) publish(sessionID string, entries []RuntimeLogEntry) {
+	if h == nil || len(entries) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, tail := range h.tails {
+		if tail.info.SessionID == sessionID {
+			h.enqueueLocked(tail, entries)
+		}
+	}
+}
+
+func (h *RuntimeLogTailHub) enqueueLocked(tail *runtimeLogTail, entries []RuntimeLogEntry) {
+	added := false
+	for _, entry := range entries {
+		if tail.info.Level != "all" && normalizeLogLevel(entry.Level) != tail.info.Level {
+			continue
+		}
+		if tail.queued >= h.queueSize {
+			tail.openGap = mergeRuntimeLogGap(tail.openGap, &RuntimeLogGap{FromSequence: entry.Sequence, ToSequence: entry.Sequence, Dropped: 1})
+			tail.info.Dropped++
+			continue
+		}
+		if tail.openGap != nil || len(tail.queue) == 0 {
+			tail.queue = append(tail.queue, runtimeLogTailBatch{gap: tail.openGap})
+			tail.openGap = nil
+		}
+		last := &tail.queue[len(tail.queue)-1]
+		last.entries = append(last.entries, entry)
+		tail.queued++
+		added = true
+	}
+	if added {
+		select {
+		case tail.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *RuntimeLogTailHub) deliver(tail *runtimeLogTail) {
+	for {
+		select {
+		case <-tail.done:
+			return
+		case <-tail.wake:
+		}
+		h.mu.Lock()
+		batches := tail.queue
+		tail.queue = nil
+		tail.queued = 0
+		h.mu.Unlock()
+
+	drain:
+		for index, batch := range batches {
+			for start := 0; start < len(batch.entries); start += runtimeLogTailBatchSize {
+				chunk := batch.entries[start:min(start+runtimeLogTailBatchSize, len(batch.entries))]
+				gap := batch.gap
+				if start > 0 {
+					gap = nil
+				}
+				if h.send(tail, gap, chunk) {
+					continue
+				}
+				// Report everything left in this drain as one gap on the next delivery.
+				lost := len(batch.entries) - start
+				failed := mergeRuntimeLogGap(gap, &RuntimeLogGap{
+					FromSequence: chunk[0].Sequence,
+					ToSequence:   batch.entries[len(batch.entries)-1].Sequence,
+					Dropped:      lost,
+				})
+				for _, rest := range batches[index+1:] {
+					failed = mergeRuntimeLogGap(failed, rest.gap)
+					if len(rest.entries) > 0 {
+						lost += len(rest.entries)
+						failed = mergeRuntimeLogGap(failed, &RuntimeLogGap{
+							FromSequence: rest.entries[0].Sequence,
+							ToSequence:   rest.entries[len(rest.entries)-1].Sequence,
+							Dropped:      len(rest.entries),
+						})
+					}
+				}
+				h.requeueGap(tail, failed, lost)
+				break drain
+			}
+			if h.isStopped(tail) {
+				return
+			}
+		}
+	}
+}
+
+func (h *RuntimeLogTailHub) send(tail *runtimeLogTail, gap *RuntimeLogGap, entries []RuntimeLogEntry) bool {
+	params := map[string]any{
+		"tail_id":    tail.info.TailID,
+		"session_id": tail.info.SessionID,
+		"entries":    entries,
+	}
+	if gap != nil {
+		params["gap"] = gap
+	}
+	// Count before sending so a notification is never observed ahead of its
+	// counter; a failed send rolls the count back.
+	h.mu.Lock()
+	tail.info.Delivered += int64(len(entries))
+	h.mu.Unlock()
+	ok := sendToSession(tail.info.NotifySessionID, map[string]any{
+		"jsonrpc": "2.0",
+		"method":  RuntimeLogTailMethodName,
+		"params":  params,
+	})
+	if !ok {
+		h.mu.Lock()
+		tail.info.Delivered -= int64(len(entries))
+		h.mu.Unlock()
+	}
+	return ok
+}
+
+// requeueGap attaches a failed delivery to the oldest pending batch, or keeps
+// it open for the next entry when nothing is queued.
+func (h *RuntimeLogTailHub) requeueGap(tail *runtimeLogTail, gap *RuntimeLogGap, lost int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	tail.info.Dropped += int64(lost)
+	if len(tail.queue) > 0 {
+		tail.queue[0].gap = mergeRuntimeLogGap(gap, tail.queue[0].gap)
+		return
+	}
+	tail.openGap = mergeRuntimeLogGap(gap, tail.openGap)
+}
+
+func (h *RuntimeLogTailHub) isStopped(tail *runtimeLogTail) bool {
+	select {
+	case <-tail.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *RuntimeLogTailHub) removeLocked(tail *runtimeLogTail) {
+	delete(h.tails, tail.info.TailID)
+	close(tail.done)
+}
+
+// RemoveSession ends every tail of a game session.
+func (h *RuntimeLogTailHub) RemoveSession(sessionID string) {
+	if h == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, tail := range h.tails {
+		if tail.info.SessionID == strings.TrimSpace(sessionID) {
+			h.removeLocked(tail)
+		}
+	}
+}
+
+// RemoveSubscriber ends every tail delivering to an MCP session.
+func (h *RuntimeLogTailHub) RemoveSubscriber(notifySessionID string) {
+	if h == nil || strings.TrimSpace(notifySessionID) == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, tail := range h.tails {
+		if tail.info.NotifySessionID == strings.TrimSpace(notifySessionID) {
+			h.removeLocked(tail)
+		}
+	}
+}
+
+func (h *RuntimeLogTailHub) stopAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, tail := range h.tails {
+		h.removeLocked(tail)
+	}
+}
+
+func (h *RuntimeLogTailHub) Health() map[string]any {
+	if h == nil {
+		return map[string]any{
+			"tails": 0,
+		}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var delivered, dropped int64
+	queued := 0
+	for _, tail := range h.tails {
+		delivered += tail.info.Delivered
+		dropped += tail.info.Dropped
+		queued += tail.queued
+	}
+	return map[string]any{
+		"tails":      len(h.tails),
+		"queue_size": h.queueSize,
+		"queued":     queued,
+		"delivered":  delivered,
+		"dropped":    dropped,
+	}
+}
+
+// mergeRuntimeLogGap combines two gaps into one covering both sequence ranges.
+func mergeRuntimeLogGap(left *RuntimeLogGap, right *RuntimeLogGap) *RuntimeLogGap {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	return &RuntimeLogGap{
+		FromSequence: min(left.FromSequence, right.FromSequence),
+		ToSequence:   max(left.ToSequence, right.ToSequence),
+		Dropped:      left.Dropped + right.Dropped,
+	}
+}
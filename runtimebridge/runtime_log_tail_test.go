@@ -0,0 +1,155 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func receiveTailNotification(t *testing.T, messages <-chan map[string]any) map[string]any {
+	t.Helper()
+	select {
+	case message := <-messages:
+		return message["params"].(map[string]any)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for runtime log notification")
+		return nil
+	}
+}
+
+func TestRuntimeLogTail_ReplaysAndStreamsFilteredEntries(t *testing.T) {
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultRuntimeLogTailHubForTests(100)
+	messages := make(chan map[string]any, 16)
+	SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		if sessionID != "mcp-1" || message["method"] != RuntimeLogTailMethodName {
+			t.Errorf("unexpected notification for %s: %v", sessionID, message)
+		}
+		messages <- message
+		return true
+	})
+	defer SetNotificationSender(nil)
+
+	store := DefaultRuntimeLogStore()
+	now := time.Now().UTC()
+	store.Append("game_1", []RuntimeLogAppendEntry{
+		{Level: "error", Message: "old-1"},
+		{Level: "error", Message: "old-2"},
+	}, now)
+	tail, err := store.StartTail("game_1", "mcp-1", "error", true, 1, now)
+	if err != nil {
+		t.Fatalf("start tail: %v", err)
+	}
+	first := receiveTailNotification(t, messages)
+	entries := first["entries"].([]RuntimeLogEntry)
+	if first["tail_id"] != tail.TailID || len(entries) != 1 || entries[0].Message != "old-2" {
+		t.Fatalf("expected replay of entries after sequence 1, got %v", first)
+	}
+
+	store.Append("game_1", []RuntimeLogAppendEntry{
+		{Level: "info", Message: "skipped"},
+		{Level: "error", Message: "new"},
+	}, now)
+	second := receiveTailNotification(t, messages)
+	entries = second["entries"].([]RuntimeLogEntry)
+	if len(entries) != 1 || entries[0].Message != "new" || second["gap"] != nil {
+		t.Fatalf("expected one filtered live entry without gap, got %v", second)
+	}
+
+	stopped, ok := DefaultRuntimeLogTailHub().Stop(tail.TailID, "mcp-2")
+	if ok {
+		t.Fatalf("expected other MCP sessions not to stop the tail, got %+v", stopped)
+	}
+	stopped, ok = DefaultRuntimeLogTailHub().Stop(tail.TailID, "mcp-1")
+	if !ok || stopped.Delivered != 2 {
+		t.Fatalf("expected stopped tail with 2 delivered entries, got %+v %v", stopped, ok)
+	}
+}
+
+func TestRuntimeLogTail_ReportsOverflowAsGap(t *testing.T) {
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultRuntimeLogTailHubForTests(2)
+	release := make(chan struct{})
+	messages := make(chan map[string]any, 16)
+	SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		<-release
+		messages <- message
+		return true
+	})
+	defer SetNotificationSender(nil)
+
+	store := DefaultRuntimeLogStore()
+	now := time.Now().UTC()
+	if _, err := store.StartTail("game_1", "mcp-1", "", false, 0, now); err != nil {
+		t.Fatalf("start tail: %v", err)
+	}
+	// seq 1 is picked up by the blocked sender; 2-3 fill the queue; 4-6 overflow.
+	store.Append("game_1", []RuntimeLogAppendEntry{{Message: "1"}}, now)
+	deadline := time.Now().Add(2 * time.Second)
+	for DefaultRuntimeLogTailHub().Health()["queued"].(int) != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	for _, message := range []string{"2", "3", "4", "5", "6"} {
+		store.Append("game_1", []RuntimeLogAppendEntry{{Message: message}}, now)
+	}
+	close(release)
+
+	receiveTailNotification(t, messages)
+	queued := receiveTailNotification(t, messages)
+	if len(queued["entries"].([]RuntimeLogEntry)) != 2 || queued["gap"] != nil {
+		t.Fatalf("expected queued entries 2-3 without gap, got %v", queued)
+	}
+	store.Append("game_1", []RuntimeLogAppendEntry{{Message: "7"}}, now)
+	resumed := receiveTailNotification(t, messages)
+	gap, ok := resumed["gap"].(*RuntimeLogGap)
+	if !ok || gap.FromSequence != 4 || gap.ToSequence != 6 || gap.Dropped != 3 {
+		t.Fatalf("expected gap 4..6 before entry 7, got %v", resumed)
+	}
+	entries := resumed["entries"].([]RuntimeLogEntry)
+	if len(entries) != 1 || entries[0].Sequence != 7 {
+		t.Fatalf("expected entry 7 after the gap, got %v", entries)
+	}
+}
+
+func TestRuntimeLogTail_FailedDeliveryBecomesGap(t *testing.T) {
+	ResetDefaultRuntimeLogStoreForTests(100)
+	ResetDefaultRuntimeLogTailHubForTests(100)
+	connected := make(chan bool, 1)
+	connected <- false
+	messages := make(chan map[string]any, 16)
+	SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		ok := <-connected
+		connected <- true
+		if ok {
+			messages <- message
+		}
+		return ok
+	})
+	defer SetNotificationSender(nil)
+
+	store := DefaultRuntimeLogStore()
+	now := time.Now().UTC()
+	tail, err := store.StartTail("game_1", "mcp-1", "all", false, 0, now)
+	if err != nil {
+		t.Fatalf("start tail: %v", err)
+	}
+	store.Append("game_1", []RuntimeLogAppendEntry{{Message: "lost-1"}, {Message: "lost-2"}}, now)
+	deadline := time.Now().Add(2 * time.Second)
+	for len(DefaultRuntimeLogTailHub().List("mcp-1")) == 1 && DefaultRuntimeLogTailHub().List("mcp-1")[0].Dropped == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	store.Append("game_1", []RuntimeLogAppendEntry{{Message: "kept"}}, now)
+
+	params := receiveTailNotification(t, messages)
+	gap, ok := params["gap"].(*RuntimeLogGap)
+	if !ok || gap.FromSequence != 1 || gap.ToSequence != 2 || gap.Dropped != 2 {
+		t.Fatalf("expected failed delivery reported as gap 1..2, got %v", params)
+	}
+	if info := DefaultRuntimeLogTailHub().List("mcp-1"); len(info) != 1 || info[0].TailID != tail.TailID || info[0].Dropped != 2 {
+		t.Fatalf("expected dropped counter on tail, got %+v", info)
+	}
+
+	DefaultRuntimeLogTailHub().RemoveSubscriber("mcp-1")
+	if len(DefaultRuntimeLogTailHub().List("mcp-1")) != 0 {
+		t.Fatal("expected subscriber removal to end its tails")
+	}
+}
@@ -0,0 +1,312 @@
+package runtimebridge
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultRuntimePerformanceCapacity = 600
+
+// RuntimePerformanceMonitors lists the monitor keys the runtime companion
+// reads from Godot's Performance singleton, in response order.
+var RuntimePerformanceMonitors = []string{
+	"fps",
+	"frame_time_ms",
+	"physics_time_ms",
+	"navigation_time_ms",
+	"memory_static_bytes",
+	"memory_static_max_bytes",
+	"object_count",
+	"resource_count",
+	"node_count",
+	"orphan_node_count",
+	"draw_calls",
+	"render_objects",
+	"render_primitives",
+	"video_memory_bytes",
+	"physics_2d_active_objects",
+	"physics_3d_active_objects",
+}
+
+var defaultRuntimePerformanceStore atomic.Pointer[RuntimePerformanceStore]
+
+func init() {
+	defaultRuntimePerformanceStore.Store(NewRuntimePerformanceStore(defaultRuntimePerformanceCapacity))
+}
+
+// RuntimePerformanceStore keeps the sampling state and a bounded series of
+// Performance monitor samples per game session.
+type RuntimePerformanceStore struct {
+	mu       sync.RWMutex
+	capacity int
+	bySess   map[string]*runtimePerformanceSeries
+}
+
+type runtimePerformanceSeries struct {
+	sampling RuntimePerformanceSampling
+	samples  []RuntimePerformanceSample
+	nextSeq  int64
+}
+
+func NewRuntimePerformanceStore(capacity int) *RuntimePerformanceStore {
+	if capacity <= 0 {
+		capacity = defaultRuntimePerformanceCapacity
+	}
+	return &RuntimePerformanceStore{
+		capacity: capacity,
+		bySess:   make(map[string]*runtimePerformanceSeries),
+	}
+}
+
+func DefaultRuntimePerformanceStore() *RuntimePerformanceStore {
+	if store := defaultRuntimePerformanceStore.Load(); store != nil {
+		return store
+	}
+	store := NewRuntimePerformanceStore(defaultRuntimePerformanceCapacity)
+	if defaultRuntimePerformanceStore.CompareAndSwap(nil, store) {
+		return store
+	}
+	return defaultRuntimePerformanceStore.Load()
+}
+
+func ResetDefaultRuntimePerformanceStoreForTests(capacity int) {
+	defaultRuntimePerformanceStore.Store(NewRuntimePerformanceStore(capacity))
+}
+
+// IsRuntimePerformanceMonitor reports whether name is a supported monitor key.
+func IsRuntimePerformanceMonitor(name string) bool {
+	return slices.Contains(RuntimePerformanceMonitors, name)
+}
+
+// Start marks sampling active for the session. Samples retained from an
+// earlier sampling run are kept so a restart continues the same series.
+func (s *RuntimePerformanceStore) Start(sessionID string, intervalMS int, monitors []string, now time.Time) RuntimePerformanceSampling {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return RuntimePerformanceSampling{}
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series := s.bySess[sessionID]
+	if series == nil {
+		series = &runtimePerformanceSeries{nextSeq: 1}
+		s.bySess[sessionID] = series
+	}
+	series.sampling = RuntimePerformanceSampling{
+		Active:     true,
+		IntervalMS: intervalMS,
+		Monitors:   slices.Clone(monitors),
+		StartedAt:  now.Format(time.RFC3339Nano),
+	}
+	return series.sampling
+}
+
+// Stop marks sampling inactive and keeps the retained samples readable. It
+// returns false when sampling was never started for the session.
+func (s *RuntimePerformanceStore) Stop(sessionID string, now time.Time) (RuntimePerformanceSampling, bool) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return RuntimePerformanceSampling{}, false
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)]
+	if !ok {
+		return RuntimePerformanceSampling{}, false
+	}
+	if series.sampling.Active {
+		series.sampling.Active = false
+		series.sampling.StoppedAt = now.Format(time.RFC3339Nano)
+	}
+	return series.sampling, true
+}
+
+func (s *RuntimePerformanceStore) Sampling(sessionID string) (RuntimePerformanceSampling, bool) {
+	if s == nil {
+		return RuntimePerformanceSampling{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)]
+	if !ok {
+		return RuntimePerformanceSampling{}, false
+	}
+	return series.sampling, true
+}
+
+// Append stores pushed samples while sampling is active and returns how many
+// were kept. Unknown monitor keys are dropped from each sample.
+func (s *RuntimePerformanceStore) Append(sessionID string, samples []RuntimePerformanceAppendSample, now time.Time) int {
+	if s == nil || strings.TrimSpace(sessionID) == "" || len(samples) == 0 {
+		return 0
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)]
+	if !ok || !series.sampling.Active {
+		return 0
+	}
+	appended := 0
+	for _, in := range samples {
+		monitors := make(map[string]float64, len(in.Monitors))
+		for name, value := range in.Monitors {
+			if IsRuntimePerformanceMonitor(name) && !math.IsNaN(value) && !math.IsInf(value, 0) {
+				monitors[name] = value
+			}
+		}
+		if len(monitors) == 0 {
+			continue
+		}
+		ts := strings.TrimSpace(in.Time)
+		if ts == "" {
+			ts = now.Format(time.RFC3339Nano)
+		}
+		series.samples = append(series.samples, RuntimePerformanceSample{
+			Sequence: series.nextSeq,
+			Time:     ts,
+			Frame:    in.Frame,
+			Monitors: monitors,
+		})
+		series.nextSeq++
+		appended++
+	}
+	if len(series.samples) > s.capacity {
+		series.samples = series.samples[len(series.samples)-s.capacity:]
+	}
+	return appended
+}
+
+// Series returns up to limit samples newer than sinceSequence (oldest first),
+// narrowed to monitors when given, and per-monitor stats over all retained
+// samples.
+func (s *RuntimePerformanceStore) Series(sessionID string, monitors []string, sinceSequence int64, limit int) (RuntimePerformanceSampling, []RuntimePerformanceSample, map[string]RuntimePerformanceStats, bool) {
+	if s == nil {
+		return RuntimePerformanceSampling{}, nil, nil, false
+	}
+	if limit <= 0 {
+		limit = 200
+	}
+	if limit > s.capacity {
+		limit = s.capacity
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)]
+	if !ok {
+		return RuntimePerformanceSampling{}, nil, nil, false
+	}
+	samples := make([]RuntimePerformanceSample, 0, min(limit, len(series.samples)))
+	for _, sample := range series.samples {
+		if sample.Sequence <= sinceSequence {
+			continue
+		}
+		samples = append(samples, filterRuntimePerformanceSample(sample, monitors))
+		if len(samples) == limit {
+			break
+		}
+	}
+	return series.sampling, samples, runtimePerformanceStats(series.samples, monitors), true
+}
+
+func (s *RuntimePerformanceStore) RemoveSession(sessionID string) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bySess, strings.TrimSpace(sessionID))
+}
+
+func (s *RuntimePerformanceStore) Health() map[string]any {
+	if s == nil {
+		return map[string]any{
+			"capacity": 0,
+			"sessions": 0,
+			"sampling": 0,
+			"samples":  0,
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sampling := 0
+	samples := 0
+	for _, series := range s.bySess {
+		if series.sampling.Active {
+			sampling++
+		}
+		samples += len(series.samples)
+	}
+	return map[string]any{
+		"capacity": s.capacity,
+		"sessions": len(s.bySess),
+		"sampling": sampling,
+		"samples":  samples,
+	}
+}
+
+func filterRuntimePerformanceSample(sample RuntimePerformanceSample, monitors []string) RuntimePerformanceSample {
+	if len(monitors) == 0 {
+		return sample
+	}
+	filtered := make(map[string]float64, len(monitors))
+	for _, name := range monitors {
+		if value, ok := sample.Monitors[name]; ok {
+			filtered[name] = value
+		}
+	}
+	sample.Monitors = filtered
+	return sample
+}
+
+// runtimePerformanceStats summarizes each monitor present in samples. P95 uses
+// the nearest-rank method over the retained values.
+func runtimePerformanceStats(samples []RuntimePerformanceSample, monitors []string) map[string]RuntimePerformanceStats {
+	values := make(map[string][]float64)
+	for _, sample := range samples {
+		for name, value := range sample.Monitors {
+			if len(monitors) > 0 && !slices.Contains(monitors, name) {
+				continue
+			}
+			values[name] = append(values[name], value)
+		}
+	}
+	stats := make(map[string]RuntimePerformanceStats, len(values))
+	for name, series := range values {
+		item := RuntimePerformanceStats{
+			Count: len(series),
+			Min:   series[0],
+			Max:   series[0],
+			Last:  series[len(series)-1],
+		}
+		sum := 0.0
+		for _, value := range series {
+			item.Min = min(item.Min, value)
+			item.Max = max(item.Max, value)
+			sum += value
+		}
+		item.Avg = sum / float64(len(series))
+		sorted := slices.Clone(series)
+		sort.Float64s(sorted)
+		rank := int(math.Ceil(0.95*float64(len(sorted)))) - 1
+		item.P95 = sorted[max(rank, 0)]
+		stats[name] = item
+	}
+	return stats
+}
@@ -0,0 +1,60 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuntimePerformanceStore_CapsSamplesAndSummarizesMonitors(t *testing.T) {
+	store := NewRuntimePerformanceStore(4)
+	now := time.Now().UTC()
+
+	if appended := store.Append("game_1", []RuntimePerformanceAppendSample{{Frame: 1, Monitors: map[string]float64{"fps": 60}}}, now); appended != 0 {
+		t.Fatalf("expected samples to be dropped before sampling starts, got %d", appended)
+	}
+
+	store.Start("game_1", 500, nil, now)
+	samples := []RuntimePerformanceAppendSample{}
+	for i, fps := range []float64{10, 60, 30, 50, 40} {
+		samples = append(samples, RuntimePerformanceAppendSample{
+			Frame:    int64(i + 1),
+			Monitors: map[string]float64{"fps": fps, "draw_calls": float64(i), "unknown_monitor": 1},
+		})
+	}
+	if appended := store.Append("game_1", samples, now); appended != 5 {
+		t.Fatalf("expected 5 appended samples, got %d", appended)
+	}
+
+	sampling, series, stats, ok := store.Series("game_1", []string{"fps"}, 0, 10)
+	if !ok || !sampling.Active || sampling.IntervalMS != 500 {
+		t.Fatalf("unexpected sampling state: %+v", sampling)
+	}
+	if len(series) != 4 || series[0].Frame != 2 || series[0].Sequence != 2 {
+		t.Fatalf("expected the oldest sample to be evicted, got %+v", series)
+	}
+	if _, ok := series[0].Monitors["draw_calls"]; ok {
+		t.Fatalf("expected samples narrowed to fps, got %+v", series[0].Monitors)
+	}
+	fps, ok := stats["fps"]
+	if !ok || len(stats) != 1 {
+		t.Fatalf("expected fps stats only, got %+v", stats)
+	}
+	if fps.Count != 4 || fps.Min != 30 || fps.Max != 60 || fps.Avg != 45 || fps.P95 != 60 || fps.Last != 40 {
+		t.Fatalf("unexpected fps stats: %+v", fps)
+	}
+
+	if sampling, ok := store.Stop("game_1", now); !ok || sampling.Active || sampling.StoppedAt == "" {
+		t.Fatalf("expected sampling to stop, got %+v", sampling)
+	}
+	if appended := store.Append("game_1", samples, now); appended != 0 {
+		t.Fatalf("expected samples to be dropped after sampling stops, got %d", appended)
+	}
+	if _, series, _, _ := store.Series("game_1", nil, 4, 10); len(series) != 1 || series[0].Sequence != 5 {
+		t.Fatalf("expected retained samples after stop, got %+v", series)
+	}
+
+	store.RemoveSession("game_1")
+	if _, ok := store.Sampling("game_1"); ok {
+		t.Fatal("expected session to be removed")
+	}
+}
@@ -0,0 +1,405 @@
+package runtimebridge
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultRuntimeScreenshotRetention = 16
+	DefaultRuntimeScreenshotMaxBytes  = 16 << 20
+	// maxScreenshotPixels guards against small PNGs that decode to huge images.
+	maxScreenshotPixels = 8192 * 8192
+)
+
+var (
+	ErrScreenshotTooLarge        = errors.New("screenshot exceeds size limit")
+	ErrInvalidScreenshotBaseline = errors.New("baseline name must match [A-Za-z0-9._-]{1,64}")
+	ErrScreenshotBaselineMissing = errors.New("screenshot baseline does not exist")
+	ErrScreenshotPathOutsideDir  = errors.New("screenshot path must be a regular file in the session's capture directory")
+	ErrScreenshotNotPNG          = errors.New("screenshot file is not a PNG")
+
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+	screenshotBaselineNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+	defaultRuntimeScreenshotStore atomic.Pointer[RuntimeScreenshotStore]
+)
+
+func init() {
+	defaultRuntimeScreenshotStore.Store(NewRuntimeScreenshotStore(DefaultRuntimeScreenshotRetention, DefaultRuntimeScreenshotMaxBytes, "", ""))
+}
+
+// RuntimeScreenshotStore retains recent PNG screenshots per game session and
+// manages named baseline images on disk.
+type RuntimeScreenshotStore struct {
+	mu          sync.RWMutex
+	retention   int
+	maxBytes    int
+	baselineDir string
+	// captureDir holds one private directory per game session that the
+	// companion writes screenshots into; only files there are ever read.
+	captureDir string
+	// bySess keeps retained screenshots per session, oldest first.
+	bySess map[string][]RuntimeScreenshot
+	nextID map[string]int64
+}
+
+func NewRuntimeScreenshotStore(retention int, maxBytes int, baselineDir string, captureDir string) *RuntimeScreenshotStore {
+	store := &RuntimeScreenshotStore{
+		bySess: make(map[string][]RuntimeScreenshot),
+		nextID: make(map[string]int64),
+	}
+	store.Configure(retention, maxBytes, baselineDir, captureDir)
+	return store
+}
+
+func DefaultRuntimeScreenshotStore() *RuntimeScreenshotStore {
+	if store := defaultRuntimeScreenshotStore.Load(); store != nil {
+		return store
+	}
+	store := NewRuntimeScreenshotStore(DefaultRuntimeScreenshotRetention, DefaultRuntimeScreenshotMaxBytes, "", "")
+	if defaultRuntimeScreenshotStore.CompareAndSwap(nil, store) {
+		return store
+	}
+	return defaultRuntimeScreenshotStore.Load()
+}
+
+func ResetDefaultRuntimeScreenshotStoreForTests(retention int, maxBytes int, baselineDir string, captureDir string) {
+	defaultRuntimeScreenshotStore.Store(NewRuntimeScreenshotStore(retention, maxBytes, baselineDir, captureDir))
+}
+
+// Configure sets retention, the PNG size limit, the baseline directory and the
+// capture directory. An empty baseline directory falls back to
+// ~/.godot-mcp/screenshot-baselines; an empty capture directory falls back to
+// <user cache dir>/godot-mcp/screenshots, or a private temporary directory.
+func (s *RuntimeScreenshotStore) Configure(retention int, maxBytes int, baselineDir string, captureDir string) {
+	if s == nil {
+		return
+	}
+	if retention <= 0 {
+		retention = DefaultRuntimeScreenshotRetention
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultRuntimeScreenshotMaxBytes
+	}
+	baselineDir = strings.TrimSpace(baselineDir)
+	if baselineDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil || home == "" {
+			home = os.TempDir()
+		}
+		baselineDir = filepath.Join(home, ".godot-mcp", "screenshot-baselines")
+	}
+	captureDir = strings.TrimSpace(captureDir)
+	if captureDir == "" {
+		if cache, err := os.UserCacheDir(); err == nil && strings.TrimSpace(cache) != "" {
+			captureDir = filepath.Join(cache, "godot-mcp", "screenshots")
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.retention = retention
+	s.maxBytes = maxBytes
+	s.baselineDir = baselineDir
+	s.captureDir = captureDir
+	for sessionID, items := range s.bySess {
+		if len(items) > retention {
+			s.bySess[sessionID] = append([]RuntimeScreenshot(nil), items[len(items)-retention:]...)
+		}
+	}
+}
+
+// Add validates and retains one PNG screenshot, evicting the oldest entries
+// beyond the retention limit.
+func (s *RuntimeScreenshotStore) Add(sessionID string, data []byte, frame int64, now time.Time) (RuntimeScreenshot, error) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return RuntimeScreenshot{}, errors.New("screenshot store requires a session id")
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	if err := s.checkSize(len(data)); err != nil {
+		return RuntimeScreenshot{}, err
+	}
+	img, err := DecodeScreenshotPNG(data)
+	if err != nil {
+		return RuntimeScreenshot{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID[sessionID]++
+	shot := RuntimeScreenshot{
+		ScreenshotID: fmt.Sprintf("shot_%d", s.nextID[sessionID]),
+		SessionID:    sessionID,
+		Frame:        frame,
+		Width:        img.Bounds().Dx(),
+		Height:       img.Bounds().Dy(),
+		Bytes:        len(data),
+		CapturedAt:   now.UTC().Format(time.RFC3339Nano),
+		PNG:          append([]byte(nil), data...),
+	}
+	items := append(s.bySess[sessionID], shot)
+	if len(items) > s.retention {
+		items = append([]RuntimeScreenshot(nil), items[len(items)-s.retention:]...)
+	}
+	s.bySess[sessionID] = items
+	return shot, nil
+}
+
+// Get returns a retained screenshot, or the latest one when screenshotID is empty.
+func (s *RuntimeScreenshotStore) Get(sessionID string, screenshotID string) (RuntimeScreenshot, bool) {
+	if s == nil {
+		return RuntimeScreenshot{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := s.bySess[strings.TrimSpace(sessionID)]
+	if len(items) == 0 {
+		return RuntimeScreenshot{}, false
+	}
+	screenshotID = strings.TrimSpace(screenshotID)
+	if screenshotID == "" {
+		return items[len(items)-1], true
+	}
+	for _, item := range items {
+		if item.ScreenshotID == screenshotID {
+			return item, true
+		}
+	}
+	return RuntimeScreenshot{}, false
+}
+
+// List returns retained screenshot metadata oldest first.
+func (s *RuntimeScreenshotStore) List(sessionID string) []RuntimeScreenshot {
+	if s == nil {
+		return []RuntimeScreenshot{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	items := s.bySess[strings.TrimSpace(sessionID)]
+	out := make([]RuntimeScreenshot, 0, len(items))
+	for _, item := range items {
+		item.PNG = nil
+		out = append(out, item)
+	}
+	return out
+}
+
+// SaveBaseline writes PNG data as the named baseline and returns its path.
+func (s *RuntimeScreenshotStore) SaveBaseline(name string, data []byte) (string, error) {
+	path, err := s.BaselinePath(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadBaseline reads the named baseline. A missing file returns
+// ErrScreenshotBaselineMissing.
+func (s *RuntimeScreenshotStore) LoadBaseline(name string) ([]byte, string, error) {
+	path, err := s.BaselinePath(name)
+	if err != nil {
+		return nil, "", err
+	}
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, path, ErrScreenshotBaselineMissing
+	}
+	if err != nil {
+		return nil, path, err
+	}
+	if err := s.checkSize(int(info.Size())); err != nil {
+		return nil, path, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, path, err
+	}
+	return data, path, nil
+}
+
+// BaselinePath validates name and resolves its file under the baseline directory.
+func (s *RuntimeScreenshotStore) BaselinePath(name string) (string, error) {
+	if s == nil {
+		return "", errors.New("screenshot store is not configured")
+	}
+	name = strings.TrimSpace(name)
+	if !screenshotBaselineNamePattern.MatchString(name) || strings.Trim(name, ".") == "" {
+		return "", ErrInvalidScreenshotBaseline
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return filepath.Join(s.baselineDir, name+".png"), nil
+}
+
+// CaptureDir creates and returns the private directory the companion of
+// sessionID writes screenshots into.
+func (s *RuntimeScreenshotStore) CaptureDir(sessionID string) (string, error) {
+	if s == nil {
+		return "", errors.New("screenshot store is not configured")
+	}
+	sessionID = strings.TrimSpace(sessionID)
+	if !gameSessionIDPattern.MatchString(sessionID) {
+		return "", ErrInvalidGameSessionID
+	}
+	s.mu.Lock()
+	if s.captureDir == "" {
+		dir, err := os.MkdirTemp("", "godot-mcp-screenshots-")
+		if err != nil {
+			s.mu.Unlock()
+			return "", err
+		}
+		s.captureDir = dir
+	}
+	root := s.captureDir
+	s.mu.Unlock()
+	if err := ensurePrivateDir(root); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(root, sessionID)
+	if err := ensurePrivateDir(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// ReadScreenshotFile reads a PNG the companion of sessionID wrote into its
+// capture directory. The path comes from the runtime, so anything outside
+// that directory, symlinks, oversized files and non-PNG content are refused
+// before the file is loaded.
+func (s *RuntimeScreenshotStore) ReadScreenshotFile(sessionID string, path string) ([]byte, error) {
+	if s == nil {
+		return nil, errors.New("screenshot store is not configured")
+	}
+	dir, err := s.CaptureDir(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	path = filepath.Clean(path)
+	if !filepath.IsAbs(path) || filepath.Dir(path) != dir {
+		return nil, fmt.Errorf("%w: %s", ErrScreenshotPathOutsideDir, path)
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %s", ErrScreenshotPathOutsideDir, path)
+	}
+	if err := s.checkSize(int(info.Size())); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	header := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(file, header); err != nil || !bytes.Equal(header, pngSignature) {
+		return nil, ErrScreenshotNotPNG
+	}
+	s.mu.RLock()
+	limit := int64(s.maxBytes)
+	s.mu.RUnlock()
+	rest, err := io.ReadAll(io.LimitReader(file, limit-int64(len(header))+1))
+	if err != nil {
+		return nil, err
+	}
+	data := append(header, rest...)
+	if err := s.checkSize(len(data)); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *RuntimeScreenshotStore) RemoveSession(sessionID string) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bySess, strings.TrimSpace(sessionID))
+	delete(s.nextID, strings.TrimSpace(sessionID))
+}
+
+func (s *RuntimeScreenshotStore) Health() map[string]any {
+	if s == nil {
+		return map[string]any{
+			"sessions":    0,
+			"screenshots": 0,
+			"bytes":       0,
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	screenshots := 0
+	total := 0
+	for _, items := range s.bySess {
+		screenshots += len(items)
+		for _, item := range items {
+			total += item.Bytes
+		}
+	}
+	return map[string]any{
+		"sessions":    len(s.bySess),
+		"screenshots": screenshots,
+		"bytes":       total,
+		"retention":   s.retention,
+		"max_bytes":   s.maxBytes,
+	}
+}
+
+func (s *RuntimeScreenshotStore) checkSize(size int) error {
+	s.mu.RLock()
+	limit := s.maxBytes
+	s.mu.RUnlock()
+	if size > limit {
+		return fmt.Errorf("%w: %d bytes (limit %d)", ErrScreenshotTooLarge, size, limit)
+	}
+	return nil
+}
+
+// DecodeScreenshotPNG decodes PNG bytes, rejecting empty, non-PNG or oversized
+// input.
+func DecodeScreenshotPNG(data []byte) (image.Image, error) {
+	if len(data) == 0 {
+		return nil, errors.New("screenshot is empty")
+	}
+	config, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode screenshot png: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxScreenshotPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrScreenshotTooLarge, config.Width, config.Height)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode screenshot png: %w", err)
+	}
+	return img, nil
+}
@@ -0,0 +1,126 @@
+package runtimebridge
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encodeTestPNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRuntimeScreenshotStore_RetainsRecentScreenshotsAndEnforcesLimits(t *testing.T) {
+	store := NewRuntimeScreenshotStore(2, 4096, t.TempDir(), filepath.Join(t.TempDir(), "captures"))
+	now := time.Now().UTC()
+	data := encodeTestPNG(t, 8, 4)
+
+	for frame := int64(1); frame <= 3; frame++ {
+		if _, err := store.Add("game_1", data, frame, now); err != nil {
+			t.Fatalf("add frame %d: %v", frame, err)
+		}
+	}
+	items := store.List("game_1")
+	if len(items) != 2 || items[0].ScreenshotID != "shot_2" || items[1].Frame != 3 {
+		t.Fatalf("expected the oldest screenshot to be evicted, got %+v", items)
+	}
+	if items[0].Width != 8 || items[0].Height != 4 || items[0].PNG != nil {
+		t.Fatalf("unexpected listed metadata: %+v", items[0])
+	}
+	latest, ok := store.Get("game_1", "")
+	if !ok || latest.ScreenshotID != "shot_3" || len(latest.PNG) == 0 {
+		t.Fatalf("expected latest screenshot with png bytes, got %+v", latest)
+	}
+	if _, ok := store.Get("game_1", "shot_1"); ok {
+		t.Fatal("expected evicted screenshot to be unavailable")
+	}
+
+	if _, err := store.Add("game_1", []byte("not a png"), 4, now); err == nil {
+		t.Fatal("expected decode error for invalid png")
+	}
+	if _, err := store.Add("game_1", make([]byte, 4097), 4, now); !errors.Is(err, ErrScreenshotTooLarge) {
+		t.Fatalf("expected size limit error, got %v", err)
+	}
+
+	store.RemoveSession("game_1")
+	if health := store.Health(); health["screenshots"] != 0 {
+		t.Fatalf("expected empty store after session removal, got %+v", health)
+	}
+}
+
+func TestRuntimeScreenshotStore_SavesAndLoadsNamedBaselines(t *testing.T) {
+	store := NewRuntimeScreenshotStore(4, 4096, t.TempDir(), filepath.Join(t.TempDir(), "captures"))
+	data := encodeTestPNG(t, 2, 2)
+
+	if _, _, err := store.LoadBaseline("main_menu"); !errors.Is(err, ErrScreenshotBaselineMissing) {
+		t.Fatalf("expected missing baseline error, got %v", err)
+	}
+	if _, err := store.SaveBaseline("main_menu", data); err != nil {
+		t.Fatalf("save baseline: %v", err)
+	}
+	loaded, _, err := store.LoadBaseline("main_menu")
+	if err != nil || !bytes.Equal(loaded, data) {
+		t.Fatalf("expected saved baseline bytes, err=%v", err)
+	}
+	for _, name := range []string{"../escape", "a/b", "..", ""} {
+		if _, err := store.SaveBaseline(name, data); !errors.Is(err, ErrInvalidScreenshotBaseline) {
+			t.Fatalf("expected invalid baseline name error for %q, got %v", name, err)
+		}
+	}
+}
+
+func TestRuntimeScreenshotStore_ReadScreenshotFileStaysInCaptureDir(t *testing.T) {
+	store := NewRuntimeScreenshotStore(4, 4096, t.TempDir(), filepath.Join(t.TempDir(), "captures"))
+	dir, err := store.CaptureDir("game_1")
+	if err != nil {
+		t.Fatalf("capture dir: %v", err)
+	}
+
+	valid := filepath.Join(dir, "frame_1.png")
+	if err := os.WriteFile(valid, encodeTestPNG(t, 2, 2), 0o600); err != nil {
+		t.Fatalf("write png: %v", err)
+	}
+	if data, err := store.ReadScreenshotFile("game_1", valid); err != nil || len(data) == 0 {
+		t.Fatalf("expected screenshot in capture dir to be read, got %d bytes, %v", len(data), err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "secret.png")
+	if err := os.WriteFile(outside, encodeTestPNG(t, 2, 2), 0o600); err != nil {
+		t.Fatalf("write outside png: %v", err)
+	}
+	for _, path := range []string{outside, filepath.Join(dir, "..", "game_2", "frame_1.png"), "frame_1.png"} {
+		if _, err := store.ReadScreenshotFile("game_1", path); !errors.Is(err, ErrScreenshotPathOutsideDir) {
+			t.Fatalf("expected %q to be refused, got %v", path, err)
+		}
+	}
+
+	link := filepath.Join(dir, "link.png")
+	if err := os.Symlink(outside, link); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if _, err := store.ReadScreenshotFile("game_1", link); !errors.Is(err, ErrScreenshotPathOutsideDir) {
+		t.Fatalf("expected symlink to be refused, got %v", err)
+	}
+
+	text := filepath.Join(dir, "frame_2.png")
+	if err := os.WriteFile(text, []byte("not a png at all"), 0o600); err != nil {
+		t.Fatalf("write text: %v", err)
+	}
+	if _, err := store.ReadScreenshotFile("game_1", text); !errors.Is(err, ErrScreenshotNotPNG) {
+		t.Fatalf("expected non-PNG file to be refused, got %v", err)
+	}
+
+	if _, err := store.CaptureDir("../game_1"); !errors.Is(err, ErrInvalidGameSessionID) {
+		t.Fatalf("expected invalid session id, got %v", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 )
 
+const DefaultRuntimeSnapshotHistoryLimit = 32
+
 var defaultRuntimeSnapshotStore atomic.Pointer[RuntimeSnapshotStore]
 
 func init() {
@@ -22,6 +24,9 @@ type RuntimeSnapshotStore struct {
 	bySessionID map[string]StoredRuntimeSnapshot
 	lastStates  map[string]string
 	transitions map[string]uint64
+	// history keeps the most recent distinct snapshots per session, oldest first.
+	history      map[string][]StoredRuntimeSnapshot
+	historyLimit int
 }
 
 func NewRuntimeSnapshotStore(staleAfter time.Duration, staleGrace time.Duration) *RuntimeSnapshotStore {
@@ -32,11 +37,13 @@ func NewRuntimeSnapshotStore(staleAfter time.Duration, staleGrace time.Duration)
 		staleGrace = 0
 	}
 	store := &RuntimeSnapshotStore{
-		staleAfter:  staleAfter,
-		staleGrace:  staleGrace,
-		bySessionID: make(map[string]StoredRuntimeSnapshot),
-		lastStates:  make(map[string]string),
-		transitions: make(map[string]uint64),
+		staleAfter:   staleAfter,
+		staleGrace:   staleGrace,
+		bySessionID:  make(map[string]StoredRuntimeSnapshot),
+		lastStates:   make(map[string]string),
+		transitions:  make(map[string]uint64),
+		history:      make(map[string][]StoredRuntimeSnapshot),
+		historyLimit: DefaultRuntimeSnapshotHistoryLimit,
 	}
 	store.cond = sync.NewCond(&store.mu)
 	return store
@@ -75,11 +82,14 @@ func (s *RuntimeSnapshotStore) Upsert(sessionID string, snapshot RuntimeSnapshot
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.ensureCondLocked()
-	s.bySessionID[sessionID] = StoredRuntimeSnapshot{
+	stored := StoredRuntimeSnapshot{
 		SessionID: sessionID,
 		Snapshot:  snapshot,
 		UpdatedAt: now.UTC(),
 	}
+	s.bySessionID[sessionID] = stored
+	s.appendHistoryLocked(sessionID, stored)
+	DefaultGameSessionHistory().observeSnapshot(sessionID, snapshot.Frame)
 	s.latestID = sessionID
 	s.observeSessionStateLocked(sessionID, s.bySessionID[sessionID], now)
 	s.cond.Broadcast()
@@ -94,6 +104,7 @@ func (s *RuntimeSnapshotStore) RemoveSession(sessionID string) {
 	s.ensureCondLocked()
 	delete(s.bySessionID, sessionID)
 	delete(s.lastStates, sessionID)
+	delete(s.history, sessionID)
 	if s.latestID == sessionID {
 		s.latestID = ""
 		var latest StoredRuntimeSnapshot
@@ -188,6 +199,59 @@ func (s *RuntimeSnapshotStore) ConfigureFreshness(staleAfter time.Duration, stal
 	s.cond.Broadcast()
 }
 
+// ConfigureHistory sets how many distinct snapshots are retained per session.
+func (s *RuntimeSnapshotStore) ConfigureHistory(limit int) {
+	if s == nil {
+		return
+	}
+	if limit <= 0 {
+		limit = DefaultRuntimeSnapshotHistoryLimit
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyLimit = limit
+	for sessionID, entries := range s.history {
+		if len(entries) > limit {
+			s.history[sessionID] = append([]StoredRuntimeSnapshot(nil), entries[len(entries)-limit:]...)
+		}
+	}
+}
+
+// History returns retained snapshots for one session ordered from oldest to newest.
+func (s *RuntimeSnapshotStore) History(sessionID string) []StoredRuntimeSnapshot {
+	if s == nil || sessionID == "" {
+		return []StoredRuntimeSnapshot{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries := s.history[sessionID]
+	out := make([]StoredRuntimeSnapshot, len(entries))
+	copy(out, entries)
+	return out
+}
+
+func (s *RuntimeSnapshotStore) appendHistoryLocked(sessionID string, stored StoredRuntimeSnapshot) {
+	if s.history == nil {
+		s.history = make(map[string][]StoredRuntimeSnapshot)
+	}
+	limit := s.historyLimit
+	if limit <= 0 {
+		limit = DefaultRuntimeSnapshotHistoryLimit
+	}
+	entries := s.history[sessionID]
+	if n := len(entries); n > 0 && stored.Snapshot.SnapshotID != "" && entries[n-1].Snapshot.SnapshotID == stored.Snapshot.SnapshotID {
+		// Forced re-pushes reuse the snapshot id; keep the newest payload only.
+		entries[n-1] = stored
+		return
+	}
+	entries = append(entries, stored)
+	if len(entries) > limit {
+		entries = append([]StoredRuntimeSnapshot(nil), entries[len(entries)-limit:]...)
+	}
+	s.history[sessionID] = entries
+}
+
 func (s *RuntimeSnapshotStore) Await(sessionID string, minFrame int64, timeout time.Duration, minFreshness string) (StoredRuntimeSnapshot, string, bool) {
 	if s == nil {
 		return StoredRuntimeSnapshot{}, "runtime_snapshot_store_unavailable", false
@@ -274,6 +338,10 @@ func (s *RuntimeSnapshotStore) Health(now time.Time) StoreHealth {
 	out.StaleAfterMS = s.staleAfter.Milliseconds()
 	out.StaleGraceMS = s.staleGrace.Milliseconds()
 	out.Sessions = len(s.bySessionID)
+	out.HistoryLimit = s.historyLimit
+	for _, entries := range s.history {
+		out.HistorySnapshots += len(entries)
+	}
 	for sessionID, stored := range s.bySessionID {
 		state, age := s.observeSessionStateLocked(sessionID, stored, now)
 		out.States[state] = out.States[state] + 1
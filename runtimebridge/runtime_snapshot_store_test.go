@@ -152,3 +152,29 @@ func TestDefaultRuntimeSnapshotStoreReset_ConcurrentLoadAndReset(t *testing.T) {
 		t.Fatalf("expected stale_after to be one of test reset values, got %s", got)
 	}
 }
+
+func TestRuntimeSnapshotStoreHistory_KeepsBoundedDistinctSnapshots(t *testing.T) {
+	store := NewRuntimeSnapshotStore(2*time.Second, 0)
+	store.ConfigureHistory(3)
+	now := time.Now().UTC()
+
+	for i, id := range []string{"snap-1", "snap-2", "snap-2", "snap-3", "snap-4"} {
+		store.Upsert("game_history", RuntimeSnapshot{SnapshotID: id, Frame: int64(i)}, now)
+	}
+
+	history := store.History("game_history")
+	if len(history) != 3 {
+		t.Fatalf("expected 3 retained snapshots, got %d", len(history))
+	}
+	if history[0].Snapshot.SnapshotID != "snap-2" || history[2].Snapshot.SnapshotID != "snap-4" {
+		t.Fatalf("unexpected history order: %s..%s", history[0].Snapshot.SnapshotID, history[2].Snapshot.SnapshotID)
+	}
+	if history[0].Snapshot.Frame != 2 {
+		t.Fatalf("expected re-pushed snapshot to keep latest payload frame 2, got %d", history[0].Snapshot.Frame)
+	}
+
+	store.RemoveSession("game_history")
+	if got := store.History("game_history"); len(got) != 0 {
+		t.Fatalf("expected history cleared on remove, got %d entries", len(got))
+	}
+}
@@ -0,0 +1,348 @@
+package runtimebridge
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultRuntimeWatchCapacity   = 1000
+	MaxRuntimeWatchesPerSession   = 32
+	runtimeWatchChangedMethodName = "notifications/godot/watch_changed"
+)
+
+var defaultRuntimeWatchStore atomic.Pointer[RuntimeWatchStore]
+
+func init() {
+	defaultRuntimeWatchStore.Store(NewRuntimeWatchStore(defaultRuntimeWatchCapacity))
+}
+
+// RuntimeWatchStore keeps session-scoped watch definitions and their sampled
+// value time series.
+type RuntimeWatchStore struct {
+	mu       sync.RWMutex
+	capacity int
+	bySess   map[string]map[string]*runtimeWatchSeries
+	nextID   map[string]int64
+}
+
+type runtimeWatchSeries struct {
+	watch   RuntimeWatch
+	order   int64
+	samples []RuntimeWatchSample
+	nextSeq int64
+}
+
+func NewRuntimeWatchStore(capacity int) *RuntimeWatchStore {
+	if capacity <= 0 {
+		capacity = defaultRuntimeWatchCapacity
+	}
+	return &RuntimeWatchStore{
+		capacity: capacity,
+		bySess:   make(map[string]map[string]*runtimeWatchSeries),
+		nextID:   make(map[string]int64),
+	}
+}
+
+func DefaultRuntimeWatchStore() *RuntimeWatchStore {
+	if store := defaultRuntimeWatchStore.Load(); store != nil {
+		return store
+	}
+	store := NewRuntimeWatchStore(defaultRuntimeWatchCapacity)
+	if defaultRuntimeWatchStore.CompareAndSwap(nil, store) {
+		return store
+	}
+	return defaultRuntimeWatchStore.Load()
+}
+
+func ResetDefaultRuntimeWatchStoreForTests(capacity int) {
+	defaultRuntimeWatchStore.Store(NewRuntimeWatchStore(capacity))
+}
+
+// Add registers a watch and assigns its id. It returns false when the session
+// already has MaxRuntimeWatchesPerSession watches.
+func (s *RuntimeWatchStore) Add(sessionID string, watch RuntimeWatch, now time.Time) (RuntimeWatch, bool) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return RuntimeWatch{}, false
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	watches := s.bySess[sessionID]
+	if watches == nil {
+		watches = make(map[string]*runtimeWatchSeries)
+		s.bySess[sessionID] = watches
+	}
+	if len(watches) >= MaxRuntimeWatchesPerSession {
+		return RuntimeWatch{}, false
+	}
+	s.nextID[sessionID]++
+	watch.WatchID = fmt.Sprintf("watch_%d", s.nextID[sessionID])
+	watch.CreatedAt = now.Format(time.RFC3339Nano)
+	watches[watch.WatchID] = &runtimeWatchSeries{watch: watch, order: s.nextID[sessionID], nextSeq: 1}
+	return watch, true
+}
+
+func (s *RuntimeWatchStore) Remove(sessionID string, watchID string) bool {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return false
+	}
+	sessionID = strings.TrimSpace(sessionID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	watches := s.bySess[sessionID]
+	if _, ok := watches[strings.TrimSpace(watchID)]; !ok {
+		return false
+	}
+	delete(watches, strings.TrimSpace(watchID))
+	return true
+}
+
+func (s *RuntimeWatchStore) Watch(sessionID string, watchID string) (RuntimeWatch, bool) {
+	if s == nil {
+		return RuntimeWatch{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)][strings.TrimSpace(watchID)]
+	if !ok {
+		return RuntimeWatch{}, false
+	}
+	return series.watch, true
+}
+
+// List returns the session's watches ordered by creation, each with stats over
+// its retained samples.
+func (s *RuntimeWatchStore) List(sessionID string) ([]RuntimeWatch, []RuntimeWatchStats) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return []RuntimeWatch{}, []RuntimeWatchStats{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	series := make([]*runtimeWatchSeries, 0, len(s.bySess[strings.TrimSpace(sessionID)]))
+	for _, item := range s.bySess[strings.TrimSpace(sessionID)] {
+		series = append(series, item)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].order < series[j].order
+	})
+	watches := make([]RuntimeWatch, 0, len(series))
+	stats := make([]RuntimeWatchStats, 0, len(series))
+	for _, item := range series {
+		watches = append(watches, item.watch)
+		stats = append(stats, runtimeWatchStats(item.samples))
+	}
+	return watches, stats
+}
+
+// Series returns up to limit samples newer than sinceSequence (oldest first)
+// and stats over all retained samples.
+func (s *RuntimeWatchStore) Series(sessionID string, watchID string, sinceSequence int64, limit int) (RuntimeWatch, []RuntimeWatchSample, RuntimeWatchStats, bool) {
+	if s == nil {
+		return RuntimeWatch{}, nil, RuntimeWatchStats{}, false
+	}
+	if limit <= 0 {
+		limit = 200
+	}
+	if limit > s.capacity {
+		limit = s.capacity
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series, ok := s.bySess[strings.TrimSpace(sessionID)][strings.TrimSpace(watchID)]
+	if !ok {
+		return RuntimeWatch{}, nil, RuntimeWatchStats{}, false
+	}
+	samples := make([]RuntimeWatchSample, 0, min(limit, len(series.samples)))
+	for _, sample := range series.samples {
+		if sample.Sequence <= sinceSequence {
+			continue
+		}
+		samples = append(samples, sample)
+		if len(samples) == limit {
+			break
+		}
+	}
+	return series.watch, samples, runtimeWatchStats(series.samples), true
+}
+
+// Append stores samples for known watches and returns how many were kept.
+// Samples whose value differs from the previous one are announced to the
+// watch's NotifySessionID.
+func (s *RuntimeWatchStore) Append(sessionID string, samples []RuntimeWatchAppendSample, now time.Time) int {
+	if s == nil || strings.TrimSpace(sessionID) == "" || len(samples) == 0 {
+		return 0
+	}
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	sessionID = strings.TrimSpace(sessionID)
+
+	type change struct {
+		watch    RuntimeWatch
+		sample   RuntimeWatchSample
+		previous any
+	}
+	changes := []change{}
+	appended := 0
+
+	s.mu.Lock()
+	for _, in := range samples {
+		series, ok := s.bySess[sessionID][strings.TrimSpace(in.WatchID)]
+		if !ok {
+			continue
+		}
+		ts := strings.TrimSpace(in.Time)
+		if ts == "" {
+			ts = now.Format(time.RFC3339Nano)
+		}
+		sample := RuntimeWatchSample{
+			Sequence: series.nextSeq,
+			Time:     ts,
+			Frame:    in.Frame,
+			Value:    in.Value,
+		}
+		series.nextSeq++
+		var previous any
+		hasPrevious := len(series.samples) > 0
+		if hasPrevious {
+			previous = series.samples[len(series.samples)-1].Value
+		}
+		series.samples = append(series.samples, sample)
+		if len(series.samples) > s.capacity {
+			series.samples = series.samples[len(series.samples)-s.capacity:]
+		}
+		appended++
+		if series.watch.NotifySessionID != "" && (!hasPrevious || !reflect.DeepEqual(previous, sample.Value)) {
+			changes = append(changes, change{watch: series.watch, sample: sample, previous: previous})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, item := range changes {
+		sendToSession(item.watch.NotifySessionID, map[string]any{
+			"jsonrpc": "2.0",
+			"method":  runtimeWatchChangedMethodName,
+			"params": map[string]any{
+				"session_id": sessionID,
+				"watch_id":   item.watch.WatchID,
+				"node":       item.watch.Node,
+				"property":   item.watch.Property,
+				"sequence":   item.sample.Sequence,
+				"frame":      item.sample.Frame,
+				"time":       item.sample.Time,
+				"value":      item.sample.Value,
+				"previous":   item.previous,
+			},
+		})
+	}
+	return appended
+}
+
+func (s *RuntimeWatchStore) RemoveSession(sessionID string) {
+	if s == nil || strings.TrimSpace(sessionID) == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.bySess, strings.TrimSpace(sessionID))
+	delete(s.nextID, strings.TrimSpace(sessionID))
+}
+
+func (s *RuntimeWatchStore) Health() map[string]any {
+	if s == nil {
+		return map[string]any{
+			"sessions": 0,
+			"watches":  0,
+			"samples":  0,
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	watches := 0
+	samples := 0
+	for _, items := range s.bySess {
+		watches += len(items)
+		for _, item := range items {
+			samples += len(item.samples)
+		}
+	}
+	return map[string]any{
+		"sessions": len(s.bySess),
+		"watches":  watches,
+		"samples":  samples,
+	}
+}
+
+func runtimeWatchStats(samples []RuntimeWatchSample) RuntimeWatchStats {
+	stats := RuntimeWatchStats{Count: len(samples)}
+	if len(samples) == 0 {
+		return stats
+	}
+	last := samples[len(samples)-1]
+	stats.Last = last.Value
+	stats.LastFrame = last.Frame
+	stats.LastTime = last.Time
+	stats.Min, stats.Max = runtimeWatchRange(samples)
+	return stats
+}
+
+// runtimeWatchRange returns min/max for numeric series, or component-wise
+// min/max when every sample is an object of numeric components (Vector2,
+// Vector3, Color). Other series have no range.
+func runtimeWatchRange(samples []RuntimeWatchSample) (any, any) {
+	if first, ok := samples[0].Value.(float64); ok {
+		low, high := first, first
+		for _, sample := range samples[1:] {
+			value, ok := sample.Value.(float64)
+			if !ok {
+				return nil, nil
+			}
+			low = min(low, value)
+			high = max(high, value)
+		}
+		return low, high
+	}
+
+	first, ok := samples[0].Value.(map[string]any)
+	if !ok || len(first) == 0 {
+		return nil, nil
+	}
+	low := make(map[string]float64, len(first))
+	high := make(map[string]float64, len(first))
+	for _, sample := range samples {
+		value, ok := sample.Value.(map[string]any)
+		if !ok || len(value) != len(first) {
+			return nil, nil
+		}
+		for key, raw := range value {
+			component, ok := raw.(float64)
+			if !ok {
+				return nil, nil
+			}
+			if current, seen := low[key]; !seen || component < current {
+				low[key] = component
+			}
+			if current, seen := high[key]; !seen || component > current {
+				high[key] = component
+			}
+		}
+	}
+	if len(low) != len(first) {
+		return nil, nil
+	}
+	return low, high
+}
@@ -0,0 +1,50 @@
+package runtimebridge
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRuntimeWatchStore_ComputesComponentRangeAndCapsSamples(t *testing.T) {
+	store := NewRuntimeWatchStore(3)
+	now := time.Now().UTC()
+
+	watch, ok := store.Add("game_1", RuntimeWatch{Node: "/root/Main/Player", Property: "position"}, now)
+	if !ok {
+		t.Fatal("expected watch to be added")
+	}
+	appended := store.Append("game_1", []RuntimeWatchAppendSample{
+		{WatchID: watch.WatchID, Frame: 1, Value: map[string]any{"x": 0.0, "y": 10.0}},
+		{WatchID: watch.WatchID, Frame: 2, Value: map[string]any{"x": 5.0, "y": 4.0}},
+		{WatchID: watch.WatchID, Frame: 3, Value: map[string]any{"x": 2.0, "y": 8.0}},
+		{WatchID: watch.WatchID, Frame: 4, Value: map[string]any{"x": 3.0, "y": 6.0}},
+	}, now)
+	if appended != 4 {
+		t.Fatalf("expected 4 appended samples, got %d", appended)
+	}
+
+	_, samples, stats, ok := store.Series("game_1", watch.WatchID, 0, 10)
+	if !ok {
+		t.Fatal("expected watch series")
+	}
+	if len(samples) != 3 || samples[0].Frame != 2 || samples[0].Sequence != 2 {
+		t.Fatalf("expected the oldest sample to be evicted, got %+v", samples)
+	}
+	low, _ := stats.Min.(map[string]float64)
+	high, _ := stats.Max.(map[string]float64)
+	if low["x"] != 2 || low["y"] != 4 || high["x"] != 5 || high["y"] != 8 {
+		t.Fatalf("unexpected component range min=%v max=%v", stats.Min, stats.Max)
+	}
+	if stats.Count != 3 || stats.LastFrame != 4 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	store.Append("game_1", []RuntimeWatchAppendSample{{WatchID: watch.WatchID, Frame: 5, Value: "dead"}}, now)
+	if _, _, stats, _ = store.Series("game_1", watch.WatchID, 0, 10); stats.Min != nil || stats.Max != nil || stats.Last != "dead" {
+		t.Fatalf("expected no range for mixed series, got %+v", stats)
+	}
+
+	if !store.Remove("game_1", watch.WatchID) || store.Remove("game_1", watch.WatchID) {
+		t.Fatal("expected watch to be removed exactly once")
+	}
+}
@@ -0,0 +1,239 @@
+package runtimebridge
+
+import (
+	"sort"
+	"strings"
+)
+
+// SceneTreeNodeRef identifies one node in a scene tree diff.
+type SceneTreeNodeRef struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// SceneTreeNodeMove describes a node whose path changed between two snapshots.
+type SceneTreeNodeMove struct {
+	FromPath   string `json:"from_path"`
+	ToPath     string `json:"to_path"`
+	FromName   string `json:"from_name"`
+	ToName     string `json:"to_name"`
+	FromParent string `json:"from_parent"`
+	ToParent   string `json:"to_parent"`
+	Type       string `json:"type"`
+}
+
+// SceneTreeDiff reports structural changes between two compact scene trees.
+// Descendants that only moved because an ancestor was renamed or reparented
+// are not reported separately.
+type SceneTreeDiff struct {
+	Added      []SceneTreeNodeRef  `json:"added"`
+	Removed    []SceneTreeNodeRef  `json:"removed"`
+	Reparented []SceneTreeNodeMove `json:"reparented"`
+	Renamed    []SceneTreeNodeMove `json:"renamed"`
+}
+
+// Empty reports whether the diff contains no changes.
+func (d SceneTreeDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Reparented) == 0 && len(d.Renamed) == 0
+}
+
+type flatSceneNode struct {
+	path       string
+	name       string
+	typeName   string
+	parent     string
+	instanceID int64
+}
+
+// DiffSceneTrees compares two compact trees keyed by node path.
+//
+// Moves are matched by instance id when both snapshots carry one; otherwise a
+// node is considered moved when exactly one removed/added pair shares the same
+// type and either the same name (reparent) or the same parent (rename).
+func DiffSceneTrees(from CompactNode, to CompactNode) SceneTreeDiff {
+	before := flattenSceneTree(from)
+	after := flattenSceneTree(to)
+
+	removed := map[string]flatSceneNode{}
+	added := map[string]flatSceneNode{}
+	for path, node := range before {
+		other, ok := after[path]
+		if ok && (node.instanceID == 0 || other.instanceID == 0 || node.instanceID == other.instanceID) {
+			continue
+		}
+		removed[path] = node
+	}
+	for path, node := range after {
+		other, ok := before[path]
+		if ok && (node.instanceID == 0 || other.instanceID == 0 || node.instanceID == other.instanceID) {
+			continue
+		}
+		added[path] = node
+	}
+
+	reparented, renamed := matchSceneTreeMoves(removed, added)
+
+	diff := SceneTreeDiff{
+		Added:      []SceneTreeNodeRef{},
+		Removed:    []SceneTreeNodeRef{},
+		Reparented: reparented,
+		Renamed:    renamed,
+	}
+	for _, path := range sortedSceneNodePaths(removed) {
+		node := removed[path]
+		diff.Removed = append(diff.Removed, SceneTreeNodeRef{Path: node.path, Name: node.name, Type: node.typeName})
+	}
+	for _, path := range sortedSceneNodePaths(added) {
+		node := added[path]
+		diff.Added = append(diff.Added, SceneTreeNodeRef{Path: node.path, Name: node.name, Type: node.typeName})
+	}
+	return diff
+}
+
+// matchSceneTreeMoves pairs removed and added nodes that represent the same
+// node, deleting matched entries from both maps. Pairs are resolved shallowest
+// first so implied descendant moves can be folded into their ancestor's move.
+func matchSceneTreeMoves(removed map[string]flatSceneNode, added map[string]flatSceneNode) ([]SceneTreeNodeMove, []SceneTreeNodeMove) {
+	addedByInstance := map[int64]string{}
+	for path, node := range added {
+		if node.instanceID != 0 {
+			addedByInstance[node.instanceID] = path
+		}
+	}
+
+	removedPaths := sortedSceneNodePaths(removed)
+	sort.SliceStable(removedPaths, func(i, j int) bool {
+		return sceneNodeDepth(removedPaths[i]) < sceneNodeDepth(removedPaths[j])
+	})
+
+	reparented := []SceneTreeNodeMove{}
+	renamed := []SceneTreeNodeMove{}
+	// prefixes maps a moved node's old path to its new path.
+	prefixes := map[string]string{}
+	for _, fromPath := range removedPaths {
+		node := removed[fromPath]
+
+		implied := false
+		toPath := ""
+		if newParent, ok := prefixes[node.parent]; ok {
+			if target, ok := added[newParent+"/"+node.name]; ok && target.typeName == node.typeName &&
+				(node.instanceID == 0 || target.instanceID == 0 || node.instanceID == target.instanceID) {
+				toPath = target.path
+				implied = true
+			}
+		}
+		if toPath == "" && node.instanceID != 0 {
+			toPath = addedByInstance[node.instanceID]
+		}
+		if toPath == "" {
+			toPath = uniqueSceneMoveCandidate(node, removed, added)
+		}
+		if toPath == "" {
+			continue
+		}
+		target := added[toPath]
+		delete(removed, fromPath)
+		delete(added, toPath)
+		prefixes[fromPath] = toPath
+		if implied {
+			continue
+		}
+		move := SceneTreeNodeMove{
+			FromPath:   fromPath,
+			ToPath:     toPath,
+			FromName:   node.name,
+			ToName:     target.name,
+			FromParent: node.parent,
+			ToParent:   target.parent,
+			Type:       target.typeName,
+		}
+		// A node under a moved ancestor that kept its relative parent was only renamed.
+		effectiveParent := node.parent
+		if newParent, ok := prefixes[node.parent]; ok {
+			effectiveParent = newParent
+		}
+		if effectiveParent != target.parent {
+			reparented = append(reparented, move)
+		} else {
+			renamed = append(renamed, move)
+		}
+	}
+
+	sort.Slice(reparented, func(i, j int) bool { return reparented[i].FromPath < reparented[j].FromPath })
+	sort.Slice(renamed, func(i, j int) bool { return renamed[i].FromPath < renamed[j].FromPath })
+	return reparented, renamed
+}
+
+func uniqueSceneMoveCandidate(node flatSceneNode, removed map[string]flatSceneNode, added map[string]flatSceneNode) string {
+	match := func(a flatSceneNode, b flatSceneNode) bool {
+		// Heuristic matching is only used for payloads without instance ids.
+		if a.typeName != b.typeName || (a.instanceID != 0 && b.instanceID != 0) {
+			return false
+		}
+		return a.name == b.name || a.parent == b.parent
+	}
+
+	candidate := ""
+	for path, other := range added {
+		if !match(node, other) {
+			continue
+		}
+		if candidate != "" {
+			return ""
+		}
+		candidate = path
+	}
+	if candidate == "" {
+		return ""
+	}
+	for path, other := range removed {
+		if path != node.path && match(other, added[candidate]) {
+			return ""
+		}
+	}
+	return candidate
+}
+
+func flattenSceneTree(root CompactNode) map[string]flatSceneNode {
+	out := map[string]flatSceneNode{}
+	var walk func(node CompactNode)
+	walk = func(node CompactNode) {
+		path := strings.TrimSpace(node.Path)
+		if path != "" {
+			out[path] = flatSceneNode{
+				path:       path,
+				name:       node.Name,
+				typeName:   node.Type,
+				parent:     sceneNodeParent(path),
+				instanceID: node.InstanceID,
+			}
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+	return out
+}
+
+func sceneNodeParent(path string) string {
+	index := strings.LastIndex(path, "/")
+	if index <= 0 {
+		return ""
+	}
+	return path[:index]
+}
+
+func sceneNodeDepth(path string) int {
+	return strings.Count(path, "/")
+}
+
+func sortedSceneNodePaths(nodes map[string]flatSceneNode) []string {
+	paths := make([]string, 0, len(nodes))
+	for path := range nodes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
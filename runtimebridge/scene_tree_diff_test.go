@@ -0,0 +1,77 @@
+package runtimebridge
+
+import "testing"
+
+func TestDiffSceneTrees_ReportsAddedAndRemovedNodes(t *testing.T) {
+	before := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Player", Name: "Player", Type: "CharacterBody2D", InstanceID: 10},
+		{Path: "/root/Main/Enemy", Name: "Enemy", Type: "CharacterBody2D", InstanceID: 11},
+	}}
+	after := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Player", Name: "Player", Type: "CharacterBody2D", InstanceID: 10},
+		{Path: "/root/Main/Bullet", Name: "Bullet", Type: "Area2D", InstanceID: 12},
+	}}
+
+	diff := DiffSceneTrees(before, after)
+	if len(diff.Added) != 1 || diff.Added[0].Path != "/root/Main/Bullet" {
+		t.Fatalf("expected Bullet added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Path != "/root/Main/Enemy" {
+		t.Fatalf("expected Enemy removed, got %+v", diff.Removed)
+	}
+	if len(diff.Reparented) != 0 || len(diff.Renamed) != 0 {
+		t.Fatalf("expected no moves, got reparented=%+v renamed=%+v", diff.Reparented, diff.Renamed)
+	}
+}
+
+func TestDiffSceneTrees_FoldsDescendantsIntoReparentedAncestor(t *testing.T) {
+	before := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Enemy", Name: "Enemy", Type: "CharacterBody2D", InstanceID: 11, Children: []CompactNode{
+			{Path: "/root/Main/Enemy/Sprite", Name: "Sprite", Type: "Sprite2D", InstanceID: 12},
+		}},
+		{Path: "/root/Main/Pool", Name: "Pool", Type: "Node", InstanceID: 13},
+	}}
+	after := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Pool", Name: "Pool", Type: "Node", InstanceID: 13, Children: []CompactNode{
+			{Path: "/root/Main/Pool/Enemy", Name: "Enemy", Type: "CharacterBody2D", InstanceID: 11, Children: []CompactNode{
+				{Path: "/root/Main/Pool/Enemy/Sprite", Name: "Sprite", Type: "Sprite2D", InstanceID: 12},
+			}},
+		}},
+	}}
+
+	diff := DiffSceneTrees(before, after)
+	if len(diff.Reparented) != 1 {
+		t.Fatalf("expected one reparented node, got %+v", diff.Reparented)
+	}
+	move := diff.Reparented[0]
+	if move.FromPath != "/root/Main/Enemy" || move.ToPath != "/root/Main/Pool/Enemy" || move.ToParent != "/root/Main/Pool" {
+		t.Fatalf("unexpected reparent move: %+v", move)
+	}
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Renamed) != 0 {
+		t.Fatalf("expected descendants folded into ancestor move, got %+v", diff)
+	}
+}
+
+func TestDiffSceneTrees_DetectsRenameWithoutInstanceIDs(t *testing.T) {
+	before := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Enemy", Name: "Enemy", Type: "CharacterBody2D", Children: []CompactNode{
+			{Path: "/root/Main/Enemy/Sprite", Name: "Sprite", Type: "Sprite2D"},
+		}},
+	}}
+	after := CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []CompactNode{
+		{Path: "/root/Main/Boss", Name: "Boss", Type: "CharacterBody2D", Children: []CompactNode{
+			{Path: "/root/Main/Boss/Sprite", Name: "Sprite", Type: "Sprite2D"},
+		}},
+	}}
+
+	diff := DiffSceneTrees(before, after)
+	if len(diff.Renamed) != 1 {
+		t.Fatalf("expected one renamed node, got %+v", diff)
+	}
+	if diff.Renamed[0].FromName != "Enemy" || diff.Renamed[0].ToName != "Boss" {
+		t.Fatalf("unexpected rename: %+v", diff.Renamed[0])
+	}
+	if len(diff.Added)+len(diff.Removed)+len(diff.Reparented) != 0 {
+		t.Fatalf("expected only a rename, got %+v", diff)
+	}
+}
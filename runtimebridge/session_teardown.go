@@ -0,0 +1,35 @@
+package runtimebridge
+
+// ForgetGameSessionTransport drops the state that only means something while
+// a game's runtime transport is live: its watchdog progress. Snapshots,
+// watches, performance samples, screenshots, logs and process info stay
+// readable for the history and compare tools until the game session is
+// forgotten.
+func ForgetGameSessionTransport(sessionID string) {
+	DefaultGameSessionWatchdog().RemoveSession(sessionID)
+}
+
+// ForgetGameSession drops everything kept for a game session outside the
+// game session registry, including its logs, log tails and launched process.
+func ForgetGameSession(sessionID string) {
+	ForgetGameSessionTransport(sessionID)
+	DefaultRuntimeSnapshotStore().RemoveSession(sessionID)
+	DefaultRuntimeWatchStore().RemoveSession(sessionID)
+	DefaultRuntimePerformanceStore().RemoveSession(sessionID)
+	DefaultRuntimeScreenshotStore().RemoveSession(sessionID)
+	DefaultRuntimeLogStore().RemoveSession(sessionID)
+	DefaultRuntimeLogTailHub().RemoveSession(sessionID)
+	DefaultGameProcessLauncher().RemoveSession(sessionID)
+}
+
+// ForgetMCPSession drops the per-session state of a closed MCP session,
+// together with every game session it owns, running or stopped.
+func ForgetMCPSession(sessionID string) {
+	for _, gameSessionID := range DefaultGameSessionRegistry().SessionIDsForEditor(sessionID) {
+		ForgetGameSession(gameSessionID)
+	}
+	DefaultGameSessionRegistry().RemoveByEditorSession(sessionID)
+	DefaultEditorStore().RemoveSession(sessionID)
+	DefaultRuntimeLogTailHub().RemoveSubscriber(sessionID)
+	DefaultRateLimiter().RemoveSession(sessionID)
+}
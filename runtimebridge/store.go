@@ -39,6 +39,9 @@ type StoreHealth struct {
 	States        map[string]int     `json:"states"`
 	Transitions   map[string]uint64  `json:"transitions"`
 	SessionHealth []SessionFreshness `json:"session_health"`
+	// History fields are only populated by RuntimeSnapshotStore.
+	HistoryLimit     int `json:"history_limit,omitempty"`
+	HistorySnapshots int `json:"history_snapshots,omitempty"`
 }
 
 // Store tracks runtime snapshots by MCP session.
@@ -338,6 +341,15 @@ func HealthSnapshot(now time.Time) map[string]any {
 	runtimeHealth := DefaultRuntimeSnapshotStore().Health(now)
 	sessionHealth := DefaultGameSessionRegistry().Health()
 	logHealth := DefaultRuntimeLogStore().Health()
+	logArchiveHealth := DefaultRuntimeLogArchive().Health()
+	logTailHealth := DefaultRuntimeLogTailHub().Health()
+	watchdogHealth := DefaultGameSessionWatchdog().Health()
+	historyHealth := DefaultGameSessionHistory().Health()
+	watchHealth := DefaultRuntimeWatchStore().Health()
+	performanceHealth := DefaultRuntimePerformanceStore().Health()
+	testRunHealth := DefaultTestRunStore().Health()
+	screenshotHealth := DefaultRuntimeScreenshotStore().Health()
+	processHealth := DefaultGameProcessLauncher().Health()
 	commandMetrics := DefaultCommandBroker().Metrics()
 
 	result := map[string]any{
@@ -357,11 +369,25 @@ func HealthSnapshot(now time.Time) map[string]any {
 			"states":         runtimeHealth.States,
 			"transitions":    runtimeHealth.Transitions,
 			"session_health": runtimeHealth.SessionHealth,
+			"history": map[string]any{
+				"limit":     runtimeHealth.HistoryLimit,
+				"snapshots": runtimeHealth.HistorySnapshots,
+			},
 		},
-		"game_sessions":  sessionHealth,
-		"runtime_logs":   logHealth,
-		"command_broker": commandMetrics,
-		"mcp_sessions":   GetSessionCounts(),
+		"game_sessions":         sessionHealth,
+		"game_session_watchdog": watchdogHealth,
+		"game_session_history":  historyHealth,
+		"runtime_logs":          logHealth,
+		"runtime_log_archive":   logArchiveHealth,
+		"runtime_log_tails":     logTailHealth,
+		"runtime_watches":       watchHealth,
+		"runtime_performance":   performanceHealth,
+		"test_runs":             testRunHealth,
+		"runtime_screenshots":   screenshotHealth,
+		"game_processes":        processHealth,
+		"command_broker":        commandMetrics,
+		"rate_limits":           DefaultRateLimiter().Health(now),
+		"mcp_sessions":          GetSessionCounts(),
 	}
 	if summaries := GetSessionSummaries(); summaries != nil {
 		result["mcp_session_details"] = summaries
@@ -0,0 +1,135 @@
+package runtimebridge
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/testreport"
+)
+
+const defaultTestRunHistoryLimit = 10
+
+var defaultTestRunStore atomic.Pointer[TestRunStore]
+
+func init() {
+	defaultTestRunStore.Store(NewTestRunStore(defaultTestRunHistoryLimit))
+}
+
+// TestRun is one finished GUT or GdUnit4 run started by godot.test.run.
+type TestRun struct {
+	RunID      string            `json:"run_id"`
+	Framework  string            `json:"framework"`
+	Path       string            `json:"path"`
+	Test       string            `json:"test,omitempty"`
+	Status     string            `json:"status"`
+	Source     string            `json:"source"`
+	StartedAt  string            `json:"started_at"`
+	FinishedAt string            `json:"finished_at"`
+	DurationMS int64             `json:"duration_ms"`
+	ExitCode   *int              `json:"exit_code,omitempty"`
+	Command    []string          `json:"command"`
+	Report     testreport.Report `json:"report"`
+	OutputTail []string          `json:"output_tail,omitempty"`
+	// OutputTruncated reports that only the last part of the runner output
+	// was kept for parsing.
+	OutputTruncated bool `json:"output_truncated,omitempty"`
+}
+
+// TestRunStore keeps the most recent test runs, newest last.
+type TestRunStore struct {
+	mu    sync.RWMutex
+	limit int
+	runs  []TestRun
+}
+
+func NewTestRunStore(limit int) *TestRunStore {
+	if limit <= 0 {
+		limit = defaultTestRunHistoryLimit
+	}
+	return &TestRunStore{limit: limit}
+}
+
+func DefaultTestRunStore() *TestRunStore {
+	if store := defaultTestRunStore.Load(); store != nil {
+		return store
+	}
+	store := NewTestRunStore(defaultTestRunHistoryLimit)
+	if defaultTestRunStore.CompareAndSwap(nil, store) {
+		return store
+	}
+	return defaultTestRunStore.Load()
+}
+
+func ResetDefaultTestRunStoreForTests(limit int) {
+	defaultTestRunStore.Store(NewTestRunStore(limit))
+}
+
+func (s *TestRunStore) Put(run TestRun) {
+	if s == nil || strings.TrimSpace(run.RunID) == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runs = append(s.runs, run)
+	if len(s.runs) > s.limit {
+		s.runs = s.runs[len(s.runs)-s.limit:]
+	}
+}
+
+// Get returns the run with runID, or the latest run when runID is empty.
+func (s *TestRunStore) Get(runID string) (TestRun, bool) {
+	if s == nil {
+		return TestRun{}, false
+	}
+	runID = strings.TrimSpace(runID)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.runs) == 0 {
+		return TestRun{}, false
+	}
+	if runID == "" {
+		return s.runs[len(s.runs)-1], true
+	}
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		if s.runs[i].RunID == runID {
+			return s.runs[i], true
+		}
+	}
+	return TestRun{}, false
+}
+
+// RunIDs lists retained run ids, newest first.
+func (s *TestRunStore) RunIDs() []string {
+	if s == nil {
+		return []string{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.runs))
+	for i := len(s.runs) - 1; i >= 0; i-- {
+		ids = append(ids, s.runs[i].RunID)
+	}
+	return ids
+}
+
+func (s *TestRunStore) Health() map[string]any {
+	if s == nil {
+		return map[string]any{
+			"limit": 0,
+			"runs":  0,
+		}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	health := map[string]any{
+		"limit": s.limit,
+		"runs":  len(s.runs),
+	}
+	if len(s.runs) > 0 {
+		last := s.runs[len(s.runs)-1]
+		health["last_run_id"] = last.RunID
+		health["last_status"] = last.Status
+	}
+	return health
+}
@@ -18,6 +18,9 @@ type CompactNode struct {
 	Path       string        `json:"path"`
 	Name       string        `json:"name"`
 	Type       string        `json:"type"`
+	InstanceID int64         `json:"instance_id,omitempty"`
+	ScriptPath string        `json:"script_path,omitempty"`
+	Groups     []string      `json:"groups,omitempty"`
 	ChildCount int           `json:"child_count"`
 	Children   []CompactNode `json:"children,omitempty"`
 }
@@ -84,6 +87,40 @@ type RuntimeLogEntry struct {
 	Message    string `json:"message"`
 	Source     string `json:"source,omitempty"`
 	StackTrace string `json:"stack_trace,omitempty"`
+	// ErrorKind, File, Line, Function and Frames are parsed from Godot's
+	// error and backtrace formats; they are empty for plain output lines.
+	ErrorKind string            `json:"error_kind,omitempty"`
+	File      string            `json:"file,omitempty"`
+	Line      int               `json:"line,omitempty"`
+	Function  string            `json:"function,omitempty"`
+	Frames    []RuntimeLogFrame `json:"frames,omitempty"`
+}
+
+// RuntimeLogQuery filters runtime log entries for one game session.
+type RuntimeLogQuery struct {
+	Level         string
+	Limit         int
+	SinceSequence int64
+	// File matches a res:// path or a path suffix in the entry location or frames.
+	File string
+	// Function matches the entry function or any frame function exactly.
+	Function string
+}
+
+// RuntimeLogGroup aggregates identical runtime errors with occurrence counts.
+type RuntimeLogGroup struct {
+	Level         string            `json:"level"`
+	ErrorKind     string            `json:"error_kind,omitempty"`
+	Message       string            `json:"message"`
+	File          string            `json:"file,omitempty"`
+	Line          int               `json:"line,omitempty"`
+	Function      string            `json:"function,omitempty"`
+	Frames        []RuntimeLogFrame `json:"frames,omitempty"`
+	Count         int               `json:"count"`
+	FirstSequence int64             `json:"first_sequence"`
+	LastSequence  int64             `json:"last_sequence"`
+	FirstTime     string            `json:"first_time"`
+	LastTime      string            `json:"last_time"`
 }
 
 // RuntimeLogAppendEntry is the append payload for runtime log ingestion.
@@ -95,7 +132,95 @@ type RuntimeLogAppendEntry struct {
 	StackTrace string `json:"stack_trace,omitempty"`
 }
 
+// RuntimeWatch is one property watch registered in the runtime companion.
+type RuntimeWatch struct {
+	WatchID    string `json:"watch_id"`
+	Node       string `json:"node"`
+	Property   string `json:"property"`
+	IntervalMS int    `json:"interval_ms"`
+	CreatedAt  string `json:"created_at"`
+	// NotifySessionID is the MCP session that receives change notifications.
+	NotifySessionID string `json:"-"`
+}
+
+// RuntimeWatchSample is one sampled value of a runtime watch.
+type RuntimeWatchSample struct {
+	Sequence int64  `json:"sequence"`
+	Time     string `json:"time"`
+	Frame    int64  `json:"frame"`
+	Value    any    `json:"value"`
+}
+
+// RuntimeWatchAppendSample is the append payload for runtime watch ingestion.
+type RuntimeWatchAppendSample struct {
+	WatchID string `json:"watch_id"`
+	Time    string `json:"time,omitempty"`
+	Frame   int64  `json:"frame"`
+	Value   any    `json:"value"`
+}
+
+// RuntimeWatchStats summarizes the retained samples of one watch. Min and Max
+// are set for numeric values, or per component for vector-like objects.
+type RuntimeWatchStats struct {
+	Count     int    `json:"count"`
+	Min       any    `json:"min,omitempty"`
+	Max       any    `json:"max,omitempty"`
+	Last      any    `json:"last"`
+	LastFrame int64  `json:"last_frame,omitempty"`
+	LastTime  string `json:"last_time,omitempty"`
+}
+
+// RuntimePerformanceSampling describes the periodic Performance monitor
+// sampling configured in the runtime companion for one game session.
+type RuntimePerformanceSampling struct {
+	Active     bool     `json:"active"`
+	IntervalMS int      `json:"interval_ms"`
+	Monitors   []string `json:"monitors,omitempty"`
+	StartedAt  string   `json:"started_at,omitempty"`
+	StoppedAt  string   `json:"stopped_at,omitempty"`
+}
+
+// RuntimePerformanceSample is one set of Performance monitor values.
+type RuntimePerformanceSample struct {
+	Sequence int64              `json:"sequence"`
+	Time     string             `json:"time"`
+	Frame    int64              `json:"frame"`
+	Monitors map[string]float64 `json:"monitors"`
+}
+
+// RuntimePerformanceAppendSample is the append payload for runtime
+// performance ingestion.
+type RuntimePerformanceAppendSample struct {
+	Time     string             `json:"time,omitempty"`
+	Frame    int64              `json:"frame"`
+	Monitors map[string]float64 `json:"monitors"`
+}
+
+// RuntimePerformanceStats summarizes the retained values of one monitor.
+type RuntimePerformanceStats struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Avg   float64 `json:"avg"`
+	P95   float64 `json:"p95"`
+	Last  float64 `json:"last"`
+}
+
+// RuntimeScreenshot is one decoded runtime screenshot retained by the server.
+type RuntimeScreenshot struct {
+	ScreenshotID string `json:"screenshot_id"`
+	SessionID    string `json:"session_id"`
+	Frame        int64  `json:"frame,omitempty"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	Bytes        int    `json:"bytes"`
+	CapturedAt   string `json:"captured_at"`
+	PNG          []byte `json:"-"`
+}
+
 // GameSession keeps one running game session lifecycle state.
+// EditorSessionID is the MCP session that owns the game: the editor that
+// played it, or the caller that launched a headless run.
 type GameSession struct {
 	SessionID        string `json:"session_id"`
 	EditorSessionID  string `json:"editor_session_id,omitempty"`
@@ -107,4 +232,13 @@ type GameSession struct {
 	Running          bool   `json:"running"`
 	HasSnapshot      bool   `json:"has_snapshot"`
 	LastSnapshotAt   string `json:"last_snapshot_at,omitempty"`
+	// InstanceIndex is the 1-based position of the session within a
+	// multi-instance godot.project.run; single runs leave it zero.
+	InstanceIndex int `json:"instance_index,omitempty"`
+	// State is running, stopped, crashed or hung. Crashed and hung are set by
+	// the game session watchdog together with StateReason and LastLogLines.
+	State          string            `json:"state,omitempty"`
+	StateReason    string            `json:"state_reason,omitempty"`
+	StateChangedAt string            `json:"state_changed_at,omitempty"`
+	LastLogLines   []RuntimeLogEntry `json:"last_log_lines,omitempty"`
 }
@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"time"
+
+	auditlog "github.com/slighter12/godot-mcp-go/internal/infra/audit"
+	"github.com/slighter12/godot-mcp-go/mcp"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+var outcomes = []string{auditlog.OutcomeSuccess, auditlog.OutcomeError, auditlog.OutcomeDenied}
+
+func GetAllTools() []tooltypes.Tool {
+	return []tooltypes.Tool{
+		&QueryTool{},
+	}
+}
+
+type QueryTool struct{}
+
+func (t *QueryTool) Name() string { return "godot.audit.query" }
+func (t *QueryTool) Description() string {
+	return "Queries the audit log of mutating tool calls and bridge command acknowledgements, newest first"
+}
+func (t *QueryTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *QueryTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string", "description": "Only records from this MCP session"},
+			"tool":       map[string]any{"type": "string", "description": "Tool name or glob such as godot.node.*"},
+			"outcome":    map[string]any{"type": "string", "enum": outcomes},
+			"since":      map[string]any{"type": "string", "description": "RFC3339 lower time bound"},
+			"until":      map[string]any{"type": "string", "description": "RFC3339 upper time bound"},
+			"limit":      map[string]any{"type": "integer", "minimum": 1, "maximum": 1000, "description": "Maximum records to return (default 100)"},
+			"verify":     map[string]any{"type": "boolean", "description": "Also recompute the hash chain over all retained records"},
+		},
+		Required: []string{},
+		Title:    "Audit Log Query",
+	}
+}
+func (t *QueryTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments := map[string]any{}
+	if err := json.Unmarshal(args, &arguments); err != nil {
+		return nil, err
+	}
+	auditLog := auditlog.Default()
+	if !auditLog.Enabled() {
+		return nil, tooltypes.NewNotAvailableError("Audit log is disabled", map[string]any{
+			"tool":   t.Name(),
+			"reason": "audit_disabled",
+		})
+	}
+
+	query := auditlog.Query{}
+	var semErr *tooltypes.SemanticError
+	if query.SessionID, semErr = stringArgument(arguments, "session_id"); semErr != nil {
+		return nil, semErr
+	}
+	if query.Tool, semErr = stringArgument(arguments, "tool"); semErr != nil {
+		return nil, semErr
+	}
+	if _, err := path.Match(query.Tool, ""); err != nil {
+		return nil, invalidArgument("tool", "tool must be a valid glob")
+	}
+	if query.Outcome, semErr = stringArgument(arguments, "outcome"); semErr != nil {
+		return nil, semErr
+	}
+	if query.Outcome != "" && query.Outcome != auditlog.OutcomeSuccess && query.Outcome != auditlog.OutcomeError && query.Outcome != auditlog.OutcomeDenied {
+		return nil, invalidArgument("outcome", "outcome must be one of success, error or denied")
+	}
+	if query.Since, semErr = timeArgument(arguments, "since"); semErr != nil {
+		return nil, semErr
+	}
+	if query.Until, semErr = timeArgument(arguments, "until"); semErr != nil {
+		return nil, semErr
+	}
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			query.Limit = int(value)
+		}
+	}
+
+	result, err := auditLog.Query(query)
+	if err != nil {
+		return nil, tooltypes.NewNotAvailableError("Audit log is unreadable", map[string]any{
+			"tool":   t.Name(),
+			"reason": "audit_unreadable",
+			"error":  err.Error(),
+		})
+	}
+	response := map[string]any{
+		"records":   result.Records,
+		"count":     len(result.Records),
+		"truncated": result.Truncated,
+	}
+	if verify, _ := arguments["verify"].(bool); verify {
+		verification, err := auditLog.Verify()
+		if err != nil {
+			return nil, tooltypes.NewNotAvailableError("Audit log is unreadable", map[string]any{
+				"tool":   t.Name(),
+				"reason": "audit_unreadable",
+				"error":  err.Error(),
+			})
+		}
+		response["verification"] = verification
+	}
+	return json.Marshal(response)
+}
+
+func stringArgument(arguments map[string]any, key string) (string, *tooltypes.SemanticError) {
+	raw, ok := arguments[key]
+	if !ok || raw == nil {
+		return "", nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", invalidArgument(key, key+" must be a string")
+	}
+	return strings.TrimSpace(value), nil
+}
+
+func timeArgument(arguments map[string]any, key string) (time.Time, *tooltypes.SemanticError) {
+	value, semErr := stringArgument(arguments, key)
+	if semErr != nil || value == "" {
+		return time.Time{}, semErr
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, invalidArgument(key, key+" must be an RFC3339 timestamp")
+	}
+	return parsed, nil
+}
+
+func invalidArgument(field string, message string) *tooltypes.SemanticError {
+	return tooltypes.NewSemanticError(tooltypes.SemanticKindInvalidParams, message, map[string]any{"field": field})
+}
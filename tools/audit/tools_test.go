@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"encoding/json"
+	"testing"
+
+	auditlog "github.com/slighter12/godot-mcp-go/internal/infra/audit"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+func TestQueryTool_FiltersAndVerifies(t *testing.T) {
+	auditlog.ResetDefaultForTests(t.TempDir(), 30)
+	t.Cleanup(func() { auditlog.ResetDefaultForTests("", 30) })
+	auditlog.Default().Append(auditlog.Record{Kind: auditlog.KindToolCall, SessionID: "s1", Tool: "godot.node.create", Outcome: auditlog.OutcomeSuccess})
+	auditlog.Default().Append(auditlog.Record{Kind: auditlog.KindToolCall, SessionID: "s1", Tool: "godot.script.modify", Outcome: auditlog.OutcomeError})
+	auditlog.Default().Append(auditlog.Record{Kind: auditlog.KindToolCall, SessionID: "s2", Tool: "godot.node.delete", Outcome: auditlog.OutcomeSuccess})
+
+	raw, err := (&QueryTool{}).Execute(json.RawMessage(`{"tool":"godot.node.*","outcome":"success","limit":1,"verify":true}`))
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var result struct {
+		Records      []auditlog.Record     `json:"records"`
+		Truncated    bool                  `json:"truncated"`
+		Verification auditlog.Verification `json:"verification"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(result.Records) != 1 || result.Records[0].Tool != "godot.node.delete" || !result.Truncated {
+		t.Fatalf("unexpected query result %#v", result)
+	}
+	if !result.Verification.Valid || result.Verification.Records != 3 {
+		t.Fatalf("expected intact chain, got %#v", result.Verification)
+	}
+}
+
+func TestQueryTool_RejectsInvalidArguments(t *testing.T) {
+	auditlog.ResetDefaultForTests(t.TempDir(), 30)
+	t.Cleanup(func() { auditlog.ResetDefaultForTests("", 30) })
+	for _, args := range []string{`{"outcome":"maybe"}`, `{"since":"yesterday"}`, `{"tool":"godot.[node"}`} {
+		_, err := (&QueryTool{}).Execute(json.RawMessage(args))
+		semErr, ok := tooltypes.AsSemanticError(err)
+		if !ok || semErr.Kind != tooltypes.SemanticKindInvalidParams {
+			t.Fatalf("expected invalid_params for %s, got %v", args, err)
+		}
+	}
+}
+
+func TestQueryTool_DisabledLog(t *testing.T) {
+	auditlog.ResetDefaultForTests("", 30)
+	_, err := (&QueryTool{}).Execute(json.RawMessage(`{}`))
+	semErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semErr.Kind != tooltypes.SemanticKindNotAvailable {
+		t.Fatalf("expected not_available when audit is disabled, got %v", err)
+	}
+}
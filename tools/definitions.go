@@ -1,11 +1,14 @@
 package tools
 
 import (
+	"github.com/slighter12/godot-mcp-go/tools/audit"
 	"github.com/slighter12/godot-mcp-go/tools/node"
 	"github.com/slighter12/godot-mcp-go/tools/project"
 	"github.com/slighter12/godot-mcp-go/tools/runtime"
+	"github.com/slighter12/godot-mcp-go/tools/scenario"
 	"github.com/slighter12/godot-mcp-go/tools/scene"
 	"github.com/slighter12/godot-mcp-go/tools/script"
+	"github.com/slighter12/godot-mcp-go/tools/testrunner"
 	"github.com/slighter12/godot-mcp-go/tools/types"
 	"github.com/slighter12/godot-mcp-go/tools/utility"
 )
@@ -17,6 +20,7 @@ func GetAllTools() []types.Tool {
 		&node.CreateNodeTool{},
 		&node.DeleteNodeTool{},
 		&node.ModifyNodeTool{},
+		&node.QueryNodesTool{},
 	)
 	all = append(all, script.GetAllTools()...)
 	all = append(all,
@@ -28,6 +32,9 @@ func GetAllTools() []types.Tool {
 	)
 	all = append(all, project.GetAllTools()...)
 	all = append(all, runtime.GetAllTools()...)
+	all = append(all, scenario.GetAllTools()...)
+	all = append(all, testrunner.GetAllTools()...)
+	all = append(all, audit.GetAllTools()...)
 	all = append(all, &utility.ListOfferingsTool{}, utility.NewRuntimeHealthTool(), utility.NewRuntimeDiagnoseTool())
 	return all
 }
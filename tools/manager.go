@@ -227,7 +227,14 @@ func ListScenesTool(args map[string]any) (any, error) {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() && strings.HasSuffix(path, ".tscn") {
+		relPath, relErr := filepath.Rel(projectRoot, path)
+		if relErr != nil {
+			return relErr
+		}
+		if info.IsDir() && path != projectRoot && types.PathSandboxSkipsDir(relPath) {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && strings.HasSuffix(path, ".tscn") && types.PathSandboxAllows(relPath) {
 			scenes = append(scenes, strings.TrimSuffix(filepath.Base(path), ".tscn"))
 		}
 		return nil
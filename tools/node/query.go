@@ -0,0 +1,185 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/nodequery"
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	defaultNodeQueryLimit = 100
+	maxNodeQueryLimit     = 500
+)
+
+type QueryNodesTool struct{}
+
+func (t *QueryNodesTool) Name() string { return "godot.node.query" }
+func (t *QueryNodesTool) Description() string {
+	return "[editor-plugin] Finds nodes in the editor or runtime scene tree with a selector query"
+}
+func (t *QueryNodesTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *QueryNodesTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"selector":          map[string]any{"type": "string", "description": "Selector such as CharacterBody2D[group=enemies]"},
+			"source":            map[string]any{"type": "string", "description": "editor (default) or runtime"},
+			"session_id":        map[string]any{"type": "string", "description": "Game session id, required for runtime source"},
+			"editor_session_id": map[string]any{"type": "string", "description": "Optional explicit editor session id override"},
+			"max_depth":         map[string]any{"type": "integer", "description": "Maximum tree depth to search (root is 0)"},
+			"limit":             map[string]any{"type": "integer", "description": "Page size (default 100, max 500)"},
+			"cursor":            map[string]any{"type": "string", "description": "Pagination cursor from nextCursor"},
+		},
+		Required: []string{"selector"},
+		Title:    "Query Nodes",
+	}
+}
+func (t *QueryNodesTool) Execute(args json.RawMessage) ([]byte, error) {
+	var arguments map[string]any
+	if err := json.Unmarshal(args, &arguments); err != nil {
+		return nil, err
+	}
+	ctx := tooltypes.ExtractMCPContext(arguments)
+	if strings.TrimSpace(ctx.SessionID) == "" || !ctx.SessionInitialized {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Node query requires an initialized MCP HTTP session", t.Name(), "editor_session_missing", map[string]any{
+			"reason": "session_not_initialized",
+		})
+	}
+
+	rawSelector, _ := arguments["selector"].(string)
+	query, err := nodequery.Parse(strings.TrimSpace(rawSelector))
+	if err != nil {
+		data := map[string]any{"field": "selector", "problem": "invalid_selector", "detail": err.Error()}
+		var syntaxErr *nodequery.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			data["position"] = syntaxErr.Pos
+		}
+		return nil, tooltypes.NewSemanticError(tooltypes.SemanticKindInvalidParams, "Invalid selector", data)
+	}
+
+	maxDepth := 0
+	if raw, ok := arguments["max_depth"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			maxDepth = int(value)
+		}
+	}
+	limit := defaultNodeQueryLimit
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			limit = min(int(value), maxNodeQueryLimit)
+		}
+	}
+
+	source := "editor"
+	if raw, ok := arguments["source"].(string); ok && strings.TrimSpace(raw) != "" {
+		source = strings.ToLower(strings.TrimSpace(raw))
+	}
+	result := map[string]any{"source": source}
+	var root nodequery.Node
+	switch source {
+	case "editor":
+		stored, semErr := tooltypes.ResolveFreshEditorSnapshot(arguments, ctx, t.Name(), "Node query is unavailable until editor snapshot is healthy")
+		if semErr != nil {
+			return nil, semErr
+		}
+		root = queryNodeFromCompact(stored.Snapshot.SceneTree, stored.Snapshot.NodeDetails)
+		result["session_id"] = stored.SessionID
+		result["updated_at"] = stored.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	case "runtime":
+		sessionID, _ := arguments["session_id"].(string)
+		sessionID = strings.TrimSpace(sessionID)
+		if sessionID == "" {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("session_id is required for runtime source", t.Name(), "game_session_missing", nil)
+		}
+		stored, ok, reason := runtimebridge.DefaultRuntimeSnapshotStore().FreshForSession(sessionID, time.Now().UTC())
+		if !ok {
+			return nil, tooltypes.NewRuntimeNotAvailableError("Runtime scene tree is unavailable", t.Name(), reason, map[string]any{"session_id": sessionID})
+		}
+		root = queryNodeFromCompact(stored.Snapshot.SceneTree, stored.Snapshot.NodeDetails)
+		result["session_id"] = sessionID
+		result["snapshot_id"] = stored.Snapshot.SnapshotID
+		result["frame"] = stored.Snapshot.Frame
+		result["updated_at"] = stored.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	default:
+		return nil, tooltypes.NewSemanticError(tooltypes.SemanticKindInvalidParams, "source must be editor or runtime", map[string]any{
+			"field":   "source",
+			"problem": "invalid_source",
+		})
+	}
+
+	matches := query.Evaluate(root, maxDepth)
+	start, err := parseNodeQueryCursor(arguments["cursor"], len(matches))
+	if err != nil {
+		return nil, err
+	}
+	end := min(start+limit, len(matches))
+	result["selector"] = strings.TrimSpace(rawSelector)
+	result["matches"] = matches[start:end]
+	result["total"] = len(matches)
+	if end < len(matches) {
+		result["nextCursor"] = strconv.Itoa(end)
+	}
+	return json.Marshal(result)
+}
+
+// queryNodeFromCompact converts a snapshot tree, filling script and groups from
+// node details when the compact node does not carry them.
+func queryNodeFromCompact(node runtimebridge.CompactNode, details map[string]runtimebridge.NodeDetail) nodequery.Node {
+	out := nodequery.Node{
+		Path:   node.Path,
+		Name:   node.Name,
+		Type:   node.Type,
+		Script: node.ScriptPath,
+		Groups: node.Groups,
+	}
+	if detail, ok := details[node.Path]; ok {
+		if out.Script == "" {
+			out.Script = detail.Script
+		}
+		if len(out.Groups) == 0 {
+			out.Groups = detail.Groups
+		}
+	}
+	if len(node.Children) > 0 {
+		out.Children = make([]nodequery.Node, 0, len(node.Children))
+		for _, child := range node.Children {
+			out.Children = append(out.Children, queryNodeFromCompact(child, details))
+		}
+	}
+	return out
+}
+
+func parseNodeQueryCursor(raw any, total int) (int, error) {
+	invalid := tooltypes.NewSemanticError(tooltypes.SemanticKindInvalidParams, "Invalid cursor value", map[string]any{
+		"field":   "cursor",
+		"problem": "invalid_cursor",
+	})
+	if raw == nil {
+		return 0, nil
+	}
+	cursor, ok := raw.(string)
+	if !ok {
+		return 0, invalid
+	}
+	cursor = strings.TrimSpace(cursor)
+	if cursor == "" {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(cursor)
+	if err != nil || offset < 0 || offset > total {
+		return 0, invalid
+	}
+	return offset, nil
+}
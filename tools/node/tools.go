@@ -74,6 +74,7 @@ func GetAllTools() []tooltypes.Tool {
 		&CreateNodeTool{},
 		&DeleteNodeTool{},
 		&ModifyNodeTool{},
+		&QueryNodesTool{},
 	}
 }
 
@@ -113,3 +113,61 @@ func TestNodeCreateTool_FailsWhenRuntimeCommandSessionMissing(t *testing.T) {
 		t.Fatalf("expected not_available kind, got %s", semanticErr.Kind)
 	}
 }
+
+func TestQueryNodesTool_MatchesEditorSnapshotWithPagination(t *testing.T) {
+	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
+	runtimebridge.DefaultEditorStore().Upsert("editor-1", runtimebridge.Snapshot{
+		RootSummary: runtimebridge.RootSummary{ActiveScene: "res://Main.tscn"},
+		SceneTree: runtimebridge.CompactNode{
+			Path: "/root/Main", Name: "Main", Type: "Node2D",
+			Children: []runtimebridge.CompactNode{
+				{Path: "/root/Main/Player", Name: "Player", Type: "CharacterBody2D"},
+				{Path: "/root/Main/Enemies", Name: "Enemies", Type: "Node2D", Children: []runtimebridge.CompactNode{
+					{Path: "/root/Main/Enemies/Goblin1", Name: "Goblin1", Type: "CharacterBody2D"},
+					{Path: "/root/Main/Enemies/Goblin2", Name: "Goblin2", Type: "CharacterBody2D"},
+				}},
+			},
+		},
+		NodeDetails: map[string]runtimebridge.NodeDetail{
+			"/root/Main/Enemies/Goblin1": {Groups: []string{"enemies"}},
+			"/root/Main/Enemies/Goblin2": {Groups: []string{"enemies"}},
+		},
+	}, time.Now().UTC())
+
+	tool := &QueryNodesTool{}
+	raw := json.RawMessage(`{
+		"selector": "CharacterBody2D[group=enemies]",
+		"limit": 1,
+		"_mcp": {"session_id": "ai-session", "session_initialized": true}
+	}`)
+	resultRaw, err := tool.Execute(raw)
+	if err != nil {
+		t.Fatalf("execute godot.node.query: %v", err)
+	}
+	var result struct {
+		SessionID  string `json:"session_id"`
+		Total      int    `json:"total"`
+		NextCursor string `json:"nextCursor"`
+		Matches    []struct {
+			Path string `json:"path"`
+		} `json:"matches"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.SessionID != "editor-1" || result.Total != 2 || result.NextCursor != "1" {
+		t.Fatalf("unexpected query result: %s", string(resultRaw))
+	}
+	if len(result.Matches) != 1 || result.Matches[0].Path != "/root/Main/Enemies/Goblin1" {
+		t.Fatalf("unexpected first page: %s", string(resultRaw))
+	}
+
+	_, err = tool.Execute(json.RawMessage(`{
+		"selector": "Node2D >",
+		"_mcp": {"session_id": "ai-session", "session_initialized": true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Kind != tooltypes.SemanticKindInvalidParams {
+		t.Fatalf("expected invalid_params for bad selector, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package project
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	projectRunModeEditor   = "editor"
+	projectRunModeHeadless = "headless"
+
+	headlessSnapshotPollInterval = 250 * time.Millisecond
+)
+
+// runHeadlessProject starts the project as a server-managed Godot child process
+// instead of asking the editor plugin to play it. The process receives a
+// runtime handshake so the runtime companion registers like an editor run, and
+// its stdout/stderr are captured into the session's runtime log. Each of
+// several instances is a separate process with its own game session.
+func runHeadlessProject(arguments map[string]any, ctx tooltypes.MCPContext, toolName string, instances int) ([]byte, error) {
+	runSessionIDs := make([]string, instances)
+	for i := range runSessionIDs {
+		runSessionIDs[i] = generateGameSessionID()
+	}
+	if requestedSessionID := strings.TrimSpace(extractString(arguments["session_id"])); requestedSessionID != "" {
+		if !runtimebridge.ValidGameSessionID(requestedSessionID) {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("session_id must be 1-64 letters, digits, '_' or '-'", toolName, "invalid_session_id", map[string]any{
+				"session_id": requestedSessionID,
+			})
+		}
+		// A headless run owns its game session; attaching to an existing one
+		// would overwrite its launch token and handshake.
+		if _, exists := runtimebridge.DefaultGameSessionRegistry().Session(requestedSessionID); exists {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("session_id already belongs to a game session", toolName, "game_session_exists", map[string]any{
+				"session_id": requestedSessionID,
+			})
+		}
+		runSessionIDs[0] = requestedSessionID
+	}
+	scenePath, semErr := resolveRunScenePath(arguments, toolName)
+	if semErr != nil {
+		return nil, semErr
+	}
+	headless := true
+	if raw, ok := arguments["headless"]; ok {
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("headless must be a boolean", toolName, "invalid_headless", nil)
+		}
+		headless = value
+	}
+	userArgs := []string{}
+	if raw, ok := arguments["user_args"]; ok {
+		items, ok := raw.([]any)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("user_args must be an array of strings", toolName, "invalid_user_args", nil)
+		}
+		for _, item := range items {
+			value, ok := item.(string)
+			if !ok {
+				return nil, tooltypes.NewRuntimeInvalidParamsError("user_args must be an array of strings", toolName, "invalid_user_args", nil)
+			}
+			userArgs = append(userArgs, value)
+		}
+	}
+
+	projectDir, err := filepath.Abs(tooltypes.ResolveProjectRootFromEnvOrCWD())
+	if err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Project root is unavailable", toolName, "project_root_missing", map[string]any{
+			"detail": err.Error(),
+		})
+	}
+	launcher := runtimebridge.DefaultGameProcessLauncher()
+	if _, err := launcher.ResolveExecutable(); err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Godot executable is not configured", toolName, "godot_executable_missing", map[string]any{
+			"detail": err.Error(),
+			"hint":   "set runtime_bridge.godot_executable or MCP_RUNTIME_BRIDGE_GODOT_EXECUTABLE",
+		})
+	}
+
+	startedAt := time.Now().UTC()
+	launched := make([]projectRunLaunch, 0, instances)
+	// The calling MCP session owns the processes, so they are stopped when
+	// it closes or expires.
+	ownerSessionID := strings.TrimSpace(ctx.SessionID)
+	for i, runSessionID := range runSessionIDs {
+		launchToken := generateLaunchToken()
+		runtimebridge.DefaultGameSessionRegistry().UpsertFromRun(runSessionID, ownerSessionID, scenePath, launchToken, startedAt)
+		if instances > 1 {
+			runtimebridge.DefaultGameSessionRegistry().SetInstanceIndex(runSessionID, i+1)
+		}
+		process, err := launcher.Launch(runtimebridge.GameProcessLaunch{
+			SessionID:   runSessionID,
+			LaunchToken: launchToken,
+			ProjectDir:  projectDir,
+			ScenePath:   scenePath,
+			Headless:    headless,
+			UserArgs:    userArgs,
+			StartedAt:   startedAt,
+		})
+		if err != nil {
+			if !errors.Is(err, runtimebridge.ErrGameProcessRunning) {
+				cleanupFailedRunSession(runSessionID)
+			}
+			return nil, withLaunchedSessions(tooltypes.NewRuntimeNotAvailableError("Project process failed to start", toolName, "game_not_running", map[string]any{
+				"session_id": runSessionID,
+				"reason":     err.Error(),
+			}), launched)
+		}
+		log.Printf("godot-mcp project.run headless process started: caller_session_id=%q game_session_id=%q instance=%d/%d pid=%d args=%q", strings.TrimSpace(ctx.SessionID), runSessionID, i+1, instances, process.PID, process.Args)
+		launched = append(launched, projectRunLaunch{sessionID: runSessionID})
+	}
+
+	sessions := make([]map[string]any, 0, len(launched))
+	for _, launch := range launched {
+		connected, exited := awaitHeadlessRuntime(launch.sessionID, projectCommandTimeout)
+		process, _ := launcher.Process(launch.sessionID)
+		if exited {
+			semErr := tooltypes.NewRuntimeNotAvailableError("Project process exited during startup", toolName, "game_not_running", map[string]any{
+				"session_id": launch.sessionID,
+				"process":    process,
+				"hint":       "read godot.runtime.log.get for captured process output",
+			})
+			if instances > 1 {
+				semErr = withLaunchedSessions(semErr, launched)
+			}
+			return nil, semErr
+		}
+		session, _ := runtimebridge.DefaultGameSessionRegistry().Session(launch.sessionID)
+		sessions = append(sessions, projectRunSessionResult(session, map[string]any{
+			"process":           process,
+			"runtime_connected": connected,
+		}))
+	}
+
+	first := sessions[0]
+	return json.Marshal(map[string]any{
+		"success":           true,
+		"source":            "runtime",
+		"mode":              projectRunModeHeadless,
+		"session_id":        first["session_id"],
+		"running":           true,
+		"started_at":        first["started_at"],
+		"scene_path":        first["scene_path"],
+		"process":           first["process"],
+		"runtime_connected": first["runtime_connected"],
+		"instances":         len(sessions),
+		"sessions":          sessions,
+	})
+}
+
+// awaitHeadlessRuntime waits for the first runtime snapshot, returning early
+// when the process exits. A process without the runtime companion autoload
+// still runs and logs, it just never connects.
+func awaitHeadlessRuntime(sessionID string, timeout time.Duration) (bool, bool) {
+	done, _ := runtimebridge.DefaultGameProcessLauncher().Done(sessionID)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if _, _, ready := runtimebridge.DefaultRuntimeSnapshotStore().Await(sessionID, 0, headlessSnapshotPollInterval, runtimebridge.FreshnessStateFresh); ready {
+			return true, false
+		}
+		select {
+		case <-done:
+			return false, true
+		default:
+		}
+	}
+	return false, false
+}
+
+// stopHeadlessProject stops a server-managed game process. It returns false
+// when the session was not launched by the server. Only live transport state
+// is dropped; logs, process info, snapshots, watches, performance samples and
+// screenshots stay readable after the stop.
+func stopHeadlessProject(sessionID string) ([]byte, bool, error) {
+	process, ok := runtimebridge.DefaultGameProcessLauncher().Process(sessionID)
+	if !ok {
+		return nil, false, nil
+	}
+	runtimebridge.DefaultGameProcessLauncher().Stop(sessionID, 0)
+	process, _ = runtimebridge.DefaultGameProcessLauncher().Process(sessionID)
+	runtimebridge.DefaultGameSessionRegistry().StopSession(sessionID, time.Now().UTC())
+	runtimebridge.ForgetGameSessionTransport(sessionID)
+	out, err := json.Marshal(map[string]any{
+		"success":    true,
+		"source":     "runtime",
+		"mode":       projectRunModeHeadless,
+		"session_id": sessionID,
+		"running":    false,
+		"process":    process,
+	})
+	return out, true, err
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/fs"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"sort"
@@ -23,6 +24,9 @@ import (
 const projectCommandTimeout = 8 * time.Second
 const projectListPageSize = 200
 
+// maxProjectRunInstances caps the instances one godot.project.run may start.
+const maxProjectRunInstances = 8
+
 type GetProjectSettingsTool struct{}
 
 func (t *GetProjectSettingsTool) Name() string        { return "godot.project.settings.get" }
@@ -131,12 +135,19 @@ func (t *ListProjectResourcesTool) Execute(args json.RawMessage) ([]byte, error)
 		}
 
 		name := entry.Name()
+		relPath, relErr := filepath.Rel(projectAbs, path)
+		if relErr != nil {
+			return relErr
+		}
 		if entry.IsDir() {
-			if shouldSkipProjectDir(name) {
+			if shouldSkipProjectDir(name) || tooltypes.PathSandboxSkipsDir(relPath) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
+		if !tooltypes.PathSandboxAllows(relPath) {
+			return nil
+		}
 
 		if !payload.IncludeHidden && strings.HasPrefix(name, ".") {
 			return nil
@@ -153,10 +164,6 @@ func (t *ListProjectResourcesTool) Execute(args json.RawMessage) ([]byte, error)
 		if statErr != nil {
 			return statErr
 		}
-		relPath, relErr := filepath.Rel(projectAbs, path)
-		if relErr != nil {
-			return relErr
-		}
 		resources = append(resources, projectResourceEntry{
 			Path:       "res://" + filepath.ToSlash(relPath),
 			Extension:  ext,
@@ -278,6 +285,7 @@ func (t *IsProjectRunningTool) Execute(args json.RawMessage) ([]byte, error) {
 		querySessionID = strings.TrimSpace(value)
 	}
 	resolvedEditorSessionID := ""
+	var runningSessions []map[string]any
 	if querySessionID == "" {
 		editorSessionID, semErr := tooltypes.ResolveFreshEditorSessionID(arguments, ctx, t.Name(), "Project running check requires healthy editor snapshot")
 		if semErr != nil {
@@ -287,6 +295,10 @@ func (t *IsProjectRunningTool) Execute(args json.RawMessage) ([]byte, error) {
 		if active, ok := runtimebridge.DefaultGameSessionRegistry().ActiveForEditor(editorSessionID); ok {
 			querySessionID = active.SessionID
 		}
+		runningSessions = make([]map[string]any, 0)
+		for _, session := range runtimebridge.DefaultGameSessionRegistry().RunningForEditor(editorSessionID) {
+			runningSessions = append(runningSessions, projectRunSessionResult(session, nil))
+		}
 	}
 	if querySessionID == "" {
 		return json.Marshal(map[string]any{
@@ -294,6 +306,7 @@ func (t *IsProjectRunningTool) Execute(args json.RawMessage) ([]byte, error) {
 			"running":           false,
 			"session_id":        "",
 			"editor_session_id": resolvedEditorSessionID,
+			"sessions":          runningSessions,
 		})
 	}
 	session, ok := runtimebridge.DefaultGameSessionRegistry().Session(querySessionID)
@@ -305,20 +318,30 @@ func (t *IsProjectRunningTool) Execute(args json.RawMessage) ([]byte, error) {
 			"editor_session_id": resolvedEditorSessionID,
 		})
 	}
-	return json.Marshal(map[string]any{
+	result := map[string]any{
 		"source":            "runtime",
 		"session_id":        session.SessionID,
 		"editor_session_id": session.EditorSessionID,
 		"running":           session.Running,
 		"started_at":        session.StartedAt,
 		"scene_path":        session.ScenePath,
-	})
+	}
+	if session.InstanceIndex > 0 {
+		result["instance_index"] = session.InstanceIndex
+	}
+	// Only an editor-scoped check lists sibling instances.
+	if runningSessions != nil {
+		result["sessions"] = runningSessions
+	}
+	return json.Marshal(result)
 }
 
 type RunProjectTool struct{}
 
-func (t *RunProjectTool) Name() string        { return "godot.project.run" }
-func (t *RunProjectTool) Description() string { return "[editor-plugin] Runs the project" }
+func (t *RunProjectTool) Name() string { return "godot.project.run" }
+func (t *RunProjectTool) Description() string {
+	return "[editor-plugin] Runs the project through the editor, or as a server-managed headless process"
+}
 func (t *RunProjectTool) Annotations() *mcp.ToolAnnotations {
 	return &mcp.ToolAnnotations{
 		ReadOnlyHint: tooltypes.BoolPtr(false),
@@ -331,6 +354,10 @@ func (t *RunProjectTool) InputSchema() mcp.InputSchema {
 			"session_id":        map[string]any{"type": "string", "description": "Optional pre-allocated game session id"},
 			"scene_path":        map[string]any{"type": "string", "description": "Optional scene path for runtime launch metadata"},
 			"editor_session_id": map[string]any{"type": "string", "description": "Optional explicit editor session id override"},
+			"mode":              map[string]any{"type": "string", "description": "editor (default) plays through the editor plugin; headless spawns a Godot process"},
+			"headless":          map[string]any{"type": "boolean", "description": "Headless mode only: pass --headless (default true)"},
+			"user_args":         map[string]any{"type": "array", "description": "Headless mode only: extra arguments passed after --"},
+			"instances":         map[string]any{"type": "integer", "description": "Number of game instances to start, each with its own game session id (default 1, max 8)"},
 		},
 		Required: []string{},
 		Title:    "Run Project",
@@ -345,45 +372,132 @@ func (t *RunProjectTool) Execute(args json.RawMessage) ([]byte, error) {
 	if strings.TrimSpace(ctx.SessionID) == "" || !ctx.SessionInitialized {
 		return nil, tooltypes.NewRuntimeNotAvailableError("Project run requires initialized session", t.Name(), "editor_session_missing", nil)
 	}
+	instances, semErr := parseProjectRunInstances(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	switch mode := strings.ToLower(extractString(arguments["mode"])); mode {
+	case "", projectRunModeEditor:
+	case projectRunModeHeadless:
+		return runHeadlessProject(arguments, ctx, t.Name(), instances)
+	default:
+		return nil, tooltypes.NewRuntimeInvalidParamsError("mode must be editor or headless", t.Name(), "invalid_run_mode", map[string]any{
+			"mode": mode,
+		})
+	}
 
-	runSessionID := strings.TrimSpace(extractString(arguments["session_id"]))
-	if runSessionID == "" {
-		runSessionID = generateGameSessionID()
+	runSessionIDs := make([]string, instances)
+	for i := range runSessionIDs {
+		runSessionIDs[i] = generateGameSessionID()
+	}
+	if requestedSessionID := strings.TrimSpace(extractString(arguments["session_id"])); requestedSessionID != "" {
+		runSessionIDs[0] = requestedSessionID
+	}
+	scenePath, semErr := resolveRunScenePath(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
 	}
-	scenePath := strings.TrimSpace(extractString(arguments["scene_path"]))
-	launchToken := generateLaunchToken()
 	startedAt := time.Now().UTC()
-	editorCommandSessionID, semErr := resolveProjectEditorCommandSessionID(arguments, ctx, runSessionID, t.Name())
+	editorCommandSessionID, semErr := resolveProjectEditorCommandSessionID(arguments, ctx, runSessionIDs[0], t.Name())
 	if semErr != nil {
 		return nil, semErr
 	}
-	log.Printf("godot-mcp project.run request received: caller_session_id=%q editor_session_id=%q game_session_id=%q launch_token=%q scene_path=%q", strings.TrimSpace(ctx.SessionID), editorCommandSessionID, runSessionID, launchToken, scenePath)
+	log.Printf("godot-mcp project.run request received: caller_session_id=%q editor_session_id=%q game_session_ids=%q instances=%d scene_path=%q", strings.TrimSpace(ctx.SessionID), editorCommandSessionID, runSessionIDs, instances, scenePath)
 
 	// Clean up zombie game sessions left by previous project.run calls that
 	// timed out waiting for the first runtime snapshot.  These are sessions
 	// that are still Running but have no RuntimeSessionID (runtime addon
-	// never connected).
-	if staleCount := runtimebridge.DefaultGameSessionRegistry().StopStaleForEditor(editorCommandSessionID, runSessionID, startedAt); staleCount > 0 {
+	// never connected).  This runs once, before any instance is registered,
+	// so instances of this run never count as stale siblings.
+	if staleCount := runtimebridge.DefaultGameSessionRegistry().StopStaleForEditor(editorCommandSessionID, runSessionIDs[0], startedAt); staleCount > 0 {
 		log.Printf("godot-mcp project.run cleaned up %d stale game session(s) for editor %q", staleCount, editorCommandSessionID)
 	}
 
-	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun(runSessionID, editorCommandSessionID, scenePath, launchToken, startedAt)
+	launched := make([]projectRunLaunch, 0, instances)
+	for i, runSessionID := range runSessionIDs {
+		launch, semErr := launchEditorProjectInstance(t.Name(), editorCommandSessionID, runSessionID, scenePath, i+1, instances, startedAt)
+		if semErr != nil {
+			return nil, withLaunchedSessions(semErr, launched)
+		}
+		launched = append(launched, launch)
+	}
+
+	for _, launch := range launched {
+		if _, reason, ready := runtimebridge.DefaultRuntimeSnapshotStore().Await(launch.sessionID, 0, projectCommandTimeout, runtimebridge.FreshnessStateFresh); !ready {
+			log.Printf("godot-mcp project.run await first snapshot failed: editor_session_id=%q game_session_id=%q reason=%q", editorCommandSessionID, launch.sessionID, strings.TrimSpace(reason))
+			semErr := tooltypes.NewRuntimeNotAvailableError("Project run timed out waiting for runtime snapshot", t.Name(), reason, map[string]any{
+				"session_id":                   launch.sessionID,
+				"editor_session_id":            editorCommandSessionID,
+				"runtime_registration_pending": true,
+			})
+			if instances > 1 {
+				semErr = withLaunchedSessions(semErr, launched)
+			}
+			return nil, semErr
+		}
+		log.Printf("godot-mcp project.run first snapshot observed: editor_session_id=%q game_session_id=%q", editorCommandSessionID, launch.sessionID)
+	}
 
-	ack, ok, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(editorCommandSessionID, t.Name(), map[string]any{
+	sessions := make([]map[string]any, 0, len(launched))
+	for _, launch := range launched {
+		session, _ := runtimebridge.DefaultGameSessionRegistry().Session(launch.sessionID)
+		sessions = append(sessions, projectRunSessionResult(session, map[string]any{
+			"result": launch.ack.Result,
+		}))
+	}
+	session, _ := runtimebridge.DefaultGameSessionRegistry().Session(launched[0].sessionID)
+	return json.Marshal(map[string]any{
+		"success":           true,
+		"source":            "runtime",
+		"session_id":        session.SessionID,
+		"editor_session_id": editorCommandSessionID,
+		"running":           true,
+		"started_at":        session.StartedAt,
+		"scene_path":        session.ScenePath,
+		"result":            launched[0].ack.Result,
+		"instances":         len(launched),
+		"sessions":          sessions,
+	})
+}
+
+// projectRunLaunch is one game instance the editor plugin acknowledged.
+type projectRunLaunch struct {
+	sessionID string
+	ack       runtimebridge.CommandAck
+}
+
+// launchEditorProjectInstance asks the editor plugin to start one game
+// instance and registers it. Instance 1 is played by the editor; further
+// instances of a multi-instance run are spawned by the plugin as separate
+// processes with their own runtime handshake.
+func launchEditorProjectInstance(toolName string, editorCommandSessionID string, runSessionID string, scenePath string, instanceIndex int, instanceCount int, startedAt time.Time) (projectRunLaunch, *tooltypes.SemanticError) {
+	launchToken := generateLaunchToken()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun(runSessionID, editorCommandSessionID, scenePath, launchToken, startedAt)
+	commandArgs := map[string]any{
 		"session_id":   runSessionID,
 		"launch_token": launchToken,
 		"scene_path":   scenePath,
-	}, projectCommandTimeout)
-	log.Printf("godot-mcp project.run dispatched: editor_session_id=%q game_session_id=%q launch_token=%q dispatch_ok=%t reason=%q", editorCommandSessionID, runSessionID, launchToken, ok, strings.TrimSpace(reason))
+	}
+	if instanceCount > 1 {
+		runtimebridge.DefaultGameSessionRegistry().SetInstanceIndex(runSessionID, instanceIndex)
+		commandArgs["instance_index"] = instanceIndex
+		commandArgs["instance_count"] = instanceCount
+	}
+
+	ack, ok, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(editorCommandSessionID, toolName, commandArgs, projectCommandTimeout)
+	log.Printf("godot-mcp project.run dispatched: editor_session_id=%q game_session_id=%q instance=%d/%d launch_token=%q dispatch_ok=%t reason=%q", editorCommandSessionID, runSessionID, instanceIndex, instanceCount, launchToken, ok, strings.TrimSpace(reason))
 	if !ok {
 		cleanupFailedRunSession(runSessionID)
-		return nil, tooltypes.NewRuntimeNotAvailableError("Project run bridge is unavailable", t.Name(), mapProjectCommandReason(reason), map[string]any{
+		if limited := tooltypes.CommandInFlightLimitedError(toolName, editorCommandSessionID, reason); limited != nil {
+			return projectRunLaunch{}, limited
+		}
+		return projectRunLaunch{}, tooltypes.NewRuntimeNotAvailableError("Project run bridge is unavailable", toolName, mapProjectCommandReason(reason), map[string]any{
 			"reason": reason,
 		})
 	}
 	if !ack.Success {
 		cleanupFailedRunSession(runSessionID)
-		return nil, tooltypes.NewRuntimeNotAvailableError("Project run failed", t.Name(), "game_not_running", map[string]any{
+		return projectRunLaunch{}, tooltypes.NewRuntimeNotAvailableError("Project run failed", toolName, "game_not_running", map[string]any{
 			"reason": ack.Error,
 		})
 	}
@@ -412,35 +526,19 @@ func (t *RunProjectTool) Execute(args json.RawMessage) ([]byte, error) {
 		}
 	}
 	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun(runSessionID, editorCommandSessionID, scenePath, launchToken, startedAt)
-	log.Printf("godot-mcp project.run ack accepted: editor_session_id=%q game_session_id=%q launch_token=%q scene_path=%q", editorCommandSessionID, runSessionID, launchToken, scenePath)
-
-	if _, reason, ready := runtimebridge.DefaultRuntimeSnapshotStore().Await(runSessionID, 0, projectCommandTimeout, runtimebridge.FreshnessStateFresh); !ready {
-		log.Printf("godot-mcp project.run await first snapshot failed: editor_session_id=%q game_session_id=%q reason=%q", editorCommandSessionID, runSessionID, strings.TrimSpace(reason))
-		return nil, tooltypes.NewRuntimeNotAvailableError("Project run timed out waiting for runtime snapshot", t.Name(), reason, map[string]any{
-			"session_id":                   runSessionID,
-			"editor_session_id":            editorCommandSessionID,
-			"runtime_registration_pending": true,
-		})
+	if instanceCount > 1 {
+		runtimebridge.DefaultGameSessionRegistry().SetInstanceIndex(runSessionID, instanceIndex)
 	}
-	log.Printf("godot-mcp project.run first snapshot observed: editor_session_id=%q game_session_id=%q", editorCommandSessionID, runSessionID)
-
-	session, _ := runtimebridge.DefaultGameSessionRegistry().Session(runSessionID)
-	return json.Marshal(map[string]any{
-		"success":           true,
-		"source":            "runtime",
-		"session_id":        runSessionID,
-		"editor_session_id": editorCommandSessionID,
-		"running":           true,
-		"started_at":        session.StartedAt,
-		"scene_path":        session.ScenePath,
-		"result":            ack.Result,
-	})
+	log.Printf("godot-mcp project.run ack accepted: editor_session_id=%q game_session_id=%q launch_token=%q scene_path=%q", editorCommandSessionID, runSessionID, launchToken, scenePath)
+	return projectRunLaunch{sessionID: runSessionID, ack: ack}, nil
 }
 
 type StopProjectTool struct{}
 
-func (t *StopProjectTool) Name() string        { return "godot.project.stop" }
-func (t *StopProjectTool) Description() string { return "[editor-plugin] Stops the running project" }
+func (t *StopProjectTool) Name() string { return "godot.project.stop" }
+func (t *StopProjectTool) Description() string {
+	return "[editor-plugin] Stops one game session, or every running game session of the editor"
+}
 func (t *StopProjectTool) Annotations() *mcp.ToolAnnotations {
 	return &mcp.ToolAnnotations{
 		ReadOnlyHint: tooltypes.BoolPtr(false),
@@ -450,7 +548,7 @@ func (t *StopProjectTool) InputSchema() mcp.InputSchema {
 	return mcp.InputSchema{
 		Type: "object",
 		Properties: map[string]any{
-			"session_id":        map[string]any{"type": "string", "description": "Optional target game session id"},
+			"session_id":        map[string]any{"type": "string", "description": "Optional target game session id; omit to stop all running instances"},
 			"editor_session_id": map[string]any{"type": "string", "description": "Optional explicit editor session id override"},
 		},
 		Required: []string{},
@@ -467,49 +565,87 @@ func (t *StopProjectTool) Execute(args json.RawMessage) ([]byte, error) {
 		return nil, tooltypes.NewRuntimeNotAvailableError("Project stop requires initialized session", t.Name(), "editor_session_missing", nil)
 	}
 	targetSessionID := strings.TrimSpace(extractString(arguments["session_id"]))
+	if out, handled, err := stopHeadlessProject(targetSessionID); handled {
+		return out, err
+	}
+	// Without a session_id every running game session of the editor is
+	// stopped, which covers all instances of a multi-instance run.
+	targetSessionIDs := []string{targetSessionID}
 	if targetSessionID == "" {
 		resolvedEditorSessionID, semErr := tooltypes.ResolveFreshEditorSessionID(arguments, ctx, t.Name(), "Project stop requires healthy editor snapshot")
 		if semErr != nil {
 			return nil, semErr
 		}
-		if active, ok := runtimebridge.DefaultGameSessionRegistry().ActiveForEditor(resolvedEditorSessionID); ok {
-			targetSessionID = active.SessionID
+		if running := runtimebridge.DefaultGameSessionRegistry().RunningForEditor(resolvedEditorSessionID); len(running) > 0 {
+			targetSessionIDs = targetSessionIDs[:0]
+			for _, session := range running {
+				targetSessionIDs = append(targetSessionIDs, session.SessionID)
+			}
 		}
 	}
 
-	editorCommandSessionID, semErr := resolveProjectEditorCommandSessionID(arguments, ctx, targetSessionID, t.Name())
+	editorCommandSessionID, semErr := resolveProjectEditorCommandSessionID(arguments, ctx, targetSessionIDs[0], t.Name())
 	if semErr != nil {
 		return nil, semErr
 	}
-	ack, ok, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(editorCommandSessionID, t.Name(), map[string]any{
-		"session_id": targetSessionID,
-	}, projectCommandTimeout)
-	if !ok {
-		return nil, tooltypes.NewRuntimeNotAvailableError("Project stop bridge is unavailable", t.Name(), mapProjectCommandReason(reason), map[string]any{
-			"reason": reason,
-		})
-	}
-	if !ack.Success {
-		return nil, tooltypes.NewRuntimeNotAvailableError("Project stop failed", t.Name(), "game_not_running", map[string]any{
-			"reason": ack.Error,
-		})
-	}
-
-	if targetSessionID != "" {
-		runtimebridge.DefaultGameSessionRegistry().StopSession(targetSessionID, time.Now().UTC())
-		runtimebridge.DefaultRuntimeSnapshotStore().RemoveSession(targetSessionID)
-		runtimebridge.DefaultRuntimeLogStore().RemoveSession(targetSessionID)
+	stopped := make([]map[string]any, 0, len(targetSessionIDs))
+	var firstResult map[string]any
+	for _, sessionID := range targetSessionIDs {
+		ack, ok, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(editorCommandSessionID, t.Name(), map[string]any{
+			"session_id": sessionID,
+		}, projectCommandTimeout)
+		if !ok {
+			if limited := tooltypes.CommandInFlightLimitedError(t.Name(), editorCommandSessionID, reason); limited != nil {
+				return nil, withStoppedSessions(limited, stopped)
+			}
+			return nil, withStoppedSessions(tooltypes.NewRuntimeNotAvailableError("Project stop bridge is unavailable", t.Name(), mapProjectCommandReason(reason), map[string]any{
+				"reason": reason,
+			}), stopped)
+		}
+		if !ack.Success {
+			return nil, withStoppedSessions(tooltypes.NewRuntimeNotAvailableError("Project stop failed", t.Name(), "game_not_running", map[string]any{
+				"reason": ack.Error,
+			}), stopped)
+		}
+		if firstResult == nil {
+			firstResult = ack.Result
+		}
+		if sessionID == "" {
+			continue
+		}
+		runtimebridge.DefaultGameSessionRegistry().StopSession(sessionID, time.Now().UTC())
+		runtimebridge.ForgetGameSession(sessionID)
+		session, _ := runtimebridge.DefaultGameSessionRegistry().Session(sessionID)
+		stopped = append(stopped, projectRunSessionResult(session, map[string]any{
+			"session_id": sessionID,
+			"result":     ack.Result,
+		}))
 	}
 	return json.Marshal(map[string]any{
 		"success":           true,
 		"source":            "runtime",
-		"session_id":        targetSessionID,
+		"session_id":        targetSessionIDs[0],
 		"editor_session_id": editorCommandSessionID,
 		"running":           false,
-		"result":            ack.Result,
+		"result":            firstResult,
+		"stopped_sessions":  stopped,
 	})
 }
 
+// withStoppedSessions lists the game sessions a stop-all already stopped
+// before one instance failed to stop.
+func withStoppedSessions(semErr *tooltypes.SemanticError, stopped []map[string]any) *tooltypes.SemanticError {
+	if len(stopped) == 0 {
+		return semErr
+	}
+	sessionIDs := make([]string, 0, len(stopped))
+	for _, session := range stopped {
+		sessionIDs = append(sessionIDs, extractString(session["session_id"]))
+	}
+	semErr.Data["stopped_session_ids"] = sessionIDs
+	return semErr
+}
+
 func GetAllTools() []tooltypes.Tool {
 	return []tooltypes.Tool{
 		&GetProjectSettingsTool{},
@@ -566,6 +702,76 @@ func resolveProjectEditorCommandSessionID(arguments map[string]any, ctx tooltype
 	return tooltypes.ResolveFreshEditorSessionID(arguments, ctx, toolName, "Project command requires healthy editor snapshot")
 }
 
+// parseProjectRunInstances reads the optional instance count of
+// godot.project.run.
+func parseProjectRunInstances(arguments map[string]any, toolName string) (int, *tooltypes.SemanticError) {
+	raw, ok := arguments["instances"]
+	if !ok || raw == nil {
+		return 1, nil
+	}
+	value, ok := raw.(float64)
+	if !ok || value != float64(int(value)) || value < 1 || value > maxProjectRunInstances {
+		return 0, tooltypes.NewRuntimeInvalidParamsError(fmt.Sprintf("instances must be an integer between 1 and %d", maxProjectRunInstances), toolName, "invalid_instances", map[string]any{
+			"max_instances": maxProjectRunInstances,
+		})
+	}
+	return int(value), nil
+}
+
+// withLaunchedSessions lists the instances that were already started when a
+// multi-instance run fails partway, so the caller can inspect or stop them.
+func withLaunchedSessions(semErr *tooltypes.SemanticError, launched []projectRunLaunch) *tooltypes.SemanticError {
+	if semErr == nil || len(launched) == 0 {
+		return semErr
+	}
+	sessionIDs := make([]string, 0, len(launched))
+	for _, launch := range launched {
+		sessionIDs = append(sessionIDs, launch.sessionID)
+	}
+	semErr.Data["launched_session_ids"] = sessionIDs
+	return semErr
+}
+
+// projectRunSessionResult describes one game session of a run or stop result.
+func projectRunSessionResult(session runtimebridge.GameSession, extra map[string]any) map[string]any {
+	out := map[string]any{
+		"session_id": session.SessionID,
+		"running":    session.Running,
+		"started_at": session.StartedAt,
+		"scene_path": session.ScenePath,
+	}
+	if session.InstanceIndex > 0 {
+		out["instance_index"] = session.InstanceIndex
+	}
+	maps.Copy(out, extra)
+	return out
+}
+
+// resolveRunScenePath normalizes scene_path to a res:// path inside the
+// project and applies the path sandbox, so running a scene cannot load files
+// the file tools refuse. An empty scene_path runs the main scene.
+func resolveRunScenePath(arguments map[string]any, toolName string) (string, *tooltypes.SemanticError) {
+	scenePath := strings.TrimSpace(extractString(arguments["scene_path"]))
+	if scenePath == "" {
+		return "", nil
+	}
+	if strings.HasPrefix(scenePath, "-") {
+		return "", tooltypes.NewRuntimeInvalidParamsError("scene_path must not start with '-'", toolName, "invalid_scene_path", map[string]any{
+			"scene_path": scenePath,
+		})
+	}
+	_, resPath, err := tooltypes.ResolveProjectFilePath(scenePath, nil)
+	if semErr, ok := tooltypes.AsSemanticError(err); ok {
+		return "", semErr
+	}
+	if err != nil {
+		return "", tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_scene_path", map[string]any{
+			"scene_path": scenePath,
+		})
+	}
+	return resPath, nil
+}
+
 func extractString(raw any) string {
 	value, _ := raw.(string)
 	return strings.TrimSpace(value)
@@ -593,8 +799,7 @@ func cleanupFailedRunSession(sessionID string) {
 		return
 	}
 	runtimebridge.DefaultGameSessionRegistry().RemoveSession(sessionID)
-	runtimebridge.DefaultRuntimeSnapshotStore().RemoveSession(sessionID)
-	runtimebridge.DefaultRuntimeLogStore().RemoveSession(sessionID)
+	runtimebridge.ForgetGameSession(sessionID)
 }
 
 type projectSettingEntry struct {
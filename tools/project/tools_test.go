@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -87,6 +89,40 @@ func TestListProjectResourcesTool_FiltersByExtension(t *testing.T) {
 	}
 }
 
+func TestListProjectResourcesTool_HidesSandboxedPaths(t *testing.T) {
+	projectRoot := t.TempDir()
+	for _, rel := range []string{"project.godot", "addons/tool/plugin.gd", "scenes/Main.tscn", "icon.svg.import"} {
+		path := filepath.Join(projectRoot, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+	t.Setenv("GODOT_PROJECT_ROOT", projectRoot)
+	tooltypes.SetPathSandboxRules(tooltypes.PathSandboxRules{Exclude: []string{"addons/**", "*.import"}})
+	t.Cleanup(func() { tooltypes.SetPathSandboxRules(tooltypes.PathSandboxRules{}) })
+
+	resultRaw, err := (&ListProjectResourcesTool{}).Execute(json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("execute godot.project.resources.list: %v", err)
+	}
+	var result struct {
+		Resources []projectResourceEntry `json:"resources"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	paths := make([]string, 0, len(result.Resources))
+	for _, entry := range result.Resources {
+		paths = append(paths, entry.Path)
+	}
+	if strings.Join(paths, ",") != "res://project.godot,res://scenes/Main.tscn" {
+		t.Fatalf("expected sandboxed paths to be hidden, got %v", paths)
+	}
+}
+
 func TestGetEditorStateTool_UsesRuntimeSnapshot(t *testing.T) {
 	runtimebridge.ResetDefaultStoreForTests(10 * time.Second)
 	runtimebridge.DefaultEditorStore().Upsert("session-1", runtimebridge.Snapshot{
@@ -789,6 +825,121 @@ func TestStopProject_DispatchesToTargetGameOwnerEditorSession(t *testing.T) {
 	}
 }
 
+func TestRunProject_StartsSeveralInstancesAndStopWithoutSessionStopsAll(t *testing.T) {
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
+	runtimebridge.ResetDefaultRuntimeSnapshotStoreForTests(10*time.Second, 0)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	runtimebridge.DefaultEditorStore().Upsert("session-1", runtimebridge.Snapshot{
+		RootSummary: runtimebridge.RootSummary{ActiveScene: "res://Main.tscn"},
+	}, time.Now().UTC())
+	broker := runtimebridge.DefaultCommandBroker()
+	var mu sync.Mutex
+	runInstances := []int{}
+	stopped := []string{}
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		commandName, _ := params["name"].(string)
+		arguments, _ := params["arguments"].(map[string]any)
+		gameSessionID, _ := arguments["session_id"].(string)
+		mu.Lock()
+		if commandName == "godot.project.stop" {
+			stopped = append(stopped, gameSessionID)
+		} else {
+			instanceIndex, _ := arguments["instance_index"].(int)
+			runInstances = append(runInstances, instanceIndex)
+		}
+		mu.Unlock()
+		go func() {
+			if commandName != "godot.project.stop" {
+				now := time.Now().UTC()
+				runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport(gameSessionID, "runtime-"+gameSessionID, "session-1", "res://Main.tscn", now, "")
+				runtimebridge.DefaultRuntimeSnapshotStore().Upsert(gameSessionID, runtimebridge.RuntimeSnapshot{
+					SessionID:  gameSessionID,
+					SnapshotID: "snap_" + gameSessionID,
+					Frame:      1,
+					UpdatedAt:  now.Format(time.RFC3339Nano),
+					Running:    true,
+				}, now)
+			}
+			broker.Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result: map[string]any{
+					"session_id": gameSessionID,
+				},
+			})
+		}()
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	resultRaw, err := (&RunProjectTool{}).Execute(json.RawMessage(`{"instances":3,"_mcp":{"session_id":"session-1","session_initialized":true}}`))
+	if err != nil {
+		t.Fatalf("execute godot.project.run: %v", err)
+	}
+	var result struct {
+		SessionID string `json:"session_id"`
+		Instances int    `json:"instances"`
+		Sessions  []struct {
+			SessionID     string `json:"session_id"`
+			InstanceIndex int    `json:"instance_index"`
+		} `json:"sessions"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.Instances != 3 || len(result.Sessions) != 3 || result.SessionID != result.Sessions[0].SessionID {
+		t.Fatalf("expected three instances led by the first, got %s", resultRaw)
+	}
+	for i, session := range result.Sessions {
+		if session.InstanceIndex != i+1 {
+			t.Fatalf("expected instance_index %d, got %+v", i+1, session)
+		}
+	}
+	if len(runInstances) != 3 || runInstances[0] != 1 || runInstances[2] != 3 {
+		t.Fatalf("expected one run command per instance, got %v", runInstances)
+	}
+	if running := runtimebridge.DefaultGameSessionRegistry().RunningForEditor("session-1"); len(running) != 3 {
+		t.Fatalf("expected three running sessions for the editor, got %d", len(running))
+	}
+
+	stopRaw, err := (&StopProjectTool{}).Execute(json.RawMessage(`{"session_id":"` + result.Sessions[1].SessionID + `","_mcp":{"session_id":"session-1","session_initialized":true}}`))
+	if err != nil {
+		t.Fatalf("execute godot.project.stop for one instance: %v", err)
+	}
+	if running := runtimebridge.DefaultGameSessionRegistry().RunningForEditor("session-1"); len(running) != 2 {
+		t.Fatalf("expected stopping one instance to keep the others, got %s", stopRaw)
+	}
+
+	stopRaw, err = (&StopProjectTool{}).Execute(json.RawMessage(`{"_mcp":{"session_id":"session-1","session_initialized":true}}`))
+	if err != nil {
+		t.Fatalf("execute godot.project.stop: %v", err)
+	}
+	var stopResult struct {
+		StoppedSessions []map[string]any `json:"stopped_sessions"`
+	}
+	if err := json.Unmarshal(stopRaw, &stopResult); err != nil {
+		t.Fatalf("unmarshal stop result: %v", err)
+	}
+	if len(stopResult.StoppedSessions) != 2 || len(stopped) != 3 {
+		t.Fatalf("expected stop without session_id to stop the remaining instances, got %s (dispatched %v)", stopRaw, stopped)
+	}
+	if running := runtimebridge.DefaultGameSessionRegistry().RunningForEditor("session-1"); len(running) != 0 {
+		t.Fatalf("expected no running instances, got %+v", running)
+	}
+}
+
+func TestRunProject_RejectsInvalidInstanceCount(t *testing.T) {
+	_, err := (&RunProjectTool{}).Execute(json.RawMessage(`{"instances":9,"_mcp":{"session_id":"session-1","session_initialized":true}}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "invalid_instances" {
+		t.Fatalf("expected invalid_instances error, got %v", err)
+	}
+}
+
 func TestIsProjectRunning_UsesGameSessionRegistry(t *testing.T) {
 	runtimebridge.ResetDefaultGameSessionRegistryForTests()
 	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
@@ -898,3 +1049,175 @@ func TestIsProjectRunning_ReturnsErrorWithoutHealthyEditorSession(t *testing.T)
 		t.Fatalf("expected code runtime_snapshot_missing, got %v", semanticErr.Data["code"])
 	}
 }
+
+func writeFakeGodot(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the godot executable")
+	}
+	path := filepath.Join(t.TempDir(), "godot")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+		t.Fatalf("write fake godot: %v", err)
+	}
+	return path
+}
+
+func TestRunProject_HeadlessReportsEarlyExitWithCapturedOutput(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(writeFakeGodot(t, "echo 'ERROR: Failed to load project' >&2\nexit 1\n"), "http://localhost:9080/mcp", filepath.Join(t.TempDir(), "handshakes"))
+	t.Setenv("GODOT_PROJECT_ROOT", t.TempDir())
+
+	_, err := (&RunProjectTool{}).Execute(json.RawMessage(`{
+		"mode":"headless",
+		"session_id":"game_headless",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "game_not_running" {
+		t.Fatalf("expected game_not_running, got %v", err)
+	}
+	entries := runtimebridge.DefaultRuntimeLogStore().Get("game_headless", "error", 10, 0)
+	if len(entries) == 0 || entries[0].Message != "ERROR: Failed to load project" || entries[0].Source != "process_stderr" {
+		t.Fatalf("expected captured stderr entry, got %+v", entries)
+	}
+}
+
+func TestRunProject_HeadlessRejectsUnsafeArguments(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	handshakeDir := filepath.Join(t.TempDir(), "handshakes")
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(writeFakeGodot(t, "exit 0\n"), "http://localhost:9080/mcp", handshakeDir)
+	t.Setenv("GODOT_PROJECT_ROOT", t.TempDir())
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_existing", "editor-1", "", "launch-token", time.Now().UTC())
+
+	for args, code := range map[string]string{
+		`"session_id":"../../escape"`:           "invalid_session_id",
+		`"session_id":"game_existing"`:          "game_session_exists",
+		`"scene_path":"--script=res://evil.gd"`: "invalid_scene_path",
+	} {
+		_, err := (&RunProjectTool{}).Execute(json.RawMessage(`{"mode":"headless",` + args + `,"_mcp":{"session_id":"ai-session","session_initialized":true}}`))
+		semanticErr, ok := tooltypes.AsSemanticError(err)
+		if !ok || semanticErr.Kind != tooltypes.SemanticKindInvalidParams || semanticErr.Data["code"] != code {
+			t.Fatalf("expected %s for %s, got %v", code, args, err)
+		}
+	}
+	if session, _ := runtimebridge.DefaultGameSessionRegistry().Session("game_existing"); session.EditorSessionID != "editor-1" {
+		t.Fatalf("expected existing game session to be left alone, got %+v", session)
+	}
+	if entries, _ := os.ReadDir(handshakeDir); len(entries) != 0 {
+		t.Fatalf("expected no handshake files, got %v", entries)
+	}
+}
+
+func TestRunProject_ScenePathGoesThroughPathSandbox(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	handshakeDir := filepath.Join(t.TempDir(), "handshakes")
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(writeFakeGodot(t, "exit 0\n"), "http://localhost:9080/mcp", handshakeDir)
+	t.Setenv("GODOT_PROJECT_ROOT", t.TempDir())
+	tooltypes.SetPathSandboxRules(tooltypes.PathSandboxRules{Exclude: []string{"secret/**"}})
+	t.Cleanup(func() { tooltypes.SetPathSandboxRules(tooltypes.PathSandboxRules{}) })
+
+	for _, mode := range []string{"headless", "editor"} {
+		for scenePath, kind := range map[string]string{
+			"res://secret/Level.tscn": tooltypes.SemanticKindPathForbidden,
+			"res://../outside.tscn":   tooltypes.SemanticKindInvalidParams,
+			"/etc/passwd":             tooltypes.SemanticKindInvalidParams,
+		} {
+			_, err := (&RunProjectTool{}).Execute(json.RawMessage(`{"mode":"` + mode + `","scene_path":"` + scenePath + `","_mcp":{"session_id":"ai-session","session_initialized":true}}`))
+			semanticErr, ok := tooltypes.AsSemanticError(err)
+			if !ok || semanticErr.Kind != kind {
+				t.Fatalf("expected %s for %s in %s mode, got %v", kind, scenePath, mode, err)
+			}
+		}
+	}
+	if entries, _ := os.ReadDir(handshakeDir); len(entries) != 0 {
+		t.Fatalf("expected no handshake files, got %v", entries)
+	}
+}
+
+func TestStopProject_StopsHeadlessProcessWithoutEditor(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(writeFakeGodot(t, "echo ready\nexec sleep 30\n"), "http://localhost:9080/mcp", filepath.Join(t.TempDir(), "handshakes"))
+
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_headless", "", "", "launch-token", time.Now().UTC())
+	if _, err := runtimebridge.DefaultGameProcessLauncher().Launch(runtimebridge.GameProcessLaunch{
+		SessionID:   "game_headless",
+		LaunchToken: "launch-token",
+		ProjectDir:  t.TempDir(),
+		Headless:    true,
+	}); err != nil {
+		t.Fatalf("launch: %v", err)
+	}
+	now := time.Now().UTC()
+	watch, _ := runtimebridge.DefaultRuntimeWatchStore().Add("game_headless", runtimebridge.RuntimeWatch{Node: "Player", Property: "hp"}, now)
+	runtimebridge.DefaultRuntimeWatchStore().Append("game_headless", []runtimebridge.RuntimeWatchAppendSample{{WatchID: watch.WatchID, Frame: 1, Value: 10.0}}, now)
+	t.Cleanup(func() { runtimebridge.ForgetGameSession("game_headless") })
+
+	raw, err := (&StopProjectTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_headless",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("stop headless project: %v", err)
+	}
+	var result struct {
+		Running bool                          `json:"running"`
+		Process runtimebridge.GameProcessInfo `json:"process"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("unmarshal stop result: %v", err)
+	}
+	if result.Running || result.Process.Running || result.Process.ExitCode == nil {
+		t.Fatalf("expected stopped process, got %s", string(raw))
+	}
+	if runtimebridge.DefaultGameSessionRegistry().IsRunning("game_headless") {
+		t.Fatal("expected game session to be stopped")
+	}
+	if _, samples, _, ok := runtimebridge.DefaultRuntimeWatchStore().Series("game_headless", watch.WatchID, 0, 0); !ok || len(samples) != 1 {
+		t.Fatalf("expected watch history to survive the stop, got ok=%v samples=%v", ok, samples)
+	}
+}
+
+func TestRunProject_HeadlessProcessStopsWhenCallerSessionCloses(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(writeFakeGodot(t, "echo ready\nexec sleep 30\n"), "http://localhost:9080/mcp", filepath.Join(t.TempDir(), "handshakes"))
+	t.Setenv("GODOT_PROJECT_ROOT", t.TempDir())
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := (&RunProjectTool{}).Execute(json.RawMessage(`{
+			"mode":"headless",
+			"session_id":"game_headless",
+			"_mcp":{"session_id":"ai-session","session_initialized":true}
+		}`))
+		result <- err
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if process, ok := runtimebridge.DefaultGameProcessLauncher().Process("game_headless"); ok && process.Running {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the headless process to start")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if session, _ := runtimebridge.DefaultGameSessionRegistry().Session("game_headless"); session.EditorSessionID != "ai-session" {
+		t.Fatalf("expected the caller to own the game session, got %+v", session)
+	}
+
+	runtimebridge.ForgetMCPSession("ai-session")
+	if _, ok := runtimebridge.DefaultGameProcessLauncher().Process("game_headless"); ok {
+		t.Fatal("expected the headless process to be stopped and forgotten")
+	}
+	select {
+	case err := <-result:
+		if semanticErr, ok := tooltypes.AsSemanticError(err); !ok || semanticErr.Data["code"] != "game_not_running" {
+			t.Fatalf("expected game_not_running once the process is stopped, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for project.run to return")
+	}
+}
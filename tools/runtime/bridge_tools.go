@@ -263,6 +263,7 @@ func (t *BridgeRuntimeSnapshotPushTool) Execute(args json.RawMessage) ([]byte, e
 	now := time.Now().UTC()
 	runtimebridge.DefaultRuntimeSnapshotStore().Upsert(sessionID, payload.Snapshot, now)
 	runtimebridge.DefaultGameSessionRegistry().MarkSnapshotReceived(sessionID, now)
+	runtimebridge.DefaultGameSessionWatchdog().ObserveSnapshot(sessionID, payload.Snapshot, now)
 	if !sessionBefore.HasSnapshot {
 		log.Printf("godot-mcp runtime snapshot accepted: first_snapshot=true session_id=%q runtime_session_id=%q snapshot_id=%q frame=%d", sessionID, strings.TrimSpace(payload.Context.SessionID), strings.TrimSpace(payload.Snapshot.SnapshotID), payload.Snapshot.Frame)
 	}
@@ -334,6 +335,120 @@ func (t *BridgeRuntimeLogPushTool) Execute(args json.RawMessage) ([]byte, error)
 	})
 }
 
+type BridgeRuntimeWatchPushTool struct{}
+
+func (t *BridgeRuntimeWatchPushTool) Name() string { return "godot.bridge.runtime.watch.push" }
+func (t *BridgeRuntimeWatchPushTool) Description() string {
+	return "Pushes sampled runtime watch values for a game session (internal bridge tool)"
+}
+func (t *BridgeRuntimeWatchPushTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *BridgeRuntimeWatchPushTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string"},
+			"samples":    map[string]any{"type": "array"},
+		},
+		Required: []string{"session_id", "samples"},
+		Title:    "Bridge Runtime Watch Push",
+	}
+}
+func (t *BridgeRuntimeWatchPushTool) Execute(args json.RawMessage) ([]byte, error) {
+	var payload struct {
+		SessionID string                                   `json:"session_id"`
+		Samples   []runtimebridge.RuntimeWatchAppendSample `json:"samples"`
+		Context   struct {
+			SessionID          string `json:"session_id"`
+			SessionInitialized bool   `json:"session_initialized"`
+		} `json:"_mcp"`
+	}
+	if err := json.Unmarshal(args, &payload); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(payload.Context.SessionID) == "" || !payload.Context.SessionInitialized {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime watch push requires initialized session", t.Name(), "editor_session_missing", nil)
+	}
+	sessionID := strings.TrimSpace(payload.SessionID)
+	if sessionID == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("session_id is required", t.Name(), "game_session_missing", nil)
+	}
+	if !runtimebridge.DefaultGameSessionRegistry().RuntimeSessionMatches(sessionID, payload.Context.SessionID) {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime watch push session mismatch", t.Name(), "game_session_missing", map[string]any{
+			"session_id": sessionID,
+			"reason":     "runtime_session_mismatch",
+		})
+	}
+	appended := runtimebridge.DefaultRuntimeWatchStore().Append(sessionID, payload.Samples, time.Now().UTC())
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"appended":   appended,
+		"dropped":    len(payload.Samples) - appended,
+	})
+}
+
+type BridgeRuntimePerformancePushTool struct{}
+
+func (t *BridgeRuntimePerformancePushTool) Name() string {
+	return "godot.bridge.runtime.performance.push"
+}
+func (t *BridgeRuntimePerformancePushTool) Description() string {
+	return "Pushes sampled runtime performance monitors for a game session (internal bridge tool)"
+}
+func (t *BridgeRuntimePerformancePushTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *BridgeRuntimePerformancePushTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string"},
+			"samples":    map[string]any{"type": "array"},
+		},
+		Required: []string{"session_id", "samples"},
+		Title:    "Bridge Runtime Performance Push",
+	}
+}
+func (t *BridgeRuntimePerformancePushTool) Execute(args json.RawMessage) ([]byte, error) {
+	var payload struct {
+		SessionID string                                         `json:"session_id"`
+		Samples   []runtimebridge.RuntimePerformanceAppendSample `json:"samples"`
+		Context   struct {
+			SessionID          string `json:"session_id"`
+			SessionInitialized bool   `json:"session_initialized"`
+		} `json:"_mcp"`
+	}
+	if err := json.Unmarshal(args, &payload); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(payload.Context.SessionID) == "" || !payload.Context.SessionInitialized {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime performance push requires initialized session", t.Name(), "editor_session_missing", nil)
+	}
+	sessionID := strings.TrimSpace(payload.SessionID)
+	if sessionID == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("session_id is required", t.Name(), "game_session_missing", nil)
+	}
+	if !runtimebridge.DefaultGameSessionRegistry().RuntimeSessionMatches(sessionID, payload.Context.SessionID) {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime performance push session mismatch", t.Name(), "game_session_missing", map[string]any{
+			"session_id": sessionID,
+			"reason":     "runtime_session_mismatch",
+		})
+	}
+	appended := runtimebridge.DefaultRuntimePerformanceStore().Append(sessionID, payload.Samples, time.Now().UTC())
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"appended":   appended,
+		"dropped":    len(payload.Samples) - appended,
+	})
+}
+
 type BridgeCommandAckTool struct{}
 
 func (t *BridgeCommandAckTool) Name() string { return "godot.bridge.command.ack" }
@@ -51,6 +51,18 @@ func requireGameSessionID(arguments map[string]any, toolName string) (string, *t
 	return value, nil
 }
 
+func optionalStringArgument(arguments map[string]any, key string, toolName string) (string, *tooltypes.SemanticError) {
+	raw, ok := arguments[key]
+	if !ok {
+		return "", nil
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", tooltypes.NewRuntimeInvalidParamsError(key+" must be a string", toolName, "invalid_"+key, nil)
+	}
+	return strings.TrimSpace(value), nil
+}
+
 func runtimeMetadata(stored runtimebridge.StoredRuntimeSnapshot) map[string]any {
 	return map[string]any{
 		"source":      "runtime",
@@ -143,6 +155,9 @@ func dispatchToRuntimeSession(gameSessionID string, commandName string, commandA
 
 	ack, dispatched, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(runtimeSessionID, commandName, commandArgs, timeout)
 	if !dispatched {
+		if limited := tooltypes.CommandInFlightLimitedError(commandName, runtimeSessionID, reason); limited != nil {
+			return runtimebridge.CommandAck{}, limited
+		}
 		return runtimebridge.CommandAck{}, tooltypes.NewRuntimeNotAvailableError(
 			"Runtime command is unavailable",
 			commandName,
@@ -0,0 +1,311 @@
+package runtime
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+// Typed input event kinds accepted by the runtime input tools via the `event` argument.
+const (
+	inputEventMouseButton  = "mouse_button"
+	inputEventMouseMotion  = "mouse_motion"
+	inputEventMouseScroll  = "mouse_scroll"
+	inputEventMouseDrag    = "mouse_drag"
+	inputEventTouch        = "touch"
+	inputEventTouchDrag    = "touch_drag"
+	inputEventMagnify      = "magnify_gesture"
+	inputEventPan          = "pan_gesture"
+	inputEventJoypadButton = "joypad_button"
+	inputEventJoypadMotion = "joypad_motion"
+)
+
+const (
+	defaultInputDragSteps   = 8
+	maxInputDragSteps       = 120
+	maxInputTouchIndex      = 9
+	maxInputJoypadDevice    = 15
+	maxInputJoypadButton    = 127
+	maxInputJoypadAxis      = 9
+	defaultInputScrollSteps = 1
+	maxInputScrollSteps     = 20
+)
+
+var (
+	mouseButtonNames = []string{"left", "right", "middle", "wheel_up", "wheel_down", "wheel_left", "wheel_right", "xbutton1", "xbutton2"}
+	scrollDirections = []string{"up", "down", "left", "right"}
+	joypadButtonMap  = map[string]int{
+		"a":              0,
+		"b":              1,
+		"x":              2,
+		"y":              3,
+		"back":           4,
+		"guide":          5,
+		"start":          6,
+		"left_stick":     7,
+		"right_stick":    8,
+		"left_shoulder":  9,
+		"right_shoulder": 10,
+		"dpad_up":        11,
+		"dpad_down":      12,
+		"dpad_left":      13,
+		"dpad_right":     14,
+	}
+	joypadAxisMap = map[string]int{
+		"left_x":        0,
+		"left_y":        1,
+		"right_x":       2,
+		"right_y":       3,
+		"trigger_left":  4,
+		"trigger_right": 5,
+	}
+)
+
+// inputEventSupportsPressState reports whether an event kind has a held state,
+// so it can be used with press/release in addition to tap.
+func inputEventSupportsPressState(eventType string) bool {
+	switch eventType {
+	case inputEventMouseButton, inputEventTouch, inputEventJoypadButton, inputEventJoypadMotion:
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeInputEvent validates a typed input event payload and returns the
+// canonical form forwarded to the runtime companion.
+func normalizeInputEvent(raw any, toolName string, allowGesture bool) (map[string]any, *tooltypes.SemanticError) {
+	payload, ok := raw.(map[string]any)
+	if !ok {
+		return nil, invalidInputEvent(toolName, "event must be an object")
+	}
+	eventType, ok := payload["type"].(string)
+	if !ok || strings.TrimSpace(eventType) == "" {
+		return nil, invalidInputEvent(toolName, "event.type is required")
+	}
+	eventType = strings.ToLower(strings.TrimSpace(eventType))
+	out := map[string]any{"type": eventType}
+
+	var semErr *tooltypes.SemanticError
+	switch eventType {
+	case inputEventMouseButton:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readMouseButton(payload, out, toolName) },
+			func() *tooltypes.SemanticError { return readInputBool(payload, "double_click", out, toolName) },
+		)
+	case inputEventMouseMotion:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "relative", false, out, toolName) },
+		)
+	case inputEventMouseScroll:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError {
+				return readInputEnum(payload, "direction", scrollDirections, "down", out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "steps", defaultInputScrollSteps, 1, maxInputScrollSteps, out, toolName)
+			},
+		)
+	case inputEventMouseDrag:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "from", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "to", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readMouseButton(payload, out, toolName) },
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "steps", defaultInputDragSteps, 1, maxInputDragSteps, out, toolName)
+			},
+		)
+	case inputEventTouch:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "index", 0, 0, maxInputTouchIndex, out, toolName)
+			},
+		)
+	case inputEventTouchDrag:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "from", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "to", true, out, toolName) },
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "index", 0, 0, maxInputTouchIndex, out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "steps", defaultInputDragSteps, 1, maxInputDragSteps, out, toolName)
+			},
+		)
+	case inputEventMagnify:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError {
+				return readInputNumber(payload, "factor", 1, 0.01, 100, out, toolName)
+			},
+		)
+	case inputEventPan:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "position", true, out, toolName) },
+			func() *tooltypes.SemanticError { return readInputPoint(payload, "delta", true, out, toolName) },
+		)
+	case inputEventJoypadButton:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "device", 0, 0, maxInputJoypadDevice, out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				return readJoypadIndex(payload, "button", joypadButtonMap, maxInputJoypadButton, out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				return readInputNumber(payload, "pressure", 1, 0, 1, out, toolName)
+			},
+		)
+	case inputEventJoypadMotion:
+		semErr = firstInputEventError(
+			func() *tooltypes.SemanticError {
+				return readInputInt(payload, "device", 0, 0, maxInputJoypadDevice, out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				return readJoypadIndex(payload, "axis", joypadAxisMap, maxInputJoypadAxis, out, toolName)
+			},
+			func() *tooltypes.SemanticError {
+				if _, ok := payload["value"]; !ok {
+					return invalidInputEvent(toolName, "event.value is required")
+				}
+				return readInputNumber(payload, "value", 0, -1, 1, out, toolName)
+			},
+		)
+	default:
+		return nil, invalidInputEvent(toolName, fmt.Sprintf("unsupported event.type: %s", eventType))
+	}
+	if semErr != nil {
+		return nil, semErr
+	}
+	if !allowGesture && !inputEventSupportsPressState(eventType) {
+		return nil, invalidInputEvent(toolName, fmt.Sprintf("event.type %s is only supported by godot.runtime.input.tap", eventType))
+	}
+	return out, nil
+}
+
+func firstInputEventError(readers ...func() *tooltypes.SemanticError) *tooltypes.SemanticError {
+	for _, read := range readers {
+		if semErr := read(); semErr != nil {
+			return semErr
+		}
+	}
+	return nil
+}
+
+func invalidInputEvent(toolName string, message string) *tooltypes.SemanticError {
+	return tooltypes.NewRuntimeInvalidParamsError(message, toolName, "input_not_supported", nil)
+}
+
+func readInputPoint(payload map[string]any, key string, required bool, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		if required {
+			return invalidInputEvent(toolName, fmt.Sprintf("event.%s is required", key))
+		}
+		return nil
+	}
+	point, ok := raw.(map[string]any)
+	if !ok {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be an object with x and y", key))
+	}
+	x, xOK := point["x"].(float64)
+	y, yOK := point["y"].(float64)
+	if !xOK || !yOK || math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be an object with numeric x and y", key))
+	}
+	out[key] = map[string]any{"x": x, "y": y}
+	return nil
+}
+
+func readMouseButton(payload map[string]any, out map[string]any, toolName string) *tooltypes.SemanticError {
+	return readInputEnum(payload, "button", mouseButtonNames, "left", out, toolName)
+}
+
+func readInputEnum(payload map[string]any, key string, allowed []string, fallback string, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		out[key] = fallback
+		return nil
+	}
+	value, ok := raw.(string)
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !ok || !slices.Contains(allowed, value) {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be one of %s", key, strings.Join(allowed, ", ")))
+	}
+	out[key] = value
+	return nil
+}
+
+func readInputBool(payload map[string]any, key string, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		return nil
+	}
+	value, ok := raw.(bool)
+	if !ok {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be a boolean", key))
+	}
+	out[key] = value
+	return nil
+}
+
+func readInputInt(payload map[string]any, key string, fallback int, minValue int, maxValue int, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		out[key] = fallback
+		return nil
+	}
+	value, ok := raw.(float64)
+	if !ok || value != math.Trunc(value) || int(value) < minValue || int(value) > maxValue {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be an integer between %d and %d", key, minValue, maxValue))
+	}
+	out[key] = int(value)
+	return nil
+}
+
+func readInputNumber(payload map[string]any, key string, fallback float64, minValue float64, maxValue float64, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		out[key] = fallback
+		return nil
+	}
+	value, ok := raw.(float64)
+	if !ok || math.IsNaN(value) || value < minValue || value > maxValue {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be a number between %g and %g", key, minValue, maxValue))
+	}
+	out[key] = value
+	return nil
+}
+
+// readJoypadIndex accepts either a named control (e.g. "a", "left_x") or a raw
+// Godot index and always forwards the numeric index.
+func readJoypadIndex(payload map[string]any, key string, names map[string]int, maxValue int, out map[string]any, toolName string) *tooltypes.SemanticError {
+	raw, ok := payload[key]
+	if !ok {
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s is required", key))
+	}
+	switch value := raw.(type) {
+	case string:
+		index, ok := names[strings.ToLower(strings.TrimSpace(value))]
+		if !ok {
+			return invalidInputEvent(toolName, fmt.Sprintf("unsupported event.%s: %s", key, value))
+		}
+		out[key] = index
+		return nil
+	case float64:
+		if value != math.Trunc(value) || value < 0 || int(value) > maxValue {
+			return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be an integer between 0 and %d", key, maxValue))
+		}
+		out[key] = int(value)
+		return nil
+	default:
+		return invalidInputEvent(toolName, fmt.Sprintf("event.%s must be a name or an integer index", key))
+	}
+}
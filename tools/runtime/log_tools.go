@@ -0,0 +1,264 @@
+package runtime
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+type RuntimeLogSearchTool struct{}
+
+func (t *RuntimeLogSearchTool) Name() string { return "godot.runtime.log.search" }
+func (t *RuntimeLogSearchTool) Description() string {
+	return "[runtime] Searches runtime logs across live and persisted game sessions"
+}
+func (t *RuntimeLogSearchTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeLogSearchTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"pattern":    map[string]any{"type": "string", "description": "Go regular expression matched against message and stack_trace"},
+			"session_id": map[string]any{"type": "string", "description": "Restrict the search to one game session"},
+			"level":      map[string]any{"type": "string"},
+			"source":     map[string]any{"type": "string", "description": "Source glob such as process_stderr or runtime_command:*"},
+			"since":      map[string]any{"type": "string", "description": "RFC3339 lower time bound"},
+			"until":      map[string]any{"type": "string", "description": "RFC3339 upper time bound"},
+			"limit":      map[string]any{"type": "integer"},
+		},
+		Title: "Runtime Log Search",
+	}
+}
+func (t *RuntimeLogSearchTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+
+	search := runtimebridge.RuntimeLogSearch{Limit: 50}
+	var semErr *tooltypes.SemanticError
+	if search.SessionID, semErr = optionalStringArgument(arguments, "session_id", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if search.Level, semErr = optionalStringArgument(arguments, "level", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if search.Source, semErr = optionalStringArgument(arguments, "source", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	pattern, semErr := optionalStringArgument(arguments, "pattern", t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	if pattern != "" {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("pattern must be a valid regular expression", t.Name(), "invalid_pattern", map[string]any{
+				"error": err.Error(),
+			})
+		}
+		search.Pattern = compiled
+	}
+	if search.Since, semErr = optionalTimeArgument(arguments, "since", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if search.Until, semErr = optionalTimeArgument(arguments, "until", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			search.Limit = int(value)
+		}
+	}
+
+	result, err := runtimebridge.SearchRuntimeLogs(search)
+	if err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log archive is unreadable", t.Name(), "log_archive_unreadable", map[string]any{
+			"error": err.Error(),
+		})
+	}
+	exports := make([]map[string]any, 0)
+	seen := map[string]bool{}
+	for _, record := range result.Records {
+		if seen[record.SessionID] {
+			continue
+		}
+		seen[record.SessionID] = true
+		exports = append(exports, map[string]any{
+			"session_id":   record.SessionID,
+			"resource_uri": runtimebridge.RuntimeLogResourceURI(record.SessionID),
+		})
+	}
+	return json.Marshal(map[string]any{
+		"source":              "runtime",
+		"matches":             result.Records,
+		"count":               len(result.Records),
+		"truncated":           result.Truncated,
+		"sessions_scanned":    result.SessionsScanned,
+		"persistence_enabled": runtimebridge.DefaultRuntimeLogArchive().Enabled(),
+		"exports":             exports,
+	})
+}
+
+type RuntimeLogTailStartTool struct{}
+
+func (t *RuntimeLogTailStartTool) Name() string { return "godot.runtime.log.tail.start" }
+func (t *RuntimeLogTailStartTool) Description() string {
+	return "[runtime] Streams new runtime log entries to this MCP session as notifications"
+}
+func (t *RuntimeLogTailStartTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *RuntimeLogTailStartTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":     map[string]any{"type": "string"},
+			"level":          map[string]any{"type": "string"},
+			"since_sequence": map[string]any{"type": "integer", "description": "Replay buffered entries after this sequence before streaming"},
+		},
+		Required: []string{"session_id"},
+		Title:    "Runtime Log Tail Start",
+	}
+}
+func (t *RuntimeLogTailStartTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	notifySessionID := strings.TrimSpace(ctx.SessionID)
+	if notifySessionID == "" {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log tail needs an MCP session with a notification stream", t.Name(), "notify_session_missing", nil)
+	}
+	session, ok := runtimebridge.DefaultGameSessionRegistry().Session(sessionID)
+	if !ok {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log stream is unavailable", t.Name(), "game_session_missing", map[string]any{
+			"session_id": sessionID,
+		})
+	}
+	if !session.Running {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log stream is unavailable", t.Name(), "game_not_running", map[string]any{
+			"session_id": sessionID,
+		})
+	}
+	level, semErr := optionalStringArgument(arguments, "level", t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	replay := false
+	var sinceSequence int64
+	if raw, ok := arguments["since_sequence"]; ok {
+		value, ok := raw.(float64)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("since_sequence must be an integer", t.Name(), "invalid_since_sequence", nil)
+		}
+		replay = true
+		sinceSequence = int64(value)
+	}
+
+	tail, err := runtimebridge.DefaultRuntimeLogStore().StartTail(sessionID, notifySessionID, level, replay, sinceSequence, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, runtimebridge.ErrRuntimeLogTailLimit) {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("Too many runtime log tails for this MCP session", t.Name(), "tail_limit_reached", map[string]any{
+				"session_id": sessionID,
+			})
+		}
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log stream is unavailable", t.Name(), "game_session_missing", map[string]any{
+			"session_id": sessionID,
+		})
+	}
+	return json.Marshal(map[string]any{
+		"source":       "runtime",
+		"session_id":   sessionID,
+		"tail_id":      tail.TailID,
+		"level":        tail.Level,
+		"replay":       replay,
+		"notification": runtimebridge.RuntimeLogTailMethodName,
+		"queue_size":   runtimebridge.DefaultRuntimeLogTailQueue,
+	})
+}
+
+type RuntimeLogTailStopTool struct{}
+
+func (t *RuntimeLogTailStopTool) Name() string { return "godot.runtime.log.tail.stop" }
+func (t *RuntimeLogTailStopTool) Description() string {
+	return "[runtime] Stops a runtime log tail started by this MCP session"
+}
+func (t *RuntimeLogTailStopTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeLogTailStopTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"tail_id": map[string]any{"type": "string"},
+		},
+		Required: []string{"tail_id"},
+		Title:    "Runtime Log Tail Stop",
+	}
+}
+func (t *RuntimeLogTailStopTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	tailID, ok := arguments["tail_id"].(string)
+	if !ok || strings.TrimSpace(tailID) == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("tail_id is required", t.Name(), "tail_not_found", nil)
+	}
+	tail, ok := runtimebridge.DefaultRuntimeLogTailHub().Stop(tailID, ctx.SessionID)
+	if !ok {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime log tail is unavailable", t.Name(), "tail_not_found", map[string]any{
+			"tail_id": strings.TrimSpace(tailID),
+		})
+	}
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": tail.SessionID,
+		"tail_id":    tail.TailID,
+		"stopped":    true,
+		"delivered":  tail.Delivered,
+		"dropped":    tail.Dropped,
+	})
+}
+
+func optionalTimeArgument(arguments map[string]any, key string, toolName string) (time.Time, *tooltypes.SemanticError) {
+	value, semErr := optionalStringArgument(arguments, key, toolName)
+	if semErr != nil || value == "" {
+		return time.Time{}, semErr
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, tooltypes.NewRuntimeInvalidParamsError(key+" must be an RFC3339 timestamp", toolName, "invalid_"+key, nil)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,236 @@
+package runtime
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	defaultPerformanceIntervalMS = 1000
+	minPerformanceIntervalMS     = 100
+	maxPerformanceIntervalMS     = 60000
+	defaultPerformanceGetLimit   = 200
+)
+
+type RuntimePerformanceGetTool struct{}
+
+func (t *RuntimePerformanceGetTool) Name() string { return "godot.runtime.performance.get" }
+func (t *RuntimePerformanceGetTool) Description() string {
+	return "[runtime] Returns Godot Performance monitor values (FPS, frame time, memory, draw calls, object/node counts) with sampled series and stats"
+}
+func (t *RuntimePerformanceGetTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimePerformanceGetTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":     map[string]any{"type": "string"},
+			"monitors":       performanceMonitorsSchema(),
+			"live":           map[string]any{"type": "boolean", "description": "Read current values from the game (default true unless sampling is active)"},
+			"limit":          map[string]any{"type": "integer"},
+			"since_sequence": map[string]any{"type": "integer"},
+		},
+		Required: []string{"session_id"},
+		Title:    "Runtime Performance Get",
+	}
+}
+func (t *RuntimePerformanceGetTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	monitors, semErr := optionalPerformanceMonitors(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	limit := defaultPerformanceGetLimit
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			limit = int(value)
+		}
+	}
+	var sinceSequence int64
+	if raw, ok := arguments["since_sequence"]; ok {
+		if value, ok := raw.(float64); ok {
+			sinceSequence = int64(value)
+		}
+	}
+	sampling, samples, stats, hasSeries := runtimebridge.DefaultRuntimePerformanceStore().Series(sessionID, monitors, sinceSequence, limit)
+	live, semErr := optionalBoolArgument(arguments, "live", !sampling.Active, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	result := map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"samples":    []runtimebridge.RuntimePerformanceSample{},
+		"stats":      map[string]runtimebridge.RuntimePerformanceStats{},
+	}
+	if hasSeries {
+		result["sampling"] = sampling
+		result["samples"] = samples
+		result["stats"] = stats
+	}
+	if live {
+		ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{
+			"monitors": monitors,
+		}, defaultRuntimeCommandTimeout)
+		if dispatchErr != nil {
+			return nil, dispatchErr
+		}
+		result["command_id"] = ack.CommandID
+		result["current"] = map[string]any{
+			"frame":    ack.Result["frame"],
+			"time":     ack.Result["timestamp"],
+			"monitors": ack.Result["monitors"],
+		}
+	}
+	return json.Marshal(result)
+}
+
+type RuntimePerformanceSampleTool struct{}
+
+func (t *RuntimePerformanceSampleTool) Name() string { return "godot.runtime.performance.sample" }
+func (t *RuntimePerformanceSampleTool) Description() string {
+	return "[runtime] Starts or stops periodic Performance monitor sampling for one game session"
+}
+func (t *RuntimePerformanceSampleTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(false),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimePerformanceSampleTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":  map[string]any{"type": "string"},
+			"enabled":     map[string]any{"type": "boolean", "description": "true starts sampling, false stops it and keeps retained samples"},
+			"interval_ms": map[string]any{"type": "integer", "description": "Sampling interval (default 1000, min 100, max 60000)"},
+			"monitors":    performanceMonitorsSchema(),
+		},
+		Required: []string{"session_id", "enabled"},
+		Title:    "Runtime Performance Sample",
+	}
+}
+func (t *RuntimePerformanceSampleTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	enabled, ok := arguments["enabled"].(bool)
+	if !ok {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("enabled must be a boolean", t.Name(), "invalid_enabled", nil)
+	}
+	intervalMS := defaultPerformanceIntervalMS
+	if raw, ok := arguments["interval_ms"]; ok {
+		value, ok := raw.(float64)
+		if !ok || value != math.Trunc(value) || int(value) < minPerformanceIntervalMS || int(value) > maxPerformanceIntervalMS {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("interval_ms must be an integer between 100 and 60000", t.Name(), "invalid_interval", nil)
+		}
+		intervalMS = int(value)
+	}
+	monitors, semErr := optionalPerformanceMonitors(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	store := runtimebridge.DefaultRuntimePerformanceStore()
+	if !enabled {
+		ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{
+			"enabled": false,
+		}, defaultRuntimeCommandTimeout)
+		if dispatchErr != nil {
+			return nil, dispatchErr
+		}
+		sampling, _ := store.Stop(sessionID, time.Now().UTC())
+		return json.Marshal(map[string]any{
+			"source":     "runtime",
+			"session_id": sessionID,
+			"command_id": ack.CommandID,
+			"sampling":   sampling,
+		})
+	}
+
+	// Sampling is marked active before dispatch so the first pushed batch is
+	// not dropped.
+	sampling := store.Start(sessionID, intervalMS, monitors, time.Now().UTC())
+	ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{
+		"enabled":     true,
+		"interval_ms": intervalMS,
+		"monitors":    monitors,
+	}, defaultRuntimeCommandTimeout)
+	if dispatchErr != nil {
+		store.Stop(sessionID, time.Now().UTC())
+		return nil, dispatchErr
+	}
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"command_id": ack.CommandID,
+		"sampling":   sampling,
+	})
+}
+
+func performanceMonitorsSchema() map[string]any {
+	return map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string", "enum": runtimebridge.RuntimePerformanceMonitors},
+		"description": "Monitor keys to include (default all)",
+	}
+}
+
+// optionalPerformanceMonitors returns the requested monitor keys, or nil for
+// all monitors.
+func optionalPerformanceMonitors(arguments map[string]any, toolName string) ([]string, *tooltypes.SemanticError) {
+	raw, ok := arguments["monitors"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("monitors must be an array of strings", toolName, "invalid_monitor", nil)
+	}
+	monitors := make([]string, 0, len(items))
+	for _, item := range items {
+		name, ok := item.(string)
+		name = strings.TrimSpace(name)
+		if !ok || !runtimebridge.IsRuntimePerformanceMonitor(name) {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("Unknown performance monitor", toolName, "invalid_monitor", map[string]any{
+				"monitor":   item,
+				"supported": runtimebridge.RuntimePerformanceMonitors,
+			})
+		}
+		monitors = append(monitors, name)
+	}
+	if len(monitors) == 0 {
+		return nil, nil
+	}
+	return monitors, nil
+}
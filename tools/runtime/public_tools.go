@@ -14,7 +14,7 @@ type GetActiveGameSessionTool struct{}
 
 func (t *GetActiveGameSessionTool) Name() string { return "godot.runtime.session.get_active" }
 func (t *GetActiveGameSessionTool) Description() string {
-	return "[runtime] Returns the active runtime game session for one resolved editor session owner"
+	return "[runtime] Returns the active runtime game session and its running sibling instances for one resolved editor session owner"
 }
 func (t *GetActiveGameSessionTool) Annotations() *mcp.ToolAnnotations {
 	return &mcp.ToolAnnotations{
@@ -53,15 +53,44 @@ func (t *GetActiveGameSessionTool) Execute(args json.RawMessage) ([]byte, error)
 		session, ok = runtimebridge.DefaultGameSessionRegistry().LatestRunning()
 	}
 	if !ok {
-		return nil, tooltypes.NewRuntimeNotAvailableError("Active game session is unavailable", t.Name(), "game_session_missing", map[string]any{
+		data := map[string]any{
 			"editor_session_id": editorSessionID,
-		})
+		}
+		// Report a watchdog-detected crash instead of a bare miss so the
+		// caller can see why the game went away.
+		crashed, found := runtimebridge.DefaultGameSessionRegistry().LastForEditor(editorSessionID)
+		if !found || crashed.State != runtimebridge.GameSessionStateCrashed {
+			crashed, found = runtimebridge.DefaultGameSessionRegistry().LatestCrashed()
+		}
+		if found {
+			data["session_id"] = crashed.SessionID
+			data["state"] = crashed.State
+			data["state_reason"] = crashed.StateReason
+			data["stopped_at"] = crashed.StoppedAt
+			data["last_log_lines"] = crashed.LastLogLines
+		}
+		return nil, tooltypes.NewRuntimeNotAvailableError("Active game session is unavailable", t.Name(), "game_session_missing", data)
+	}
+	// Sibling instances of a multi-instance run are listed so each one can be
+	// addressed by its own session_id.
+	sessions := make([]map[string]any, 0)
+	for _, running := range runtimebridge.DefaultGameSessionRegistry().RunningForEditor(session.EditorSessionID) {
+		sessions = append(sessions, gameSessionSummary(running))
+	}
+	if len(sessions) == 0 {
+		sessions = append(sessions, gameSessionSummary(session))
 	}
 	return json.Marshal(map[string]any{
 		"source":             "runtime",
 		"session_id":         session.SessionID,
+		"instance_index":     session.InstanceIndex,
+		"sessions":           sessions,
 		"editor_session_id":  editorSessionID,
 		"running":            session.Running,
+		"state":              session.State,
+		"state_reason":       session.StateReason,
+		"state_changed_at":   session.StateChangedAt,
+		"last_log_lines":     session.LastLogLines,
 		"started_at":         session.StartedAt,
 		"scene_path":         session.ScenePath,
 		"runtime_session_id": session.RuntimeSessionID,
@@ -70,6 +99,19 @@ func (t *GetActiveGameSessionTool) Execute(args json.RawMessage) ([]byte, error)
 	})
 }
 
+func gameSessionSummary(session runtimebridge.GameSession) map[string]any {
+	return map[string]any{
+		"session_id":         session.SessionID,
+		"instance_index":     session.InstanceIndex,
+		"state":              session.State,
+		"started_at":         session.StartedAt,
+		"scene_path":         session.ScenePath,
+		"runtime_session_id": session.RuntimeSessionID,
+		"has_snapshot":       session.HasSnapshot,
+		"last_snapshot_at":   session.LastSnapshotAt,
+	}
+}
+
 type RuntimeSyncNowTool struct{}
 
 func (t *RuntimeSyncNowTool) Name() string { return "godot.runtime.sync_now" }
@@ -265,6 +307,146 @@ func (t *RuntimeSceneTreeGetTool) Execute(args json.RawMessage) ([]byte, error)
 	return json.Marshal(out)
 }
 
+type RuntimeSceneTreeDiffTool struct{}
+
+func (t *RuntimeSceneTreeDiffTool) Name() string { return "godot.runtime.scene_tree.diff" }
+func (t *RuntimeSceneTreeDiffTool) Description() string {
+	return "[runtime] Diffs the runtime scene tree between two retained snapshots"
+}
+func (t *RuntimeSceneTreeDiffTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeSceneTreeDiffTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":       map[string]any{"type": "string"},
+			"from_snapshot_id": map[string]any{"type": "string"},
+			"to_snapshot_id":   map[string]any{"type": "string"},
+			"from_frame":       map[string]any{"type": "integer"},
+			"to_frame":         map[string]any{"type": "integer"},
+		},
+		Required: []string{"session_id"},
+		Title:    "Runtime Scene Tree Diff",
+	}
+}
+func (t *RuntimeSceneTreeDiffTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	history := runtimebridge.DefaultRuntimeSnapshotStore().History(sessionID)
+	if len(history) == 0 {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime snapshot history is unavailable", t.Name(), "runtime_snapshot_missing", map[string]any{
+			"session_id": sessionID,
+		})
+	}
+
+	toIndex, semErr := resolveSnapshotHistoryIndex(history, arguments, "to", len(history)-1, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	if len(history) < 2 {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime scene tree diff needs at least two snapshots", t.Name(), "runtime_snapshot_missing", map[string]any{
+			"session_id":   sessionID,
+			"history_size": len(history),
+		})
+	}
+	fromIndex, semErr := resolveSnapshotHistoryIndex(history, arguments, "from", toIndex-1, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	// Both ends are retained here, so a bad order is the caller's argument
+	// error rather than an evicted snapshot.
+	if fromIndex < 0 || fromIndex >= toIndex {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("from must name a snapshot older than to", t.Name(), "invalid_range", map[string]any{
+			"session_id": sessionID,
+			"to":         snapshotHistoryRef(history[toIndex]),
+			"oldest":     snapshotHistoryRef(history[0]),
+		})
+	}
+
+	from := history[fromIndex]
+	to := history[toIndex]
+	diff := runtimebridge.DiffSceneTrees(from.Snapshot.SceneTree, to.Snapshot.SceneTree)
+	return json.Marshal(map[string]any{
+		"source":       "runtime",
+		"session_id":   sessionID,
+		"from":         snapshotHistoryRef(from),
+		"to":           snapshotHistoryRef(to),
+		"changed":      !diff.Empty(),
+		"added":        diff.Added,
+		"removed":      diff.Removed,
+		"reparented":   diff.Reparented,
+		"renamed":      diff.Renamed,
+		"history_size": len(history),
+	})
+}
+
+// resolveSnapshotHistoryIndex selects one retained snapshot by `<prefix>_snapshot_id`
+// or `<prefix>_frame` (latest snapshot at or before the frame), falling back to fallback.
+func resolveSnapshotHistoryIndex(history []runtimebridge.StoredRuntimeSnapshot, arguments map[string]any, prefix string, fallback int, toolName string) (int, *tooltypes.SemanticError) {
+	idKey := prefix + "_snapshot_id"
+	frameKey := prefix + "_frame"
+	rawID, hasID := arguments[idKey]
+	rawFrame, hasFrame := arguments[frameKey]
+	if hasID && hasFrame {
+		return 0, tooltypes.NewRuntimeInvalidParamsError(idKey+" and "+frameKey+" are mutually exclusive", toolName, "runtime_snapshot_missing", nil)
+	}
+	if hasID {
+		snapshotID, ok := rawID.(string)
+		if !ok || strings.TrimSpace(snapshotID) == "" {
+			return 0, tooltypes.NewRuntimeInvalidParamsError(idKey+" must be a non-empty string", toolName, "runtime_snapshot_missing", nil)
+		}
+		snapshotID = strings.TrimSpace(snapshotID)
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].Snapshot.SnapshotID == snapshotID {
+				return i, nil
+			}
+		}
+		return 0, tooltypes.NewRuntimeNotAvailableError("Runtime snapshot is not in retained history", toolName, "runtime_snapshot_missing", map[string]any{
+			"session_id": history[0].SessionID,
+			idKey:        snapshotID,
+		})
+	}
+	if hasFrame {
+		frame, ok := rawFrame.(float64)
+		if !ok {
+			return 0, tooltypes.NewRuntimeInvalidParamsError(frameKey+" must be a number", toolName, "runtime_snapshot_missing", nil)
+		}
+		for i := len(history) - 1; i >= 0; i-- {
+			if history[i].Snapshot.Frame <= int64(frame) {
+				return i, nil
+			}
+		}
+		return 0, tooltypes.NewRuntimeNotAvailableError("Runtime snapshot is not in retained history", toolName, "runtime_snapshot_missing", map[string]any{
+			"session_id":   history[0].SessionID,
+			frameKey:       int64(frame),
+			"oldest_frame": history[0].Snapshot.Frame,
+		})
+	}
+	return fallback, nil
+}
+
+func snapshotHistoryRef(stored runtimebridge.StoredRuntimeSnapshot) map[string]any {
+	return map[string]any{
+		"snapshot_id": stored.Snapshot.SnapshotID,
+		"frame":       stored.Snapshot.Frame,
+		"updated_at":  stored.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
 type RuntimeNodePropertiesGetTool struct{}
 
 func (t *RuntimeNodePropertiesGetTool) Name() string { return "godot.runtime.node_properties.get" }
@@ -358,9 +540,10 @@ func (t *RuntimeInputTapTool) InputSchema() mcp.InputSchema {
 		Properties: map[string]any{
 			"session_id":  map[string]any{"type": "string"},
 			"input":       map[string]any{"type": "string"},
+			"event":       map[string]any{"type": "object"},
 			"duration_ms": map[string]any{"type": "integer"},
 		},
-		Required: []string{"session_id", "input"},
+		Required: []string{"session_id"},
 		Title:    "Runtime Input Tap",
 	}
 }
@@ -383,8 +566,9 @@ func (t *RuntimeInputPressTool) InputSchema() mcp.InputSchema {
 		Properties: map[string]any{
 			"session_id": map[string]any{"type": "string"},
 			"input":      map[string]any{"type": "string"},
+			"event":      map[string]any{"type": "object"},
 		},
-		Required: []string{"session_id", "input"},
+		Required: []string{"session_id"},
 		Title:    "Runtime Input Press",
 	}
 }
@@ -407,8 +591,9 @@ func (t *RuntimeInputReleaseTool) InputSchema() mcp.InputSchema {
 		Properties: map[string]any{
 			"session_id": map[string]any{"type": "string"},
 			"input":      map[string]any{"type": "string"},
+			"event":      map[string]any{"type": "object"},
 		},
-		Required: []string{"session_id", "input"},
+		Required: []string{"session_id"},
 		Title:    "Runtime Input Release",
 	}
 }
@@ -428,11 +613,29 @@ func executeInputCommand(args json.RawMessage, toolName string, allowDuration bo
 	if semErr != nil {
 		return nil, semErr
 	}
-	input, ok := arguments["input"].(string)
-	if !ok || strings.TrimSpace(input) == "" {
-		return nil, tooltypes.NewRuntimeInvalidParamsError("input is required", toolName, "input_not_supported", nil)
+	rawInput, hasInput := arguments["input"]
+	rawEvent, hasEvent := arguments["event"]
+	if hasInput && hasEvent {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("input and event are mutually exclusive", toolName, "input_not_supported", nil)
+	}
+	cmdArgs := map[string]any{}
+	input := ""
+	var event map[string]any
+	if hasEvent {
+		normalized, semErr := normalizeInputEvent(rawEvent, toolName, allowDuration)
+		if semErr != nil {
+			return nil, semErr
+		}
+		event = normalized
+		cmdArgs["event"] = event
+	} else {
+		value, ok := rawInput.(string)
+		if !ok || strings.TrimSpace(value) == "" {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("input or event is required", toolName, "input_not_supported", nil)
+		}
+		input = strings.TrimSpace(value)
+		cmdArgs["input"] = input
 	}
-	cmdArgs := map[string]any{"input": strings.TrimSpace(input)}
 	if allowDuration {
 		if raw, ok := arguments["duration_ms"]; ok {
 			if value, ok := raw.(float64); ok && int(value) > 0 {
@@ -444,16 +647,20 @@ func executeInputCommand(args json.RawMessage, toolName string, allowDuration bo
 	if dispatchErr != nil {
 		return nil, dispatchErr
 	}
-	return json.Marshal(map[string]any{
+	out := map[string]any{
 		"source":      "runtime",
 		"session_id":  sessionID,
 		"command_id":  ack.CommandID,
-		"input":       strings.TrimSpace(input),
+		"input":       input,
 		"frame":       ack.Result["frame"],
 		"timestamp":   ack.Result["timestamp"],
 		"updated_at":  ack.Result["updated_at"],
 		"snapshot_id": ack.Result["snapshot_id"],
-	})
+	}
+	if event != nil {
+		out["event"] = event
+	}
+	return json.Marshal(out)
 }
 
 type RuntimeLogGetTool struct{}
@@ -476,6 +683,9 @@ func (t *RuntimeLogGetTool) InputSchema() mcp.InputSchema {
 			"level":          map[string]any{"type": "string"},
 			"limit":          map[string]any{"type": "integer"},
 			"since_sequence": map[string]any{"type": "integer"},
+			"file":           map[string]any{"type": "string", "description": "res:// path or path suffix matched against the error location and backtrace frames"},
+			"function":       map[string]any{"type": "string", "description": "Function name matched against the error location and backtrace frames"},
+			"group":          map[string]any{"type": "boolean", "description": "Fold identical errors into groups with occurrence counts"},
 		},
 		Required: []string{"session_id"},
 		Title:    "Runtime Log Get",
@@ -525,11 +735,32 @@ func (t *RuntimeLogGetTool) Execute(args json.RawMessage) ([]byte, error) {
 			sinceSequence = int64(value)
 		}
 	}
-	entries := runtimebridge.DefaultRuntimeLogStore().Get(sessionID, level, limit, sinceSequence)
+	query := runtimebridge.RuntimeLogQuery{
+		Level:         level,
+		Limit:         limit,
+		SinceSequence: sinceSequence,
+	}
+	if query.File, semErr = optionalStringArgument(arguments, "file", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if query.Function, semErr = optionalStringArgument(arguments, "function", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	group, semErr := optionalBoolArgument(arguments, "group", false, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	if group {
+		return json.Marshal(map[string]any{
+			"source":     "runtime",
+			"session_id": sessionID,
+			"groups":     runtimebridge.DefaultRuntimeLogStore().Group(sessionID, query),
+		})
+	}
 	return json.Marshal(map[string]any{
 		"source":     "runtime",
 		"session_id": sessionID,
-		"entries":    entries,
+		"entries":    runtimebridge.DefaultRuntimeLogStore().Query(sessionID, query),
 	})
 }
 
@@ -584,7 +815,7 @@ type RuntimeScreenshotGetTool struct{}
 
 func (t *RuntimeScreenshotGetTool) Name() string { return "godot.runtime.screenshot.get" }
 func (t *RuntimeScreenshotGetTool) Description() string {
-	return "[runtime] Captures one runtime screenshot from running game"
+	return "[runtime] Captures one runtime screenshot from running game and returns it as image content"
 }
 func (t *RuntimeScreenshotGetTool) Annotations() *mcp.ToolAnnotations {
 	return &mcp.ToolAnnotations{
@@ -596,8 +827,9 @@ func (t *RuntimeScreenshotGetTool) InputSchema() mcp.InputSchema {
 	return mcp.InputSchema{
 		Type: "object",
 		Properties: map[string]any{
-			"session_id": map[string]any{"type": "string"},
-			"mode":       map[string]any{"type": "string"},
+			"session_id":    map[string]any{"type": "string"},
+			"mode":          map[string]any{"type": "string"},
+			"include_image": map[string]any{"type": "boolean", "description": "Return the PNG as image content (default true)"},
 		},
 		Required: []string{"session_id"},
 		Title:    "Runtime Screenshot Get",
@@ -625,9 +857,13 @@ func (t *RuntimeScreenshotGetTool) Execute(args json.RawMessage) ([]byte, error)
 			mode = strings.TrimSpace(value)
 		}
 	}
-	ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{"mode": mode}, defaultRuntimeCommandTimeout)
-	if dispatchErr != nil {
-		return nil, dispatchErr
+	includeImage, semErr := optionalBoolArgument(arguments, "include_image", true, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	ack, shot, semErr := captureRuntimeScreenshot(sessionID, mode, t.Name())
+	if semErr != nil {
+		return nil, semErr
 	}
 	out := map[string]any{
 		"source":     "runtime",
@@ -637,6 +873,14 @@ func (t *RuntimeScreenshotGetTool) Execute(args json.RawMessage) ([]byte, error)
 	for key, value := range ack.Result {
 		out[key] = value
 	}
+	out["screenshot_id"] = shot.ScreenshotID
+	out["width"] = shot.Width
+	out["height"] = shot.Height
+	out["bytes"] = shot.Bytes
+	out["captured_at"] = shot.CapturedAt
+	if includeImage {
+		out[tooltypes.ToolResultImagesKey] = []tooltypes.ToolResultImage{tooltypes.PNGToolResultImage(shot.PNG)}
+	}
 	return json.Marshal(out)
 }
 
@@ -2,6 +2,12 @@ package runtime
 
 import (
 	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -95,6 +101,41 @@ func TestGetActiveGameSessionTool_UsesExplicitEditorSessionOverride(t *testing.T
 	}
 }
 
+func TestGetActiveGameSessionTool_ReportsCrashedSession(t *testing.T) {
+	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	now := time.Now().UTC()
+	runtimebridge.DefaultEditorStore().Upsert("editor-owner", runtimebridge.Snapshot{
+		RootSummary: runtimebridge.RootSummary{ActiveScene: "res://Main.tscn"},
+	}, now)
+	registry := runtimebridge.DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game_owner", "editor-owner", "res://Main.tscn", "launch-token", now)
+	registry.RegisterRuntimeTransport("game_owner", "runtime-1", "editor-owner", "res://Main.tscn", now, "launch-token")
+	registry.MarkCrashed("game_owner", runtimebridge.GameSessionReasonTransportClosed, []runtimebridge.RuntimeLogEntry{
+		{Sequence: 7, Level: "error", Message: "SCRIPT ERROR: Invalid call"},
+	}, now)
+
+	tool := &GetActiveGameSessionTool{}
+	_, err := tool.Execute(json.RawMessage(`{
+		"editor_session_id":"editor-owner",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok {
+		t.Fatalf("expected semantic error, got %v", err)
+	}
+	if semanticErr.Data["code"] != "game_session_missing" {
+		t.Fatalf("expected code game_session_missing, got %v", semanticErr.Data["code"])
+	}
+	if semanticErr.Data["session_id"] != "game_owner" || semanticErr.Data["state"] != "crashed" || semanticErr.Data["state_reason"] != "transport_closed" {
+		t.Fatalf("expected crashed session details, got %v", semanticErr.Data)
+	}
+	lines, _ := semanticErr.Data["last_log_lines"].([]runtimebridge.RuntimeLogEntry)
+	if len(lines) != 1 || lines[0].Sequence != 7 {
+		t.Fatalf("expected last log lines, got %v", semanticErr.Data["last_log_lines"])
+	}
+}
+
 func TestGetActiveGameSessionTool_ReturnsNotAvailableWhenNoHealthyEditorSession(t *testing.T) {
 	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
 	runtimebridge.ResetDefaultGameSessionRegistryForTests()
@@ -270,6 +311,84 @@ func TestRuntimeSceneTreeGetTool_ReturnsRuntimeSnapshotMissingCode(t *testing.T)
 	}
 }
 
+func TestRuntimeSceneTreeDiffTool_DiffsRetainedSnapshots(t *testing.T) {
+	runtimebridge.ResetDefaultRuntimeSnapshotStoreForTests(10*time.Second, 0)
+	now := time.Now().UTC()
+	store := runtimebridge.DefaultRuntimeSnapshotStore()
+	store.Upsert("game_1", runtimebridge.RuntimeSnapshot{
+		SnapshotID: "snap_00000001",
+		Frame:      10,
+		SceneTree: runtimebridge.CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []runtimebridge.CompactNode{
+			{Path: "/root/Main/Enemy", Name: "Enemy", Type: "CharacterBody2D", InstanceID: 2},
+		}},
+	}, now)
+	store.Upsert("game_1", runtimebridge.RuntimeSnapshot{
+		SnapshotID: "snap_00000002",
+		Frame:      20,
+		SceneTree:  runtimebridge.CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D"},
+	}, now)
+	store.Upsert("game_1", runtimebridge.RuntimeSnapshot{
+		SnapshotID: "snap_00000003",
+		Frame:      30,
+		SceneTree: runtimebridge.CompactNode{Path: "/root/Main", Name: "Main", Type: "Node2D", Children: []runtimebridge.CompactNode{
+			{Path: "/root/Main/Coin", Name: "Coin", Type: "Area2D", InstanceID: 3},
+		}},
+	}, now)
+
+	tool := &RuntimeSceneTreeDiffTool{}
+	raw := json.RawMessage(`{
+		"session_id":"game_1",
+		"from_frame":15,
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`)
+	resultRaw, err := tool.Execute(raw)
+	if err != nil {
+		t.Fatalf("execute godot.runtime.scene_tree.diff: %v", err)
+	}
+	var result struct {
+		From    map[string]any                   `json:"from"`
+		To      map[string]any                   `json:"to"`
+		Added   []runtimebridge.SceneTreeNodeRef `json:"added"`
+		Removed []runtimebridge.SceneTreeNodeRef `json:"removed"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result.From["snapshot_id"] != "snap_00000001" || result.To["snapshot_id"] != "snap_00000003" {
+		t.Fatalf("expected diff snap_00000001..snap_00000003, got %v..%v", result.From["snapshot_id"], result.To["snapshot_id"])
+	}
+	if len(result.Added) != 1 || result.Added[0].Path != "/root/Main/Coin" {
+		t.Fatalf("expected Coin added, got %+v", result.Added)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].Path != "/root/Main/Enemy" {
+		t.Fatalf("expected Enemy removed, got %+v", result.Removed)
+	}
+
+	_, err = tool.Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"from_snapshot_id":"snap_missing",
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok {
+		t.Fatalf("expected semantic error, got %v", err)
+	}
+	if semanticErr.Data["code"] != "runtime_snapshot_missing" {
+		t.Fatalf("expected code runtime_snapshot_missing, got %v", semanticErr.Data["code"])
+	}
+
+	for _, args := range []string{
+		`"to_snapshot_id":"snap_00000001"`,
+		`"from_snapshot_id":"snap_00000003","to_snapshot_id":"snap_00000002"`,
+	} {
+		_, err = tool.Execute(json.RawMessage(`{"session_id":"game_1",` + args + `,"_mcp":{"session_id":"editor-1","session_initialized":true}}`))
+		semanticErr, ok = tooltypes.AsSemanticError(err)
+		if !ok || semanticErr.Kind != tooltypes.SemanticKindInvalidParams || semanticErr.Data["code"] != "invalid_range" {
+			t.Fatalf("expected invalid_range for %s, got %v", args, err)
+		}
+	}
+}
+
 func TestRuntimeInputPressTool_ReturnsGameSessionMissingWithoutRuntimeTransport(t *testing.T) {
 	runtimebridge.ResetDefaultGameSessionRegistryForTests()
 
@@ -292,6 +411,88 @@ func TestRuntimeInputPressTool_ReturnsGameSessionMissingWithoutRuntimeTransport(
 	}
 }
 
+func TestRuntimeInputPressTool_RejectsGestureOnlyEvent(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+
+	tool := &RuntimeInputPressTool{}
+	raw := json.RawMessage(`{
+		"session_id":"game_1",
+		"event":{"type":"mouse_drag","from":{"x":0,"y":0},"to":{"x":100,"y":40}},
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`)
+	_, err := tool.Execute(raw)
+	if err == nil {
+		t.Fatal("expected semantic error")
+	}
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok {
+		t.Fatalf("expected semantic error, got %T", err)
+	}
+	if semanticErr.Kind != tooltypes.SemanticKindInvalidParams {
+		t.Fatalf("expected invalid_params kind, got %s", semanticErr.Kind)
+	}
+	if semanticErr.Data["code"] != "input_not_supported" {
+		t.Fatalf("expected code input_not_supported, got %v", semanticErr.Data["code"])
+	}
+}
+
+func TestRuntimeInputTapTool_DispatchesNormalizedEvent(t *testing.T) {
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+
+	dispatched := make(chan map[string]any, 1)
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		arguments, _ := params["arguments"].(map[string]any)
+		dispatched <- arguments
+		go func() {
+			runtimebridge.DefaultCommandBroker().Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result:    map[string]any{"frame": 42},
+			})
+		}()
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	tool := &RuntimeInputTapTool{}
+	raw := json.RawMessage(`{
+		"session_id":"game_1",
+		"event":{"type":"joypad_button","button":"Start"},
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`)
+	resultRaw, err := tool.Execute(raw)
+	if err != nil {
+		t.Fatalf("execute godot.runtime.input.tap: %v", err)
+	}
+
+	arguments := <-dispatched
+	event, ok := arguments["event"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected event argument, got %v", arguments)
+	}
+	if event["type"] != "joypad_button" || event["button"] != 6 || event["device"] != 0 {
+		t.Fatalf("expected normalized joypad_button event, got %v", event)
+	}
+	if _, ok := arguments["input"]; ok {
+		t.Fatalf("expected no input argument when event is used, got %v", arguments["input"])
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if result["frame"] != float64(42) {
+		t.Fatalf("expected frame=42, got %v", result["frame"])
+	}
+}
+
 func TestRuntimeLogGetTool_RejectsMissingGameSession(t *testing.T) {
 	runtimebridge.ResetDefaultGameSessionRegistryForTests()
 	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
@@ -430,6 +631,174 @@ func TestRuntimeLogGetTool_ReturnsEmptyEntriesForCleanRunningSession(t *testing.
 	}
 }
 
+func TestRuntimeLogGetTool_GroupsParsedErrorsByFile(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+	runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{
+		{Level: "error", Message: "SCRIPT ERROR: Invalid call. Nonexistent function 'jump'.", StackTrace: "at: _ready (res://player.gd:12)"},
+		{Level: "error", Message: "SCRIPT ERROR: Invalid call. Nonexistent function 'jump'.", StackTrace: "at: _ready (res://player.gd:12)"},
+		{Level: "error", Message: "SCRIPT ERROR: Division by zero.", StackTrace: "at: _process (res://enemy.gd:7)"},
+	}, now)
+
+	tool := &RuntimeLogGetTool{}
+	resultRaw, err := tool.Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"file":"player.gd",
+		"group":true,
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.log.get: %v", err)
+	}
+	var result struct {
+		Groups  []runtimebridge.RuntimeLogGroup `json:"groups"`
+		Entries []runtimebridge.RuntimeLogEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if len(result.Entries) != 0 || len(result.Groups) != 1 {
+		t.Fatalf("expected one group and no entries, got %s", string(resultRaw))
+	}
+	group := result.Groups[0]
+	if group.Count != 2 || group.ErrorKind != "script_error" || group.File != "res://player.gd" || group.Line != 12 || group.Function != "_ready" {
+		t.Fatalf("unexpected group: %#v", group)
+	}
+
+	_, err = tool.Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"function":7,
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	semErr, ok := err.(*tooltypes.SemanticError)
+	if !ok || semErr.Data["code"] != "invalid_function" {
+		t.Fatalf("expected invalid_function error, got %v", err)
+	}
+}
+
+func TestRuntimeLogSearchTool_FindsEntriesFromRemovedSessions(t *testing.T) {
+	runtimebridge.ResetDefaultRuntimeLogArchiveForTests(t.TempDir(), runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	t.Cleanup(func() {
+		runtimebridge.ResetDefaultRuntimeLogArchiveForTests("", runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	})
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultRuntimeLogStore().Append("game_old", []runtimebridge.RuntimeLogAppendEntry{
+		{Level: "error", Message: "SCRIPT ERROR: Division by zero.", Source: "process_stderr"},
+		{Level: "info", Message: "level loaded", Source: "process_stdout"},
+	}, now)
+	runtimebridge.DefaultRuntimeLogStore().RemoveSession("game_old")
+
+	tool := &RuntimeLogSearchTool{}
+	resultRaw, err := tool.Execute(json.RawMessage(`{
+		"pattern":"(?i)division",
+		"source":"process_*",
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.log.search: %v", err)
+	}
+	var result struct {
+		Matches            []runtimebridge.RuntimeLogRecord `json:"matches"`
+		PersistenceEnabled bool                             `json:"persistence_enabled"`
+		Exports            []map[string]any                 `json:"exports"`
+	}
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+	if !result.PersistenceEnabled || len(result.Matches) != 1 || result.Matches[0].SessionID != "game_old" {
+		t.Fatalf("unexpected search result: %s", string(resultRaw))
+	}
+	if len(result.Exports) != 1 || result.Exports[0]["resource_uri"] != "godot://runtime/logs/game_old" {
+		t.Fatalf("expected export resource for matched session, got %v", result.Exports)
+	}
+
+	_, err = tool.Execute(json.RawMessage(`{
+		"pattern":"(",
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	semErr, ok := err.(*tooltypes.SemanticError)
+	if !ok || semErr.Data["code"] != "invalid_pattern" {
+		t.Fatalf("expected invalid_pattern error, got %v", err)
+	}
+}
+
+func TestRuntimeLogTailTools_StreamAppendedEntriesToCallerSession(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	runtimebridge.ResetDefaultRuntimeLogTailHubForTests(100)
+	notifications := make(chan map[string]any, 4)
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		if sessionID == "editor-1" {
+			notifications <- message
+		}
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+
+	startRaw, err := (&RuntimeLogTailStartTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"level":"error",
+		"_mcp":{"session_id":"editor-1","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.log.tail.start: %v", err)
+	}
+	var started struct {
+		TailID       string `json:"tail_id"`
+		Notification string `json:"notification"`
+	}
+	if err := json.Unmarshal(startRaw, &started); err != nil {
+		t.Fatalf("unmarshal start result: %v", err)
+	}
+	if started.TailID == "" || started.Notification != "notifications/godot/runtime_log" {
+		t.Fatalf("unexpected start result: %s", string(startRaw))
+	}
+
+	runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{
+		{Level: "error", Message: "boom"},
+	}, now)
+	select {
+	case message := <-notifications:
+		params := message["params"].(map[string]any)
+		if params["tail_id"] != started.TailID || params["session_id"] != "game_1" {
+			t.Fatalf("unexpected notification params: %v", params)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for runtime log notification")
+	}
+
+	stopTool := &RuntimeLogTailStopTool{}
+	_, err = stopTool.Execute(json.RawMessage(`{"tail_id":"` + started.TailID + `","_mcp":{"session_id":"editor-2","session_initialized":true}}`))
+	semErr, ok := err.(*tooltypes.SemanticError)
+	if !ok || semErr.Data["code"] != "tail_not_found" {
+		t.Fatalf("expected tail_not_found for another MCP session, got %v", err)
+	}
+	stopRaw, err := stopTool.Execute(json.RawMessage(`{"tail_id":"` + started.TailID + `","_mcp":{"session_id":"editor-1","session_initialized":true}}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.log.tail.stop: %v", err)
+	}
+	var stopped struct {
+		Stopped   bool  `json:"stopped"`
+		Delivered int64 `json:"delivered"`
+	}
+	if err := json.Unmarshal(stopRaw, &stopped); err != nil {
+		t.Fatalf("unmarshal stop result: %v", err)
+	}
+	if !stopped.Stopped || stopped.Delivered != 1 {
+		t.Fatalf("unexpected stop result: %s", string(stopRaw))
+	}
+}
+
 func TestRuntimeLogClearTool_DispatchesAndClearsBuffer(t *testing.T) {
 	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
 	runtimebridge.ResetDefaultGameSessionRegistryForTests()
@@ -480,3 +849,377 @@ func TestRuntimeLogClearTool_DispatchesAndClearsBuffer(t *testing.T) {
 		t.Fatalf("expected cleared runtime log buffer, got %d entries", len(entries))
 	}
 }
+
+func TestRuntimeWatchTools_RecordSeriesAndNotifyChanges(t *testing.T) {
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeWatchStoreForTests(100)
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+
+	changes := []map[string]any{}
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		if message["method"] == "notifications/godot/watch_changed" {
+			if sessionID != "ai-session" {
+				t.Errorf("expected watch change for ai-session, got %q", sessionID)
+			}
+			changes = append(changes, params)
+			return true
+		}
+		commandID, _ := params["command_id"].(string)
+		go func() {
+			runtimebridge.DefaultCommandBroker().Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result:    map[string]any{"node": "/root/Main/Player", "value": 100},
+			})
+		}()
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	addRaw, err := (&RuntimeWatchAddTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"node":"Player",
+		"property":"hp",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.watch.add: %v", err)
+	}
+	var added struct {
+		Watch runtimebridge.RuntimeWatch `json:"watch"`
+	}
+	if err := json.Unmarshal(addRaw, &added); err != nil {
+		t.Fatalf("unmarshal add result: %v", err)
+	}
+	if added.Watch.WatchID == "" || added.Watch.IntervalMS != defaultWatchIntervalMS {
+		t.Fatalf("unexpected watch: %+v", added.Watch)
+	}
+
+	pushRaw := json.RawMessage(`{
+		"session_id":"game_1",
+		"samples":[
+			{"watch_id":"` + added.Watch.WatchID + `","frame":10,"value":100},
+			{"watch_id":"` + added.Watch.WatchID + `","frame":20,"value":75},
+			{"watch_id":"watch_unknown","frame":20,"value":1}
+		],
+		"_mcp":{"session_id":"runtime-1","session_initialized":true}
+	}`)
+	if _, err := (&BridgeRuntimeWatchPushTool{}).Execute(pushRaw); err != nil {
+		t.Fatalf("execute godot.bridge.runtime.watch.push: %v", err)
+	}
+	if len(changes) != 2 || changes[1]["value"] != float64(75) || changes[1]["previous"] != float64(100) {
+		t.Fatalf("expected two change notifications, got %v", changes)
+	}
+
+	getRaw, err := (&RuntimeWatchGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"watch_id":"` + added.Watch.WatchID + `",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.watch.get: %v", err)
+	}
+	var series struct {
+		Samples []runtimebridge.RuntimeWatchSample `json:"samples"`
+		Stats   runtimebridge.RuntimeWatchStats    `json:"stats"`
+	}
+	if err := json.Unmarshal(getRaw, &series); err != nil {
+		t.Fatalf("unmarshal get result: %v", err)
+	}
+	if len(series.Samples) != 2 || series.Stats.Min != float64(75) || series.Stats.Max != float64(100) || series.Stats.Last != float64(75) {
+		t.Fatalf("unexpected watch series: %s", string(getRaw))
+	}
+
+	_, err = (&RuntimeWatchGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"watch_id":"watch_unknown",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "watch_not_found" {
+		t.Fatalf("expected watch_not_found, got %v", err)
+	}
+}
+
+func TestRuntimePerformanceTools_SampleSeriesAndLiveRead(t *testing.T) {
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimePerformanceStoreForTests(100)
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+
+	commands := []string{}
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		name, _ := params["name"].(string)
+		commands = append(commands, name)
+		go func() {
+			runtimebridge.DefaultCommandBroker().Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result:    map[string]any{"frame": 42, "monitors": map[string]any{"fps": 58.0}},
+			})
+		}()
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	_, err := (&RuntimePerformanceSampleTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"enabled":true,
+		"monitors":["fps","bogus"],
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "invalid_monitor" {
+		t.Fatalf("expected invalid_monitor, got %v", err)
+	}
+
+	if _, err := (&RuntimePerformanceSampleTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"enabled":true,
+		"interval_ms":250,
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`)); err != nil {
+		t.Fatalf("execute godot.runtime.performance.sample: %v", err)
+	}
+
+	if _, err := (&BridgeRuntimePerformancePushTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"samples":[
+			{"frame":10,"monitors":{"fps":60,"node_count":12}},
+			{"frame":20,"monitors":{"fps":30,"node_count":14}}
+		],
+		"_mcp":{"session_id":"runtime-1","session_initialized":true}
+	}`)); err != nil {
+		t.Fatalf("execute godot.bridge.runtime.performance.push: %v", err)
+	}
+
+	getRaw, err := (&RuntimePerformanceGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"monitors":["fps"],
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.performance.get: %v", err)
+	}
+	var series struct {
+		Sampling runtimebridge.RuntimePerformanceSampling         `json:"sampling"`
+		Samples  []runtimebridge.RuntimePerformanceSample         `json:"samples"`
+		Stats    map[string]runtimebridge.RuntimePerformanceStats `json:"stats"`
+		Current  map[string]any                                   `json:"current"`
+	}
+	if err := json.Unmarshal(getRaw, &series); err != nil {
+		t.Fatalf("unmarshal get result: %v", err)
+	}
+	if !series.Sampling.Active || series.Sampling.IntervalMS != 250 || len(series.Samples) != 2 || series.Current != nil {
+		t.Fatalf("unexpected performance series: %s", string(getRaw))
+	}
+	if fps := series.Stats["fps"]; fps.Min != 30 || fps.Max != 60 || fps.Avg != 45 || fps.Last != 30 {
+		t.Fatalf("unexpected fps stats: %+v", fps)
+	}
+
+	if _, err := (&RuntimePerformanceSampleTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"enabled":false,
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`)); err != nil {
+		t.Fatalf("stop godot.runtime.performance.sample: %v", err)
+	}
+	getRaw, err = (&RuntimePerformanceGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute live godot.runtime.performance.get: %v", err)
+	}
+	series.Current = nil
+	if err := json.Unmarshal(getRaw, &series); err != nil {
+		t.Fatalf("unmarshal live get result: %v", err)
+	}
+	monitors, _ := series.Current["monitors"].(map[string]any)
+	if series.Sampling.Active || len(series.Samples) != 2 || monitors["fps"] != 58.0 {
+		t.Fatalf("expected retained samples plus a live read, got %s", string(getRaw))
+	}
+	if len(commands) != 3 || commands[2] != "godot.runtime.performance.get" {
+		t.Fatalf("unexpected dispatched commands: %v", commands)
+	}
+}
+
+func TestRuntimeScreenshotTools_StoreCaptureAndCompareAgainstBaseline(t *testing.T) {
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeScreenshotStoreForTests(4, 1<<20, t.TempDir(), filepath.Join(t.TempDir(), "captures"))
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+
+	frames := []color.NRGBA{{R: 20, G: 40, B: 60, A: 255}, {R: 20, G: 40, B: 60, A: 255}, {R: 250, G: 10, B: 10, A: 255}}
+	captured := 0
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		arguments, _ := params["arguments"].(map[string]any)
+		frameDir, _ := arguments["output_dir"].(string)
+		img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+		for y := 0; y < 2; y++ {
+			for x := 0; x < 4; x++ {
+				img.SetNRGBA(x, y, color.NRGBA{R: 20, G: 40, B: 60, A: 255})
+			}
+		}
+		img.SetNRGBA(0, 0, frames[captured])
+		captured++
+		path := filepath.Join(frameDir, fmt.Sprintf("frame_%d.png", captured))
+		file, err := os.Create(path)
+		if err != nil {
+			t.Errorf("create frame: %v", err)
+			return false
+		}
+		_ = png.Encode(file, img)
+		_ = file.Close()
+		go func(frame int) {
+			runtimebridge.DefaultCommandBroker().Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result:    map[string]any{"path": path, "width": 4, "height": 2, "frame": frame},
+			})
+		}(captured * 10)
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	getRaw, err := (&RuntimeScreenshotGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.screenshot.get: %v", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(getRaw, &got); err != nil {
+		t.Fatalf("unmarshal get result: %v", err)
+	}
+	images, _ := got[tooltypes.ToolResultImagesKey].([]any)
+	if got["screenshot_id"] != "shot_1" || len(images) != 1 {
+		t.Fatalf("expected stored screenshot with image content, got %s", string(getRaw))
+	}
+
+	_, err = (&RuntimeScreenshotCompareTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"baseline":"title",
+		"screenshot_id":"shot_1",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "baseline_missing" {
+		t.Fatalf("expected baseline_missing, got %v", err)
+	}
+
+	if _, err := (&RuntimeScreenshotBaselineSaveTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_1",
+		"name":"title",
+		"screenshot_id":"shot_1",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`)); err != nil {
+		t.Fatalf("execute godot.runtime.screenshot.baseline.save: %v", err)
+	}
+
+	compare := func() map[string]any {
+		raw, err := (&RuntimeScreenshotCompareTool{}).Execute(json.RawMessage(`{
+			"session_id":"game_1",
+			"baseline":"title",
+			"_mcp":{"session_id":"ai-session","session_initialized":true}
+		}`))
+		if err != nil {
+			t.Fatalf("execute godot.runtime.screenshot.compare: %v", err)
+		}
+		var out map[string]any
+		if err := json.Unmarshal(raw, &out); err != nil {
+			t.Fatalf("unmarshal compare result: %v", err)
+		}
+		return out
+	}
+	if out := compare(); out["passed"] != true || out["diff_pixels"] != float64(0) {
+		t.Fatalf("expected identical capture to pass, got %v", out)
+	}
+	out := compare()
+	if out["passed"] != false || out["diff_pixels"] != float64(1) || out["diff_ratio"] != 0.125 {
+		t.Fatalf("expected one differing pixel, got %v", out)
+	}
+	if diffImages, _ := out[tooltypes.ToolResultImagesKey].([]any); len(diffImages) != 1 {
+		t.Fatalf("expected diff image content, got %v", out)
+	}
+}
+
+func TestRuntimeSessionTools_ListAndGetFinishedRuns(t *testing.T) {
+	runtimebridge.ResetDefaultGameSessionHistoryForTests(10)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(50)
+	now := time.Now().UTC()
+	registry := runtimebridge.DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game_a", "editor-1", "res://Main.tscn", "token-a", now.Add(-2*time.Minute))
+	runtimebridge.DefaultRuntimeLogStore().Append("game_a", []runtimebridge.RuntimeLogAppendEntry{{Level: "error", Message: "boom"}}, now)
+	registry.StopSession("game_a", now.Add(-time.Minute))
+	registry.RemoveSession("game_a")
+	registry.UpsertFromRun("game_b", "editor-1", "res://Main.tscn", "token-b", now)
+
+	listRaw, err := (&RuntimeSessionListTool{}).Execute(json.RawMessage(`{
+		"outcome":"stopped",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.session.list: %v", err)
+	}
+	var list struct {
+		Sessions []runtimebridge.GameSessionRecord `json:"sessions"`
+		Count    int                               `json:"count"`
+	}
+	if err := json.Unmarshal(listRaw, &list); err != nil {
+		t.Fatalf("unmarshal list: %v", err)
+	}
+	if list.Count != 1 || list.Sessions[0].SessionID != "game_a" || list.Sessions[0].ErrorCount != 1 {
+		t.Fatalf("expected stopped game_a with one error, got %+v", list)
+	}
+	if list.Sessions[0].DurationMS != 60000 || len(list.Sessions[0].Events) != 0 {
+		t.Fatalf("expected 60s duration without events in list, got %+v", list.Sessions[0])
+	}
+
+	getRaw, err := (&RuntimeSessionGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_a",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.runtime.session.get: %v", err)
+	}
+	var detail struct {
+		Session        runtimebridge.GameSessionRecord `json:"session"`
+		LogResourceURI string                          `json:"log_resource_uri"`
+	}
+	if err := json.Unmarshal(getRaw, &detail); err != nil {
+		t.Fatalf("unmarshal detail: %v", err)
+	}
+	if detail.Session.ExitReason != runtimebridge.GameSessionExitProjectStop || len(detail.Session.Events) != 2 {
+		t.Fatalf("expected project_stop record with events, got %+v", detail.Session)
+	}
+	if detail.LogResourceURI != runtimebridge.RuntimeLogResourceURI("game_a") {
+		t.Fatalf("expected log resource uri, got %q", detail.LogResourceURI)
+	}
+
+	_, err = (&RuntimeSessionGetTool{}).Execute(json.RawMessage(`{
+		"session_id":"game_missing",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "game_session_missing" {
+		t.Fatalf("expected game_session_missing, got %v", err)
+	}
+}
@@ -0,0 +1,347 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"image/png"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/imagediff"
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	screenshotCaptureCommand       = "godot.runtime.screenshot.get"
+	defaultScreenshotDiffThreshold = 0.1
+)
+
+type RuntimeScreenshotCompareTool struct{}
+
+func (t *RuntimeScreenshotCompareTool) Name() string { return "godot.runtime.screenshot.compare" }
+func (t *RuntimeScreenshotCompareTool) Description() string {
+	return "[runtime] Compares a runtime screenshot against a saved baseline and returns a diff image"
+}
+func (t *RuntimeScreenshotCompareTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeScreenshotCompareTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":         map[string]any{"type": "string"},
+			"baseline":           map[string]any{"type": "string", "description": "Baseline name saved by godot.runtime.screenshot.baseline.save"},
+			"screenshot_id":      map[string]any{"type": "string", "description": "Retained screenshot to compare; a new one is captured when omitted"},
+			"mode":               map[string]any{"type": "string", "description": "perceptual (default) or pixel"},
+			"threshold":          map[string]any{"type": "number", "description": "Per-pixel delta 0..1 above which a pixel differs (default 0.1)"},
+			"max_diff_ratio":     map[string]any{"type": "number", "description": "Largest differing pixel ratio that still passes (default 0)"},
+			"include_diff_image": map[string]any{"type": "boolean", "description": "Return the diff PNG as image content (default true)"},
+		},
+		Required: []string{"session_id", "baseline"},
+		Title:    "Runtime Screenshot Compare",
+	}
+}
+func (t *RuntimeScreenshotCompareTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	baselineName, _ := arguments["baseline"].(string)
+	baselineName = strings.TrimSpace(baselineName)
+	if baselineName == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("baseline is required", t.Name(), "invalid_baseline", nil)
+	}
+	mode := imagediff.ModePerceptual
+	if raw, ok := arguments["mode"]; ok {
+		value, _ := raw.(string)
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != imagediff.ModePixel && value != imagediff.ModePerceptual {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("mode must be pixel or perceptual", t.Name(), "invalid_diff_mode", nil)
+		}
+		mode = value
+	}
+	threshold, semErr := optionalRatioArgument(arguments, "threshold", defaultScreenshotDiffThreshold, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	maxDiffRatio, semErr := optionalRatioArgument(arguments, "max_diff_ratio", 0, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	includeDiff, semErr := optionalBoolArgument(arguments, "include_diff_image", true, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	store := runtimebridge.DefaultRuntimeScreenshotStore()
+	baselineData, baselinePath, err := store.LoadBaseline(baselineName)
+	if err != nil {
+		return nil, screenshotBaselineError(err, baselineName, baselinePath, t.Name())
+	}
+	baselineImage, err := runtimebridge.DecodeScreenshotPNG(baselineData)
+	if err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Screenshot baseline is not a valid PNG", t.Name(), "baseline_invalid", map[string]any{
+			"baseline": baselineName,
+			"path":     baselinePath,
+			"detail":   err.Error(),
+		})
+	}
+	shot, semErr := resolveRuntimeScreenshot(arguments, sessionID, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	candidateImage, err := runtimebridge.DecodeScreenshotPNG(shot.PNG)
+	if err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Runtime screenshot could not be decoded", t.Name(), "screenshot_invalid", map[string]any{
+			"session_id":    sessionID,
+			"screenshot_id": shot.ScreenshotID,
+		})
+	}
+
+	out := map[string]any{
+		"source":        "runtime",
+		"session_id":    sessionID,
+		"baseline":      baselineName,
+		"screenshot_id": shot.ScreenshotID,
+		"frame":         shot.Frame,
+		"mode":          mode,
+		"threshold":     threshold,
+	}
+	result, err := imagediff.Compare(baselineImage, candidateImage, imagediff.Options{Threshold: threshold, Mode: mode})
+	var mismatch *imagediff.SizeMismatchError
+	if errors.As(err, &mismatch) {
+		out["passed"] = false
+		out["reason"] = "size_mismatch"
+		out["baseline_size"] = map[string]any{"width": mismatch.Baseline.X, "height": mismatch.Baseline.Y}
+		out["screenshot_size"] = map[string]any{"width": mismatch.Candidate.X, "height": mismatch.Candidate.Y}
+		return json.Marshal(out)
+	}
+	if err != nil {
+		return nil, tooltypes.NewRuntimeInvalidParamsError(err.Error(), t.Name(), "invalid_diff_options", nil)
+	}
+	out["passed"] = result.DiffRatio <= maxDiffRatio
+	out["max_diff_ratio"] = maxDiffRatio
+	out["diff_pixels"] = result.DiffPixels
+	out["total_pixels"] = result.TotalPixels
+	out["diff_ratio"] = result.DiffRatio
+	out["max_delta"] = result.MaxDelta
+	out["width"] = result.Width
+	out["height"] = result.Height
+	if includeDiff {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, result.Diff); err != nil {
+			return nil, err
+		}
+		out[tooltypes.ToolResultImagesKey] = []tooltypes.ToolResultImage{tooltypes.PNGToolResultImage(buf.Bytes())}
+	}
+	return json.Marshal(out)
+}
+
+type RuntimeScreenshotBaselineSaveTool struct{}
+
+func (t *RuntimeScreenshotBaselineSaveTool) Name() string {
+	return "godot.runtime.screenshot.baseline.save"
+}
+func (t *RuntimeScreenshotBaselineSaveTool) Description() string {
+	return "[runtime] Saves a runtime screenshot as a named baseline for screenshot compare"
+}
+func (t *RuntimeScreenshotBaselineSaveTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *RuntimeScreenshotBaselineSaveTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":    map[string]any{"type": "string"},
+			"name":          map[string]any{"type": "string", "description": "Baseline name ([A-Za-z0-9._-], up to 64 characters)"},
+			"screenshot_id": map[string]any{"type": "string", "description": "Retained screenshot to save; a new one is captured when omitted"},
+			"overwrite":     map[string]any{"type": "boolean", "description": "Replace an existing baseline (default false)"},
+		},
+		Required: []string{"session_id", "name"},
+		Title:    "Runtime Screenshot Baseline Save",
+	}
+}
+func (t *RuntimeScreenshotBaselineSaveTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	name, _ := arguments["name"].(string)
+	name = strings.TrimSpace(name)
+	overwrite, semErr := optionalBoolArgument(arguments, "overwrite", false, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	store := runtimebridge.DefaultRuntimeScreenshotStore()
+	_, existingPath, err := store.LoadBaseline(name)
+	exists := err == nil || errors.Is(err, runtimebridge.ErrScreenshotTooLarge)
+	switch {
+	case exists && !overwrite:
+		return nil, tooltypes.NewRuntimeInvalidParamsError("Screenshot baseline already exists", t.Name(), "baseline_exists", map[string]any{
+			"baseline": name,
+			"path":     existingPath,
+		})
+	case !exists && !errors.Is(err, runtimebridge.ErrScreenshotBaselineMissing):
+		return nil, screenshotBaselineError(err, name, existingPath, t.Name())
+	}
+	shot, semErr := resolveRuntimeScreenshot(arguments, sessionID, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	path, err := store.SaveBaseline(name, shot.PNG)
+	if err != nil {
+		return nil, screenshotBaselineError(err, name, path, t.Name())
+	}
+	return json.Marshal(map[string]any{
+		"source":        "runtime",
+		"session_id":    sessionID,
+		"baseline":      name,
+		"path":          path,
+		"screenshot_id": shot.ScreenshotID,
+		"frame":         shot.Frame,
+		"width":         shot.Width,
+		"height":        shot.Height,
+		"replaced":      exists,
+	})
+}
+
+// captureRuntimeScreenshot asks the companion for a screenshot and retains the
+// PNG it wrote. The companion writes into the session's private capture
+// directory, handed out as output_dir, and the image is read from the
+// reported path rather than carried in the ack so large frames stay under the
+// JSON-RPC body limit; companions may still inline small frames as png_base64.
+func captureRuntimeScreenshot(sessionID string, mode string, toolName string) (runtimebridge.CommandAck, runtimebridge.RuntimeScreenshot, *tooltypes.SemanticError) {
+	store := runtimebridge.DefaultRuntimeScreenshotStore()
+	outputDir, err := store.CaptureDir(sessionID)
+	if err != nil {
+		return runtimebridge.CommandAck{}, runtimebridge.RuntimeScreenshot{}, tooltypes.NewRuntimeNotAvailableError("Runtime screenshot is unavailable", toolName, "screenshot_unavailable", map[string]any{
+			"session_id": sessionID,
+			"detail":     err.Error(),
+		})
+	}
+	ack, semErr := dispatchToRuntimeSession(sessionID, screenshotCaptureCommand, map[string]any{"mode": mode, "output_dir": outputDir}, defaultRuntimeCommandTimeout)
+	if semErr != nil {
+		return runtimebridge.CommandAck{}, runtimebridge.RuntimeScreenshot{}, semErr
+	}
+	path, _ := ack.Result["path"].(string)
+	var data []byte
+	if encoded, ok := ack.Result["png_base64"].(string); ok && encoded != "" {
+		data, err = base64.StdEncoding.DecodeString(encoded)
+	} else if strings.TrimSpace(path) != "" {
+		data, err = store.ReadScreenshotFile(sessionID, strings.TrimSpace(path))
+	} else {
+		err = errors.New("screenshot ack has no path or png_base64")
+	}
+	var shot runtimebridge.RuntimeScreenshot
+	if err == nil {
+		shot, err = store.Add(sessionID, data, ackInt64(ack.Result["frame"]), time.Now().UTC())
+	}
+	if err != nil {
+		code := "screenshot_unavailable"
+		if errors.Is(err, runtimebridge.ErrScreenshotTooLarge) {
+			code = "screenshot_too_large"
+		}
+		return runtimebridge.CommandAck{}, runtimebridge.RuntimeScreenshot{}, tooltypes.NewRuntimeNotAvailableError("Runtime screenshot is unavailable", toolName, code, map[string]any{
+			"session_id": sessionID,
+			"command_id": ack.CommandID,
+			"path":       path,
+			"detail":     err.Error(),
+		})
+	}
+	return ack, shot, nil
+}
+
+// resolveRuntimeScreenshot returns the retained screenshot named by
+// screenshot_id, or captures a new viewport screenshot when it is omitted.
+func resolveRuntimeScreenshot(arguments map[string]any, sessionID string, toolName string) (runtimebridge.RuntimeScreenshot, *tooltypes.SemanticError) {
+	screenshotID, _ := arguments["screenshot_id"].(string)
+	screenshotID = strings.TrimSpace(screenshotID)
+	if screenshotID == "" {
+		_, shot, semErr := captureRuntimeScreenshot(sessionID, "viewport", toolName)
+		return shot, semErr
+	}
+	shot, ok := runtimebridge.DefaultRuntimeScreenshotStore().Get(sessionID, screenshotID)
+	if !ok {
+		return runtimebridge.RuntimeScreenshot{}, tooltypes.NewRuntimeNotAvailableError("Runtime screenshot is not retained", toolName, "screenshot_not_found", map[string]any{
+			"session_id":    sessionID,
+			"screenshot_id": screenshotID,
+		})
+	}
+	return shot, nil
+}
+
+func screenshotBaselineError(err error, name string, path string, toolName string) *tooltypes.SemanticError {
+	data := map[string]any{"baseline": name}
+	if path != "" {
+		data["path"] = path
+	}
+	switch {
+	case errors.Is(err, runtimebridge.ErrInvalidScreenshotBaseline):
+		return tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_baseline", data)
+	case errors.Is(err, runtimebridge.ErrScreenshotBaselineMissing):
+		return tooltypes.NewRuntimeNotAvailableError("Screenshot baseline does not exist", toolName, "baseline_missing", data)
+	default:
+		data["detail"] = err.Error()
+		return tooltypes.NewRuntimeNotAvailableError("Screenshot baseline is unavailable", toolName, "baseline_unavailable", data)
+	}
+}
+
+func optionalBoolArgument(arguments map[string]any, key string, fallback bool, toolName string) (bool, *tooltypes.SemanticError) {
+	raw, ok := arguments[key]
+	if !ok {
+		return fallback, nil
+	}
+	value, ok := raw.(bool)
+	if !ok {
+		return false, tooltypes.NewRuntimeInvalidParamsError(key+" must be a boolean", toolName, "invalid_"+key, nil)
+	}
+	return value, nil
+}
+
+func optionalRatioArgument(arguments map[string]any, key string, fallback float64, toolName string) (float64, *tooltypes.SemanticError) {
+	raw, ok := arguments[key]
+	if !ok {
+		return fallback, nil
+	}
+	value, ok := raw.(float64)
+	if !ok || value < 0 || value > 1 {
+		return 0, tooltypes.NewRuntimeInvalidParamsError(key+" must be a number between 0 and 1", toolName, "invalid_"+key, nil)
+	}
+	return value, nil
+}
+
+func ackInt64(raw any) int64 {
+	switch value := raw.(type) {
+	case float64:
+		return int64(value)
+	case int:
+		return int64(value)
+	case int64:
+		return value
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,130 @@
+package runtime
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+var gameSessionOutcomes = map[string]bool{
+	runtimebridge.GameSessionStateRunning: true,
+	runtimebridge.GameSessionStateStopped: true,
+	runtimebridge.GameSessionStateCrashed: true,
+	runtimebridge.GameSessionStateHung:    true,
+}
+
+type RuntimeSessionListTool struct{}
+
+func (t *RuntimeSessionListTool) Name() string { return "godot.runtime.session.list" }
+func (t *RuntimeSessionListTool) Description() string {
+	return "[runtime] Lists recent game sessions with outcome, duration, error and snapshot counts"
+}
+func (t *RuntimeSessionListTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeSessionListTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"outcome":    map[string]any{"type": "string", "enum": []string{"running", "stopped", "crashed", "hung"}},
+			"scene_path": map[string]any{"type": "string"},
+			"limit":      map[string]any{"type": "integer"},
+		},
+		Title: "Runtime Session List",
+	}
+}
+func (t *RuntimeSessionListTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+
+	query := runtimebridge.GameSessionHistoryQuery{Limit: 20}
+	var semErr *tooltypes.SemanticError
+	if query.Outcome, semErr = optionalStringArgument(arguments, "outcome", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if query.Outcome != "" && !gameSessionOutcomes[query.Outcome] {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("outcome must be running, stopped, crashed or hung", t.Name(), "invalid_outcome", nil)
+	}
+	if query.ScenePath, semErr = optionalStringArgument(arguments, "scene_path", t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			query.Limit = int(value)
+		}
+	}
+
+	records := runtimebridge.DefaultGameSessionHistory().List(query, time.Now().UTC())
+	for i := range records {
+		// Events and log lines stay on godot.runtime.session.get.
+		records[i].Events = nil
+		records[i].LastLogLines = nil
+	}
+	return json.Marshal(map[string]any{
+		"source":   "runtime",
+		"sessions": records,
+		"count":    len(records),
+	})
+}
+
+type RuntimeSessionGetTool struct{}
+
+func (t *RuntimeSessionGetTool) Name() string { return "godot.runtime.session.get" }
+func (t *RuntimeSessionGetTool) Description() string {
+	return "[runtime] Returns one game session record with lifecycle events and last log lines"
+}
+func (t *RuntimeSessionGetTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeSessionGetTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string"},
+		},
+		Required: []string{"session_id"},
+		Title:    "Runtime Session Get",
+	}
+}
+func (t *RuntimeSessionGetTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	record, ok := runtimebridge.DefaultGameSessionHistory().Get(sessionID, time.Now().UTC())
+	if !ok {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Game session history is unavailable", t.Name(), "game_session_missing", map[string]any{
+			"session_id": sessionID,
+		})
+	}
+	result := map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"session":    record,
+	}
+	if runtimebridge.DefaultRuntimeLogArchive().Enabled() || len(runtimebridge.DefaultRuntimeLogStore().Last(sessionID, 1)) > 0 {
+		result["log_resource_uri"] = runtimebridge.RuntimeLogResourceURI(sessionID)
+	}
+	return json.Marshal(result)
+}
@@ -5,21 +5,37 @@ import "github.com/slighter12/godot-mcp-go/tools/types"
 func GetAllTools() []types.Tool {
 	return []types.Tool{
 		&GetActiveGameSessionTool{},
+		&RuntimeSessionListTool{},
+		&RuntimeSessionGetTool{},
 		&RuntimeSyncNowTool{},
 		&AwaitRuntimeSnapshotTool{},
 		&RuntimeSceneTreeGetTool{},
+		&RuntimeSceneTreeDiffTool{},
 		&RuntimeNodePropertiesGetTool{},
 		&RuntimeInputTapTool{},
 		&RuntimeInputPressTool{},
 		&RuntimeInputReleaseTool{},
 		&RuntimeLogGetTool{},
 		&RuntimeLogClearTool{},
+		&RuntimeLogSearchTool{},
+		&RuntimeLogTailStartTool{},
+		&RuntimeLogTailStopTool{},
 		&RuntimeScreenshotGetTool{},
+		&RuntimeScreenshotCompareTool{},
+		&RuntimeScreenshotBaselineSaveTool{},
+		&RuntimeWatchAddTool{},
+		&RuntimeWatchRemoveTool{},
+		&RuntimeWatchListTool{},
+		&RuntimeWatchGetTool{},
+		&RuntimePerformanceGetTool{},
+		&RuntimePerformanceSampleTool{},
 		&BridgeEditorSyncTool{},
 		&BridgeEditorPingTool{},
 		&BridgeRuntimeRegisterTool{},
 		&BridgeRuntimeSnapshotPushTool{},
 		&BridgeRuntimeLogPushTool{},
+		&BridgeRuntimeWatchPushTool{},
+		&BridgeRuntimePerformancePushTool{},
 		&BridgeCommandAckTool{},
 	}
 }
@@ -0,0 +1,326 @@
+package runtime
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	defaultWatchIntervalMS = 100
+	minWatchIntervalMS     = 16
+	maxWatchIntervalMS     = 60000
+	defaultWatchGetLimit   = 200
+)
+
+type RuntimeWatchAddTool struct{}
+
+func (t *RuntimeWatchAddTool) Name() string { return "godot.runtime.watch.add" }
+func (t *RuntimeWatchAddTool) Description() string {
+	return "[runtime] Watches a runtime node property and records its value over time"
+}
+func (t *RuntimeWatchAddTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *RuntimeWatchAddTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":  map[string]any{"type": "string"},
+			"node":        map[string]any{"type": "string"},
+			"property":    map[string]any{"type": "string", "description": "Property name or indexed path such as position:x"},
+			"interval_ms": map[string]any{"type": "integer", "description": "Sampling interval (default 100, min 16, max 60000)"},
+			"notify":      map[string]any{"type": "boolean", "description": "Send notifications/godot/watch_changed on value changes (default true)"},
+		},
+		Required: []string{"session_id", "node", "property"},
+		Title:    "Runtime Watch Add",
+	}
+}
+func (t *RuntimeWatchAddTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	node, ok := arguments["node"].(string)
+	if !ok || strings.TrimSpace(node) == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("node is required", t.Name(), "node_not_found", nil)
+	}
+	property, ok := arguments["property"].(string)
+	if !ok || strings.TrimSpace(property) == "" {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("property is required", t.Name(), "property_not_supported", nil)
+	}
+	intervalMS := defaultWatchIntervalMS
+	if raw, ok := arguments["interval_ms"]; ok {
+		value, ok := raw.(float64)
+		if !ok || value != math.Trunc(value) || int(value) < minWatchIntervalMS || int(value) > maxWatchIntervalMS {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("interval_ms must be an integer between 16 and 60000", t.Name(), "invalid_interval", nil)
+		}
+		intervalMS = int(value)
+	}
+	notify := true
+	if raw, ok := arguments["notify"]; ok {
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("notify must be a boolean", t.Name(), "invalid_notify", nil)
+		}
+		notify = value
+	}
+
+	watch := runtimebridge.RuntimeWatch{
+		Node:       strings.TrimSpace(node),
+		Property:   strings.TrimSpace(property),
+		IntervalMS: intervalMS,
+	}
+	if notify {
+		watch.NotifySessionID = strings.TrimSpace(ctx.SessionID)
+	}
+	store := runtimebridge.DefaultRuntimeWatchStore()
+	watch, ok = store.Add(sessionID, watch, time.Now().UTC())
+	if !ok {
+		return nil, tooltypes.NewRuntimeInvalidParamsError("Too many watches for this game session", t.Name(), "watch_limit_reached", map[string]any{
+			"session_id": sessionID,
+			"limit":      runtimebridge.MaxRuntimeWatchesPerSession,
+		})
+	}
+
+	// The watch is registered before dispatch so samples pushed right after the
+	// companion accepts it are not dropped.
+	ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{
+		"watch_id":    watch.WatchID,
+		"node":        watch.Node,
+		"property":    watch.Property,
+		"interval_ms": watch.IntervalMS,
+	}, defaultRuntimeCommandTimeout)
+	if dispatchErr != nil {
+		store.Remove(sessionID, watch.WatchID)
+		return nil, dispatchErr
+	}
+
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"command_id": ack.CommandID,
+		"watch":      watch,
+		"notify":     notify,
+		"resolved":   ack.Result["node"],
+		"type":       ack.Result["type"],
+		"value":      ack.Result["value"],
+		"frame":      ack.Result["frame"],
+		"updated_at": ack.Result["updated_at"],
+	})
+}
+
+type RuntimeWatchRemoveTool struct{}
+
+func (t *RuntimeWatchRemoveTool) Name() string { return "godot.runtime.watch.remove" }
+func (t *RuntimeWatchRemoveTool) Description() string {
+	return "[runtime] Stops a runtime property watch and drops its samples"
+}
+func (t *RuntimeWatchRemoveTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:    tooltypes.BoolPtr(false),
+		DestructiveHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeWatchRemoveTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string"},
+			"watch_id":   map[string]any{"type": "string"},
+		},
+		Required: []string{"session_id", "watch_id"},
+		Title:    "Runtime Watch Remove",
+	}
+}
+func (t *RuntimeWatchRemoveTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	watchID, semErr := requireWatchID(arguments, sessionID, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	ack, dispatchErr := dispatchToRuntimeSession(sessionID, t.Name(), map[string]any{
+		"watch_id": watchID,
+	}, defaultRuntimeCommandTimeout)
+	if dispatchErr != nil {
+		return nil, dispatchErr
+	}
+	removed := runtimebridge.DefaultRuntimeWatchStore().Remove(sessionID, watchID)
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"command_id": ack.CommandID,
+		"watch_id":   watchID,
+		"removed":    removed,
+	})
+}
+
+type RuntimeWatchListTool struct{}
+
+func (t *RuntimeWatchListTool) Name() string { return "godot.runtime.watch.list" }
+func (t *RuntimeWatchListTool) Description() string {
+	return "[runtime] Lists runtime property watches for one game session"
+}
+func (t *RuntimeWatchListTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeWatchListTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id": map[string]any{"type": "string"},
+		},
+		Required: []string{"session_id"},
+		Title:    "Runtime Watch List",
+	}
+}
+func (t *RuntimeWatchListTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"watches":    listWatchSummaries(sessionID),
+	})
+}
+
+type RuntimeWatchGetTool struct{}
+
+func (t *RuntimeWatchGetTool) Name() string { return "godot.runtime.watch.get" }
+func (t *RuntimeWatchGetTool) Description() string {
+	return "[runtime] Returns sampled values of a runtime property watch with min/max/last"
+}
+func (t *RuntimeWatchGetTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint:   tooltypes.BoolPtr(true),
+		IdempotentHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *RuntimeWatchGetTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"session_id":     map[string]any{"type": "string"},
+			"watch_id":       map[string]any{"type": "string"},
+			"limit":          map[string]any{"type": "integer"},
+			"since_sequence": map[string]any{"type": "integer"},
+		},
+		Required: []string{"session_id", "watch_id"},
+		Title:    "Runtime Watch Get",
+	}
+}
+func (t *RuntimeWatchGetTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments, ctx, err := decodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if semErr := requireInitializedContext(ctx, t.Name()); semErr != nil {
+		return nil, semErr
+	}
+	sessionID, semErr := requireGameSessionID(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	watchID, semErr := requireWatchID(arguments, sessionID, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+
+	limit := defaultWatchGetLimit
+	if raw, ok := arguments["limit"]; ok {
+		if value, ok := raw.(float64); ok && int(value) > 0 {
+			limit = int(value)
+		}
+	}
+	var sinceSequence int64
+	if raw, ok := arguments["since_sequence"]; ok {
+		if value, ok := raw.(float64); ok {
+			sinceSequence = int64(value)
+		}
+	}
+	watch, samples, stats, ok := runtimebridge.DefaultRuntimeWatchStore().Series(sessionID, watchID, sinceSequence, limit)
+	if !ok {
+		return nil, watchNotFoundError(sessionID, watchID, t.Name())
+	}
+	return json.Marshal(map[string]any{
+		"source":     "runtime",
+		"session_id": sessionID,
+		"watch":      watch,
+		"samples":    samples,
+		"stats":      stats,
+	})
+}
+
+func requireWatchID(arguments map[string]any, sessionID string, toolName string) (string, *tooltypes.SemanticError) {
+	watchID, ok := arguments["watch_id"].(string)
+	if !ok || strings.TrimSpace(watchID) == "" {
+		return "", tooltypes.NewRuntimeInvalidParamsError("watch_id is required", toolName, "watch_not_found", nil)
+	}
+	watchID = strings.TrimSpace(watchID)
+	if _, ok := runtimebridge.DefaultRuntimeWatchStore().Watch(sessionID, watchID); !ok {
+		return "", watchNotFoundError(sessionID, watchID, toolName)
+	}
+	return watchID, nil
+}
+
+func watchNotFoundError(sessionID string, watchID string, toolName string) *tooltypes.SemanticError {
+	return tooltypes.NewRuntimeNotAvailableError("Runtime watch is unavailable", toolName, "watch_not_found", map[string]any{
+		"session_id": sessionID,
+		"watch_id":   watchID,
+	})
+}
+
+func listWatchSummaries(sessionID string) []map[string]any {
+	watches, stats := runtimebridge.DefaultRuntimeWatchStore().List(sessionID)
+	out := make([]map[string]any, 0, len(watches))
+	for i, watch := range watches {
+		out = append(out, map[string]any{
+			"watch_id":    watch.WatchID,
+			"node":        watch.Node,
+			"property":    watch.Property,
+			"interval_ms": watch.IntervalMS,
+			"created_at":  watch.CreatedAt,
+			"notify":      watch.NotifySessionID != "",
+			"stats":       stats[i],
+		})
+	}
+	return out
+}
@@ -0,0 +1,536 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	"github.com/slighter12/godot-mcp-go/tools/node"
+	"github.com/slighter12/godot-mcp-go/tools/project"
+	"github.com/slighter12/godot-mcp-go/tools/runtime"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	statusPassed  = "passed"
+	statusFailed  = "failed"
+	statusError   = "error"
+	statusSkipped = "skipped"
+
+	maxScenarioWaitMS  = 60000
+	assertPollInterval = 100 * time.Millisecond
+)
+
+// scenarioRunner executes scenario steps by calling the existing project,
+// node and runtime tools with the caller's MCP context, so every runtime
+// command goes through the same CommandBroker path as a direct tool call.
+type scenarioRunner struct {
+	mcpContext      any
+	callID          string
+	editorSessionID string
+	sessionID       string
+	// launched reports whether the current game session was started by a run
+	// step and is still running.
+	launched bool
+	// logBaseline is the last runtime log sequence seen before the scenario
+	// took over the session; assert_no_errors only looks at newer entries.
+	logBaseline int64
+	artifacts   []map[string]any
+}
+
+type stepResult struct {
+	Index      int              `json:"index"`
+	Name       string           `json:"name,omitempty"`
+	Action     string           `json:"action"`
+	Status     string           `json:"status"`
+	StartedAt  string           `json:"started_at,omitempty"`
+	DurationMS int64            `json:"duration_ms"`
+	Code       string           `json:"code,omitempty"`
+	Message    string           `json:"message,omitempty"`
+	Details    map[string]any   `json:"details,omitempty"`
+	Artifacts  []map[string]any `json:"artifacts,omitempty"`
+}
+
+type stepOutcome struct {
+	status    string
+	code      string
+	message   string
+	details   map[string]any
+	artifacts []map[string]any
+}
+
+func passed(details map[string]any) stepOutcome {
+	return stepOutcome{status: statusPassed, details: details}
+}
+
+func failed(message string, details map[string]any) stepOutcome {
+	return stepOutcome{status: statusFailed, code: "assertion_failed", message: message, details: details}
+}
+
+func stepError(code string, message string) stepOutcome {
+	return stepOutcome{status: statusError, code: code, message: message}
+}
+
+// toolError turns a tool failure into a step error, keeping the semantic code.
+func toolError(err error) stepOutcome {
+	if semErr, ok := tooltypes.AsSemanticError(err); ok {
+		code, _ := semErr.Data["code"].(string)
+		if code == "" {
+			code, _ = semErr.Data["problem"].(string)
+		}
+		return stepOutcome{status: statusError, code: code, message: semErr.Message, details: semErr.Data}
+	}
+	return stepError("tool_failed", err.Error())
+}
+
+func (r *scenarioRunner) run(scenario Scenario, stopOnFailure bool, keepRunning bool) map[string]any {
+	started := time.Now().UTC()
+	if r.sessionID != "" {
+		r.logBaseline = latestLogSequence(r.sessionID)
+	}
+
+	results := make([]stepResult, 0, len(scenario.Steps))
+	summary := map[string]int{statusPassed: 0, statusFailed: 0, statusError: 0, statusSkipped: 0}
+	halted := false
+	for i, step := range scenario.Steps {
+		result := stepResult{Index: i + 1, Name: step.Name, Action: step.Action}
+		if halted {
+			result.Status = statusSkipped
+			results = append(results, result)
+			summary[statusSkipped]++
+			continue
+		}
+		stepStarted := time.Now().UTC()
+		outcome := r.execute(step)
+		result.Status = outcome.status
+		result.StartedAt = stepStarted.Format(time.RFC3339Nano)
+		result.DurationMS = time.Since(stepStarted).Milliseconds()
+		result.Code = outcome.code
+		result.Message = outcome.message
+		result.Details = outcome.details
+		result.Artifacts = outcome.artifacts
+		for _, artifact := range outcome.artifacts {
+			artifact["step"] = i + 1
+			r.artifacts = append(r.artifacts, artifact)
+		}
+		results = append(results, result)
+		summary[outcome.status]++
+		if outcome.status != statusPassed && stopOnFailure {
+			halted = true
+		}
+	}
+
+	report := map[string]any{
+		"scenario":   scenario.Name,
+		"session_id": r.sessionID,
+		"started_at": started.Format(time.RFC3339Nano),
+		"steps":      results,
+		"artifacts":  r.artifacts,
+		"passed":     summary[statusPassed] == len(results),
+		"status":     statusPassed,
+		"summary":    map[string]any{"total": len(results), "passed": summary[statusPassed], "failed": summary[statusFailed], "errors": summary[statusError], "skipped": summary[statusSkipped]},
+	}
+	if scenario.Description != "" {
+		report["description"] = scenario.Description
+	}
+	if summary[statusPassed] != len(results) {
+		report["status"] = statusFailed
+	}
+	if r.artifacts == nil {
+		report["artifacts"] = []map[string]any{}
+	}
+	if r.launched && !keepRunning {
+		teardown := map[string]any{"stopped": true}
+		if _, err := r.call(&project.StopProjectTool{}, r.withEditorSession(map[string]any{"session_id": r.sessionID})); err != nil {
+			outcome := toolError(err)
+			teardown = map[string]any{"stopped": false, "code": outcome.code, "message": outcome.message}
+		}
+		report["teardown"] = teardown
+	}
+	report["duration_ms"] = time.Since(started).Milliseconds()
+	return report
+}
+
+func (r *scenarioRunner) execute(step Step) stepOutcome {
+	switch step.Action {
+	case actionRun:
+		return r.runGame(step)
+	case actionWait:
+		ms, err := intArg(step.Args, "ms", 0, 1, maxScenarioWaitMS)
+		if err != nil || ms == 0 {
+			return stepError("invalid_step", "wait requires ms between 1 and 60000")
+		}
+		time.Sleep(time.Duration(ms) * time.Millisecond)
+		return passed(map[string]any{"ms": ms})
+	}
+
+	if r.sessionID == "" {
+		return stepError("game_session_missing", "no game session; add a run step or pass session_id")
+	}
+	switch step.Action {
+	case actionStop:
+		if _, err := r.call(&project.StopProjectTool{}, r.withEditorSession(map[string]any{"session_id": r.sessionID})); err != nil {
+			return toolError(err)
+		}
+		r.launched = false
+		return passed(map[string]any{"session_id": r.sessionID})
+	case actionAwaitSnapshot:
+		result, err := r.call(&runtime.AwaitRuntimeSnapshotTool{}, r.forwardArgs(step, "min_frame", "timeout_ms", "freshness"))
+		if err != nil {
+			return toolError(err)
+		}
+		return passed(pick(result, "snapshot_id", "frame"))
+	case actionTap, actionPress, actionRelease:
+		var tool tooltypes.Tool = &runtime.RuntimeInputTapTool{}
+		switch step.Action {
+		case actionPress:
+			tool = &runtime.RuntimeInputPressTool{}
+		case actionRelease:
+			tool = &runtime.RuntimeInputReleaseTool{}
+		}
+		result, err := r.call(tool, r.forwardArgs(step, "input", "event", "duration_ms"))
+		if err != nil {
+			return toolError(err)
+		}
+		return passed(pick(result, "input", "event", "frame"))
+	case actionAssertNodeExists, actionAssertNodeGone:
+		return r.assertNode(step)
+	case actionAssertProperty:
+		return r.assertProperty(step)
+	case actionAssertNoErrors:
+		return r.assertNoErrors()
+	case actionScreenshot:
+		return r.screenshot(step)
+	}
+	return stepError("invalid_step", fmt.Sprintf("unknown action %q", step.Action))
+}
+
+func (r *scenarioRunner) runGame(step Step) stepOutcome {
+	args := r.withEditorSession(r.forwardArgs(step, "mode", "headless", "user_args"))
+	delete(args, "session_id")
+	if scene := stringArg(step.Args, "scene"); scene != "" {
+		args["scene_path"] = scene
+	}
+	result, err := r.call(&project.RunProjectTool{}, args)
+	if err != nil {
+		return toolError(err)
+	}
+	sessionID, _ := result["session_id"].(string)
+	if strings.TrimSpace(sessionID) == "" {
+		return stepError("game_session_missing", "project run did not return a game session")
+	}
+	r.sessionID = sessionID
+	r.launched = true
+	r.logBaseline = 0
+	return passed(pick(result, "session_id", "scene_path", "started_at"))
+}
+
+func (r *scenarioRunner) assertNode(step Step) stepOutcome {
+	selector := strings.TrimSpace(stringArg(step.Args, "selector"))
+	if selector == "" {
+		nodePath := strings.TrimSpace(stringArg(step.Args, "node"))
+		if nodePath == "" {
+			return stepError("invalid_step", step.Action+" requires node or selector")
+		}
+		key := "name"
+		if strings.Contains(nodePath, "/") {
+			key = "path"
+		}
+		selector = "*[" + key + "=" + strconv.Quote(nodePath) + "]"
+	}
+	wantPresent := step.Action == actionAssertNodeExists
+	return r.poll(step, func() stepOutcome {
+		result, err := r.call(&node.QueryNodesTool{}, map[string]any{
+			"selector":   selector,
+			"source":     "runtime",
+			"session_id": r.sessionID,
+			"limit":      10,
+		})
+		if err != nil {
+			return toolError(err)
+		}
+		total, _ := result["total"].(float64)
+		details := map[string]any{"selector": selector, "total": int(total), "frame": result["frame"], "matches": result["matches"]}
+		if wantPresent && total == 0 {
+			return failed("no node matches "+selector, details)
+		}
+		if !wantPresent && total > 0 {
+			return failed(fmt.Sprintf("%d node(s) match %s", int(total), selector), details)
+		}
+		return passed(details)
+	})
+}
+
+// assertProperty compares one runtime node property against equals,
+// not_equals, greater_than or less_than. A property such as position:x reads
+// one component of a vector-like value.
+func (r *scenarioRunner) assertProperty(step Step) stepOutcome {
+	nodePath := strings.TrimSpace(stringArg(step.Args, "node"))
+	property := strings.TrimSpace(stringArg(step.Args, "property"))
+	if nodePath == "" || property == "" {
+		return stepError("invalid_step", "assert_property requires node and property")
+	}
+	baseProperty, component, _ := strings.Cut(property, ":")
+	tolerance := 0.0
+	if raw, ok := step.Args["tolerance"]; ok {
+		value, ok := raw.(float64)
+		if !ok || value < 0 {
+			return stepError("invalid_step", "tolerance must be a non-negative number")
+		}
+		tolerance = value
+	}
+	var check func(actual any) (bool, string)
+	switch {
+	case hasArg(step.Args, "equals"):
+		expected := step.Args["equals"]
+		check = func(actual any) (bool, string) {
+			return valuesEqual(actual, expected, tolerance), fmt.Sprintf("expected %s to equal %v, got %v", property, expected, actual)
+		}
+	case hasArg(step.Args, "not_equals"):
+		expected := step.Args["not_equals"]
+		check = func(actual any) (bool, string) {
+			return !valuesEqual(actual, expected, tolerance), fmt.Sprintf("expected %s to differ from %v", property, expected)
+		}
+	case hasArg(step.Args, "greater_than"), hasArg(step.Args, "less_than"):
+		bound, greater := step.Args["greater_than"], true
+		if !hasArg(step.Args, "greater_than") {
+			bound, greater = step.Args["less_than"], false
+		}
+		limit, ok := bound.(float64)
+		if !ok {
+			return stepError("invalid_step", "greater_than and less_than must be numbers")
+		}
+		check = func(actual any) (bool, string) {
+			value, ok := actual.(float64)
+			if greater {
+				return ok && value > limit, fmt.Sprintf("expected %s > %v, got %v", property, limit, actual)
+			}
+			return ok && value < limit, fmt.Sprintf("expected %s < %v, got %v", property, limit, actual)
+		}
+	default:
+		return stepError("invalid_step", "assert_property requires equals, not_equals, greater_than or less_than")
+	}
+
+	return r.poll(step, func() stepOutcome {
+		result, err := r.call(&runtime.RuntimeNodePropertiesGetTool{}, map[string]any{
+			"session_id": r.sessionID,
+			"node":       nodePath,
+			"properties": []string{baseProperty},
+		})
+		if err != nil {
+			return toolError(err)
+		}
+		properties, _ := result["properties"].(map[string]any)
+		actual, ok := properties[baseProperty]
+		if !ok {
+			return stepError("property_not_supported", "property unavailable: "+baseProperty)
+		}
+		if component != "" {
+			value, _ := actual.(map[string]any)
+			actual = value[component]
+		}
+		details := map[string]any{"node": nodePath, "property": property, "actual": actual, "frame": result["frame"]}
+		if ok, message := check(actual); !ok {
+			return failed(message, details)
+		}
+		return passed(details)
+	})
+}
+
+func (r *scenarioRunner) assertNoErrors() stepOutcome {
+	result, err := r.call(&runtime.RuntimeLogGetTool{}, map[string]any{
+		"session_id":     r.sessionID,
+		"level":          "error",
+		"since_sequence": r.logBaseline,
+		"limit":          20,
+	})
+	if err != nil {
+		return toolError(err)
+	}
+	entries, _ := result["entries"].([]any)
+	details := map[string]any{"error_count": len(entries), "since_sequence": r.logBaseline}
+	if len(entries) > 0 {
+		details["entries"] = entries
+		return failed(fmt.Sprintf("runtime log has %d error(s)", len(entries)), details)
+	}
+	return passed(details)
+}
+
+// screenshot captures the viewport, or compares it against a saved baseline
+// when baseline is set. Either way the screenshot is reported as an artifact.
+func (r *scenarioRunner) screenshot(step Step) stepOutcome {
+	baseline := strings.TrimSpace(stringArg(step.Args, "baseline"))
+	if baseline == "" {
+		args := r.forwardArgs(step, "mode")
+		args["include_image"] = false
+		result, err := r.call(&runtime.RuntimeScreenshotGetTool{}, args)
+		if err != nil {
+			return toolError(err)
+		}
+		outcome := passed(pick(result, "screenshot_id", "frame", "width", "height"))
+		outcome.artifacts = []map[string]any{screenshotArtifact(result)}
+		return outcome
+	}
+
+	args := r.forwardArgs(step, "mode", "threshold", "max_diff_ratio")
+	args["baseline"] = baseline
+	args["include_diff_image"] = false
+	result, err := r.call(&runtime.RuntimeScreenshotCompareTool{}, args)
+	if err != nil {
+		return toolError(err)
+	}
+	details := pick(result, "baseline", "screenshot_id", "diff_ratio", "max_diff_ratio", "diff_pixels", "reason")
+	outcome := passed(details)
+	if ok, _ := result["passed"].(bool); !ok {
+		outcome = failed("screenshot differs from baseline "+baseline, details)
+	}
+	artifact := screenshotArtifact(result)
+	artifact["baseline"] = baseline
+	outcome.artifacts = []map[string]any{artifact}
+	return outcome
+}
+
+// poll re-runs check every assertPollInterval while it fails, for up to the
+// step's timeout_ms (default 0, a single attempt).
+func (r *scenarioRunner) poll(step Step, check func() stepOutcome) stepOutcome {
+	timeoutMS, err := intArg(step.Args, "timeout_ms", 0, 0, maxScenarioWaitMS)
+	if err != nil {
+		return stepError("invalid_step", "timeout_ms must be an integer between 0 and 60000")
+	}
+	deadline := time.Now().Add(time.Duration(timeoutMS) * time.Millisecond)
+	for {
+		outcome := check()
+		if outcome.status != statusFailed || !time.Now().Before(deadline) {
+			return outcome
+		}
+		time.Sleep(assertPollInterval)
+	}
+}
+
+// call executes a tool with the caller's MCP context and decodes its object
+// result. When the scenario itself runs inside the tool pipeline, the step
+// goes back through it so the caller's scopes, permission profile, rate limits
+// and audit log apply to every nested tool. Inline images are dropped;
+// screenshots stay addressable by id.
+func (r *scenarioRunner) call(tool tooltypes.Tool, args map[string]any) (map[string]any, error) {
+	var out []byte
+	var err error
+	if caller, ok := tooltypes.LookupNestedToolCaller(r.callID); ok {
+		out, err = caller(tool.Name(), args)
+	} else {
+		args["_mcp"] = r.mcpContext
+		var raw []byte
+		if raw, err = json.Marshal(args); err != nil {
+			return nil, err
+		}
+		out, err = tool.Execute(raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]any{}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, err
+	}
+	delete(result, tooltypes.ToolResultImagesKey)
+	return result, nil
+}
+
+// forwardArgs copies the listed step arguments and targets the current game
+// session.
+func (r *scenarioRunner) forwardArgs(step Step, keys ...string) map[string]any {
+	args := map[string]any{"session_id": r.sessionID}
+	for _, key := range keys {
+		if value, ok := step.Args[key]; ok {
+			args[key] = value
+		}
+	}
+	return args
+}
+
+func (r *scenarioRunner) withEditorSession(args map[string]any) map[string]any {
+	if r.editorSessionID != "" {
+		args["editor_session_id"] = r.editorSessionID
+	}
+	return args
+}
+
+func latestLogSequence(sessionID string) int64 {
+	entries := runtimebridge.DefaultRuntimeLogStore().Last(sessionID, 1)
+	if len(entries) == 0 {
+		return 0
+	}
+	return entries[len(entries)-1].Sequence
+}
+
+func screenshotArtifact(result map[string]any) map[string]any {
+	artifact := pick(result, "screenshot_id", "path", "frame")
+	artifact["type"] = "screenshot"
+	return artifact
+}
+
+func pick(result map[string]any, keys ...string) map[string]any {
+	out := make(map[string]any, len(keys))
+	for _, key := range keys {
+		if value, ok := result[key]; ok && value != nil {
+			out[key] = value
+		}
+	}
+	return out
+}
+
+func hasArg(args map[string]any, key string) bool {
+	_, ok := args[key]
+	return ok
+}
+
+func intArg(args map[string]any, key string, fallback int, minValue int, maxValue int) (int, error) {
+	raw, ok := args[key]
+	if !ok {
+		return fallback, nil
+	}
+	value, ok := raw.(float64)
+	if !ok || value != math.Trunc(value) || int(value) < minValue || int(value) > maxValue {
+		return 0, fmt.Errorf("%s must be an integer between %d and %d", key, minValue, maxValue)
+	}
+	return int(value), nil
+}
+
+// valuesEqual compares decoded JSON values, allowing tolerance on numbers and
+// on each component of vector-like objects and arrays.
+func valuesEqual(actual any, expected any, tolerance float64) bool {
+	switch want := expected.(type) {
+	case float64:
+		got, ok := actual.(float64)
+		return ok && math.Abs(got-want) <= tolerance
+	case map[string]any:
+		got, ok := actual.(map[string]any)
+		if !ok || len(got) != len(want) {
+			return false
+		}
+		for key, value := range want {
+			if !valuesEqual(got[key], value, tolerance) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		got, ok := actual.([]any)
+		if !ok || len(got) != len(want) {
+			return false
+		}
+		for i := range want {
+			if !valuesEqual(got[i], want[i], tolerance) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(actual, expected)
+	}
+}
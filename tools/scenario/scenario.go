@@ -0,0 +1,134 @@
+package scenario
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const maxScenarioSteps = 200
+
+// Step actions understood by the scenario runner.
+const (
+	actionRun              = "run"
+	actionStop             = "stop"
+	actionAwaitSnapshot    = "await_snapshot"
+	actionTap              = "tap"
+	actionPress            = "press"
+	actionRelease          = "release"
+	actionWait             = "wait"
+	actionAssertNodeExists = "assert_node_exists"
+	actionAssertNodeGone   = "assert_node_missing"
+	actionAssertProperty   = "assert_property"
+	actionAssertNoErrors   = "assert_no_errors"
+	actionScreenshot       = "screenshot"
+)
+
+var scenarioActions = []string{
+	actionRun,
+	actionStop,
+	actionAwaitSnapshot,
+	actionTap,
+	actionPress,
+	actionRelease,
+	actionWait,
+	actionAssertNodeExists,
+	actionAssertNodeGone,
+	actionAssertProperty,
+	actionAssertNoErrors,
+	actionScreenshot,
+}
+
+var scenarioExtensions = []string{".json", ".yaml", ".yml"}
+
+// Scenario is a parsed playtest scenario file.
+type Scenario struct {
+	Name          string `json:"name"`
+	Description   string `json:"description,omitempty"`
+	StopOnFailure *bool  `json:"stop_on_failure,omitempty"`
+	Steps         []Step `json:"steps"`
+}
+
+// Step is one scenario step. Args holds every key except action and name and
+// is passed to the action handler.
+type Step struct {
+	Name   string
+	Action string
+	Args   map[string]any
+}
+
+func (s *Step) UnmarshalJSON(data []byte) error {
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return fmt.Errorf("step must be an object")
+	}
+	action, _ := fields["action"].(string)
+	s.Action = strings.ToLower(strings.TrimSpace(action))
+	s.Name, _ = fields["name"].(string)
+	s.Name = strings.TrimSpace(s.Name)
+	delete(fields, "action")
+	delete(fields, "name")
+	s.Args = fields
+	return nil
+}
+
+func (s Step) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any, len(s.Args)+2)
+	for key, value := range s.Args {
+		fields[key] = value
+	}
+	fields["action"] = s.Action
+	if s.Name != "" {
+		fields["name"] = s.Name
+	}
+	return json.Marshal(fields)
+}
+
+// parseScenario decodes a JSON or YAML scenario. YAML is converted to JSON
+// first so both formats yield the same argument types.
+func parseScenario(data []byte, format string) (Scenario, error) {
+	if format == "yaml" {
+		var raw any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return Scenario{}, fmt.Errorf("invalid yaml: %w", err)
+		}
+		converted, err := json.Marshal(raw)
+		if err != nil {
+			return Scenario{}, fmt.Errorf("invalid yaml: %w", err)
+		}
+		data = converted
+	}
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("invalid scenario: %w", err)
+	}
+	return scenario, validateScenario(scenario)
+}
+
+func scenarioFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	default:
+		return "json"
+	}
+}
+
+func validateScenario(scenario Scenario) error {
+	if len(scenario.Steps) == 0 {
+		return fmt.Errorf("scenario has no steps")
+	}
+	if len(scenario.Steps) > maxScenarioSteps {
+		return fmt.Errorf("scenario has %d steps; at most %d are allowed", len(scenario.Steps), maxScenarioSteps)
+	}
+	for i, step := range scenario.Steps {
+		if !slices.Contains(scenarioActions, step.Action) {
+			return fmt.Errorf("step %d: unknown action %q", i+1, step.Action)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"strings"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+func GetAllTools() []tooltypes.Tool {
+	return []tooltypes.Tool{
+		&RunScenarioTool{},
+	}
+}
+
+type RunScenarioTool struct{}
+
+func (t *RunScenarioTool) Name() string { return "godot.test.scenario.run" }
+func (t *RunScenarioTool) Description() string {
+	return "[runtime] Runs a playtest scenario (run scene, await snapshot, input, assertions, screenshots) and returns a pass/fail report"
+}
+func (t *RunScenarioTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *RunScenarioTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"path":              map[string]any{"type": "string", "description": "Scenario file under the project (.json, .yaml or .yml)"},
+			"scenario":          map[string]any{"type": "object", "description": "Inline scenario, used when path is omitted"},
+			"session_id":        map[string]any{"type": "string", "description": "Optional running game session to start from instead of a run step"},
+			"editor_session_id": map[string]any{"type": "string", "description": "Optional explicit editor session id override for run/stop steps"},
+			"stop_on_failure":   map[string]any{"type": "boolean", "description": "Skip remaining steps after the first failure (default true)"},
+			"keep_running":      map[string]any{"type": "boolean", "description": "Leave a game started by the scenario running (default false)"},
+		},
+		Required: []string{},
+		Title:    "Run Test Scenario",
+	}
+}
+func (t *RunScenarioTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments := map[string]any{}
+	if err := json.Unmarshal(args, &arguments); err != nil {
+		return nil, err
+	}
+	ctx := tooltypes.ExtractMCPContext(arguments)
+	if strings.TrimSpace(ctx.SessionID) == "" || !ctx.SessionInitialized {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Scenario run requires initialized session", t.Name(), "editor_session_missing", nil)
+	}
+
+	scenario, scenarioPath, semErr := loadScenario(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	stopOnFailure := true
+	if scenario.StopOnFailure != nil {
+		stopOnFailure = *scenario.StopOnFailure
+	}
+	if raw, ok := arguments["stop_on_failure"]; ok {
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("stop_on_failure must be a boolean", t.Name(), "invalid_stop_on_failure", nil)
+		}
+		stopOnFailure = value
+	}
+	keepRunning := false
+	if raw, ok := arguments["keep_running"]; ok {
+		value, ok := raw.(bool)
+		if !ok {
+			return nil, tooltypes.NewRuntimeInvalidParamsError("keep_running must be a boolean", t.Name(), "invalid_keep_running", nil)
+		}
+		keepRunning = value
+	}
+
+	runner := &scenarioRunner{
+		mcpContext:      arguments["_mcp"],
+		callID:          ctx.CallID,
+		editorSessionID: strings.TrimSpace(stringArg(arguments, "editor_session_id")),
+		sessionID:       strings.TrimSpace(stringArg(arguments, "session_id")),
+	}
+	report := runner.run(scenario, stopOnFailure, keepRunning)
+	report["path"] = scenarioPath
+	return json.Marshal(report)
+}
+
+// loadScenario reads the scenario from path, or from the inline scenario
+// argument when path is omitted.
+func loadScenario(arguments map[string]any, toolName string) (Scenario, string, *tooltypes.SemanticError) {
+	path := strings.TrimSpace(stringArg(arguments, "path"))
+	if path == "" {
+		inline, ok := arguments["scenario"].(map[string]any)
+		if !ok {
+			return Scenario{}, "", tooltypes.NewRuntimeInvalidParamsError("path or scenario is required", toolName, "invalid_scenario", nil)
+		}
+		data, err := json.Marshal(inline)
+		if err != nil {
+			return Scenario{}, "", tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_scenario", nil)
+		}
+		scenario, err := parseScenario(data, "json")
+		if err != nil {
+			return Scenario{}, "", tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_scenario", nil)
+		}
+		return scenario, "", nil
+	}
+
+	data, resPath, err := tooltypes.ReadProjectFile(path, scenarioExtensions)
+	if semErr, ok := tooltypes.AsSemanticError(err); ok {
+		return Scenario{}, "", semErr
+	}
+	if err != nil {
+		code := "invalid_scenario"
+		if errors.Is(err, fs.ErrNotExist) {
+			code = "scenario_not_found"
+		}
+		return Scenario{}, "", tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, code, map[string]any{"path": path})
+	}
+	scenario, err := parseScenario(data, scenarioFormat(resPath))
+	if err != nil {
+		return Scenario{}, "", tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_scenario", map[string]any{"path": resPath})
+	}
+	if scenario.Name == "" {
+		scenario.Name = resPath
+	}
+	return scenario, resPath, nil
+}
+
+func stringArg(arguments map[string]any, key string) string {
+	value, _ := arguments[key].(string)
+	return value
+}
@@ -0,0 +1,142 @@
+package scenario
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+func TestRunScenarioTool_ReportsStepResultsFromYAMLFile(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectRoot, "project.godot"), []byte("[application]\n"), 0644); err != nil {
+		t.Fatalf("write project.godot: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(projectRoot, "tests"), 0755); err != nil {
+		t.Fatalf("mkdir tests: %v", err)
+	}
+	scenarioYAML := `name: Player moves right
+steps:
+  - action: tap
+    input: ui_right
+    duration_ms: 50
+  - name: player moved
+    action: assert_property
+    node: Player
+    property: position:x
+    equals: 100
+    tolerance: 0.5
+  - action: assert_no_errors
+  - action: stop
+`
+	if err := os.WriteFile(filepath.Join(projectRoot, "tests", "move.yaml"), []byte(scenarioYAML), 0644); err != nil {
+		t.Fatalf("write scenario: %v", err)
+	}
+	t.Setenv("GODOT_PROJECT_ROOT", projectRoot)
+
+	runtimebridge.ResetDefaultCommandBrokerForTests(2 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+
+	now := time.Now().UTC()
+	runtimebridge.DefaultGameSessionRegistry().UpsertFromRun("game_1", "editor-1", "res://Main.tscn", "launch-token", now)
+	runtimebridge.DefaultGameSessionRegistry().RegisterRuntimeTransport("game_1", "runtime-1", "editor-1", "res://Main.tscn", now, "launch-token")
+	runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{{Level: "error", Message: "before scenario"}}, now)
+
+	commands := []string{}
+	runtimebridge.SetNotificationSender(func(sessionID string, message map[string]any) bool {
+		params, _ := message["params"].(map[string]any)
+		commandID, _ := params["command_id"].(string)
+		name, _ := params["name"].(string)
+		commands = append(commands, name)
+		result := map[string]any{"frame": 12}
+		if name == "godot.runtime.input.tap" {
+			runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{{Level: "error", Message: "player script failed"}}, time.Now().UTC())
+		}
+		if name == "godot.runtime.node_properties.get" {
+			result["properties"] = map[string]any{"position": map[string]any{"x": 100.25, "y": 0.0}}
+		}
+		go func() {
+			runtimebridge.DefaultCommandBroker().Ack(sessionID, runtimebridge.CommandAck{
+				CommandID: commandID,
+				Success:   true,
+				Result:    result,
+			})
+		}()
+		return true
+	})
+	defer runtimebridge.SetNotificationSender(nil)
+
+	raw, err := (&RunScenarioTool{}).Execute(json.RawMessage(`{
+		"path":"res://tests/move.yaml",
+		"session_id":"game_1",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute godot.test.scenario.run: %v", err)
+	}
+	var report struct {
+		Scenario string         `json:"scenario"`
+		Path     string         `json:"path"`
+		Status   string         `json:"status"`
+		Passed   bool           `json:"passed"`
+		Summary  map[string]int `json:"summary"`
+		Steps    []stepResult   `json:"steps"`
+	}
+	if err := json.Unmarshal(raw, &report); err != nil {
+		t.Fatalf("unmarshal report: %v", err)
+	}
+	if report.Scenario != "Player moves right" || report.Path != "res://tests/move.yaml" || report.Passed || report.Status != statusFailed {
+		t.Fatalf("unexpected report: %s", string(raw))
+	}
+	wantStatuses := []string{statusPassed, statusPassed, statusFailed, statusSkipped}
+	for i, want := range wantStatuses {
+		if report.Steps[i].Status != want {
+			t.Fatalf("step %d: expected %s, got %+v", i+1, want, report.Steps[i])
+		}
+	}
+	if report.Steps[1].Name != "player moved" || report.Steps[1].StartedAt == "" {
+		t.Fatalf("unexpected property step: %+v", report.Steps[1])
+	}
+	if report.Steps[2].Details["error_count"] != float64(1) {
+		t.Fatalf("expected only the error logged during the scenario, got %+v", report.Steps[2].Details)
+	}
+	if report.Summary["total"] != 4 || report.Summary["passed"] != 2 || report.Summary["failed"] != 1 || report.Summary["skipped"] != 1 {
+		t.Fatalf("unexpected summary: %+v", report.Summary)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("expected the skipped stop step not to dispatch, got %v", commands)
+	}
+}
+
+func TestRunScenarioTool_RejectsInvalidScenarios(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectRoot, "project.godot"), []byte("[application]\n"), 0644); err != nil {
+		t.Fatalf("write project.godot: %v", err)
+	}
+	t.Setenv("GODOT_PROJECT_ROOT", projectRoot)
+
+	cases := map[string]string{
+		`{"path":"res://tests/missing.json"}`:                                "scenario_not_found",
+		`{"scenario":{"steps":[{"action":"fly"}]}}`:                          "invalid_scenario",
+		`{"scenario":{"name":"empty","steps":[]}}`:                           "invalid_scenario",
+		`{"scenario":{"steps":[{"action":"wait","ms":1}]},"keep_running":1}`: "invalid_keep_running",
+	}
+	for input, code := range cases {
+		var arguments map[string]any
+		if err := json.Unmarshal([]byte(input), &arguments); err != nil {
+			t.Fatalf("unmarshal case: %v", err)
+		}
+		arguments["_mcp"] = map[string]any{"session_id": "ai-session", "session_initialized": true}
+		raw, _ := json.Marshal(arguments)
+		_, err := (&RunScenarioTool{}).Execute(raw)
+		semanticErr, ok := tooltypes.AsSemanticError(err)
+		if !ok || semanticErr.Data["code"] != code {
+			t.Fatalf("%s: expected %s, got %v", input, code, err)
+		}
+	}
+}
@@ -42,13 +42,19 @@ func (t *ListProjectScenesTool) Execute(args json.RawMessage) ([]byte, error) {
 		if err != nil {
 			return err
 		}
-		if info.IsDir() || strings.ToLower(filepath.Ext(path)) != ".tscn" {
-			return nil
-		}
 		relPath, relErr := filepath.Rel(projectRoot, path)
 		if relErr != nil {
 			return relErr
 		}
+		if info.IsDir() {
+			if path != projectRoot && types.PathSandboxSkipsDir(relPath) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.ToLower(filepath.Ext(path)) != ".tscn" || !types.PathSandboxAllows(relPath) {
+			return nil
+		}
 		names = append(names, strings.TrimSuffix(filepath.Base(path), ".tscn"))
 		resPaths = append(resPaths, "res://"+filepath.ToSlash(relPath))
 		return nil
@@ -253,6 +259,9 @@ func validateCreateSceneArguments(arguments map[string]any, toolName string) (ma
 	if err != nil {
 		return nil, err
 	}
+	if err := types.CheckProjectPath(path); err != nil {
+		return nil, err
+	}
 	out := map[string]any{"path": path}
 	if raw, exists := arguments["content"]; exists {
 		content, ok := raw.(string)
@@ -283,6 +292,9 @@ func validateApplySceneArguments(arguments map[string]any, toolName string) (map
 	if path == "" {
 		return nil, newSceneInvalidParamsError("path is required", toolName, "missing_path", nil)
 	}
+	if err := types.CheckProjectPath(path); err != nil {
+		return nil, err
+	}
 	return map[string]any{"path": path}, nil
 }
 
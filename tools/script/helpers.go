@@ -18,16 +18,22 @@ func listProjectScripts() ([]string, []string, error) {
 		if err != nil {
 			return err
 		}
+		relPath, relErr := filepath.Rel(projectRoot, path)
+		if relErr != nil {
+			return relErr
+		}
 		if info.IsDir() {
+			if path != projectRoot && types.PathSandboxSkipsDir(relPath) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		ext := strings.ToLower(filepath.Ext(path))
 		if ext != ".gd" && ext != ".rs" {
 			return nil
 		}
-		relPath, relErr := filepath.Rel(projectRoot, path)
-		if relErr != nil {
-			return relErr
+		if !types.PathSandboxAllows(relPath) {
+			return nil
 		}
 		scriptNames = append(scriptNames, strings.TrimSuffix(filepath.Base(path), ext))
 		scriptPaths = append(scriptPaths, "res://"+filepath.ToSlash(relPath))
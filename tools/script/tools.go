@@ -183,6 +183,9 @@ func validateModifyScriptArguments(arguments map[string]any, toolName string) (m
 	if err != nil {
 		return nil, err
 	}
+	if err := types.CheckProjectPath(path); err != nil {
+		return nil, err
+	}
 	contentValue, exists := arguments["content"]
 	if !exists {
 		return nil, newScriptInvalidParamsError("content is required", toolName, "missing_content", nil)
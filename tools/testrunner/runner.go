@@ -0,0 +1,256 @@
+package testrunner
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/testreport"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+const (
+	frameworkGUT     = "gut"
+	frameworkGdUnit4 = "gdunit4"
+
+	runStatusPassed  = "passed"
+	runStatusFailed  = "failed"
+	runStatusError   = "error"
+	runStatusTimeout = "timeout"
+
+	sourceJUnit   = "junit"
+	sourceConsole = "console"
+
+	outputTailLines = 40
+	// maxCapturedOutputBytes bounds the runner output kept in memory. The
+	// console summaries are printed last, so the tail is what gets kept.
+	maxCapturedOutputBytes = 4 << 20
+)
+
+var frameworks = []string{frameworkGUT, frameworkGdUnit4}
+
+// frameworkAddonDirs maps each framework to the addon directory that marks it
+// as installed in the project.
+var frameworkAddonDirs = map[string]string{
+	frameworkGUT:     "addons/gut",
+	frameworkGdUnit4: "addons/gdUnit4",
+}
+
+// gdUnitWarningExitCode is returned by GdUnitCmdTool when all tests passed but
+// warnings such as orphan nodes were reported.
+const gdUnitWarningExitCode = 101
+
+// testRunPlan is a resolved godot.test.run request.
+type testRunPlan struct {
+	framework  string
+	projectDir string
+	path       string
+	test       string
+	executable string
+	timeout    time.Duration
+}
+
+// detectFramework returns the single test framework installed under addons/.
+func detectFramework(projectDir string) (string, []string) {
+	installed := []string{}
+	for _, framework := range frameworks {
+		if stat, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(frameworkAddonDirs[framework]))); err == nil && stat.IsDir() {
+			installed = append(installed, framework)
+		}
+	}
+	if len(installed) != 1 {
+		return "", installed
+	}
+	return installed[0], installed
+}
+
+// defaultTestPath picks res://test or res://tests, whichever exists.
+func defaultTestPath(projectDir string) string {
+	for _, candidate := range []string{"test", "tests"} {
+		if stat, err := os.Stat(filepath.Join(projectDir, candidate)); err == nil && stat.IsDir() {
+			return "res://" + candidate
+		}
+	}
+	return "res://test"
+}
+
+// commandArgs builds the runner command line. reportDir receives the JUnit
+// XML report.
+func (p testRunPlan) commandArgs(reportDir string) []string {
+	args := []string{"--headless", "--path", p.projectDir}
+	switch p.framework {
+	case frameworkGUT:
+		args = append(args, "-s", "res://addons/gut/gut_cmdln.gd")
+		if strings.HasSuffix(strings.ToLower(p.path), ".gd") {
+			args = append(args, "-gtest="+p.path)
+		} else {
+			args = append(args, "-gdir="+p.path, "-ginclude_subdirs")
+		}
+		if p.test != "" {
+			args = append(args, "-gunit_test_name="+p.test)
+		}
+		args = append(args, "-gjunit_xml_file="+filepath.Join(reportDir, "results.xml"), "-gexit")
+	case frameworkGdUnit4:
+		target := p.path
+		if p.test != "" {
+			target += ":" + p.test
+		}
+		args = append(args, "-s", "res://addons/gdUnit4/bin/GdUnitCmdTool.gd", "--ignoreHeadlessMode", "-a", target, "-rd", reportDir)
+	}
+	return args
+}
+
+// junitReportPath locates the JUnit XML written by the runner, if any.
+// GdUnit4 writes one report_<n> directory per run.
+func (p testRunPlan) junitReportPath(reportDir string) string {
+	if p.framework == frameworkGUT {
+		return filepath.Join(reportDir, "results.xml")
+	}
+	matches, _ := filepath.Glob(filepath.Join(reportDir, "report_*", "results.xml"))
+	if len(matches) == 0 {
+		return ""
+	}
+	sort.Strings(matches)
+	return matches[len(matches)-1]
+}
+
+// runTests executes the plan and returns the parsed run. Errors are returned
+// only when the process could not be started at all.
+func runTests(plan testRunPlan) (runtimebridge.TestRun, error) {
+	reportDir, err := os.MkdirTemp("", "godot-mcp-test-")
+	if err != nil {
+		return runtimebridge.TestRun{}, fmt.Errorf("create report directory: %w", err)
+	}
+	defer os.RemoveAll(reportDir)
+
+	args := plan.commandArgs(reportDir)
+	ctx, cancel := context.WithTimeout(context.Background(), plan.timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, plan.executable, args...)
+	cmd.Dir = plan.projectDir
+	cmd.WaitDelay = 2 * time.Second
+	output := &tailBuffer{limit: maxCapturedOutputBytes}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	startedAt := time.Now().UTC()
+	if err := cmd.Start(); err != nil {
+		return runtimebridge.TestRun{}, err
+	}
+	waitErr := cmd.Wait()
+	finishedAt := time.Now().UTC()
+
+	run := runtimebridge.TestRun{
+		RunID:           generateTestRunID(),
+		Framework:       plan.framework,
+		Path:            plan.path,
+		Test:            plan.test,
+		StartedAt:       startedAt.Format(time.RFC3339Nano),
+		FinishedAt:      finishedAt.Format(time.RFC3339Nano),
+		DurationMS:      finishedAt.Sub(startedAt).Milliseconds(),
+		Command:         append([]string{plan.executable}, args...),
+		OutputTail:      outputTail(output.String(), outputTailLines),
+		OutputTruncated: output.truncated,
+	}
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded)
+	if !timedOut && cmd.ProcessState != nil {
+		exitCode := cmd.ProcessState.ExitCode()
+		if exitCode >= 0 {
+			run.ExitCode = &exitCode
+		}
+	}
+
+	run.Source = sourceConsole
+	parsed := false
+	if reportPath := plan.junitReportPath(reportDir); reportPath != "" {
+		if data, err := os.ReadFile(reportPath); err == nil {
+			if report, err := testreport.ParseJUnitXML(data); err == nil {
+				run.Report = report
+				run.Source = sourceJUnit
+				parsed = true
+			}
+		}
+	}
+	if !parsed {
+		if plan.framework == frameworkGdUnit4 {
+			run.Report = testreport.ParseGdUnitConsole(output.String())
+		} else {
+			run.Report = testreport.ParseGUTConsole(output.String())
+		}
+	}
+	run.Status = runStatus(plan.framework, run.Report, run.ExitCode, timedOut, waitErr)
+	return run, nil
+}
+
+func runStatus(framework string, report testreport.Report, exitCode *int, timedOut bool, waitErr error) string {
+	switch {
+	case timedOut:
+		return runStatusTimeout
+	case report.Summary.Total == 0:
+		return runStatusError
+	case !report.OK():
+		return runStatusFailed
+	case exitCode == nil:
+		if waitErr != nil {
+			return runStatusError
+		}
+		return runStatusPassed
+	case *exitCode == 0, framework == frameworkGdUnit4 && *exitCode == gdUnitWarningExitCode:
+		return runStatusPassed
+	default:
+		// Every case passed but the runner still failed, e.g. a script error
+		// after the last test.
+		return runStatusFailed
+	}
+}
+
+// tailBuffer keeps the last limit bytes written to it. It compacts only once
+// twice the limit is buffered, so long outputs are not shifted on every
+// write. exec.Cmd serializes writes when Stdout and Stderr are the same
+// writer.
+type tailBuffer struct {
+	limit     int
+	data      []byte
+	truncated bool
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	if len(b.data) > b.limit {
+		b.truncated = true
+	}
+	if len(b.data) > 2*b.limit {
+		b.data = append(b.data[:0], b.data[len(b.data)-b.limit:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	return string(b.data[max(len(b.data)-b.limit, 0):])
+}
+
+func outputTail(output string, limit int) []string {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	lines = slices.DeleteFunc(lines, func(line string) bool { return strings.TrimSpace(line) == "" })
+	if len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	return lines
+}
+
+func generateTestRunID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("test_%d", time.Now().UTC().UnixNano())
+	}
+	return "test_" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,212 @@
+package testrunner
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const (
+	defaultTestTimeoutMS = 300000
+	minTestTimeoutMS     = 1000
+	maxTestTimeoutMS     = 3600000
+)
+
+// testRunInProgress allows a single godot.test.run at a time; parallel runs
+// would race on the project's .godot import cache.
+var testRunInProgress atomic.Bool
+
+func GetAllTools() []tooltypes.Tool {
+	return []tooltypes.Tool{
+		&RunTestsTool{},
+		&TestResultGetTool{},
+	}
+}
+
+type RunTestsTool struct{}
+
+func (t *RunTestsTool) Name() string { return "godot.test.run" }
+func (t *RunTestsTool) Description() string {
+	return "Runs GUT or GdUnit4 unit tests with the headless Godot binary and returns structured per-test results"
+}
+func (t *RunTestsTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(false),
+	}
+}
+func (t *RunTestsTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"framework":  map[string]any{"type": "string", "enum": frameworks, "description": "Test framework; detected from res://addons when omitted"},
+			"path":       map[string]any{"type": "string", "description": "Test directory or script (default res://test, or res://tests when only that exists)"},
+			"test":       map[string]any{"type": "string", "description": "Optional test name filter"},
+			"timeout_ms": map[string]any{"type": "integer", "minimum": minTestTimeoutMS, "maximum": maxTestTimeoutMS, "description": "Kill the runner after this long (default 300000)"},
+		},
+		Required: []string{},
+		Title:    "Run Unit Tests",
+	}
+}
+func (t *RunTestsTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments := map[string]any{}
+	if err := json.Unmarshal(args, &arguments); err != nil {
+		return nil, err
+	}
+	ctx := tooltypes.ExtractMCPContext(arguments)
+	if strings.TrimSpace(ctx.SessionID) == "" || !ctx.SessionInitialized {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Test run requires initialized session", t.Name(), "editor_session_missing", nil)
+	}
+
+	plan, semErr := resolveTestRunPlan(arguments, t.Name())
+	if semErr != nil {
+		return nil, semErr
+	}
+	if !testRunInProgress.CompareAndSwap(false, true) {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Another test run is in progress", t.Name(), "test_run_in_progress", nil)
+	}
+	defer testRunInProgress.Store(false)
+
+	run, err := runTests(plan)
+	if err != nil {
+		return nil, tooltypes.NewRuntimeNotAvailableError("Test runner failed to start", t.Name(), "test_runner_failed", map[string]any{
+			"framework": plan.framework,
+			"reason":    err.Error(),
+		})
+	}
+	runtimebridge.DefaultTestRunStore().Put(run)
+	return json.Marshal(run)
+}
+
+func resolveTestRunPlan(arguments map[string]any, toolName string) (testRunPlan, *tooltypes.SemanticError) {
+	projectDir, err := filepath.Abs(tooltypes.ResolveProjectRootFromEnvOrCWD())
+	if err != nil {
+		return testRunPlan{}, tooltypes.NewRuntimeNotAvailableError("Project root is unavailable", toolName, "project_root_missing", map[string]any{
+			"detail": err.Error(),
+		})
+	}
+	plan := testRunPlan{projectDir: projectDir, timeout: defaultTestTimeoutMS * time.Millisecond}
+
+	framework := strings.ToLower(strings.TrimSpace(stringArg(arguments, "framework")))
+	if framework == "" {
+		detected, installed := detectFramework(projectDir)
+		if detected == "" {
+			if len(installed) > 1 {
+				return testRunPlan{}, tooltypes.NewRuntimeInvalidParamsError("Several test frameworks are installed; pass framework", toolName, "invalid_framework", map[string]any{
+					"installed": installed,
+				})
+			}
+			return testRunPlan{}, tooltypes.NewRuntimeNotAvailableError("No test framework found under res://addons", toolName, "test_framework_missing", map[string]any{
+				"hint": "install GUT (res://addons/gut) or GdUnit4 (res://addons/gdUnit4)",
+			})
+		}
+		framework = detected
+	} else if !slices.Contains(frameworks, framework) {
+		return testRunPlan{}, tooltypes.NewRuntimeInvalidParamsError("framework must be gut or gdunit4", toolName, "invalid_framework", nil)
+	} else if stat, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(frameworkAddonDirs[framework]))); err != nil || !stat.IsDir() {
+		return testRunPlan{}, tooltypes.NewRuntimeNotAvailableError("Test framework is not installed", toolName, "test_framework_missing", map[string]any{
+			"framework": framework,
+			"addon":     "res://" + frameworkAddonDirs[framework],
+		})
+	}
+	plan.framework = framework
+
+	path := strings.TrimSpace(stringArg(arguments, "path"))
+	if path == "" {
+		path = defaultTestPath(projectDir)
+	}
+	fullPath, resPath, err := tooltypes.ResolveProjectFilePath(path, nil)
+	if semErr, ok := tooltypes.AsSemanticError(err); ok {
+		return testRunPlan{}, semErr
+	}
+	if err != nil {
+		return testRunPlan{}, tooltypes.NewRuntimeInvalidParamsError(err.Error(), toolName, "invalid_path", map[string]any{"path": path})
+	}
+	if _, err := os.Stat(fullPath); err != nil {
+		code := "invalid_path"
+		if errors.Is(err, os.ErrNotExist) {
+			code = "test_path_not_found"
+		}
+		return testRunPlan{}, tooltypes.NewRuntimeInvalidParamsError("Test path does not exist", toolName, code, map[string]any{"path": resPath})
+	}
+	plan.path = resPath
+	plan.test = strings.TrimSpace(stringArg(arguments, "test"))
+
+	if raw, ok := arguments["timeout_ms"]; ok {
+		value, ok := raw.(float64)
+		if !ok || value != math.Trunc(value) || value < minTestTimeoutMS || value > maxTestTimeoutMS {
+			return testRunPlan{}, tooltypes.NewRuntimeInvalidParamsError("timeout_ms must be an integer between 1000 and 3600000", toolName, "invalid_timeout", nil)
+		}
+		plan.timeout = time.Duration(value) * time.Millisecond
+	}
+
+	executable, err := runtimebridge.DefaultGameProcessLauncher().ResolveExecutable()
+	if err != nil {
+		return testRunPlan{}, tooltypes.NewRuntimeNotAvailableError("Godot executable is not configured", toolName, "godot_executable_missing", map[string]any{
+			"detail": err.Error(),
+			"hint":   "set runtime_bridge.godot_executable or MCP_RUNTIME_BRIDGE_GODOT_EXECUTABLE",
+		})
+	}
+	plan.executable = executable
+	return plan, nil
+}
+
+type TestResultGetTool struct{}
+
+func (t *TestResultGetTool) Name() string { return "godot.test.result.get" }
+func (t *TestResultGetTool) Description() string {
+	return "Returns a stored godot.test.run result, the latest one by default"
+}
+func (t *TestResultGetTool) Annotations() *mcp.ToolAnnotations {
+	return &mcp.ToolAnnotations{
+		ReadOnlyHint: tooltypes.BoolPtr(true),
+	}
+}
+func (t *TestResultGetTool) InputSchema() mcp.InputSchema {
+	return mcp.InputSchema{
+		Type: "object",
+		Properties: map[string]any{
+			"run_id": map[string]any{"type": "string", "description": "Optional run id; defaults to the latest run"},
+		},
+		Required: []string{},
+		Title:    "Get Unit Test Result",
+	}
+}
+func (t *TestResultGetTool) Execute(args json.RawMessage) ([]byte, error) {
+	arguments := map[string]any{}
+	if err := json.Unmarshal(args, &arguments); err != nil {
+		return nil, err
+	}
+	store := runtimebridge.DefaultTestRunStore()
+	runID := strings.TrimSpace(stringArg(arguments, "run_id"))
+	run, ok := store.Get(runID)
+	if !ok {
+		if runID == "" {
+			return nil, tooltypes.NewRuntimeNotAvailableError("No test run has been recorded", t.Name(), "test_result_missing", map[string]any{
+				"hint": "call godot.test.run first",
+			})
+		}
+		return nil, tooltypes.NewRuntimeInvalidParamsError("Unknown test run id", t.Name(), "test_result_not_found", map[string]any{
+			"run_id":  runID,
+			"run_ids": store.RunIDs(),
+		})
+	}
+	return json.Marshal(struct {
+		runtimebridge.TestRun
+		RunIDs []string `json:"run_ids"`
+	}{TestRun: run, RunIDs: store.RunIDs()})
+}
+
+func stringArg(arguments map[string]any, key string) string {
+	value, _ := arguments[key].(string)
+	return value
+}
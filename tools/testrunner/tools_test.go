@@ -0,0 +1,174 @@
+package testrunner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+const gutJUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="GutTests" failures="1" tests="2">
+  <testsuite name="res://test/unit/test_player.gd" tests="2" failures="1" skipped="0">
+    <testcase name="test_jump" assertions="1" status="pass" classname="res://test/unit/test_player.gd" time="0.004"></testcase>
+    <testcase name="test_health" assertions="1" status="fail" classname="res://test/unit/test_player.gd" time="0.002">
+      <failure message="failed">[Failed]:  [100] expected to equal [90]:  at line 12</failure>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+func setupTestProject(t *testing.T, addon string) string {
+	t.Helper()
+	projectRoot := t.TempDir()
+	for _, dir := range []string{filepath.Join(projectRoot, filepath.FromSlash(addon)), filepath.Join(projectRoot, "test", "unit")} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	t.Setenv("GODOT_PROJECT_ROOT", projectRoot)
+	return projectRoot
+}
+
+func writeFakeGodot(t *testing.T, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as the godot executable")
+	}
+	path := filepath.Join(t.TempDir(), "godot")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+		t.Fatalf("write fake godot: %v", err)
+	}
+	return path
+}
+
+func TestRunTests_GUTParsesJUnitReportAndStoresResult(t *testing.T) {
+	setupTestProject(t, "addons/gut")
+	reportSource := filepath.Join(t.TempDir(), "report.xml")
+	if err := os.WriteFile(reportSource, []byte(gutJUnitReport), 0o644); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+	godot := writeFakeGodot(t, `for arg in "$@"; do
+  case "$arg" in
+    -gjunit_xml_file=*) cp "`+reportSource+`" "${arg#-gjunit_xml_file=}" ;;
+  esac
+done
+echo "1 of 2 tests failed"
+exit 1
+`)
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(godot, "http://localhost:9080/mcp", filepath.Join(t.TempDir(), "handshakes"))
+	runtimebridge.ResetDefaultTestRunStoreForTests(10)
+
+	result, err := (&RunTestsTool{}).Execute(json.RawMessage(`{
+		"test":"test_health",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var run runtimebridge.TestRun
+	if err := json.Unmarshal(result, &run); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if run.Framework != frameworkGUT || run.Path != "res://test" || run.Status != runStatusFailed || run.Source != sourceJUnit {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if run.ExitCode == nil || *run.ExitCode != 1 {
+		t.Fatalf("expected exit code 1, got %v", run.ExitCode)
+	}
+	if !slices.Contains(run.Command, "-gdir=res://test") || !slices.Contains(run.Command, "-gunit_test_name=test_health") {
+		t.Fatalf("unexpected command: %v", run.Command)
+	}
+	if run.Report.Summary.Total != 2 || run.Report.Summary.Failed != 1 {
+		t.Fatalf("unexpected summary: %+v", run.Report.Summary)
+	}
+	failed := run.Report.Cases[1]
+	if failed.Status != "failed" || failed.File != "res://test/unit/test_player.gd" || failed.Line != 12 {
+		t.Fatalf("unexpected failed case: %+v", failed)
+	}
+
+	stored, err := (&TestResultGetTool{}).Execute(json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("result get: %v", err)
+	}
+	var storedRun struct {
+		RunID  string   `json:"run_id"`
+		RunIDs []string `json:"run_ids"`
+	}
+	if err := json.Unmarshal(stored, &storedRun); err != nil {
+		t.Fatalf("decode stored: %v", err)
+	}
+	if storedRun.RunID != run.RunID || len(storedRun.RunIDs) != 1 {
+		t.Fatalf("unexpected stored result: %+v", storedRun)
+	}
+}
+
+func TestRunTests_GdUnitFallsBackToConsoleOutput(t *testing.T) {
+	setupTestProject(t, "addons/gdUnit4")
+	godot := writeFakeGodot(t, `echo "res://test/unit/test_enemy.gd > test_spawn PASSED 3ms"
+echo "res://test/unit/test_enemy.gd > test_attack PASSED 5ms"
+exit 101
+`)
+	runtimebridge.ResetDefaultGameProcessLauncherForTests(godot, "http://localhost:9080/mcp", filepath.Join(t.TempDir(), "handshakes"))
+	runtimebridge.ResetDefaultTestRunStoreForTests(10)
+
+	result, err := (&RunTestsTool{}).Execute(json.RawMessage(`{
+		"path":"res://test/unit",
+		"_mcp":{"session_id":"ai-session","session_initialized":true}
+	}`))
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	var run runtimebridge.TestRun
+	if err := json.Unmarshal(result, &run); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if run.Framework != frameworkGdUnit4 || run.Status != runStatusPassed || run.Source != sourceConsole {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	if run.Report.Summary.Total != 2 || run.Report.Summary.Passed != 2 {
+		t.Fatalf("unexpected summary: %+v", run.Report.Summary)
+	}
+	if !slices.Contains(run.Command, "res://test/unit") {
+		t.Fatalf("unexpected command: %v", run.Command)
+	}
+}
+
+func TestRunTests_RequiresInstalledFramework(t *testing.T) {
+	setupTestProject(t, "addons/other")
+	runtimebridge.ResetDefaultTestRunStoreForTests(10)
+
+	_, err := (&RunTestsTool{}).Execute(json.RawMessage(`{"_mcp":{"session_id":"ai-session","session_initialized":true}}`))
+	semanticErr, ok := tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "test_framework_missing" {
+		t.Fatalf("expected test_framework_missing, got %v", err)
+	}
+
+	_, err = (&TestResultGetTool{}).Execute(json.RawMessage(`{}`))
+	semanticErr, ok = tooltypes.AsSemanticError(err)
+	if !ok || semanticErr.Data["code"] != "test_result_missing" {
+		t.Fatalf("expected test_result_missing, got %v", err)
+	}
+}
+
+func TestTailBuffer_KeepsLastBytesAndMarksTruncation(t *testing.T) {
+	buffer := &tailBuffer{limit: 8}
+	for _, chunk := range []string{"abc", "defgh", "ijklmnop", "qr"} {
+		if _, err := buffer.Write([]byte(chunk)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	if got := buffer.String(); got != "klmnopqr" || !buffer.truncated {
+		t.Fatalf("expected last 8 bytes and truncation, got %q truncated=%v", got, buffer.truncated)
+	}
+
+	short := &tailBuffer{limit: 8}
+	_, _ = short.Write([]byte("ok"))
+	if short.String() != "ok" || short.truncated {
+		t.Fatalf("expected short output untouched, got %q truncated=%v", short.String(), short.truncated)
+	}
+}
@@ -0,0 +1,52 @@
+package types
+
+import "encoding/base64"
+
+// ToolResultImagesKey is a reserved result key. Entries under it are moved out
+// of the structured result and returned as MCP image content blocks.
+const ToolResultImagesKey = "_mcp_images"
+
+// ToolResultImage is one image attached to a tool result.
+type ToolResultImage struct {
+	Data     string `json:"data"`
+	MimeType string `json:"mimeType"`
+}
+
+// PNGToolResultImage encodes PNG bytes as an image content entry.
+func PNGToolResultImage(data []byte) ToolResultImage {
+	return ToolResultImage{Data: base64.StdEncoding.EncodeToString(data), MimeType: "image/png"}
+}
+
+// SplitToolResultImages removes ToolResultImagesKey from an object result and
+// returns the remaining result with MCP image content blocks.
+func SplitToolResultImages(result any) (any, []map[string]any) {
+	object, ok := result.(map[string]any)
+	if !ok {
+		return result, nil
+	}
+	raw, ok := object[ToolResultImagesKey]
+	if !ok {
+		return result, nil
+	}
+	stripped := make(map[string]any, len(object)-1)
+	for key, value := range object {
+		if key != ToolResultImagesKey {
+			stripped[key] = value
+		}
+	}
+	entries, _ := raw.([]any)
+	blocks := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		image, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+		data, _ := image["data"].(string)
+		mimeType, _ := image["mimeType"].(string)
+		if data == "" || mimeType == "" {
+			continue
+		}
+		blocks = append(blocks, map[string]any{"type": "image", "data": data, "mimeType": mimeType})
+	}
+	return stripped, blocks
+}
@@ -10,6 +10,8 @@ type MCPContext struct {
 	SessionInitialized      bool
 	EmitProgress            bool
 	ProgressToken           any
+	// CallID identifies the pipeline call for LookupNestedToolCaller.
+	CallID string
 }
 
 func ExtractMCPContext(arguments map[string]any) MCPContext {
@@ -36,6 +38,9 @@ func ExtractMCPContext(arguments map[string]any) MCPContext {
 	if emitProgress, ok := rawContext["emit_progress_notifications"].(bool); ok {
 		ctx.EmitProgress = emitProgress
 	}
+	if callID, ok := rawContext["call_id"].(string); ok {
+		ctx.CallID = strings.TrimSpace(callID)
+	}
 	switch token := rawContext["progress_token"].(type) {
 	case string:
 		token = strings.TrimSpace(token)
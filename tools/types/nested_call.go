@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NestedToolCaller runs another tool on behalf of the tool call in progress
+// and returns its JSON result. The tool pipeline registers one per call so
+// nested calls pass the same auth, profile, rate-limit and audit gates as the
+// outer call.
+type NestedToolCaller func(toolName string, arguments map[string]any) ([]byte, error)
+
+var (
+	nestedToolCallers   sync.Map
+	nestedToolCallerSeq atomic.Uint64
+)
+
+// RegisterNestedToolCaller stores caller for the duration of one tool call.
+// The returned id travels in _mcp.call_id; release must be called once the
+// outer call returns.
+func RegisterNestedToolCaller(caller NestedToolCaller) (string, func()) {
+	callID := "call-" + strconv.FormatUint(nestedToolCallerSeq.Add(1), 10)
+	nestedToolCallers.Store(callID, caller)
+	return callID, func() { nestedToolCallers.Delete(callID) }
+}
+
+// LookupNestedToolCaller returns the caller registered for callID.
+func LookupNestedToolCaller(callID string) (NestedToolCaller, bool) {
+	callID = strings.TrimSpace(callID)
+	if callID == "" {
+		return nil, false
+	}
+	value, ok := nestedToolCallers.Load(callID)
+	if !ok {
+		return nil, false
+	}
+	return value.(NestedToolCaller), true
+}
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SemanticKindPathForbidden marks project paths rejected by the path sandbox.
+const SemanticKindPathForbidden = "path_forbidden"
+
+// PathSandboxRules limit which project files file-based tools may touch.
+// Patterns are slash-separated globs relative to res:// where ** matches any
+// number of directories; a pattern without a slash matches a file or
+// directory name at any depth. A pattern that matches a directory covers
+// everything below it.
+type PathSandboxRules struct {
+	// Include, when non-empty, lists the only paths tools may use.
+	Include []string `json:"include"`
+	// Exclude wins over Include.
+	Exclude []string `json:"exclude"`
+}
+
+var (
+	pathSandboxMu    sync.RWMutex
+	pathSandboxRules PathSandboxRules
+)
+
+// SetPathSandboxRules replaces the active path sandbox rules.
+func SetPathSandboxRules(rules PathSandboxRules) {
+	pathSandboxMu.Lock()
+	defer pathSandboxMu.Unlock()
+	pathSandboxRules = PathSandboxRules{
+		Include: append([]string(nil), rules.Include...),
+		Exclude: append([]string(nil), rules.Exclude...),
+	}
+}
+
+// ActivePathSandboxRules returns a copy of the active path sandbox rules.
+func ActivePathSandboxRules() PathSandboxRules {
+	pathSandboxMu.RLock()
+	defer pathSandboxMu.RUnlock()
+	return PathSandboxRules{
+		Include: append([]string{}, pathSandboxRules.Include...),
+		Exclude: append([]string{}, pathSandboxRules.Exclude...),
+	}
+}
+
+// CheckProjectPath returns a path_forbidden semantic error when the sandbox
+// rejects input, a res:// or project-relative path.
+func CheckProjectPath(input string) error {
+	rel := projectRelativeSlashPath(input)
+	rules := ActivePathSandboxRules()
+	if pattern, excluded := matchSandboxPatterns(rules.Exclude, rel); excluded {
+		return newPathForbiddenError(rel, "exclude", pattern)
+	}
+	if len(rules.Include) > 0 {
+		if _, included := matchSandboxPatterns(rules.Include, rel); !included {
+			return newPathForbiddenError(rel, "include", "")
+		}
+	}
+	return nil
+}
+
+// PathSandboxAllows reports whether the sandbox lets tools use rel.
+func PathSandboxAllows(rel string) bool {
+	return CheckProjectPath(rel) == nil
+}
+
+// PathSandboxSkipsDir reports whether a project walk can skip the directory
+// rel because an exclude rule covers it.
+func PathSandboxSkipsDir(rel string) bool {
+	_, excluded := matchSandboxPatterns(ActivePathSandboxRules().Exclude, projectRelativeSlashPath(rel))
+	return excluded
+}
+
+func newPathForbiddenError(rel string, rule string, pattern string) *SemanticError {
+	data := map[string]any{"path": "res://" + rel, "rule": rule}
+	if pattern != "" {
+		data["pattern"] = pattern
+	}
+	return NewSemanticError(SemanticKindPathForbidden, "Path is blocked by the path sandbox", data)
+}
+
+func projectRelativeSlashPath(input string) string {
+	rel := strings.TrimSpace(input)
+	rel = strings.TrimPrefix(rel, "res://")
+	rel = strings.ReplaceAll(filepath.ToSlash(rel), "\\", "/")
+	rel = strings.TrimPrefix(path.Clean("/"+rel), "/")
+	return rel
+}
+
+// matchSandboxPatterns matches rel and each of its parent directories.
+func matchSandboxPatterns(patterns []string, rel string) (string, bool) {
+	if rel == "" {
+		return "", false
+	}
+	segments := strings.Split(rel, "/")
+	for _, pattern := range patterns {
+		patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+		for end := len(segments); end > 0; end-- {
+			candidate := segments[:end]
+			if len(patternSegments) == 1 && patternSegments[0] != "**" {
+				candidate = candidate[len(candidate)-1:]
+			}
+			if matchSegments(patternSegments, candidate) {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+func matchSegments(pattern []string, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], segments[0])
+	return err == nil && matched && matchSegments(pattern[1:], segments[1:])
+}
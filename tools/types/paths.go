@@ -47,6 +47,9 @@ func ResolveProjectFilePath(input string, allowedExts []string) (string, string,
 	if !isWithinRoot(fullAbs, projectAbs) {
 		return "", "", fmt.Errorf("path escapes project root")
 	}
+	if err := CheckProjectPath(filepath.ToSlash(cleanRel)); err != nil {
+		return "", "", err
+	}
 
 	if len(allowedExts) > 0 {
 		ext := strings.ToLower(filepath.Ext(fullAbs))
@@ -89,6 +92,12 @@ func ReadProjectFile(input string, allowedExts []string) ([]byte, string, error)
 	if !isWithinRoot(resolvedPath, projectReal) {
 		return nil, "", fmt.Errorf("path escapes project root")
 	}
+	// A symlink must not lead into a path the sandbox rejects.
+	if realRel, relErr := filepath.Rel(projectReal, resolvedPath); relErr == nil {
+		if err := CheckProjectPath(filepath.ToSlash(realRel)); err != nil {
+			return nil, "", err
+		}
+	}
 
 	fileInfo, err := os.Stat(resolvedPath)
 	if err != nil {
@@ -222,3 +222,35 @@ func TestReadProjectFile_CacheDoesNotBypassSymlinkEscapeCheck(t *testing.T) {
 		t.Fatalf("expected path escapes project root error, got %v", err)
 	}
 }
+
+func TestResolveProjectFilePath_AppliesPathSandbox(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectRoot, "project.godot"), []byte("[application]"), 0o644); err != nil {
+		t.Fatalf("write project.godot: %v", err)
+	}
+	t.Setenv("GODOT_PROJECT_ROOT", projectRoot)
+	SetPathSandboxRules(PathSandboxRules{
+		Include: []string{"scenes/**", "scripts"},
+		Exclude: []string{"*.import", "scenes/secrets/**"},
+	})
+	t.Cleanup(func() { SetPathSandboxRules(PathSandboxRules{}) })
+
+	for _, allowed := range []string{"res://scenes/Main.tscn", "scripts/player/Player.gd"} {
+		if _, _, err := ResolveProjectFilePath(allowed, nil); err != nil {
+			t.Fatalf("expected %s to be allowed, got %v", allowed, err)
+		}
+	}
+	cases := map[string]string{
+		"res://scenes/icon.png.import":     "exclude",
+		"res://scenes/secrets/keys.tres":   "exclude",
+		"res://addons/plugin/plugin.gd":    "include",
+		"res://scenes/../addons/plugin.gd": "include",
+	}
+	for input, rule := range cases {
+		_, _, err := ResolveProjectFilePath(input, nil)
+		semErr, ok := AsSemanticError(err)
+		if !ok || semErr.Kind != SemanticKindPathForbidden || semErr.Data["rule"] != rule {
+			t.Fatalf("expected %s to be forbidden by %s, got %v", input, rule, err)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package types
+
+import (
+	"maps"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+// Reasons carried in the data of rate_limited semantic errors.
+const (
+	RateLimitReasonSession  = "session_rate_limited"
+	RateLimitReasonTool     = "tool_rate_limited"
+	RateLimitReasonInFlight = "in_flight_limited"
+)
+
+// minCommandRetryAfter is the retry hint for in-flight refusals before the
+// broker has measured any ack latency.
+const minCommandRetryAfter = 100 * time.Millisecond
+
+// NewRateLimitedError reports a refused call with a retry_after_ms hint.
+func NewRateLimitedError(message string, retryAfter time.Duration, data map[string]any) *SemanticError {
+	payload := map[string]any{}
+	maps.Copy(payload, data)
+	payload["retry_after_ms"] = max((retryAfter + time.Millisecond - 1).Milliseconds(), 1)
+	return NewSemanticError(SemanticKindRateLimited, message, payload)
+}
+
+// CommandInFlightLimitedError turns a command broker in-flight refusal into a
+// rate_limited error; it returns nil for every other dispatch failure reason.
+func CommandInFlightLimitedError(toolName string, targetSessionID string, reason string) *SemanticError {
+	if reason != runtimebridge.CommandReasonInFlightLimited {
+		return nil
+	}
+	broker := runtimebridge.DefaultCommandBroker()
+	retryAfter := time.Duration(broker.Metrics().AvgLatencyMS * float64(time.Millisecond))
+	return NewRateLimitedError("Too many runtime commands in flight for the target session", max(retryAfter, minCommandRetryAfter), map[string]any{
+		"reason":            RateLimitReasonInFlight,
+		"tool":              toolName,
+		"target_session_id": targetSessionID,
+		"max_in_flight":     broker.MaxInFlight(),
+	})
+}
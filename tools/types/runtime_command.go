@@ -93,6 +93,9 @@ func DispatchRuntimeCommand(options RuntimeCommandDispatchOptions) ([]byte, erro
 	ack, ok, reason := runtimebridge.DefaultCommandBroker().DispatchAndWait(runtimeSessionID, options.CommandName, commandArgs, options.Timeout)
 	if !ok {
 		emitRuntimeCommandProgress(ctx, options.CommandName, 1.0, "runtime command unavailable")
+		if limited := CommandInFlightLimitedError(options.CommandName, runtimeSessionID, reason); limited != nil {
+			return nil, limited
+		}
 		return nil, NewNotAvailableError(options.BridgeUnavailableMessage, map[string]any{
 			"feature": "runtime_bridge",
 			"reason":  reason,
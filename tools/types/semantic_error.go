@@ -10,6 +10,7 @@ const (
 	SemanticKindNotSupported    = "not_supported"
 	SemanticKindNotAvailable    = "not_available"
 	SemanticKindExecutionFailed = "execution_failed"
+	SemanticKindRateLimited     = "rate_limited"
 )
 
 // SemanticError marks tool failures that should be surfaced as structured isError payloads.
@@ -44,10 +44,11 @@ func (t *ListOfferingsTool) Execute(args json.RawMessage) ([]byte, error) {
 	editorFresh := editorHealth.States["fresh"]
 
 	runtimeStatus := map[string]any{
-		"connected":    false,
-		"registered":   false,
-		"session_id":   "",
-		"has_snapshot": false,
+		"connected":        false,
+		"registered":       false,
+		"session_id":       "",
+		"has_snapshot":     false,
+		"running_sessions": len(runtimebridge.DefaultGameSessionRegistry().RunningSessions()),
 	}
 	runtimeAvailable := "unavailable"
 	if gameSession, hasGame := runtimebridge.DefaultGameSessionRegistry().LatestRunning(); hasGame {
@@ -171,8 +172,26 @@ func (t *RuntimeDiagnoseTool) Execute(args json.RawMessage) ([]byte, error) {
 			"editor_session_id":    gameSession.EditorSessionID,
 			"launch_token_present": strings.TrimSpace(gameSession.LaunchToken) != "",
 			"started_at":           gameSession.StartedAt,
+			"state":                gameSession.State,
+			"state_reason":         gameSession.StateReason,
+			"state_changed_at":     gameSession.StateChangedAt,
+			"last_log_lines":       gameSession.LastLogLines,
 		}
 	}
+	crashedSession, hasCrashed := runtimebridge.DefaultGameSessionRegistry().LatestCrashed()
+	// game_session describes the latest run; running_game_sessions covers
+	// every instance when several games run at once.
+	runningSessions := make([]map[string]any, 0)
+	for _, session := range runtimebridge.DefaultGameSessionRegistry().RunningSessions() {
+		runningSessions = append(runningSessions, map[string]any{
+			"session_id":         session.SessionID,
+			"editor_session_id":  session.EditorSessionID,
+			"instance_index":     session.InstanceIndex,
+			"state":              session.State,
+			"runtime_session_id": session.RuntimeSessionID,
+			"has_snapshot":       session.HasSnapshot,
+		})
+	}
 
 	// Editor store info
 	editorHealth := runtimebridge.DefaultEditorStore().Health(now)
@@ -182,18 +201,28 @@ func (t *RuntimeDiagnoseTool) Execute(args json.RawMessage) ([]byte, error) {
 	mcpCounts := runtimebridge.GetSessionCounts()
 
 	// Build pipeline checklist
-	checklist := buildPipelineChecklist(hasGame, gameSession, editorFresh, mcpCounts)
+	checklist := buildPipelineChecklist(hasGame, gameSession, hasCrashed, crashedSession, editorFresh, mcpCounts)
 
 	result := map[string]any{
-		"timestamp":    now.Format(time.RFC3339Nano),
-		"game_session": gameSessionInfo,
-		"mcp_sessions": mcpCounts,
+		"timestamp":             now.Format(time.RFC3339Nano),
+		"game_session":          gameSessionInfo,
+		"running_game_sessions": runningSessions,
+		"mcp_sessions":          mcpCounts,
 		"editor_store": map[string]any{
 			"sessions":    editorHealth.Sessions,
 			"fresh_count": editorFresh,
 		},
 		"pipeline_checklist": checklist,
 	}
+	if hasCrashed {
+		result["last_crashed_session"] = map[string]any{
+			"session_id":     crashedSession.SessionID,
+			"state_reason":   crashedSession.StateReason,
+			"started_at":     crashedSession.StartedAt,
+			"stopped_at":     crashedSession.StoppedAt,
+			"last_log_lines": crashedSession.LastLogLines,
+		}
+	}
 	return json.Marshal(result)
 }
 
@@ -203,14 +232,17 @@ type pipelineStep struct {
 	Hint string `json:"hint,omitempty"`
 }
 
-func buildPipelineChecklist(hasGame bool, game runtimebridge.GameSession, editorFresh int, mcpCounts map[string]any) []pipelineStep {
-	steps := make([]pipelineStep, 0, 5)
+func buildPipelineChecklist(hasGame bool, game runtimebridge.GameSession, hasCrashed bool, crashed runtimebridge.GameSession, editorFresh int, mcpCounts map[string]any) []pipelineStep {
+	steps := make([]pipelineStep, 0, 6)
 
 	// Step 1: game session exists
 	gameOK := hasGame
 	step1 := pipelineStep{Step: "game_session_exists", OK: gameOK}
 	if !gameOK {
 		step1.Hint = "no running game session — call project.run first"
+		if hasCrashed {
+			step1.Hint = "last game session crashed (" + crashed.StateReason + ") — inspect last_crashed_session.last_log_lines, then call project.run again"
+		}
 	}
 	steps = append(steps, step1)
 
@@ -255,5 +287,17 @@ func buildPipelineChecklist(hasGame bool, game runtimebridge.GameSession, editor
 	}
 	steps = append(steps, step5)
 
+	// Step 6: game still advancing frames
+	healthyOK := snapshotOK && game.State != runtimebridge.GameSessionStateHung
+	step6 := pipelineStep{Step: "game_session_responsive", OK: healthyOK}
+	if !healthyOK {
+		if !snapshotOK {
+			step6.Hint = "depends on first_snapshot_received"
+		} else {
+			step6.Hint = "runtime snapshots arrive but the frame counter stopped advancing — the game is likely stuck in a loop or blocked; inspect game_session.last_log_lines"
+		}
+	}
+	steps = append(steps, step6)
+
 	return steps
 }
@@ -36,8 +36,8 @@ func TestRuntimeDiagnoseTool_ReturnsChecklist(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected pipeline_checklist array, got %T", result["pipeline_checklist"])
 	}
-	if len(checklist) != 5 {
-		t.Fatalf("expected 5 checklist steps, got %d", len(checklist))
+	if len(checklist) != 6 {
+		t.Fatalf("expected 6 checklist steps, got %d", len(checklist))
 	}
 
 	// Step 1: game_session_exists should be true
@@ -132,6 +132,49 @@ func TestRuntimeDiagnoseTool_AllGreen(t *testing.T) {
 	}
 }
 
+func TestRuntimeDiagnoseTool_ReportsHungAndCrashedSessions(t *testing.T) {
+	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
+	runtimebridge.ResetDefaultGameSessionRegistryForTests()
+	runtimebridge.ResetDefaultRuntimeSnapshotStoreForTests(10*time.Second, 0)
+	runtimebridge.SetSessionInfoProvider(nil)
+
+	now := time.Now().UTC()
+	registry := runtimebridge.DefaultGameSessionRegistry()
+	registry.UpsertFromRun("game-old", "editor-1", "res://Main.tscn", "token-old", now.Add(-time.Minute))
+	registry.RegisterRuntimeTransport("game-old", "runtime-old", "editor-1", "res://Main.tscn", now.Add(-time.Minute), "token-old")
+	registry.MarkCrashed("game-old", runtimebridge.GameSessionReasonSnapshotTimeout, []runtimebridge.RuntimeLogEntry{{Sequence: 1, Level: "error", Message: "boom"}}, now)
+	registry.UpsertFromRun("game-1", "editor-1", "res://Main.tscn", "token-1", now)
+	registry.RegisterRuntimeTransport("game-1", "runtime-1", "editor-1", "res://Main.tscn", now, "token-1")
+	registry.MarkSnapshotReceived("game-1", now)
+	registry.MarkHung("game-1", runtimebridge.GameSessionReasonFrameStalled, nil, now)
+
+	resultRaw, err := NewRuntimeDiagnoseTool().Execute(json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("execute runtime.diagnose: %v", err)
+	}
+	var result map[string]any
+	if err := json.Unmarshal(resultRaw, &result); err != nil {
+		t.Fatalf("unmarshal result: %v", err)
+	}
+
+	gameSession, _ := result["game_session"].(map[string]any)
+	if gameSession["session_id"] != "game-1" || gameSession["state"] != "hung" || gameSession["state_reason"] != "frame_stalled" {
+		t.Fatalf("expected hung game-1, got %v", gameSession)
+	}
+	crashed, _ := result["last_crashed_session"].(map[string]any)
+	if crashed["session_id"] != "game-old" || crashed["state_reason"] != "snapshot_timeout" {
+		t.Fatalf("expected crashed game-old, got %v", crashed)
+	}
+	if lines, _ := crashed["last_log_lines"].([]any); len(lines) != 1 {
+		t.Fatalf("expected crash log lines, got %v", crashed["last_log_lines"])
+	}
+	checklist, _ := result["pipeline_checklist"].([]any)
+	step6, _ := checklist[5].(map[string]any)
+	if step6["step"] != "game_session_responsive" || step6["ok"] != false {
+		t.Fatalf("expected game_session_responsive=false, got %v", step6)
+	}
+}
+
 func TestRuntimeHealthTool_IncludesMCPSessions(t *testing.T) {
 	runtimebridge.ResetDefaultEditorStoreForTests(10 * time.Second)
 	runtimebridge.ResetDefaultRuntimeSnapshotStoreForTests(10*time.Second, 0)
@@ -0,0 +1,222 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/logger"
+	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+const authIdentityContextKey = "godot_mcp_auth_identity"
+
+var (
+	errAuthMissing = errors.New("missing bearer token")
+	errAuthInvalid = errors.New("invalid bearer token")
+	errAuthExpired = errors.New("bearer token expired")
+	// errAuthInsufficientScope rejects valid OAuth tokens that grant none of
+	// the configured scopes.
+	errAuthInsufficientScope = errors.New("bearer token grants no MCP scope")
+)
+
+// AuthIdentity is the credential an HTTP request authenticated with. Sessions
+// are bound to the TokenID that initialized them; scopes are always taken
+// from the current request.
+type AuthIdentity struct {
+	// TokenID names the credential's subject: the static token id, or
+	// "oauth:" plus the JWT subject.
+	TokenID   string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+type authenticator struct {
+	tokens map[[sha256.Size]byte]AuthIdentity
+	// oauth validates JWT access tokens when resource-server mode is on.
+	oauth *oauthResource
+	// bridgeToken is handed to game processes the server launches itself.
+	bridgeToken string
+}
+
+// newAuthenticator builds the token table from the config and its tokens
+// file. It returns nil when authentication is disabled.
+func newAuthenticator(cfg config.Auth) (*authenticator, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	tokens := append([]config.AuthToken{}, cfg.Tokens...)
+	if cfg.TokensFile != "" {
+		loaded, err := loadAuthTokensFile(cfg.TokensFile)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, loaded...)
+	}
+	tokens = config.NormalizeAuthTokens(tokens)
+	if err := config.ValidateAuthTokens(tokens); err != nil {
+		return nil, err
+	}
+	oauthResource, err := newOAuthResource(cfg.OAuth)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 && oauthResource == nil {
+		return nil, errors.New("auth is enabled but no tokens are configured")
+	}
+
+	auth := &authenticator{tokens: make(map[[sha256.Size]byte]AuthIdentity, len(tokens)), oauth: oauthResource}
+	for _, token := range tokens {
+		identity := AuthIdentity{TokenID: token.ID, Scopes: token.Scopes}
+		if token.ExpiresAt != "" {
+			identity.ExpiresAt, _ = time.Parse(time.RFC3339, token.ExpiresAt)
+		}
+		auth.tokens[sha256.Sum256([]byte(token.Token))] = identity
+		if auth.bridgeToken == "" && identity.ExpiresAt.IsZero() && isBridgeOnlyToken(identity.Scopes) {
+			auth.bridgeToken = token.Token
+		}
+	}
+	return auth, nil
+}
+
+// isBridgeOnlyToken reports whether a token carries exactly the bridge scope.
+// Only such a token is written into game handshake files, so a leaked file
+// never hands out read, write or admin access.
+func isBridgeOnlyToken(scopes []string) bool {
+	return len(scopes) == 1 && scopes[0] == toolspec.AuthScopeBridge
+}
+
+func loadAuthTokensFile(path string) ([]config.AuthToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read auth tokens file: %w", err)
+	}
+	var file struct {
+		Tokens []config.AuthToken `json:"tokens"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse auth tokens file %s: %w", path, err)
+	}
+	return file.Tokens, nil
+}
+
+// authenticate resolves an Authorization header value to a token identity.
+// Static tokens are checked first; anything else is validated as an OAuth
+// access token when resource-server mode is on.
+func (a *authenticator) authenticate(header string, now time.Time) (AuthIdentity, error) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(header), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return AuthIdentity{}, errAuthMissing
+	}
+	identity, ok := a.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		if a.oauth != nil {
+			return a.oauth.authenticate(token, now)
+		}
+		return AuthIdentity{}, errAuthInvalid
+	}
+	if !identity.ExpiresAt.IsZero() && !now.Before(identity.ExpiresAt) {
+		return AuthIdentity{}, errAuthExpired
+	}
+	return identity, nil
+}
+
+// configureAuth loads the bearer token table and passes the bridge token to
+// server-launched game processes.
+func (s *Server) configureAuth() error {
+	auth, err := newAuthenticator(s.config.Auth)
+	if err != nil {
+		return err
+	}
+	s.auth = auth
+	if auth != nil {
+		if auth.bridgeToken == "" {
+			logger.Warn("No non-expiring token with only the bridge scope; headless game processes cannot authenticate")
+		}
+		runtimebridge.DefaultGameProcessLauncher().SetAuthToken(auth.bridgeToken)
+		logger.Info("Bearer token authentication enabled", "tokens", len(auth.tokens))
+	}
+	return nil
+}
+
+// authMiddleware rejects /mcp requests without a valid bearer token before
+// any MCP handling. CORS preflight requests carry no credentials and pass.
+func (s *Server) authMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if s.auth == nil || c.Request().Method == http.MethodOptions || c.Request().URL.Path != "/mcp" {
+				return next(c)
+			}
+			identity, err := s.auth.authenticate(c.Request().Header.Get(echo.HeaderAuthorization), time.Now().UTC())
+			if err != nil {
+				logger.Warn("Rejected unauthenticated MCP request", "remote_addr", c.RealIP(), "reason", err.Error())
+				status := http.StatusUnauthorized
+				errorCode := ""
+				switch {
+				case errors.Is(err, errAuthInsufficientScope):
+					status, errorCode = http.StatusForbidden, "insufficient_scope"
+				case !errors.Is(err, errAuthMissing):
+					errorCode = "invalid_token"
+				}
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, s.auth.challenge(errorCode))
+				message := "Unauthorized: "
+				if status == http.StatusForbidden {
+					message = "Forbidden: "
+				}
+				return c.JSON(status, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), message+err.Error(), nil))
+			}
+			c.Set(authIdentityContextKey, identity)
+			return next(c)
+		}
+	}
+}
+
+// challenge builds the WWW-Authenticate value for a rejected request. In
+// resource-server mode it points clients at the protected resource metadata
+// so they can discover the authorization server.
+func (a *authenticator) challenge(errorCode string) string {
+	challenge := `Bearer realm="godot-mcp"`
+	if a.oauth != nil {
+		challenge += fmt.Sprintf(`, resource_metadata=%q, scope=%q`, a.oauth.metadataURL, strings.Join(a.oauth.scopesSupported(), " "))
+	}
+	if errorCode != "" {
+		challenge += fmt.Sprintf(`, error=%q`, errorCode)
+	}
+	return challenge
+}
+
+// requestAuthIdentity returns the identity set by authMiddleware.
+func requestAuthIdentity(c echo.Context) (AuthIdentity, bool) {
+	identity, ok := c.Get(authIdentityContextKey).(AuthIdentity)
+	return identity, ok
+}
+
+// sessionCredentialMismatch reports whether an existing session was
+// initialized by a different subject than the current request's token. An
+// initialized session with no bound subject, such as one restored from a
+// snapshot written while auth was off, matches no token.
+func (s *Server) sessionCredentialMismatch(c echo.Context, sessionID string) bool {
+	if s.auth == nil {
+		return false
+	}
+	identity, _ := requestAuthIdentity(c)
+	bound, ok := s.sessionManager.AuthIdentity(sessionID)
+	if !ok {
+		return s.sessionManager.IsInitializeAccepted(sessionID)
+	}
+	return bound.TokenID != identity.TokenID
+}
+
+func forbiddenSessionCredential(c echo.Context) error {
+	return c.JSON(http.StatusForbidden, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), "MCP session belongs to a different credential", nil))
+}
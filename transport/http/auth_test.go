@@ -0,0 +1,291 @@
+package http
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+func newAuthTestHTTPServer(t *testing.T, auth config.Auth) *Server {
+	t.Helper()
+	server := newTestHTTPServer(t, false)
+	server.config.Auth = auth
+	if err := server.configureAuth(); err != nil {
+		t.Fatalf("configure auth: %v", err)
+	}
+	t.Cleanup(func() { runtimebridge.DefaultGameProcessLauncher().SetAuthToken("") })
+	server.setupEcho()
+	return server
+}
+
+func serveAuthMCP(t *testing.T, server *Server, body map[string]any, sessionID string, token string) (map[string]any, *httptest.ResponseRecorder) {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/mcp", bytes.NewReader(raw))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(headerProtocolVersion, "2025-11-25")
+	if sessionID != "" {
+		req.Header.Set(headerSessionID, sessionID)
+	}
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+
+	var parsed map[string]any
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &parsed); err != nil {
+			t.Fatalf("unmarshal response: %v", err)
+		}
+	}
+	return parsed, rec
+}
+
+func initializeAuthSession(t *testing.T, server *Server, token string) string {
+	t.Helper()
+	_, rec := serveAuthMCP(t, server, map[string]any{
+		"jsonrpc": jsonrpc.Version,
+		"id":      "init",
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": "2025-11-25",
+			"capabilities":    map[string]any{"godot": map[string]any{"mutating": true}},
+			"clientInfo":      map[string]any{"name": "auth-test", "version": "0.2.0"},
+		},
+	}, "", token)
+	sessionID := rec.Header().Get(headerSessionID)
+	if rec.Code != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize failed, status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	_, rec = serveAuthMCP(t, server, map[string]any{
+		"jsonrpc": jsonrpc.Version,
+		"method":  "notifications/initialized",
+	}, sessionID, token)
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("initialized notification failed, status=%d", rec.Code)
+	}
+	return sessionID
+}
+
+func TestAuthMiddleware_RejectsMissingInvalidAndExpiredTokens(t *testing.T) {
+	server := newAuthTestHTTPServer(t, config.Auth{
+		Enabled: true,
+		Tokens: []config.AuthToken{
+			{ID: "editor", Token: "editor-secret"},
+			{ID: "old", Token: "old-secret", ExpiresAt: "2020-01-01T00:00:00Z"},
+		},
+	})
+	initialize := map[string]any{
+		"jsonrpc": jsonrpc.Version,
+		"id":      "init",
+		"method":  "initialize",
+		"params":  map[string]any{"protocolVersion": "2025-11-25", "capabilities": map[string]any{}},
+	}
+
+	for name, token := range map[string]string{"missing": "", "invalid": "wrong-secret", "expired": "old-secret"} {
+		_, rec := serveAuthMCP(t, server, initialize, "", token)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("%s token: expected 401, got %d", name, rec.Code)
+		}
+		if rec.Header().Get(echo.HeaderWWWAuthenticate) == "" {
+			t.Fatalf("%s token: expected WWW-Authenticate challenge", name)
+		}
+	}
+	if server.sessionManager.SessionCounts()["total"] != 0 {
+		t.Fatalf("expected no session to be created by rejected requests")
+	}
+
+	sessionID := initializeAuthSession(t, server, "editor-secret")
+	identity, ok := server.sessionManager.AuthIdentity(sessionID)
+	if !ok || identity.TokenID != "editor" {
+		t.Fatalf("expected session bound to editor token, got %+v ok=%t", identity, ok)
+	}
+}
+
+func TestAuth_SessionBoundToTokenAndScopesEnforced(t *testing.T) {
+	tokensFile := filepath.Join(t.TempDir(), "tokens.json")
+	if err := os.WriteFile(tokensFile, []byte(`{"tokens":[{"id":"writer","token":"writer-secret","scopes":["write","bridge"]},{"id":"games","token":"games-secret","scopes":["bridge"]}]}`), 0o600); err != nil {
+		t.Fatalf("write tokens file: %v", err)
+	}
+	server := newAuthTestHTTPServer(t, config.Auth{
+		Enabled:    true,
+		Tokens:     []config.AuthToken{{ID: "reader", Token: "reader-secret", Scopes: []string{"read"}}},
+		TokensFile: tokensFile,
+	})
+	if server.auth.bridgeToken != "games-secret" {
+		t.Fatalf("expected the bridge-only token to be handed to launched games, got %q", server.auth.bridgeToken)
+	}
+
+	readerSession := initializeAuthSession(t, server, "reader-secret")
+	callTool := func(sessionID string, token string, name string) (map[string]any, *httptest.ResponseRecorder) {
+		return serveAuthMCP(t, server, map[string]any{
+			"jsonrpc": jsonrpc.Version,
+			"id":      "call",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": name, "arguments": map[string]any{}},
+		}, sessionID, token)
+	}
+
+	_, rec := callTool(readerSession, "writer-secret", "godot.offerings.list")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for session used with another token, got %d", rec.Code)
+	}
+
+	response, rec := callTool(readerSession, "reader-secret", "godot.project.stop")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	result := mustMap(t, response["result"])
+	errorPayload := mustMap(t, result["error"])
+	if result["isError"] != true || errorPayload["reason"] != "scope_denied" || errorPayload["required_scope"] != "write" {
+		t.Fatalf("expected scope_denied for mutating tool, got %+v", result)
+	}
+
+	response, _ = callTool(readerSession, "reader-secret", "godot.offerings.list")
+	result = mustMap(t, response["result"])
+	if result["isError"] != false {
+		t.Fatalf("expected read tool to succeed, got %+v", result)
+	}
+
+	writerSession := initializeAuthSession(t, server, "writer-secret")
+	response, _ = callTool(writerSession, "writer-secret", "godot.offerings.list")
+	result = mustMap(t, response["result"])
+	if result["isError"] != false {
+		t.Fatalf("expected write scope to include read tools, got %+v", result)
+	}
+
+	response, _ = callTool(writerSession, "writer-secret", "godot.audit.query")
+	errorPayload = mustMap(t, mustMap(t, response["result"])["error"])
+	if errorPayload["reason"] != "scope_denied" || errorPayload["required_scope"] != "audit" {
+		t.Fatalf("expected audit query to need the audit scope, got %+v", errorPayload)
+	}
+}
+
+// newOAuthTestHTTPServer starts a resource-server mode HTTP server and
+// returns a function that issues HS256 access tokens for a subject and scope.
+func newOAuthTestHTTPServer(t *testing.T) (*Server, func(subject string, scope string) string) {
+	t.Helper()
+	secret := "0123456789abcdef0123456789abcdef"
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	jwks := `{"keys":[{"kty":"oct","kid":"gw","k":"` + base64.RawURLEncoding.EncodeToString([]byte(secret)) + `"}]}`
+	if err := os.WriteFile(jwksFile, []byte(jwks), 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+	server := newAuthTestHTTPServer(t, config.Auth{
+		Enabled: true,
+		OAuth: config.OAuth{
+			Enabled:              true,
+			Resource:             "https://mcp.example.com/mcp",
+			Issuer:               "https://gateway.example.com",
+			AuthorizationServers: []string{"https://gateway.example.com"},
+			JWKSFile:             jwksFile,
+			ReadScopes:           []string{"mcp:read"},
+			WriteScopes:          []string{"mcp:write"},
+			BridgeScopes:         []string{"mcp:bridge"},
+		},
+	})
+	issue := func(subject string, scope string) string {
+		encode := func(value map[string]any) string {
+			data, _ := json.Marshal(value)
+			return base64.RawURLEncoding.EncodeToString(data)
+		}
+		signingInput := encode(map[string]any{"alg": "HS256", "kid": "gw"}) + "." + encode(map[string]any{
+			"iss":   "https://gateway.example.com",
+			"sub":   subject,
+			"aud":   "https://mcp.example.com/mcp",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": scope,
+		})
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(signingInput))
+		return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+	return server, issue
+}
+
+func TestOAuth_MetadataChallengeAndJWTScopes(t *testing.T) {
+	server, issueFor := newOAuthTestHTTPServer(t)
+	issue := func(scope string) string { return issueFor("remote-teammate", scope) }
+
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/oauth-protected-resource/mcp", nil)
+	rec := httptest.NewRecorder()
+	server.echo.ServeHTTP(rec, req)
+	var metadata map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &metadata); err != nil || rec.Code != http.StatusOK {
+		t.Fatalf("metadata request failed, status=%d body=%s", rec.Code, rec.Body.String())
+	}
+	if metadata["resource"] != "https://mcp.example.com/mcp" {
+		t.Fatalf("unexpected metadata: %+v", metadata)
+	}
+
+	_, rec = serveAuthMCP(t, server, map[string]any{"jsonrpc": jsonrpc.Version, "id": "ping", "method": "ping"}, "", "")
+	challenge := rec.Header().Get(echo.HeaderWWWAuthenticate)
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(challenge, `resource_metadata="https://mcp.example.com/.well-known/oauth-protected-resource/mcp"`) {
+		t.Fatalf("expected resource_metadata challenge, status=%d challenge=%q", rec.Code, challenge)
+	}
+	_, rec = serveAuthMCP(t, server, map[string]any{"jsonrpc": jsonrpc.Version, "id": "ping", "method": "ping"}, "", issue("profile"))
+	if rec.Code != http.StatusForbidden || !strings.Contains(rec.Header().Get(echo.HeaderWWWAuthenticate), `error="insufficient_scope"`) {
+		t.Fatalf("expected insufficient_scope, status=%d challenge=%q", rec.Code, rec.Header().Get(echo.HeaderWWWAuthenticate))
+	}
+
+	readToken := issue("mcp:read")
+	sessionID := initializeAuthSession(t, server, readToken)
+	identity, _ := server.sessionManager.AuthIdentity(sessionID)
+	if identity.TokenID != "oauth:remote-teammate" {
+		t.Fatalf("unexpected identity: %+v", identity)
+	}
+	response, _ := serveAuthMCP(t, server, map[string]any{
+		"jsonrpc": jsonrpc.Version,
+		"id":      "call",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "godot.project.stop", "arguments": map[string]any{}},
+	}, sessionID, readToken)
+	errorPayload := mustMap(t, mustMap(t, response["result"])["error"])
+	if errorPayload["reason"] != "scope_denied" || errorPayload["required_scope"] != "write" {
+		t.Fatalf("expected read-only token to be denied mutating tools, got %+v", errorPayload)
+	}
+}
+
+func TestOAuth_ScopesFollowEachRequestToken(t *testing.T) {
+	server, issue := newOAuthTestHTTPServer(t)
+	sessionID := initializeAuthSession(t, server, issue("remote-teammate", "mcp:write"))
+	callStop := func(token string) (map[string]any, *httptest.ResponseRecorder) {
+		return serveAuthMCP(t, server, map[string]any{
+			"jsonrpc": jsonrpc.Version,
+			"id":      "call",
+			"method":  "tools/call",
+			"params":  map[string]any{"name": "godot.project.stop", "arguments": map[string]any{}},
+		}, sessionID, token)
+	}
+
+	response, rec := callStop(issue("remote-teammate", "mcp:read"))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected same subject to reuse the session, got %d", rec.Code)
+	}
+	errorPayload := mustMap(t, mustMap(t, response["result"])["error"])
+	if errorPayload["reason"] != "scope_denied" || errorPayload["required_scope"] != "write" {
+		t.Fatalf("expected downscoped token to be denied mutating tools, got %+v", errorPayload)
+	}
+
+	if _, rec := callStop(issue("someone-else", "mcp:write")); rec.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for another subject, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/logger"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
+)
+
+// bridgeSecretRelativePath is where the editor plugin keeps the bridge
+// secret inside the project; .godot/ is not committed.
+const bridgeSecretRelativePath = ".godot/godot_mcp/bridge_secret"
+
+func (s *Server) bridgeRoleRequired() bool {
+	return s.config == nil || !s.config.ToolControls.AllowBridgeWithoutRole
+}
+
+func (s *Server) bridgeSecretPath() string {
+	if s.config != nil && s.config.ToolControls.BridgeSecretFile != "" {
+		return s.config.ToolControls.BridgeSecretFile
+	}
+	return filepath.Join(tooltypes.ResolveProjectRootFromEnvOrCWD(), filepath.FromSlash(bridgeSecretRelativePath))
+}
+
+// configureBridgeRoles makes sure a bridge secret exists and hands it to
+// server-launched game processes so their runtime sessions can claim the
+// runtime role.
+func (s *Server) configureBridgeRoles() {
+	if !s.bridgeRoleRequired() {
+		logger.Warn("Bridge tools are callable by any session (tool_controls.allow_bridge_without_role=true)")
+		return
+	}
+	secret, err := ensureBridgeSecret(s.bridgeSecretPath())
+	if err != nil {
+		logger.Warn("Bridge secret is unavailable; editor and runtime sessions cannot claim their role", "path", s.bridgeSecretPath(), "error", err)
+		return
+	}
+	runtimebridge.DefaultGameProcessLauncher().SetBridgeSecret(secret)
+}
+
+// ensureBridgeSecret returns the secret stored at path, creating it when the
+// plugin has not written one yet.
+func ensureBridgeSecret(path string) (string, error) {
+	if secret, err := readBridgeSecret(path); err == nil {
+		return secret, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(buf)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		// The plugin created it first.
+		return readBridgeSecret(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	if _, err := file.WriteString(secret); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+func readBridgeSecret(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", errors.New("bridge secret file is empty")
+	}
+	return secret, nil
+}
+
+// negotiateSessionRole resolves initialize.params.capabilities.godot.bridge
+// to a session role. A missing or wrong secret demotes the session to agent.
+func (s *Server) negotiateSessionRole(paramsRaw json.RawMessage) string {
+	var params struct {
+		Capabilities struct {
+			Godot struct {
+				Bridge struct {
+					Role   string `json:"role"`
+					Secret string `json:"secret"`
+				} `json:"bridge"`
+			} `json:"godot"`
+		} `json:"capabilities"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil {
+		return toolspec.SessionRoleAgent
+	}
+	bridge := params.Capabilities.Godot.Bridge
+	role := strings.ToLower(strings.TrimSpace(bridge.Role))
+	if role != toolspec.SessionRoleEditor && role != toolspec.SessionRoleRuntime {
+		return toolspec.SessionRoleAgent
+	}
+	secret, err := readBridgeSecret(s.bridgeSecretPath())
+	if err != nil {
+		logger.Warn("Session claimed a bridge role but the bridge secret is unavailable", "role", role, "error", err)
+		return toolspec.SessionRoleAgent
+	}
+	if subtle.ConstantTimeCompare([]byte(strings.TrimSpace(bridge.Secret)), []byte(secret)) != 1 {
+		logger.Warn("Session claimed a bridge role with a wrong bridge secret", "role", role)
+		return toolspec.SessionRoleAgent
+	}
+	return role
+}
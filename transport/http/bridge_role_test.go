@@ -0,0 +1,104 @@
+package http
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+func TestBridgeTools_RequireEditorOrRuntimeRole(t *testing.T) {
+	runtimebridge.ResetDefaultStoreForTests(10 * time.Second)
+	server := newTestHTTPServer(t, false)
+	server.config.ToolControls.AllowBridgeWithoutRole = false
+	server.config.ToolControls.BridgeSecretFile = filepath.Join(t.TempDir(), "godot_mcp", "bridge_secret")
+	secret, err := ensureBridgeSecret(server.config.ToolControls.BridgeSecretFile)
+	if err != nil {
+		t.Fatalf("ensure bridge secret: %v", err)
+	}
+	if again, _ := ensureBridgeSecret(server.config.ToolControls.BridgeSecretFile); again != secret {
+		t.Fatalf("expected existing bridge secret to be reused")
+	}
+	if err := os.WriteFile(server.config.ToolControls.BridgeSecretFile, []byte(secret+"\n"), 0o600); err != nil {
+		t.Fatalf("rewrite bridge secret: %v", err)
+	}
+
+	initialize := func(bridge map[string]any) (string, map[string]any) {
+		capabilities := map[string]any{"godot": map[string]any{"mutating": true}}
+		if bridge != nil {
+			capabilities["godot"].(map[string]any)["bridge"] = bridge
+		}
+		response, sessionID, status := postMCP(t, server, map[string]any{
+			"jsonrpc": jsonrpc.Version,
+			"id":      "init",
+			"method":  "initialize",
+			"params": map[string]any{
+				"protocolVersion": "2025-11-25",
+				"capabilities":    capabilities,
+				"clientInfo":      map[string]any{"name": "role-test", "version": "0.2.0"},
+			},
+		}, "", "2025-11-25")
+		if status != 200 || sessionID == "" {
+			t.Fatalf("initialize failed, status=%d", status)
+		}
+		notifyInitialized(t, server, sessionID)
+		return sessionID, mustMap(t, mustMap(t, response["result"])["godot"])
+	}
+	editorSync := func(sessionID string) map[string]any {
+		response, _, _ := postMCP(t, server, map[string]any{
+			"jsonrpc": jsonrpc.Version,
+			"id":      "sync",
+			"method":  "tools/call",
+			"params": map[string]any{
+				"name": "godot.bridge.editor.sync",
+				"arguments": map[string]any{"snapshot": map[string]any{
+					"root_summary": map[string]any{"active_scene": "res://Main.tscn"},
+					"scene_tree":   map[string]any{"path": "/Root", "name": "Root", "type": "Node2D", "child_count": 0},
+				}},
+			},
+		}, sessionID, "2025-11-25")
+		return mustMap(t, response["result"])
+	}
+
+	agentSession, godotInfo := initialize(nil)
+	if godotInfo["role"] != "agent" {
+		t.Fatalf("expected agent role, got %+v", godotInfo)
+	}
+	result := editorSync(agentSession)
+	errorPayload := mustMap(t, result["error"])
+	if result["isError"] != true || errorPayload["reason"] != "bridge_role_required" || errorPayload["session_role"] != "agent" {
+		t.Fatalf("expected agent sync to be rejected, got %+v", result)
+	}
+
+	forgedSession, godotInfo := initialize(map[string]any{"role": "editor", "secret": "guessed"})
+	if godotInfo["role"] != "agent" {
+		t.Fatalf("expected wrong secret to demote to agent, got %+v", godotInfo)
+	}
+	if result := editorSync(forgedSession); result["isError"] != true {
+		t.Fatalf("expected forged editor sync to be rejected, got %+v", result)
+	}
+
+	runtimeSession, _ := initialize(map[string]any{"role": "runtime", "secret": secret})
+	if result := editorSync(runtimeSession); result["isError"] != true {
+		t.Fatalf("expected runtime session to be denied editor sync, got %+v", result)
+	}
+
+	editorSession, godotInfo := initialize(map[string]any{"role": "editor", "secret": secret})
+	if godotInfo["role"] != "editor" {
+		t.Fatalf("expected editor role, got %+v", godotInfo)
+	}
+	if result := editorSync(editorSession); result["isError"] != false {
+		t.Fatalf("expected editor sync to succeed, got %+v", result)
+	}
+
+	roles := map[string]any{}
+	for _, summary := range server.sessionManager.SessionSummaries() {
+		roles[summary["session_id"].(string)] = summary["role"]
+	}
+	if roles[agentSession] != "agent" || roles[runtimeSession] != "runtime" || roles[editorSession] != "editor" {
+		t.Fatalf("unexpected session roles: %+v", roles)
+	}
+}
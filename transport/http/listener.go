@@ -0,0 +1,145 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/logger"
+)
+
+// buildTLSConfig loads the server certificate and, for mutual TLS, the
+// client CA pool.
+func buildTLSConfig(cfg config.TLS) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if cfg.MinVersion == "1.3" {
+		tlsConfig.MinVersion = tls.VersionTLS13
+	}
+	if cfg.ClientCAFile != "" {
+		data, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read tls client ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("tls client ca file %s has no PEM certificates", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		if cfg.ClientAuth == "verify_if_given" {
+			tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+	return tlsConfig, nil
+}
+
+// listenUnixSocket listens on path with the configured file mode. A stale
+// socket left by a previous run is removed; any other file is kept.
+func listenUnixSocket(cfg config.UnixSocket) (net.Listener, error) {
+	mode, err := cfg.FileMode()
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Lstat(cfg.Path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("unix socket path %s exists and is not a socket", cfg.Path)
+		}
+		if conn, dialErr := net.Dial("unix", cfg.Path); dialErr == nil {
+			conn.Close()
+			return nil, fmt.Errorf("unix socket %s is already in use", cfg.Path)
+		}
+		if err := os.Remove(cfg.Path); err != nil {
+			return nil, fmt.Errorf("remove stale unix socket: %w", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(cfg.Path, mode); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("chmod unix socket: %w", err)
+	}
+	return listener, nil
+}
+
+// streamableHTTPListeners opens the TCP listener (wrapped in TLS when
+// enabled) and the Unix domain socket listener the config asks for.
+func (s *Server) streamableHTTPListeners() ([]net.Listener, error) {
+	serverConfig := s.config.Server
+	var listeners []net.Listener
+	closeAll := func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}
+	if !serverConfig.UnixSocket.DisableTCP {
+		host := strings.TrimSpace(serverConfig.Host)
+		if host == "" {
+			host = "localhost"
+		}
+		addr := net.JoinHostPort(host, fmt.Sprintf("%d", serverConfig.Port))
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return nil, err
+		}
+		if serverConfig.TLS.Enabled {
+			tlsConfig, err := buildTLSConfig(serverConfig.TLS)
+			if err != nil {
+				listener.Close()
+				return nil, err
+			}
+			listener = tls.NewListener(listener, tlsConfig)
+		}
+		logger.Info("Streamable HTTP server starting to listen", "address", addr, "tls", serverConfig.TLS.Enabled, "mtls", serverConfig.TLS.ClientCAFile != "")
+		listeners = append(listeners, listener)
+	}
+	if serverConfig.UnixSocket.Enabled {
+		listener, err := listenUnixSocket(serverConfig.UnixSocket)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		logger.Info("Streamable HTTP server listening on unix socket", "path", serverConfig.UnixSocket.Path, "mode", serverConfig.UnixSocket.Mode)
+		listeners = append(listeners, listener)
+	}
+	if len(listeners) == 0 {
+		return nil, errors.New("no streamable HTTP listener is enabled")
+	}
+	return listeners, nil
+}
+
+// serveListeners serves the echo handler on every listener and returns when
+// the first one fails.
+func (s *Server) serveListeners(listeners []net.Listener) error {
+	server := s.echo.Server
+	server.Handler = s.echo
+	errs := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		go func() {
+			errs <- server.Serve(listener)
+		}()
+	}
+	err := <-errs
+	server.Close()
+	if unixSocket := s.config.Server.UnixSocket; unixSocket.Enabled {
+		_ = os.Remove(unixSocket.Path)
+	}
+	return err
+}
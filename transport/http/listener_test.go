@@ -0,0 +1,202 @@
+package http
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func issueTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parse certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	return testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func writeTestFile(t *testing.T, dir string, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return path
+}
+
+func startTestListeners(t *testing.T, server *Server) []net.Listener {
+	t.Helper()
+	server.setupEcho()
+	listeners, err := server.streamableHTTPListeners()
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		_ = server.serveListeners(listeners)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+		<-done
+	})
+	return listeners
+}
+
+func TestStreamableHTTPListener_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := issueTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test ca"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	serverCert := issueTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, &ca)
+	clientCert := issueTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "teammate"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, &ca)
+
+	server := newTestHTTPServer(t, false)
+	server.config.Server.Host = "127.0.0.1"
+	server.config.Server.Port = 0
+	server.config.Server.TLS.Enabled = true
+	server.config.Server.TLS.CertFile = writeTestFile(t, dir, "server.pem", serverCert.certPEM)
+	server.config.Server.TLS.KeyFile = writeTestFile(t, dir, "server.key", serverCert.keyPEM)
+	server.config.Server.TLS.ClientCAFile = writeTestFile(t, dir, "ca.pem", ca.certPEM)
+	server.config.Server.TLS.ClientAuth = "require"
+	listeners := startTestListeners(t, server)
+	url := "https://" + listeners[0].Addr().String() + "/"
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := func(certificates []tls.Certificate) *http.Client {
+		return &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certificates},
+		}}
+	}
+
+	if resp, err := client(nil).Get(url); err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected request without client certificate to fail, got %d", resp.StatusCode)
+	}
+	pair, err := tls.X509KeyPair(clientCert.certPEM, clientCert.keyPEM)
+	if err != nil {
+		t.Fatalf("client key pair: %v", err)
+	}
+	resp, err := client([]tls.Certificate{pair}).Get(url)
+	if err != nil {
+		t.Fatalf("mtls request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestStreamableHTTPListener_UnixSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix socket file modes are not supported on windows")
+	}
+	// Keep the path short; unix socket paths are limited to ~100 bytes.
+	dir, err := os.MkdirTemp("", "mcp")
+	if err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	socketPath := filepath.Join(dir, "mcp.sock")
+
+	// A stale socket from a crashed run must not block startup.
+	stale, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen stale socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	server := newTestHTTPServer(t, false)
+	server.config.Server.UnixSocket.Enabled = true
+	server.config.Server.UnixSocket.Path = socketPath
+	server.config.Server.UnixSocket.Mode = "0660"
+	server.config.Server.UnixSocket.DisableTCP = true
+	listeners := startTestListeners(t, server)
+	if len(listeners) != 1 {
+		t.Fatalf("expected only the unix socket listener, got %d", len(listeners))
+	}
+
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatalf("stat socket: %v", err)
+	}
+	if info.Mode().Perm() != 0o660 {
+		t.Fatalf("expected socket mode 0660, got %o", info.Mode().Perm())
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	resp, err := client.Get("http://unix/")
+	if err != nil {
+		t.Fatalf("unix socket request: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	if _, err := listenUnixSocket(server.config.Server.UnixSocket); err == nil {
+		t.Fatalf("expected a second listener on an active socket to fail")
+	}
+}
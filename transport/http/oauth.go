@@ -0,0 +1,127 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/internal/infra/oauth"
+)
+
+const protectedResourceMetadataPath = "/.well-known/oauth-protected-resource"
+
+// oauthResource is the OAuth 2.1 resource-server side of the HTTP transport:
+// it validates JWT access tokens and maps their scopes to toolspec scopes.
+type oauthResource struct {
+	cfg         config.OAuth
+	validator   *oauth.Validator
+	metadataURL string
+}
+
+func newOAuthResource(cfg config.OAuth) (*oauthResource, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	validator := &oauth.Validator{
+		Issuer:    cfg.Issuer,
+		Audience:  cfg.Resource,
+		ClockSkew: time.Duration(cfg.ClockSkewSeconds) * time.Second,
+	}
+	if cfg.JWKSFile != "" {
+		keyFile, err := oauth.LoadKeyFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		validator.Keys = keyFile.Keys
+	} else {
+		keySet := oauth.NewRemoteKeySet(cfg.JWKSURL)
+		validator.Keys = keySet.Keys
+		validator.Refresh = keySet.Refresh
+	}
+	metadataURL, err := protectedResourceMetadataURL(cfg.Resource)
+	if err != nil {
+		return nil, err
+	}
+	return &oauthResource{cfg: cfg, validator: validator, metadataURL: metadataURL}, nil
+}
+
+// protectedResourceMetadataURL inserts the well-known segment between the
+// resource's host and path, per RFC 9728.
+func protectedResourceMetadataURL(resource string) (string, error) {
+	parsed, err := url.Parse(resource)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid auth.oauth.resource %q: expected an absolute URL", resource)
+	}
+	return parsed.Scheme + "://" + parsed.Host + protectedResourceMetadataPath + strings.TrimSuffix(parsed.Path, "/"), nil
+}
+
+func (o *oauthResource) authenticate(token string, now time.Time) (AuthIdentity, error) {
+	claims, err := o.validator.Validate(token, now)
+	if err != nil {
+		if errors.Is(err, oauth.ErrExpired) {
+			return AuthIdentity{}, errAuthExpired
+		}
+		return AuthIdentity{}, fmt.Errorf("%w: %v", errAuthInvalid, err)
+	}
+	scopes := o.mapScopes(claims.Scopes)
+	if len(scopes) == 0 {
+		return AuthIdentity{}, errAuthInsufficientScope
+	}
+	subject := claims.Subject
+	if subject == "" {
+		subject = claims.ClientID
+	}
+	return AuthIdentity{TokenID: "oauth:" + subject, Scopes: scopes, ExpiresAt: claims.ExpiresAt}, nil
+}
+
+// mapScopes converts token scopes to the read/write/bridge/audit scopes enforced
+// by the tool pipeline.
+func (o *oauthResource) mapScopes(tokenScopes []string) []string {
+	var scopes []string
+	for _, mapping := range []struct {
+		scope   string
+		granted []string
+	}{
+		{toolspec.AuthScopeRead, o.cfg.ReadScopes},
+		{toolspec.AuthScopeWrite, o.cfg.WriteScopes},
+		{toolspec.AuthScopeBridge, o.cfg.BridgeScopes},
+		{toolspec.AuthScopeAudit, o.cfg.AuditScopes},
+	} {
+		for _, scope := range tokenScopes {
+			if slices.Contains(mapping.granted, scope) {
+				scopes = append(scopes, mapping.scope)
+				break
+			}
+		}
+	}
+	return scopes
+}
+
+func (o *oauthResource) scopesSupported() []string {
+	scopes := append(append(append(append([]string{}, o.cfg.ReadScopes...), o.cfg.WriteScopes...), o.cfg.BridgeScopes...), o.cfg.AuditScopes...)
+	slices.Sort(scopes)
+	return slices.Compact(scopes)
+}
+
+// handleProtectedResourceMetadata serves the RFC 9728 metadata clients use
+// to find the authorization server for this MCP endpoint.
+func (s *Server) handleProtectedResourceMetadata(c echo.Context) error {
+	if s.auth == nil || s.auth.oauth == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+	resource := s.auth.oauth
+	return c.JSON(http.StatusOK, map[string]any{
+		"resource":                 resource.cfg.Resource,
+		"authorization_servers":    resource.cfg.AuthorizationServers,
+		"scopes_supported":         resource.scopesSupported(),
+		"bearer_methods_supported": []string{"header"},
+		"resource_name":            "Godot MCP",
+	})
+}
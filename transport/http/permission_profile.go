@@ -0,0 +1,49 @@
+package http
+
+import (
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+)
+
+// permissionPolicyFromConfig converts tool_controls.permission_profiles and
+// their bindings for the tool pipeline.
+func permissionPolicyFromConfig(controls config.ToolControls) toolspec.PermissionPolicy {
+	policy := toolspec.PermissionPolicy{
+		Profiles:       make(map[string]toolspec.PermissionProfile, len(controls.PermissionProfiles)),
+		DefaultProfile: controls.DefaultPermissionProfile,
+	}
+	for name, profile := range controls.PermissionProfiles {
+		constraints := make([]toolspec.ArgumentConstraint, 0, len(profile.ArgumentConstraints))
+		for _, constraint := range profile.ArgumentConstraints {
+			constraints = append(constraints, toolspec.ArgumentConstraint{
+				Tool:         constraint.Tool,
+				Argument:     constraint.Argument,
+				PathPrefixes: constraint.PathPrefixes,
+				Values:       constraint.Values,
+			})
+		}
+		policy.Profiles[name] = toolspec.PermissionProfile{
+			Name:                name,
+			Classes:             profile.Classes,
+			Allow:               profile.Allow,
+			Deny:                profile.Deny,
+			ArgumentConstraints: constraints,
+			Selectable:          profile.Selectable,
+		}
+	}
+	for _, binding := range controls.ProfileBindings {
+		policy.Bindings = append(policy.Bindings, toolspec.PermissionBinding{
+			Profile:     binding.Profile,
+			ClientName:  binding.ClientName,
+			AuthTokenID: binding.AuthTokenID,
+		})
+	}
+	return policy
+}
+
+func (s *Server) permissionPolicy() toolspec.PermissionPolicy {
+	if s == nil || s.config == nil {
+		return toolspec.PermissionPolicy{}
+	}
+	return permissionPolicyFromConfig(s.config.ToolControls)
+}
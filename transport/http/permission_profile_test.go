@@ -0,0 +1,75 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
+)
+
+func TestPermissionProfiles_SelectedAtInitialize(t *testing.T) {
+	server := newTestHTTPServer(t, false)
+	server.config.ToolControls.PermissionProfiles = map[string]config.PermissionProfile{
+		"ci":       {Classes: []string{"read_only"}},
+		"designer": {Deny: []string{"godot.script.*"}, Selectable: true},
+		"locked":   {Allow: []string{"godot.project.info"}},
+	}
+	server.config.ToolControls.ProfileBindings = []config.PermissionProfileBinding{
+		{Profile: "ci", ClientName: "ci-*"},
+	}
+	server.config.ToolControls.DefaultPermissionProfile = "locked"
+
+	initialize := func(clientName string, requested string) (map[string]any, string) {
+		godot := map[string]any{"mutating": true}
+		if requested != "" {
+			godot["permission_profile"] = requested
+		}
+		response, sessionID, _ := postMCP(t, server, map[string]any{
+			"jsonrpc": jsonrpc.Version,
+			"id":      "init",
+			"method":  "initialize",
+			"params": map[string]any{
+				"protocolVersion": "2025-11-25",
+				"capabilities":    map[string]any{"godot": godot},
+				"clientInfo":      map[string]any{"name": clientName, "version": "0.2.0"},
+			},
+		}, "", "2025-11-25")
+		if sessionID != "" {
+			notifyInitialized(t, server, sessionID)
+		}
+		return response, sessionID
+	}
+	profileOf := func(response map[string]any) any {
+		return mustMap(t, mustMap(t, response["result"])["godot"])["permission_profile"]
+	}
+
+	// A binding wins over the client's request.
+	response, ciSession := initialize("ci-runner", "designer")
+	if got := profileOf(response); got != "ci" {
+		t.Fatalf("expected bound ci profile, got %v", got)
+	}
+	response, _, _ = postMCP(t, server, map[string]any{
+		"jsonrpc": jsonrpc.Version,
+		"id":      "create",
+		"method":  "tools/call",
+		"params":  map[string]any{"name": "godot.node.create", "arguments": map[string]any{"type": "Node2D", "name": "Enemy"}},
+	}, ciSession, "2025-11-25")
+	errorPayload := mustMap(t, mustMap(t, response["result"])["error"])
+	if errorPayload["reason"] != "profile_denied" || errorPayload["rule"] != "class" || errorPayload["profile"] != "ci" {
+		t.Fatalf("expected ci profile to block mutating tool, got %+v", errorPayload)
+	}
+
+	response, _ = initialize("editor-agent", "designer")
+	if got := profileOf(response); got != "designer" {
+		t.Fatalf("expected requested designer profile, got %v", got)
+	}
+	response, _ = initialize("editor-agent", "")
+	if got := profileOf(response); got != "locked" {
+		t.Fatalf("expected default locked profile, got %v", got)
+	}
+
+	response, sessionID := initialize("editor-agent", "locked")
+	if sessionID != "" || response["error"] == nil {
+		t.Fatalf("expected non-selectable profile request to fail initialize, got %+v", response)
+	}
+}
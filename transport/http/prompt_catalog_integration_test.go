@@ -784,6 +784,9 @@ func newTestHTTPServer(t *testing.T, promptCatalogEnabled bool) *Server {
 
 	cfg := config.NewConfig()
 	cfg.PromptCatalog.Enabled = promptCatalogEnabled
+	// Most transport tests drive bridge tools from plain sessions; role
+	// enforcement is covered in bridge_role_test.go.
+	cfg.ToolControls.AllowBridgeWithoutRole = true
 
 	server := NewServer(cfg)
 	server.promptCatalog = promptcatalog.NewRegistry(promptCatalogEnabled)
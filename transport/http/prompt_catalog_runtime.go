@@ -597,12 +597,17 @@ func (s *Server) BroadcastPromptListChanged() int {
 }
 
 func (s *Server) SendJSONRPCNotificationToSession(sessionID string, message map[string]any) bool {
-	transport, ok := s.sessionManager.GetTransport(sessionID)
+	data, err := json.Marshal(message)
+	if err != nil {
+		logger.Warn("Failed to encode SSE notification", "session_id", sessionID, "error", err)
+		return false
+	}
+	event, transport, ok := s.sessionManager.QueueSSEEvent(sessionID, "message", data)
 	if !ok || transport == nil {
 		return false
 	}
 
-	if err := transport.SendSSEWithTimeout("message", message, promptCatalogNotificationWriteTimeout); err != nil {
+	if err := transport.SendReplayEvent(event, promptCatalogNotificationWriteTimeout); err != nil {
 		logger.Warn("Failed to send SSE notification", "session_id", sessionID, "error", err)
 		s.sessionManager.ClearTransportIfMatch(sessionID, transport)
 		return false
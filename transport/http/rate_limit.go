@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+// rateLimitPolicyFromConfig converts tool_controls.rate_limits for the
+// runtime bridge rate limiter.
+func rateLimitPolicyFromConfig(limits config.RateLimits) runtimebridge.RateLimitPolicy {
+	policy := runtimebridge.RateLimitPolicy{
+		Session: runtimebridge.RateLimit{RatePerSecond: limits.Session.RatePerSecond, Burst: limits.Session.Burst},
+		Tools:   make(map[string]runtimebridge.RateLimit, len(limits.Tools)),
+	}
+	for pattern, limit := range limits.Tools {
+		policy.Tools[pattern] = runtimebridge.RateLimit{RatePerSecond: limit.RatePerSecond, Burst: limit.Burst}
+	}
+	return policy
+}
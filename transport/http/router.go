@@ -20,6 +20,7 @@ import (
 	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
 	"github.com/slighter12/godot-mcp-go/promptcatalog"
 	"github.com/slighter12/godot-mcp-go/runtimebridge"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
 	"github.com/slighter12/godot-mcp-go/transport/shared"
 )
 
@@ -36,6 +37,8 @@ func RegisterRoutes(e *echo.Echo, s *Server) {
 	e.GET("/mcp", s.handleStreamableHTTPGet)
 	e.DELETE("/mcp", s.handleStreamableHTTPDelete)
 	e.OPTIONS("/mcp", s.handleOptions)
+	e.GET(protectedResourceMetadataPath, s.handleProtectedResourceMetadata)
+	e.GET(protectedResourceMetadataPath+"/*", s.handleProtectedResourceMetadata)
 }
 
 func (s *Server) handleHTTPInfo(c echo.Context) error {
@@ -130,6 +133,9 @@ func (s *Server) handleStreamableHTTPPost(c echo.Context) error {
 			}
 		}
 	}
+	if sessionID != "" && s.sessionCredentialMismatch(c, sessionID) {
+		return forbiddenSessionCredential(c)
+	}
 	if acceptedOneWay {
 		if sessionID == "" {
 			return c.JSON(http.StatusBadRequest, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), "Missing MCP-Session-Id header", nil))
@@ -146,9 +152,10 @@ func (s *Server) handleStreamableHTTPPost(c echo.Context) error {
 	initializeSucceeded := false
 	notificationFailed := false
 
+	identity, _ := requestAuthIdentity(c)
 	for _, request := range requests {
 		logger.Debug("Streamable HTTP request received", "method", request.Method, "id", request.ID)
-		response, handleErr := s.handleMessage(request, sessionID)
+		response, handleErr := s.handleMessageWithAuth(request, sessionID, identity)
 		if handleErr != nil {
 			logger.Error("Error handling message", "error", handleErr, "method", request.Method)
 			if request.ID != nil {
@@ -172,6 +179,12 @@ func (s *Server) handleStreamableHTTPPost(c echo.Context) error {
 		responses = append(responses, response)
 	}
 
+	if initializeSucceeded && s.auth != nil {
+		if identity, ok := requestAuthIdentity(c); ok {
+			s.sessionManager.BindAuthIdentity(sessionID, identity)
+		}
+	}
+
 	shouldAttachSessionHeader := sessionID != "" && s.sessionManager.HasSession(sessionID)
 	if hasInitialize {
 		shouldAttachSessionHeader = shouldAttachSessionHeader && initializeSucceeded
@@ -206,6 +219,9 @@ func (s *Server) handleStreamableHTTPGet(c echo.Context) error {
 	if !s.sessionManager.HasSession(sessionID) {
 		return c.JSON(http.StatusNotFound, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), "Unknown MCP session", nil))
 	}
+	if s.sessionCredentialMismatch(c, sessionID) {
+		return forbiddenSessionCredential(c)
+	}
 
 	if !acceptsEventStream(c.Request().Header.Get(echo.HeaderAccept)) {
 		return c.JSON(http.StatusBadRequest, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), "Accept header must include text/event-stream", nil))
@@ -227,6 +243,7 @@ func (s *Server) handleStreamableHTTPGet(c echo.Context) error {
 	defer stopStream()
 
 	transport := NewStreamableHTTPTransport(c.Response().Writer, flusher, stopStream)
+	transport.replay, _ = s.sessionManager.ReplayBuffer(sessionID)
 	if err := transport.SendComment("stream opened"); err != nil {
 		logger.Warn("Failed to write initial SSE comment", "session_id", sessionID, "error", err)
 		return nil
@@ -234,12 +251,24 @@ func (s *Server) handleStreamableHTTPGet(c echo.Context) error {
 
 	// Publish transport only after SSE headers + initial frame are sent.
 	// This prevents concurrent notification writes from racing with stream setup.
-	if !s.sessionManager.SetTransport(sessionID, transport) {
+	// A reconnecting client first receives the events its last stream missed.
+	lastEventID, resume := parseLastEventID(c.Request().Header.Get("Last-Event-ID"))
+	replayed, complete, err := transport.PublishAndReplay(lastEventID, resume, func() bool {
+		return s.sessionManager.SetTransport(sessionID, transport)
+	})
+	if errors.Is(err, errSSETransportNotBound) {
 		transport.Close()
 		logger.Warn("SSE session disappeared before stream binding", "session_id", sessionID)
 		return nil
 	}
 	defer s.sessionManager.ClearTransportIfMatch(sessionID, transport)
+	if err != nil {
+		logger.Warn("Failed to replay SSE events", "session_id", sessionID, "last_event_id", lastEventID, "error", err)
+		return nil
+	}
+	if resume {
+		logger.Info("Resumed SSE stream", "session_id", sessionID, "last_event_id", lastEventID, "replayed", replayed, "complete", complete)
+	}
 
 	<-streamCtx.Done()
 	return nil
@@ -257,15 +286,25 @@ func (s *Server) handleStreamableHTTPDelete(c echo.Context) error {
 	if !s.sessionManager.HasSession(sessionID) {
 		return c.JSON(http.StatusNotFound, jsonrpc.NewErrorResponse(nil, int(jsonrpc.ErrInvalidRequest), "Unknown MCP session", nil))
 	}
+	if s.sessionCredentialMismatch(c, sessionID) {
+		return forbiddenSessionCredential(c)
+	}
 	s.sessionManager.RemoveSession(sessionID)
 	return c.NoContent(http.StatusNoContent)
 }
 
 func (s *Server) handleMessage(msg jsonrpc.Request, sessionID string) (any, error) {
+	return s.handleMessageWithAuth(msg, sessionID, AuthIdentity{})
+}
+
+// handleMessageWithAuth handles msg for a request authenticated as identity.
+// Initialize selects the session's permission profile from it and tool calls
+// are checked against its scopes.
+func (s *Server) handleMessageWithAuth(msg jsonrpc.Request, sessionID string, identity AuthIdentity) (any, error) {
 	switch msg.Method {
 	case "initialize":
 		logger.Debug("Handling initialize message", "request_id", msg.ID)
-		return s.handleInit(msg, sessionID)
+		return s.handleInit(msg, sessionID, identity)
 	case "initialized", "notifications/initialized":
 		if msg.ID != nil {
 			return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidRequest), "Invalid request", nil), nil
@@ -304,13 +343,13 @@ func (s *Server) handleMessage(msg jsonrpc.Request, sessionID string) (any, erro
 			_ = json.Unmarshal(msg.Params, &peek)
 			log.Printf("godot-mcp tools/call received: session_id=%q tool=%q initialized=%t",
 				sessionID, peek.Name, s.sessionManager.IsFullyInitialized(sessionID))
-			return shared.BuildToolCallResponseWithContextAndOptions(msg, s.toolManager, s.handleGodotResource, s.toolCallContext(sessionID), s.toolCallOptions()), nil
+			return shared.BuildToolCallResponseWithContextAndOptions(msg, s.toolManager, s.handleGodotResource, s.toolCallContext(sessionID, identity), s.toolCallOptions()), nil
 		}
-		return shared.DispatchStandardMethodWithPromptOptions(msg, s.toolManager, s.promptCatalog, s.handleGodotResource, s.promptRenderOptions()), nil
+		return shared.DispatchStandardMethodWithContextAndOptions(msg, s.toolManager, s.promptCatalog, s.handleGodotResource, s.promptRenderOptions(), s.toolCallContext(sessionID, identity), s.toolCallOptions()), nil
 	}
 }
 
-func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Response, error) {
+func (s *Server) handleInit(msg jsonrpc.Request, sessionID string, identity AuthIdentity) (*jsonrpc.Response, error) {
 	logger.Debug("Handling init message", "request_id", msg.ID)
 	negotiatedVersion, err := mcpv20251125.ValidateHTTPInitializeProtocolVersion(msg.Params)
 	if err != nil {
@@ -320,6 +359,13 @@ func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Res
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), err.Error(), nil), nil
 	}
 
+	profile, err := shared.SelectPermissionProfile(s.permissionPolicy(), msg.Params, identity.TokenID)
+	if err != nil {
+		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), err.Error(), map[string]any{
+			"field": "capabilities.godot.permission_profile",
+		}), nil
+	}
+
 	tools, err := s.registry.GetServerTools("default")
 	if err != nil {
 		logger.Error("Failed to get server tools", "error", err, "server_id", "default")
@@ -330,6 +376,7 @@ func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Res
 		s.sessionManager.CreateSession(sessionID)
 		s.sessionManager.MarkInitializeAccepted(sessionID)
 		clientName, clientVersion := extractClientInfo(msg.Params)
+		s.sessionManager.SetClientInfo(sessionID, clientName, clientVersion)
 		logger.Info("Initialize accepted for session", "session_id", sessionID, "protocol_version", negotiatedVersion, "client_name", clientName, "client_version", clientVersion)
 	}
 
@@ -337,9 +384,19 @@ func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Res
 	if !mutatingAllowed && s.config != nil && s.config.ToolControls.AllowMutatingWithoutCapability {
 		mutatingAllowed = true
 	}
+	role := s.negotiateSessionRole(msg.Params)
 	if sessionID != "" {
 		s.sessionManager.SetProtocolVersion(sessionID, negotiatedVersion)
 		s.sessionManager.SetMutatingAllowed(sessionID, mutatingAllowed)
+		s.sessionManager.SetRole(sessionID, role)
+		s.sessionManager.SetPermissionProfile(sessionID, profile, shared.RequestedPermissionProfile(msg.Params))
+	}
+	godot := map[string]any{
+		"mutating": mutatingAllowed,
+		"role":     role,
+	}
+	if profile != nil {
+		godot["permission_profile"] = profile.Name
 	}
 	result := map[string]any{
 		"type":            string(mcp.TypeInit),
@@ -352,9 +409,7 @@ func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Res
 			"name":    "godot-mcp-go",
 			"version": "0.2.0",
 		},
-		"godot": map[string]any{
-			"mutating": mutatingAllowed,
-		},
+		"godot": godot,
 	}
 	if sessionID != "" {
 		result["sessionId"] = sessionID
@@ -364,6 +419,9 @@ func (s *Server) handleInit(msg jsonrpc.Request, sessionID string) (*jsonrpc.Res
 }
 
 func (s *Server) handleGodotResource(path string) (any, error) {
+	if result, ok, err := shared.ReadRuntimeLogResource(path); ok {
+		return result, err
+	}
 	switch path {
 	case "godot://script/current":
 		return map[string]any{"type": "script", "path": "current"}, nil
@@ -373,6 +431,8 @@ func (s *Server) handleGodotResource(path string) (any, error) {
 		return map[string]any{"name": "godot-mcp", "version": "0.2.0", "type": "godot"}, nil
 	case "godot://policy/godot-checks":
 		return map[string]any{"policy": "policy-godot", "checks": promptcatalog.GodotPolicyChecks()}, nil
+	case "godot://policy/path-sandbox":
+		return map[string]any{"policy": "path-sandbox", "rules": tooltypes.ActivePathSandboxRules()}, nil
 	case "godot://runtime/metrics":
 		return runtimebridge.HealthSnapshot(time.Now().UTC()), nil
 	default:
@@ -1367,6 +1367,7 @@ func BenchmarkHandleMessageGetEditorStateParallel(b *testing.B) {
 	})
 	cfg := config.NewConfig()
 	cfg.PromptCatalog.Enabled = true
+	cfg.ToolControls.AllowBridgeWithoutRole = true
 	server := NewServer(cfg)
 	server.promptCatalog = promptcatalog.NewRegistry(true)
 	server.toolManager.RegisterDefaultTools()
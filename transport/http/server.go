@@ -14,6 +14,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/internal/infra/audit"
 	"github.com/slighter12/godot-mcp-go/internal/infra/notifications"
 	"github.com/slighter12/godot-mcp-go/logger"
 	"github.com/slighter12/godot-mcp-go/mcp"
@@ -32,6 +33,7 @@ type Server struct {
 	sessionManager *SessionManager
 	config         *config.Config
 	echo           *echo.Echo
+	auth           *authenticator
 
 	promptCatalogReloadMu                   sync.Mutex
 	promptCatalogFileFingerprint            string
@@ -55,6 +57,7 @@ func NewServer(cfg *config.Config) *Server {
 		config:         cfg,
 		echo:           echo.New(),
 	}
+	server.sessionManager.SetSSEReplayLimit(cfg.Server.SSEReplayLimit)
 	runtimebridge.DefaultEditorStore().ConfigureFreshness(
 		time.Duration(cfg.RuntimeBridge.StaleAfterSeconds)*time.Second,
 		time.Duration(cfg.RuntimeBridge.StaleGraceMS)*time.Millisecond,
@@ -63,8 +66,41 @@ func NewServer(cfg *config.Config) *Server {
 		time.Duration(cfg.RuntimeBridge.StaleAfterSeconds)*time.Second,
 		time.Duration(cfg.RuntimeBridge.StaleGraceMS)*time.Millisecond,
 	)
+	runtimebridge.DefaultRuntimeSnapshotStore().ConfigureHistory(cfg.RuntimeBridge.SnapshotHistoryLimit)
+	runtimebridge.DefaultGameSessionHistory().Configure(cfg.RuntimeBridge.SessionHistoryLimit)
+	runtimebridge.DefaultGameSessionWatchdog().Configure(
+		time.Duration(cfg.RuntimeBridge.StaleAfterSeconds)*time.Second+time.Duration(cfg.RuntimeBridge.StaleGraceMS)*time.Millisecond,
+		time.Duration(cfg.RuntimeBridge.HangAfterSeconds)*time.Second,
+	)
+	runtimebridge.DefaultRuntimeScreenshotStore().Configure(
+		cfg.RuntimeBridge.ScreenshotRetention,
+		cfg.RuntimeBridge.ScreenshotMaxBytes,
+		cfg.RuntimeBridge.ScreenshotBaselineDir,
+		"",
+	)
+	logDir := ""
+	if cfg.RuntimeBridge.LogPersistenceEnabled {
+		logDir = cfg.RuntimeBridge.LogDir
+	}
+	runtimebridge.DefaultRuntimeLogArchive().Configure(logDir, cfg.RuntimeBridge.LogMaxFileBytes, cfg.RuntimeBridge.LogMaxFiles)
+	runtimebridge.DefaultRateLimiter().Configure(rateLimitPolicyFromConfig(cfg.ToolControls.RateLimits))
+	runtimebridge.DefaultCommandBroker().ConfigureMaxInFlight(cfg.ToolControls.RateLimits.MaxInFlightCommands)
+	auditDir := ""
+	if cfg.Audit.Enabled {
+		auditDir = cfg.Audit.Dir
+	}
+	audit.Default().Configure(auditDir, cfg.Audit.RetentionDays)
+	tooltypes.SetPathSandboxRules(tooltypes.PathSandboxRules{
+		Include: cfg.ToolControls.PathSandbox.Include,
+		Exclude: cfg.ToolControls.PathSandbox.Exclude,
+	})
+	runtimebridge.DefaultGameProcessLauncher().Configure(cfg.RuntimeBridge.GodotExecutable, server.runtimeHandshakeURL(), "")
 	runtimebridge.SetNotificationSender(server.SendJSONRPCNotificationToSession)
 	runtimebridge.SetSessionInfoProvider(server.sessionManager)
+	runtimebridge.SetRuntimeTransportProbe(func(sessionID string) bool {
+		_, ok := server.sessionManager.GetTransport(sessionID)
+		return ok
+	})
 	tooltypes.SetRuntimeCommandProgressNotifier(server.SendRuntimeCommandProgressNotification)
 	return server
 }
@@ -97,7 +133,16 @@ func (s *Server) Start() error {
 		return err
 	}
 	logger.Info("Default server registered successfully", "server_id", "default")
+	if err := s.configureAuth(); err != nil {
+		logger.Error("Failed to configure authentication", "error", err)
+		return err
+	}
+	s.configureBridgeRoles()
+	if !useStdio {
+		s.configureSessionPersistence()
+	}
 	go s.startCleanupGoroutine()
+	go s.startGameSessionWatchdog()
 	s.setupEcho()
 	if useStdio {
 		return s.startStdioServer()
@@ -120,6 +165,7 @@ func (s *Server) setupEcho() {
 	s.echo.Use(middleware.Logger())
 	s.echo.Use(middleware.Recover())
 	s.echo.Use(s.originValidationMiddleware())
+	s.echo.Use(s.authMiddleware())
 	s.echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOriginFunc: func(origin string) (bool, error) {
 			return s.isAllowedOrigin(origin), nil
@@ -129,6 +175,7 @@ func (s *Server) setupEcho() {
 			echo.HeaderOrigin,
 			echo.HeaderContentType,
 			echo.HeaderAccept,
+			echo.HeaderAuthorization,
 			"MCP-Session-Id",
 			"MCP-Protocol-Version",
 			"Last-Event-ID",
@@ -142,7 +189,17 @@ func (s *Server) startCleanupGoroutine() {
 	defer ticker.Stop()
 	for range ticker.C {
 		s.registry.Cleanup(10 * time.Minute)
-		s.sessionManager.CleanupSessions(10 * time.Minute)
+		s.sessionManager.CleanupSessions(sessionIdleTimeout)
+	}
+}
+
+func (s *Server) startGameSessionWatchdog() {
+	ticker := time.NewTicker(runtimebridge.DefaultGameSessionWatchdogInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		for _, incident := range runtimebridge.DefaultGameSessionWatchdog().Check(time.Now().UTC()) {
+			logger.Warn("Game session watchdog incident", "session_id", incident.SessionID, "state", incident.State, "reason", incident.Reason)
+		}
 	}
 }
 
@@ -158,13 +215,31 @@ func (s *Server) startStdioServer() error {
 func (s *Server) startStreamableHTTPServer() error {
 	logger.Info("Starting MCP server in Streamable HTTP mode", "port", s.config.Server.Port)
 	logger.Debug("Streamable HTTP server configuration", "config", s.config)
+	listeners, err := s.streamableHTTPListeners()
+	if err != nil {
+		return err
+	}
+	if s.config.Server.UnixSocket.DisableTCP {
+		logger.Warn("TCP listener is disabled; the editor plugin and launched games cannot connect over the unix socket")
+	}
+	if tls := s.config.Server.TLS; tls.Enabled && tls.ClientCAFile != "" && tls.ClientAuth != "verify_if_given" {
+		logger.Warn("TLS client certificates are required; the editor plugin and launched games send none and cannot connect over TCP")
+	}
+	return s.serveListeners(listeners)
+}
+
+// runtimeHandshakeURL is the streamable HTTP URL handed to game processes the
+// server launches itself.
+func (s *Server) runtimeHandshakeURL() string {
 	host := strings.TrimSpace(s.config.Server.Host)
-	if host == "" {
+	if host == "" || host == "0.0.0.0" || host == "::" {
 		host = "localhost"
 	}
-	addr := net.JoinHostPort(host, fmt.Sprintf("%d", s.config.Server.Port))
-	logger.Info("Streamable HTTP server starting to listen", "address", addr)
-	return s.echo.Start(addr)
+	scheme := "http"
+	if s.config.Server.TLS.Enabled {
+		scheme = "https"
+	}
+	return scheme + "://" + net.JoinHostPort(host, fmt.Sprintf("%d", s.config.Server.Port)) + "/mcp"
 }
 
 func (s *Server) originValidationMiddleware() echo.MiddlewareFunc {
@@ -288,18 +363,32 @@ func (s *Server) toolCallOptions() shared.ToolCallOptions {
 		PermissionMode:            s.config.ToolControls.PermissionMode,
 		AllowedTools:              s.config.ToolControls.AllowedTools,
 		EmitProgressNotifications: s.config.ToolControls.EmitProgressNotifications,
+		BridgeRoleRequired:        s.bridgeRoleRequired(),
+		PermissionPolicy:          s.permissionPolicy(),
 	}
 }
 
-func (s *Server) toolCallContext(sessionID string) shared.ToolCallContext {
+// toolCallContext builds the tool call context for a request authenticated as
+// identity. Scopes come from the request's own token, so a narrower token
+// used on an existing session is enforced as issued.
+func (s *Server) toolCallContext(sessionID string, identity AuthIdentity) shared.ToolCallContext {
 	callerSessionID := strings.TrimSpace(sessionID)
-	return shared.ToolCallContext{
+	callContext := shared.ToolCallContext{
 		SessionID:               callerSessionID,
 		RuntimeSessionID:        s.resolveRuntimeReadSessionID(callerSessionID),
 		RuntimeCommandSessionID: s.resolveRuntimeCommandSessionID(callerSessionID),
 		SessionInitialized:      s.sessionManager.IsInitialized(callerSessionID),
 		MutatingAllowed:         s.isMutatingAllowedForSession(callerSessionID),
+		SessionRole:             s.sessionManager.Role(callerSessionID),
+		PermissionProfile:       s.sessionManager.PermissionProfile(callerSessionID),
+	}
+	callContext.ClientName, callContext.ClientVersion = s.sessionManager.ClientInfo(callerSessionID)
+	if s.auth != nil {
+		callContext.AuthRequired = true
+		callContext.AuthScopes = identity.Scopes
+		callContext.AuthTokenID = identity.TokenID
 	}
+	return callContext
 }
 
 func (s *Server) isMutatingAllowedForSession(sessionID string) bool {
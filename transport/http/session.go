@@ -4,13 +4,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
 	"github.com/slighter12/godot-mcp-go/runtimebridge"
 )
 
+// defaultSSEReplayLimit matches the server.sse_replay_limit default.
+const defaultSSEReplayLimit = 256
+
 // SessionManager manages MCP sessions for Streamable HTTP
 type SessionManager struct {
-	sessions map[string]*Session
-	mu       sync.RWMutex
+	sessions       map[string]*Session
+	mu             sync.RWMutex
+	sseReplayLimit int
+	// store persists session metadata across restarts; nil keeps sessions
+	// in memory only.
+	store *sessionStore
 }
 
 // Session represents an MCP session
@@ -22,21 +30,48 @@ type Session struct {
 	Initialized        bool
 	ProtocolVer        string
 	Mutating           bool
-	Transport          *StreamableHTTPTransport
+	// Auth is the bearer token identity that initialized the session; nil
+	// when authentication is disabled.
+	Auth *AuthIdentity
+	// Role is the negotiated session role (editor, runtime or agent).
+	Role string
+	// PermissionProfile is the profile selected at initialize; nil when no
+	// profile applies.
+	PermissionProfile *toolspec.PermissionProfile
+	// RequestedProfile is the profile the client asked for at initialize,
+	// kept so selection can be re-run against the config after a restart.
+	RequestedProfile string
+	// ClientName and ClientVersion come from initialize clientInfo.
+	ClientName    string
+	ClientVersion string
+	Transport     *StreamableHTTPTransport
+	// Replay numbers the session's SSE events across GET stream reconnects.
+	Replay *sseReplayBuffer
 }
 
 // NewSessionManager creates a new session manager
 func NewSessionManager() *SessionManager {
 	return &SessionManager{
-		sessions: make(map[string]*Session),
+		sessions:       make(map[string]*Session),
+		sseReplayLimit: defaultSSEReplayLimit,
 	}
 }
 
+// SetSSEReplayLimit sets how many SSE events new sessions keep for
+// Last-Event-ID replay.
+func (sm *SessionManager) SetSSEReplayLimit(limit int) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.sseReplayLimit = max(limit, 0)
+}
+
 // CreateSession creates a new session
 func (sm *SessionManager) CreateSession(sessionID string) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
+	defer sm.markDirtyLocked()
+
 	if session, exists := sm.sessions[sessionID]; exists {
 		session.LastSeen = time.Now()
 		return
@@ -46,6 +81,7 @@ func (sm *SessionManager) CreateSession(sessionID string) {
 		ID:       sessionID,
 		Created:  time.Now(),
 		LastSeen: time.Now(),
+		Replay:   newSSEReplayBuffer(sm.sseReplayLimit),
 	}
 }
 
@@ -57,6 +93,7 @@ func (sm *SessionManager) GetSession(sessionID string) (*Session, bool) {
 	session, exists := sm.sessions[sessionID]
 	if exists {
 		session.LastSeen = time.Now()
+		sm.markDirtyLocked()
 	}
 	return session, exists
 }
@@ -80,6 +117,7 @@ func (sm *SessionManager) TouchSession(sessionID string) bool {
 		return false
 	}
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -97,6 +135,7 @@ func (sm *SessionManager) SetTransport(sessionID string, transport *StreamableHT
 	}
 	session.Transport = transport
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -114,6 +153,7 @@ func (sm *SessionManager) ClearTransport(sessionID string) bool {
 		session.Transport = nil
 	}
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -132,6 +172,7 @@ func (sm *SessionManager) ClearTransportIfMatch(sessionID string, target *Stream
 	session.Transport.Close()
 	session.Transport = nil
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -149,6 +190,7 @@ func (sm *SessionManager) MarkInitialized(sessionID string) bool {
 	}
 	session.Initialized = true
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -165,6 +207,7 @@ func (sm *SessionManager) MarkInitializeAccepted(sessionID string) bool {
 	session.InitializeAccepted = true
 	session.Initialized = false
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -218,6 +261,7 @@ func (sm *SessionManager) SetProtocolVersion(sessionID string, version string) b
 	}
 	session.ProtocolVer = version
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -232,6 +276,7 @@ func (sm *SessionManager) SetMutatingAllowed(sessionID string, allowed bool) boo
 	}
 	session.Mutating = allowed
 	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
 	return true
 }
 
@@ -247,6 +292,119 @@ func (sm *SessionManager) IsMutatingAllowed(sessionID string) bool {
 	return session.Mutating
 }
 
+// SetRole stores the role a session negotiated at initialize.
+func (sm *SessionManager) SetRole(sessionID string, role string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return false
+	}
+	session.Role = role
+	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
+	return true
+}
+
+// Role returns a session's negotiated role; unknown sessions and sessions
+// that never claimed a role are agents.
+func (sm *SessionManager) Role(sessionID string) string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists || session.Role == "" {
+		return toolspec.SessionRoleAgent
+	}
+	return session.Role
+}
+
+// SetPermissionProfile stores the permission profile selected at initialize
+// and the profile the client requested.
+func (sm *SessionManager) SetPermissionProfile(sessionID string, profile *toolspec.PermissionProfile, requested string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return false
+	}
+	session.PermissionProfile = profile
+	session.RequestedProfile = requested
+	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
+	return true
+}
+
+// PermissionProfile returns a session's permission profile, or nil.
+func (sm *SessionManager) PermissionProfile(sessionID string) *toolspec.PermissionProfile {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return nil
+	}
+	return session.PermissionProfile
+}
+
+// SetClientInfo stores the clientInfo sent with initialize.
+func (sm *SessionManager) SetClientInfo(sessionID string, name string, version string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return false
+	}
+	session.ClientName = name
+	session.ClientVersion = version
+	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
+	return true
+}
+
+// ClientInfo returns the clientInfo name and version a session initialized with.
+func (sm *SessionManager) ClientInfo(sessionID string) (string, string) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return "", ""
+	}
+	return session.ClientName, session.ClientVersion
+}
+
+// BindAuthIdentity ties a session to the subject that initialized it. The
+// scopes are dropped: each request is authorized by its own token.
+func (sm *SessionManager) BindAuthIdentity(sessionID string, identity AuthIdentity) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return false
+	}
+	session.Auth = &AuthIdentity{TokenID: identity.TokenID, ExpiresAt: identity.ExpiresAt}
+	session.LastSeen = time.Now()
+	sm.markDirtyLocked()
+	return true
+}
+
+// AuthIdentity returns the subject bound to a session.
+func (sm *SessionManager) AuthIdentity(sessionID string) (AuthIdentity, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists || session.Auth == nil {
+		return AuthIdentity{}, false
+	}
+	return *session.Auth, true
+}
+
 // GetProtocolVersion returns the negotiated protocol version for a session.
 func (sm *SessionManager) GetProtocolVersion(sessionID string) (string, bool) {
 	sm.mu.RLock()
@@ -271,6 +429,36 @@ func (sm *SessionManager) GetTransport(sessionID string) (*StreamableHTTPTranspo
 	return session.Transport, true
 }
 
+// ReplayBuffer returns the SSE replay buffer of an existing session.
+func (sm *SessionManager) ReplayBuffer(sessionID string) (*sseReplayBuffer, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists || session.Replay == nil {
+		return nil, false
+	}
+	return session.Replay, true
+}
+
+// QueueSSEEvent numbers an event and keeps it in the session replay buffer
+// before any write, so it can be replayed even when no GET stream is bound or
+// the write fails. It returns the bound transport, if any, to write it to.
+func (sm *SessionManager) QueueSSEEvent(sessionID string, event string, data []byte) (sseReplayEvent, *StreamableHTTPTransport, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	session, exists := sm.sessions[sessionID]
+	if !exists {
+		return sseReplayEvent{}, nil, false
+	}
+	queued := sseReplayEvent{event: event, data: data}
+	if session.Replay != nil {
+		queued = session.Replay.add(event, data)
+	}
+	return queued, session.Transport, true
+}
+
 // SessionIDsWithTransport returns current session IDs with bound SSE transport.
 func (sm *SessionManager) SessionIDsWithTransport() []string {
 	sm.mu.RLock()
@@ -307,24 +495,23 @@ func (sm *SessionManager) LatestSessionIDWithTransport() (string, bool) {
 	return latestID, true
 }
 
-// RemoveSession removes a session
+// RemoveSession removes a session. Its runtime state is torn down after
+// sm.mu is released, since stopping a launched game can take the whole
+// process grace period.
 func (sm *SessionManager) RemoveSession(sessionID string) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
-	if session, exists := sm.sessions[sessionID]; exists {
-		if gameSession, ok := runtimebridge.DefaultGameSessionRegistry().ActiveForEditor(sessionID); ok {
-			runtimebridge.DefaultRuntimeSnapshotStore().RemoveSession(gameSession.SessionID)
-			runtimebridge.DefaultRuntimeLogStore().RemoveSession(gameSession.SessionID)
-			runtimebridge.DefaultGameSessionRegistry().RemoveSession(gameSession.SessionID)
-		} else {
-			runtimebridge.DefaultGameSessionRegistry().RemoveByEditorSession(sessionID)
-		}
+	session, exists := sm.sessions[sessionID]
+	if exists {
 		if session.Transport != nil {
 			session.Transport.Close()
 		}
 		delete(sm.sessions, sessionID)
-		runtimebridge.DefaultEditorStore().RemoveSession(sessionID)
+		sm.markDirtyLocked()
+	}
+	sm.mu.Unlock()
+
+	if exists {
+		runtimebridge.ForgetMCPSession(sessionID)
 	}
 }
 
@@ -335,7 +522,7 @@ func (sm *SessionManager) SessionSummaries() []map[string]any {
 
 	summaries := make([]map[string]any, 0, len(sm.sessions))
 	for _, session := range sm.sessions {
-		summaries = append(summaries, map[string]any{
+		summary := map[string]any{
 			"session_id":          session.ID,
 			"created":             session.Created.UTC().Format(time.RFC3339Nano),
 			"last_seen":           session.LastSeen.UTC().Format(time.RFC3339Nano),
@@ -343,7 +530,26 @@ func (sm *SessionManager) SessionSummaries() []map[string]any {
 			"initialized":         session.Initialized,
 			"has_transport":       session.Transport != nil,
 			"mutating":            session.Mutating,
-		})
+			"role":                session.Role,
+		}
+		if session.Role == "" {
+			summary["role"] = toolspec.SessionRoleAgent
+		}
+		if session.Auth != nil {
+			summary["auth_token_id"] = session.Auth.TokenID
+		}
+		if session.PermissionProfile != nil {
+			summary["permission_profile"] = session.PermissionProfile.Name
+		}
+		if session.ClientName != "" {
+			summary["client_name"] = session.ClientName
+		}
+		if session.Replay != nil {
+			lastEventID, buffered := session.Replay.stats()
+			summary["sse_last_event_id"] = lastEventID
+			summary["sse_replay_buffered"] = buffered
+		}
+		summaries = append(summaries, summary)
 	}
 	return summaries
 }
@@ -371,26 +577,27 @@ func (sm *SessionManager) SessionCounts() map[string]any {
 	}
 }
 
-// CleanupSessions removes expired sessions
+// CleanupSessions removes expired sessions. As in RemoveSession, their
+// runtime state is torn down without holding sm.mu.
 func (sm *SessionManager) CleanupSessions(timeout time.Duration) {
 	sm.mu.Lock()
-	defer sm.mu.Unlock()
-
 	now := time.Now()
+	var expired []string
 	for sessionID, session := range sm.sessions {
 		if now.Sub(session.LastSeen) > timeout {
-			if gameSession, ok := runtimebridge.DefaultGameSessionRegistry().ActiveForEditor(sessionID); ok {
-				runtimebridge.DefaultRuntimeSnapshotStore().RemoveSession(gameSession.SessionID)
-				runtimebridge.DefaultRuntimeLogStore().RemoveSession(gameSession.SessionID)
-				runtimebridge.DefaultGameSessionRegistry().RemoveSession(gameSession.SessionID)
-			} else {
-				runtimebridge.DefaultGameSessionRegistry().RemoveByEditorSession(sessionID)
-			}
 			if session.Transport != nil {
 				session.Transport.Close()
 			}
 			delete(sm.sessions, sessionID)
-			runtimebridge.DefaultEditorStore().RemoveSession(sessionID)
+			expired = append(expired, sessionID)
 		}
 	}
+	if len(expired) > 0 {
+		sm.markDirtyLocked()
+	}
+	sm.mu.Unlock()
+
+	for _, sessionID := range expired {
+		runtimebridge.ForgetMCPSession(sessionID)
+	}
 }
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+	"github.com/slighter12/godot-mcp-go/logger"
+)
+
+const (
+	sessionSnapshotVersion = 1
+	// sessionIdleTimeout is how long a session survives without requests.
+	sessionIdleTimeout = 10 * time.Minute
+	// sessionSaveInterval coalesces snapshot writes.
+	sessionSaveInterval = time.Second
+	// sseRestartIDGap moves SSE event ids forward after a restart so events
+	// written after the last snapshot are never renumbered.
+	sseRestartIDGap = 1 << 16
+)
+
+// sessionSnapshot is the file written by server.session_persistence.
+type sessionSnapshot struct {
+	Version  int                `json:"version"`
+	SavedAt  time.Time          `json:"saved_at"`
+	Sessions []persistedSession `json:"sessions"`
+}
+
+// persistedSession holds what a client needs to keep using its
+// MCP-Session-Id after a restart. Stream transports are not persisted; the
+// client reopens its GET stream.
+type persistedSession struct {
+	ID                 string                `json:"id"`
+	Created            time.Time             `json:"created"`
+	LastSeen           time.Time             `json:"last_seen"`
+	InitializeAccepted bool                  `json:"initialize_accepted"`
+	Initialized        bool                  `json:"initialized"`
+	ProtocolVersion    string                `json:"protocol_version"`
+	Mutating           bool                  `json:"mutating"`
+	RequestedProfile   string                `json:"requested_permission_profile,omitempty"`
+	ClientName         string                `json:"client_name,omitempty"`
+	ClientVersion      string                `json:"client_version,omitempty"`
+	Auth               *persistedAuthBinding `json:"auth,omitempty"`
+	SSELastEventID     uint64                `json:"sse_last_event_id,omitempty"`
+}
+
+// persistedAuthBinding is the subject a session was initialized with. Token
+// secrets and scopes are never written; every request still authenticates
+// and is authorized by its own token.
+type persistedAuthBinding struct {
+	TokenID   string    `json:"token_id"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// sessionStore writes session snapshots to one JSON file.
+type sessionStore struct {
+	path  string
+	dirty chan struct{}
+}
+
+func newSessionStore(path string) *sessionStore {
+	return &sessionStore{path: path, dirty: make(chan struct{}, 1)}
+}
+
+func (st *sessionStore) load() (sessionSnapshot, error) {
+	raw, err := os.ReadFile(st.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return sessionSnapshot{Version: sessionSnapshotVersion}, nil
+	}
+	if err != nil {
+		return sessionSnapshot{}, fmt.Errorf("read session snapshot: %w", err)
+	}
+	var snapshot sessionSnapshot
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return sessionSnapshot{}, fmt.Errorf("decode session snapshot %s: %w", st.path, err)
+	}
+	if snapshot.Version != sessionSnapshotVersion {
+		return sessionSnapshot{}, fmt.Errorf("unsupported session snapshot version %d in %s", snapshot.Version, st.path)
+	}
+	return snapshot, nil
+}
+
+// save replaces the snapshot file atomically so a crash mid-write keeps the
+// previous snapshot.
+func (st *sessionStore) save(snapshot sessionSnapshot) error {
+	raw, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode session snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(st.path), 0o700); err != nil {
+		return fmt.Errorf("create session snapshot dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(st.path), filepath.Base(st.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create session snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(raw, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write session snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write session snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), st.path); err != nil {
+		return fmt.Errorf("replace session snapshot: %w", err)
+	}
+	return nil
+}
+
+// EnablePersistence restores the sessions saved at path and keeps the file
+// updated from then on. The snapshot is not trusted for privileges: restored
+// sessions are agents until they initialize again with the bridge secret, and
+// their permission profile is selected again from policy. Sessions idle past
+// the cleanup timeout, or whose requested profile is no longer selectable,
+// are not restored. A snapshot that cannot be read is reported and replaced
+// by the next save.
+func (sm *SessionManager) EnablePersistence(path string, policy toolspec.PermissionPolicy) (int, error) {
+	store := newSessionStore(path)
+	snapshot, loadErr := store.load()
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.store = store
+	now := time.Now()
+	restored := 0
+	for _, saved := range snapshot.Sessions {
+		if saved.ID == "" || now.Sub(saved.LastSeen) > sessionIdleTimeout {
+			continue
+		}
+		if _, exists := sm.sessions[saved.ID]; exists {
+			continue
+		}
+		session := &Session{
+			ID:                 saved.ID,
+			Created:            saved.Created,
+			LastSeen:           saved.LastSeen,
+			InitializeAccepted: saved.InitializeAccepted,
+			Initialized:        saved.Initialized,
+			ProtocolVer:        saved.ProtocolVersion,
+			Mutating:           saved.Mutating,
+			Role:               toolspec.SessionRoleAgent,
+			RequestedProfile:   saved.RequestedProfile,
+			ClientName:         saved.ClientName,
+			ClientVersion:      saved.ClientVersion,
+			Replay:             newResumedSSEReplayBuffer(sm.sseReplayLimit, saved.SSELastEventID+sseRestartIDGap),
+		}
+		if saved.Auth != nil {
+			session.Auth = &AuthIdentity{TokenID: saved.Auth.TokenID, ExpiresAt: saved.Auth.ExpiresAt}
+		}
+		if saved.InitializeAccepted {
+			authTokenID := ""
+			if session.Auth != nil {
+				authTokenID = session.Auth.TokenID
+			}
+			profile, err := policy.Select(saved.ClientName, authTokenID, saved.RequestedProfile)
+			if err != nil {
+				continue
+			}
+			session.PermissionProfile = profile
+		}
+		sm.sessions[saved.ID] = session
+		restored++
+	}
+	sm.markDirtyLocked()
+	return restored, loadErr
+}
+
+// markDirtyLocked schedules a snapshot write; callers hold sm.mu.
+func (sm *SessionManager) markDirtyLocked() {
+	if sm.store == nil {
+		return
+	}
+	select {
+	case sm.store.dirty <- struct{}{}:
+	default:
+	}
+}
+
+func (sm *SessionManager) snapshotLocked() sessionSnapshot {
+	snapshot := sessionSnapshot{
+		Version:  sessionSnapshotVersion,
+		SavedAt:  time.Now().UTC(),
+		Sessions: make([]persistedSession, 0, len(sm.sessions)),
+	}
+	for _, session := range sm.sessions {
+		saved := persistedSession{
+			ID:                 session.ID,
+			Created:            session.Created.UTC(),
+			LastSeen:           session.LastSeen.UTC(),
+			InitializeAccepted: session.InitializeAccepted,
+			Initialized:        session.Initialized,
+			ProtocolVersion:    session.ProtocolVer,
+			Mutating:           session.Mutating,
+			RequestedProfile:   session.RequestedProfile,
+			ClientName:         session.ClientName,
+			ClientVersion:      session.ClientVersion,
+		}
+		if session.Auth != nil {
+			saved.Auth = &persistedAuthBinding{TokenID: session.Auth.TokenID, ExpiresAt: session.Auth.ExpiresAt}
+		}
+		if session.Replay != nil {
+			saved.SSELastEventID, _ = session.Replay.stats()
+		}
+		snapshot.Sessions = append(snapshot.Sessions, saved)
+	}
+	sort.Slice(snapshot.Sessions, func(i, j int) bool {
+		return snapshot.Sessions[i].ID < snapshot.Sessions[j].ID
+	})
+	return snapshot
+}
+
+// saveSnapshot writes the current sessions when persistence is enabled.
+func (sm *SessionManager) saveSnapshot() error {
+	sm.mu.RLock()
+	store := sm.store
+	if store == nil {
+		sm.mu.RUnlock()
+		return nil
+	}
+	snapshot := sm.snapshotLocked()
+	sm.mu.RUnlock()
+	return store.save(snapshot)
+}
+
+// runPersistence writes a snapshot after session changes, at most once per
+// sessionSaveInterval.
+func (sm *SessionManager) runPersistence() {
+	sm.mu.RLock()
+	store := sm.store
+	sm.mu.RUnlock()
+	if store == nil {
+		return
+	}
+	for range store.dirty {
+		if err := sm.saveSnapshot(); err != nil {
+			logger.Warn("Failed to persist MCP sessions", "path", store.path, "error", err)
+		}
+		time.Sleep(sessionSaveInterval)
+	}
+}
+
+func (s *Server) configureSessionPersistence() {
+	persistence := s.config.Server.SessionPersistence
+	if !persistence.Enabled {
+		return
+	}
+	restored, err := s.sessionManager.EnablePersistence(persistence.Path, s.permissionPolicy())
+	if err != nil {
+		logger.Warn("Ignoring unreadable session snapshot", "path", persistence.Path, "error", err)
+	}
+	logger.Info("Session persistence enabled", "path", persistence.Path, "restored_sessions", restored)
+	go s.sessionManager.runPersistence()
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/config"
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
+)
+
+func TestSessionManager_PersistenceRestoresSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	policy := toolspec.PermissionPolicy{Profiles: map[string]toolspec.PermissionProfile{
+		"reviewer": {Name: "reviewer", Classes: []string{"read"}, Selectable: true},
+	}}
+
+	sm := NewSessionManager()
+	if _, err := sm.EnablePersistence(path, policy); err != nil {
+		t.Fatalf("EnablePersistence: %v", err)
+	}
+	sm.CreateSession("agent-1")
+	sm.MarkInitializeAccepted("agent-1")
+	sm.MarkInitialized("agent-1")
+	sm.SetProtocolVersion("agent-1", "2025-11-25")
+	sm.SetMutatingAllowed("agent-1", true)
+	sm.SetClientInfo("agent-1", "codex", "1.2.0")
+	profile := policy.Profiles["reviewer"]
+	sm.SetPermissionProfile("agent-1", &profile, "reviewer")
+	sm.BindAuthIdentity("agent-1", AuthIdentity{TokenID: "editor", Scopes: []string{"read"}})
+	replay, _ := sm.ReplayBuffer("agent-1")
+	replay.add("message", []byte(`{}`))
+	sm.CreateSession("idle")
+	sm.sessions["idle"].LastSeen = time.Now().Add(-2 * sessionIdleTimeout)
+	sm.CreateSession("stale-profile")
+	sm.MarkInitializeAccepted("stale-profile")
+	stale := toolspec.PermissionProfile{Name: "removed"}
+	sm.SetPermissionProfile("stale-profile", &stale, "removed")
+	if err := sm.saveSnapshot(); err != nil {
+		t.Fatalf("saveSnapshot: %v", err)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected private snapshot file, got %v %v", info, err)
+	}
+
+	restored := NewSessionManager()
+	count, err := restored.EnablePersistence(path, policy)
+	if err != nil || count != 1 {
+		t.Fatalf("expected one restored session, got %d %v", count, err)
+	}
+	if restored.HasSession("idle") || restored.HasSession("stale-profile") {
+		t.Fatalf("expected idle and stale-profile sessions to be dropped")
+	}
+	if !restored.IsFullyInitialized("agent-1") || !restored.IsMutatingAllowed("agent-1") {
+		t.Fatalf("expected negotiated state to be restored")
+	}
+	if version, _ := restored.GetProtocolVersion("agent-1"); version != "2025-11-25" {
+		t.Fatalf("expected protocol version, got %q", version)
+	}
+	if name, version := restored.ClientInfo("agent-1"); name != "codex" || version != "1.2.0" {
+		t.Fatalf("expected client info, got %q %q", name, version)
+	}
+	if got := restored.PermissionProfile("agent-1"); got == nil || got.Name != "reviewer" {
+		t.Fatalf("expected reviewer profile, got %#v", got)
+	}
+	if identity, ok := restored.AuthIdentity("agent-1"); !ok || identity.TokenID != "editor" || identity.Scopes != nil {
+		t.Fatalf("expected auth binding without scopes, got %#v %v", identity, ok)
+	}
+	restoredReplay, _ := restored.ReplayBuffer("agent-1")
+	if id := restoredReplay.add("message", []byte(`{}`)).id; id <= 1 {
+		t.Fatalf("expected SSE ids to move past the persisted id, got %d", id)
+	}
+	if _, complete := restoredReplay.after(1); complete {
+		t.Fatalf("expected pre-restart events to be reported as lost")
+	}
+}
+
+func TestSessionManager_PersistenceDoesNotTrustSnapshotPrivileges(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	snapshot := `{"version":1,"sessions":[{"id":"agent-1","last_seen":"` + time.Now().UTC().Format(time.RFC3339Nano) + `",` +
+		`"initialize_accepted":true,"initialized":true,"role":"editor","permission_profile":"admin",` +
+		`"requested_permission_profile":"","client_name":"codex","auth":{"token_id":"reader","scopes":["*"]}}]}`
+	if err := os.WriteFile(path, []byte(snapshot), 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	policy := toolspec.PermissionPolicy{
+		Profiles: map[string]toolspec.PermissionProfile{
+			"admin":    {Name: "admin"},
+			"reviewer": {Name: "reviewer", Classes: []string{"read"}},
+		},
+		Bindings: []toolspec.PermissionBinding{{Profile: "reviewer", AuthTokenID: "reader"}},
+	}
+
+	sm := NewSessionManager()
+	if count, err := sm.EnablePersistence(path, policy); err != nil || count != 1 {
+		t.Fatalf("expected restored session, got %d %v", count, err)
+	}
+	if role := sm.Role("agent-1"); role != toolspec.SessionRoleAgent {
+		t.Fatalf("expected restored session to be an agent, got %q", role)
+	}
+	if got := sm.PermissionProfile("agent-1"); got == nil || got.Name != "reviewer" {
+		t.Fatalf("expected profile selected from current bindings, got %#v", got)
+	}
+	if identity, _ := sm.AuthIdentity("agent-1"); identity.Scopes != nil {
+		t.Fatalf("expected snapshot scopes to be ignored, got %v", identity.Scopes)
+	}
+}
+
+func TestSessionManager_PersistenceReplacesUnreadableSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	sm := NewSessionManager()
+	if count, err := sm.EnablePersistence(path, toolspec.PermissionPolicy{}); err == nil || count != 0 {
+		t.Fatalf("expected decode error, got %d %v", count, err)
+	}
+	sm.CreateSession("fresh")
+	if err := sm.saveSnapshot(); err != nil {
+		t.Fatalf("saveSnapshot: %v", err)
+	}
+	if count, err := NewSessionManager().EnablePersistence(path, toolspec.PermissionPolicy{}); err != nil || count != 1 {
+		t.Fatalf("expected rewritten snapshot, got %d %v", count, err)
+	}
+}
+
+func TestStreamableHTTP_RestoredSessionSkipsReinitialize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	first := newTestHTTPServer(t, false)
+	if _, err := first.sessionManager.EnablePersistence(path, first.permissionPolicy()); err != nil {
+		t.Fatalf("EnablePersistence: %v", err)
+	}
+	_, sessionID, status := postMCP(t, first, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": "2025-11-25",
+			"capabilities":    map[string]any{},
+			"clientInfo":      map[string]any{"name": "test-client", "version": "1.0.0"},
+		},
+	}, "", "")
+	if status != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize failed: status=%d session=%q", status, sessionID)
+	}
+	if _, _, status := postMCP(t, first, map[string]any{"jsonrpc": "2.0", "method": "notifications/initialized"}, sessionID, "2025-11-25"); status != http.StatusAccepted {
+		t.Fatalf("initialized notification failed: %d", status)
+	}
+	if err := first.sessionManager.saveSnapshot(); err != nil {
+		t.Fatalf("saveSnapshot: %v", err)
+	}
+
+	second := newTestHTTPServer(t, false)
+	if count, err := second.sessionManager.EnablePersistence(path, second.permissionPolicy()); err != nil || count != 1 {
+		t.Fatalf("expected restored session, got %d %v", count, err)
+	}
+	resp, _, status := postMCP(t, second, map[string]any{"jsonrpc": "2.0", "id": 2, "method": "ping"}, sessionID, "2025-11-25")
+	if status != http.StatusOK || resp["error"] != nil {
+		t.Fatalf("expected restored session to accept ping, got status=%d resp=%v", status, resp)
+	}
+}
+
+func TestStreamableHTTP_RestoredUnboundSessionRejectedWhenAuthEnabled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	first := newTestHTTPServer(t, false)
+	if _, err := first.sessionManager.EnablePersistence(path, first.permissionPolicy()); err != nil {
+		t.Fatalf("EnablePersistence: %v", err)
+	}
+	_, sessionID, status := postMCP(t, first, map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": "2025-11-25",
+			"capabilities":    map[string]any{},
+			"clientInfo":      map[string]any{"name": "test-client", "version": "1.0.0"},
+		},
+	}, "", "")
+	if status != http.StatusOK || sessionID == "" {
+		t.Fatalf("initialize failed: status=%d session=%q", status, sessionID)
+	}
+	if err := first.sessionManager.saveSnapshot(); err != nil {
+		t.Fatalf("saveSnapshot: %v", err)
+	}
+
+	second := newAuthTestHTTPServer(t, config.Auth{
+		Enabled: true,
+		Tokens:  []config.AuthToken{{ID: "reader", Token: "reader-secret", Scopes: []string{"read"}}},
+	})
+	if count, err := second.sessionManager.EnablePersistence(path, second.permissionPolicy()); err != nil || count != 1 {
+		t.Fatalf("expected restored session, got %d %v", count, err)
+	}
+	_, rec := serveAuthMCP(t, second, map[string]any{"jsonrpc": "2.0", "id": 2, "method": "ping"}, sessionID, "reader-secret")
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("expected unbound restored session to be rejected, got status=%d body=%s", rec.Code, rec.Body.String())
+	}
+
+	fresh := initializeAuthSession(t, second, "reader-secret")
+	if _, rec := serveAuthMCP(t, second, map[string]any{"jsonrpc": "2.0", "id": 3, "method": "ping"}, fresh, "reader-secret"); rec.Code != http.StatusOK {
+		t.Fatalf("expected fresh session to accept ping, got status=%d", rec.Code)
+	}
+}
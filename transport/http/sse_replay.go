@@ -0,0 +1,91 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// sseReplayEvent is one SSE frame queued for a session stream.
+type sseReplayEvent struct {
+	id    uint64
+	event string
+	data  []byte
+}
+
+// sseReplayBuffer assigns monotonic event ids for one MCP session and keeps
+// the last limit events so a client reconnecting with Last-Event-ID receives
+// what its dropped GET stream missed. Events are kept before they are
+// written, so events sent while no stream is bound or whose write fails are
+// still replayed. It outlives the individual stream transports of the
+// session.
+type sseReplayBuffer struct {
+	mu      sync.Mutex
+	limit   int
+	lastID  uint64
+	evicted uint64
+	events  []sseReplayEvent
+}
+
+func newSSEReplayBuffer(limit int) *sseReplayBuffer {
+	return &sseReplayBuffer{limit: max(limit, 0)}
+}
+
+// newResumedSSEReplayBuffer continues numbering after lastID for a session
+// restored from a snapshot; the events themselves were not kept.
+func newResumedSSEReplayBuffer(limit int, lastID uint64) *sseReplayBuffer {
+	return &sseReplayBuffer{limit: max(limit, 0), lastID: lastID, evicted: lastID}
+}
+
+// add assigns the next id to an event and keeps it, dropping the oldest
+// beyond the limit.
+func (b *sseReplayBuffer) add(event string, data []byte) sseReplayEvent {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.lastID++
+	added := sseReplayEvent{id: b.lastID, event: event, data: data}
+	if b.limit == 0 {
+		b.evicted = added.id
+		return added
+	}
+	if len(b.events) >= b.limit {
+		drop := len(b.events) - b.limit + 1
+		b.evicted = b.events[drop-1].id
+		b.events = append(b.events[:0], b.events[drop:]...)
+	}
+	b.events = append(b.events, added)
+	return added
+}
+
+// after returns the retained events newer than lastID, oldest first. complete
+// is false when events after lastID were already evicted.
+func (b *sseReplayBuffer) after(lastID uint64) (events []sseReplayEvent, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, event := range b.events {
+		if event.id > lastID {
+			events = append(events, event)
+		}
+	}
+	return events, lastID >= b.evicted
+}
+
+func (b *sseReplayBuffer) stats() (lastID uint64, buffered int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.lastID, len(b.events)
+}
+
+// parseLastEventID reads the Last-Event-ID request header; ok is false when
+// the header is absent or was not issued by this server.
+func parseLastEventID(header string) (uint64, bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(header, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func bindReplayTransport(t *testing.T, server *Server, sessionID string, lastEventID uint64, resume bool) (*StreamableHTTPTransport, *httptest.ResponseRecorder, int, bool) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	transport := NewStreamableHTTPTransport(rec, rec)
+	transport.replay, _ = server.sessionManager.ReplayBuffer(sessionID)
+	replayed, complete, err := transport.PublishAndReplay(lastEventID, resume, func() bool {
+		return server.sessionManager.SetTransport(sessionID, transport)
+	})
+	if err != nil {
+		t.Fatalf("PublishAndReplay: %v", err)
+	}
+	return transport, rec, replayed, complete
+}
+
+func TestSSEReplay_ResumesAfterLastEventID(t *testing.T) {
+	server := newTestHTTPServer(t, false)
+	server.sessionManager.SetSSEReplayLimit(2)
+	sessionID := "session-replay"
+	server.sessionManager.CreateSession(sessionID)
+
+	first, firstRec, _, _ := bindReplayTransport(t, server, sessionID, 0, false)
+	for _, method := range []string{"notifications/one", "notifications/two", "notifications/three"} {
+		if !server.SendJSONRPCNotificationToSession(sessionID, map[string]any{"jsonrpc": "2.0", "method": method}) {
+			t.Fatalf("expected %s to be delivered", method)
+		}
+	}
+	if body := firstRec.Body.String(); !strings.Contains(body, "id: 1\nevent: message\n") || !strings.Contains(body, "id: 3\nevent: message\n") {
+		t.Fatalf("expected numbered events, got %q", body)
+	}
+
+	_, secondRec, replayed, complete := bindReplayTransport(t, server, sessionID, 1, true)
+	if !first.IsClosed() {
+		t.Fatalf("expected reconnect to close the previous stream")
+	}
+	body := secondRec.Body.String()
+	if replayed != 2 || !complete || strings.Contains(body, "notifications/one") || !strings.Contains(body, "id: 2\n") || !strings.Contains(body, "notifications/three") {
+		t.Fatalf("expected events 2 and 3 replayed, got replayed=%d complete=%v body=%q", replayed, complete, body)
+	}
+	if !server.SendJSONRPCNotificationToSession(sessionID, map[string]any{"jsonrpc": "2.0", "method": "notifications/four"}) {
+		t.Fatalf("expected live delivery after replay")
+	}
+	if !strings.Contains(secondRec.Body.String(), "id: 4\nevent: message\n") {
+		t.Fatalf("expected ids to continue after replay, got %q", secondRec.Body.String())
+	}
+
+	_, _, replayed, complete = bindReplayTransport(t, server, sessionID, 0, true)
+	if replayed != 2 || complete {
+		t.Fatalf("expected evicted events to be reported, got replayed=%d complete=%v", replayed, complete)
+	}
+}
+
+func TestSSEReplay_KeepsEventsSentWhileDisconnected(t *testing.T) {
+	server := newTestHTTPServer(t, false)
+	sessionID := "session-offline"
+	server.sessionManager.CreateSession(sessionID)
+
+	first, _, _, _ := bindReplayTransport(t, server, sessionID, 0, false)
+	if !server.SendJSONRPCNotificationToSession(sessionID, map[string]any{"jsonrpc": "2.0", "method": "notifications/one"}) {
+		t.Fatalf("expected live delivery")
+	}
+	// A write to the dropped stream fails and unbinds it; the next event
+	// finds no stream at all.
+	first.Close()
+	if server.SendJSONRPCNotificationToSession(sessionID, map[string]any{"jsonrpc": "2.0", "method": "notifications/two"}) {
+		t.Fatalf("expected delivery to the closed stream to fail")
+	}
+	if _, ok := server.sessionManager.GetTransport(sessionID); ok {
+		t.Fatalf("expected the failed stream to be unbound")
+	}
+	if server.SendJSONRPCNotificationToSession(sessionID, map[string]any{"jsonrpc": "2.0", "method": "notifications/three"}) {
+		t.Fatalf("expected no delivery without a stream")
+	}
+
+	_, rec, replayed, complete := bindReplayTransport(t, server, sessionID, 1, true)
+	body := rec.Body.String()
+	if replayed != 2 || !complete || !strings.Contains(body, "id: 2\nevent: message\n") || !strings.Contains(body, "notifications/three") {
+		t.Fatalf("expected events sent while disconnected to be replayed, got replayed=%d complete=%v body=%q", replayed, complete, body)
+	}
+}
+
+func TestSSEReplay_SkipsQueuedEventsCoveredByReplay(t *testing.T) {
+	server := newTestHTTPServer(t, false)
+	sessionID := "session-race"
+	server.sessionManager.CreateSession(sessionID)
+
+	event, _, ok := server.sessionManager.QueueSSEEvent(sessionID, "message", []byte(`{"method":"notifications/one"}`))
+	if !ok {
+		t.Fatalf("expected event to be queued")
+	}
+	transport, rec, replayed, _ := bindReplayTransport(t, server, sessionID, 0, true)
+	if err := transport.SendReplayEvent(event, 0); err != nil {
+		t.Fatalf("SendReplayEvent: %v", err)
+	}
+	if replayed != 1 || strings.Count(rec.Body.String(), "notifications/one") != 1 {
+		t.Fatalf("expected the replayed event once, got replayed=%d body=%q", replayed, rec.Body.String())
+	}
+}
+
+func TestSSEReplay_WritesConcurrentlyNumberedEventsInIDOrder(t *testing.T) {
+	server := newTestHTTPServer(t, false)
+	sessionID := "session-order"
+	server.sessionManager.CreateSession(sessionID)
+	transport, rec, _, _ := bindReplayTransport(t, server, sessionID, 0, false)
+
+	first, _, _ := server.sessionManager.QueueSSEEvent(sessionID, "message", []byte(`{"method":"notifications/one"}`))
+	second, _, _ := server.sessionManager.QueueSSEEvent(sessionID, "message", []byte(`{"method":"notifications/two"}`))
+	if err := transport.SendReplayEvent(second, 0); err != nil {
+		t.Fatalf("SendReplayEvent second: %v", err)
+	}
+	if err := transport.SendReplayEvent(first, 0); err != nil {
+		t.Fatalf("SendReplayEvent first: %v", err)
+	}
+
+	body := rec.Body.String()
+	one := strings.Index(body, fmt.Sprintf("id: %d\n", first.id))
+	two := strings.Index(body, fmt.Sprintf("id: %d\n", second.id))
+	if one < 0 || two < 0 || one > two || strings.Count(body, "notifications/one") != 1 || strings.Count(body, "notifications/two") != 1 {
+		t.Fatalf("expected each event once in id order, got %q", body)
+	}
+}
+
+func TestSSEReplay_IgnoresForeignLastEventID(t *testing.T) {
+	for _, header := range []string{"", " ", "abc", "-1"} {
+		if _, ok := parseLastEventID(header); ok {
+			t.Fatalf("expected %q to be ignored", header)
+		}
+	}
+	if id, ok := parseLastEventID(" 42 "); !ok || id != 42 {
+		t.Fatalf("expected 42, got %d %v", id, ok)
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,6 +10,10 @@ import (
 	"time"
 )
 
+// errSSETransportNotBound reports a stream whose session disappeared before
+// the transport could be published.
+var errSSETransportNotBound = errors.New("SSE transport was not bound to a session")
+
 // StreamableHTTPTransport provides optional SSE writer utilities.
 // The current request handling path in router.go is still response-based.
 type StreamableHTTPTransport struct {
@@ -18,6 +23,12 @@ type StreamableHTTPTransport struct {
 	closed  bool
 	onClose func()
 	once    sync.Once
+	// replay numbers events and keeps them for Last-Event-ID resumption;
+	// nil writes events without ids.
+	replay *sseReplayBuffer
+	// writtenThrough is the highest event id written to this stream, by the
+	// resume replay or a live write; queued events at or below it are done.
+	writtenThrough uint64
 }
 
 // NewStreamableHTTPTransport creates a new Streamable HTTP transport
@@ -53,13 +64,109 @@ func (t *StreamableHTTPTransport) SendSSEWithTimeout(event string, data any, tim
 		return fmt.Errorf("failed to marshal SSE data: %w", err)
 	}
 
-	sseMessage := fmt.Sprintf("event: %s\ndata: %s\n\n", event, string(dataJSON))
-	if err := t.writeLocked(sseMessage, timeout); err != nil {
+	if t.replay == nil {
+		return t.writeEventLocked(sseReplayEvent{event: event, data: dataJSON}, timeout)
+	}
+	return t.writeEventLocked(t.replay.add(event, dataJSON), timeout)
+}
+
+// SendReplayEvent writes an event the session manager already numbered and
+// kept in the session replay buffer.
+func (t *StreamableHTTPTransport) SendReplayEvent(event sseReplayEvent, timeout time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.closed {
+		return fmt.Errorf("transport is closed")
+	}
+	return t.writeEventLocked(event, timeout)
+}
+
+// writeEventLocked writes a numbered event together with every kept event
+// before it that this stream has not written yet, in id order. Ids are
+// assigned before the writer lock is taken, so concurrent senders may reach
+// it out of order; draining the replay buffer keeps the stream ascending and
+// a later Last-Event-ID from skipping an unwritten event.
+func (t *StreamableHTTPTransport) writeEventLocked(event sseReplayEvent, timeout time.Duration) error {
+	if event.id == 0 {
+		return t.writeFrameLocked(event, timeout)
+	}
+	if event.id <= t.writtenThrough {
+		return nil
+	}
+	if t.replay != nil {
+		pending, _ := t.replay.after(t.writtenThrough)
+		for _, queued := range pending {
+			if queued.id > event.id {
+				break
+			}
+			if err := t.writeFrameLocked(queued, timeout); err != nil {
+				return err
+			}
+			t.writtenThrough = queued.id
+		}
+	}
+	if event.id <= t.writtenThrough {
+		return nil
+	}
+	if err := t.writeFrameLocked(event, timeout); err != nil {
+		return err
+	}
+	t.writtenThrough = event.id
+	return nil
+}
+
+func (t *StreamableHTTPTransport) writeFrameLocked(event sseReplayEvent, timeout time.Duration) error {
+	if err := t.writeLocked(formatSSEEvent(event.id, event.event, event.data), timeout); err != nil {
 		return fmt.Errorf("failed to write SSE message: %w", err)
 	}
 	return nil
 }
 
+// PublishAndReplay binds the transport through publish and then writes the
+// events kept after lastEventID. The writer stays locked throughout, so
+// notifications sent to the new stream queue behind the replay and are
+// skipped when the replay already covered them; the old stream is closed by
+// publish before the replay snapshot is taken.
+func (t *StreamableHTTPTransport) PublishAndReplay(lastEventID uint64, resume bool, publish func() bool) (replayed int, complete bool, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Events numbered before publish went to the previous stream; a fresh
+	// stream starts after them. Read before publish so an event queued for
+	// this stream right after it is not mistaken for one of them.
+	var queuedBefore uint64
+	if t.replay != nil {
+		queuedBefore, _ = t.replay.stats()
+	}
+	if t.closed || !publish() {
+		return 0, false, errSSETransportNotBound
+	}
+	if !resume || t.replay == nil {
+		t.writtenThrough = queuedBefore
+		return 0, true, nil
+	}
+
+	events, complete := t.replay.after(lastEventID)
+	t.writtenThrough = lastEventID
+	for _, event := range events {
+		if err := t.writeLocked(formatSSEEvent(event.id, event.event, event.data), 0); err != nil {
+			return replayed, complete, fmt.Errorf("failed to replay SSE message: %w", err)
+		}
+		t.writtenThrough = event.id
+		replayed++
+	}
+	return replayed, complete, nil
+}
+
+// formatSSEEvent renders one SSE frame; id 0 omits the id field.
+func formatSSEEvent(id uint64, event string, dataJSON []byte) string {
+	if id == 0 {
+		return fmt.Sprintf("event: %s\ndata: %s\n\n", event, string(dataJSON))
+	}
+	return fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", id, event, string(dataJSON))
+}
+
 // SendComment writes one SSE comment frame (":" prefixed lines).
 func (t *StreamableHTTPTransport) SendComment(comment string) error {
 	t.mu.Lock()
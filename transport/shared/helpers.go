@@ -13,16 +13,26 @@ import (
 	"text/template"
 
 	"github.com/slighter12/godot-mcp-go/internal/application/toolpipeline"
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
 	"github.com/slighter12/godot-mcp-go/mcp"
 	"github.com/slighter12/godot-mcp-go/mcp/jsonrpc"
 	"github.com/slighter12/godot-mcp-go/promptcatalog"
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
 	"github.com/slighter12/godot-mcp-go/tools"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
 )
 
 const pageSize = 50
 const maxRenderedPromptBytes = 128 * 1024
 const toolExecutionErrorMessage = "Tool execution failed"
 
+// TextResource is a resources/read result returned verbatim under its own
+// MIME type instead of being JSON-encoded.
+type TextResource struct {
+	MimeType string
+	Text     string
+}
+
 type promptsGetParams struct {
 	Name      string            `json:"name"`
 	Arguments map[string]string `json:"arguments,omitempty"`
@@ -45,6 +55,13 @@ type ToolCallContext struct {
 	RuntimeCommandSessionID string
 	SessionInitialized      bool
 	MutatingAllowed         bool
+	AuthRequired            bool
+	AuthScopes              []string
+	SessionRole             string
+	PermissionProfile       *toolspec.PermissionProfile
+	ClientName              string
+	ClientVersion           string
+	AuthTokenID             string
 }
 
 const (
@@ -75,6 +92,10 @@ type ToolCallOptions struct {
 	PermissionMode            string
 	AllowedTools              []string
 	EmitProgressNotifications bool
+	BridgeRoleRequired        bool
+	// PermissionPolicy holds the permission profiles transports select from
+	// at initialize.
+	PermissionPolicy toolspec.PermissionPolicy
 }
 
 func DefaultPromptRenderOptions() PromptRenderOptions {
@@ -128,7 +149,7 @@ func BuildToolsListResponse(msg jsonrpc.Request, tools []mcp.Tool) *jsonrpc.Resp
 }
 
 func BuildResourcesListResponse(msg jsonrpc.Request) *jsonrpc.Response {
-	resources := defaultResources()
+	resources := append(defaultResources(), runtimeLogResources()...)
 	start, err := ParseCursor(msg.Params, len(resources))
 	if err != nil {
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), err.Error(), nil)
@@ -145,6 +166,13 @@ func BuildResourcesListResponse(msg jsonrpc.Request) *jsonrpc.Response {
 }
 
 func BuildResourcesReadResponse(msg jsonrpc.Request, readResource func(string) (any, error)) *jsonrpc.Response {
+	return BuildResourcesReadResponseWithContextAndOptions(msg, readResource, ToolCallContext{}, DefaultToolCallOptions())
+}
+
+// BuildResourcesReadResponseWithContextAndOptions serves resources/read after
+// the same scope, permission mode and profile checks tools/call applies to
+// the equivalent tool.
+func BuildResourcesReadResponseWithContextAndOptions(msg jsonrpc.Request, readResource func(string) (any, error), callContext ToolCallContext, options ToolCallOptions) *jsonrpc.Response {
 	var params struct {
 		URI string `json:"uri"`
 	}
@@ -154,11 +182,25 @@ func BuildResourcesReadResponse(msg jsonrpc.Request, readResource func(string) (
 	if params.URI == "" {
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), "Resource URI is required", nil)
 	}
+	if denied := toolpipeline.ResourceReadDenied(params.URI, pipelineToolCallContext(callContext), pipelineToolCallOptions(options)); denied != nil {
+		return semanticError(msg.ID, jsonrpc.ErrInvalidRequest, denied.Message, denied.Kind, denied.Data)
+	}
 
 	result, err := readResource(params.URI)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), err.Error(), nil)
 	}
+	if text, ok := result.(TextResource); ok {
+		return jsonrpc.NewResponse(msg.ID, map[string]any{
+			"contents": []map[string]any{
+				{
+					"uri":      params.URI,
+					"mimeType": text.MimeType,
+					"text":     text.Text,
+				},
+			},
+		})
+	}
 	resultJSON, err := json.Marshal(result)
 	if err != nil {
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInternalError), "Failed to encode resource result", nil)
@@ -346,19 +388,25 @@ func DispatchStandardMethodWithPromptOptions(msg jsonrpc.Request, toolManager *t
 }
 
 func DispatchStandardMethodWithOptions(msg jsonrpc.Request, toolManager *tools.Manager, catalog *promptcatalog.Registry, readResource func(string) (any, error), promptRenderOptions PromptRenderOptions, toolCallOptions ToolCallOptions) any {
+	return DispatchStandardMethodWithContextAndOptions(msg, toolManager, catalog, readResource, promptRenderOptions, ToolCallContext{}, toolCallOptions)
+}
+
+// DispatchStandardMethodWithContextAndOptions is DispatchStandardMethodWithOptions
+// for transports that track per-session tool call context.
+func DispatchStandardMethodWithContextAndOptions(msg jsonrpc.Request, toolManager *tools.Manager, catalog *promptcatalog.Registry, readResource func(string) (any, error), promptRenderOptions PromptRenderOptions, callContext ToolCallContext, toolCallOptions ToolCallOptions) any {
 	switch msg.Method {
 	case "tools/list":
 		return BuildToolsListResponse(msg, toolManager.GetTools())
 	case "resources/list":
 		return BuildResourcesListResponse(msg)
 	case "resources/read":
-		return BuildResourcesReadResponse(msg, readResource)
+		return BuildResourcesReadResponseWithContextAndOptions(msg, readResource, callContext, toolCallOptions)
 	case "prompts/list":
 		return BuildPromptsListResponse(msg, catalog)
 	case "prompts/get":
 		return BuildPromptsGetResponseWithOptions(msg, catalog, promptRenderOptions)
 	case "tools/call":
-		return BuildToolCallResponseWithContextAndOptions(msg, toolManager, readResource, ToolCallContext{}, toolCallOptions)
+		return BuildToolCallResponseWithContextAndOptions(msg, toolManager, readResource, callContext, toolCallOptions)
 	case "ping":
 		return BuildPingResponse(msg)
 	default:
@@ -743,29 +791,46 @@ func BuildToolCallResponseWithContextAndOptions(msg jsonrpc.Request, toolManager
 		Message:      msg,
 		ToolManager:  toolManager,
 		ReadResource: readResource,
-		Context: toolpipeline.ToolCallContext{
-			SessionID:               callContext.SessionID,
-			RuntimeSessionID:        callContext.RuntimeSessionID,
-			RuntimeCommandSessionID: callContext.RuntimeCommandSessionID,
-			SessionInitialized:      callContext.SessionInitialized,
-			MutatingAllowed:         callContext.MutatingAllowed,
-		},
-		Options: toolpipeline.ToolCallOptions{
-			SchemaValidationEnabled:   options.SchemaValidationEnabled,
-			RejectUnknownArguments:    options.RejectUnknownArguments,
-			PermissionMode:            options.PermissionMode,
-			AllowedTools:              options.AllowedTools,
-			EmitProgressNotifications: options.EmitProgressNotifications,
-		},
+		Context:      pipelineToolCallContext(callContext),
+		Options:      pipelineToolCallOptions(options),
 	})
 }
 
+func pipelineToolCallContext(callContext ToolCallContext) toolpipeline.ToolCallContext {
+	return toolpipeline.ToolCallContext{
+		SessionID:               callContext.SessionID,
+		RuntimeSessionID:        callContext.RuntimeSessionID,
+		RuntimeCommandSessionID: callContext.RuntimeCommandSessionID,
+		SessionInitialized:      callContext.SessionInitialized,
+		MutatingAllowed:         callContext.MutatingAllowed,
+		AuthRequired:            callContext.AuthRequired,
+		AuthScopes:              callContext.AuthScopes,
+		SessionRole:             callContext.SessionRole,
+		PermissionProfile:       callContext.PermissionProfile,
+		ClientName:              callContext.ClientName,
+		ClientVersion:           callContext.ClientVersion,
+		AuthTokenID:             callContext.AuthTokenID,
+	}
+}
+
+func pipelineToolCallOptions(options ToolCallOptions) toolpipeline.ToolCallOptions {
+	return toolpipeline.ToolCallOptions{
+		SchemaValidationEnabled:   options.SchemaValidationEnabled,
+		RejectUnknownArguments:    options.RejectUnknownArguments,
+		PermissionMode:            options.PermissionMode,
+		AllowedTools:              options.AllowedTools,
+		EmitProgressNotifications: options.EmitProgressNotifications,
+		BridgeRoleRequired:        options.BridgeRoleRequired,
+	}
+}
+
 func BuildToolSuccessResult(toolName string, result any) map[string]any {
+	result, images := tooltypes.SplitToolResultImages(result)
 	return map[string]any{
 		"type":              string(mcp.TypeResult),
 		"tool":              toolName,
 		"result":            result,
-		"content":           ToolContentFromResult(result),
+		"content":           append(ToolContentFromResult(result), images...),
 		"structuredContent": result,
 		"isError":           false,
 	}
@@ -779,6 +844,38 @@ func ToolContentFromResult(result any) []map[string]any {
 	return []map[string]any{{"type": "text", "text": string(resultJSON)}}
 }
 
+// SelectPermissionProfile picks the session's permission profile at
+// initialize from clientInfo.name, the auth token id and the profile
+// requested in capabilities.godot.permission_profile.
+func SelectPermissionProfile(policy toolspec.PermissionPolicy, paramsRaw json.RawMessage, authTokenID string) (*toolspec.PermissionProfile, error) {
+	var params struct {
+		ClientInfo struct {
+			Name string `json:"name"`
+		} `json:"clientInfo"`
+	}
+	_ = json.Unmarshal(paramsRaw, &params)
+	requested := RequestedPermissionProfile(paramsRaw)
+	profile, err := policy.Select(strings.TrimSpace(params.ClientInfo.Name), authTokenID, requested)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", err, requested)
+	}
+	return profile, nil
+}
+
+// RequestedPermissionProfile returns the profile named in
+// initialize.params.capabilities.godot.permission_profile.
+func RequestedPermissionProfile(paramsRaw json.RawMessage) string {
+	var params struct {
+		Capabilities struct {
+			Godot struct {
+				PermissionProfile string `json:"permission_profile"`
+			} `json:"godot"`
+		} `json:"capabilities"`
+	}
+	_ = json.Unmarshal(paramsRaw, &params)
+	return strings.TrimSpace(params.Capabilities.Godot.PermissionProfile)
+}
+
 func ServerCapabilities(promptCatalogEnabled bool, promptListChanged bool) map[string]any {
 	capabilities := map[string]any{
 		"tools":     map[string]any{},
@@ -839,6 +936,11 @@ func defaultResources() []map[string]any {
 			"name":     "Godot Policy Checks",
 			"mimeType": "application/json",
 		},
+		{
+			"uri":      "godot://policy/path-sandbox",
+			"name":     "Path Sandbox Rules",
+			"mimeType": "application/json",
+		},
 		{
 			"uri":      "godot://runtime/metrics",
 			"name":     "Runtime Metrics",
@@ -847,6 +949,38 @@ func defaultResources() []map[string]any {
 	}
 }
 
+// runtimeLogResources lists one JSONL export per game session with live or
+// persisted runtime logs.
+func runtimeLogResources() []map[string]any {
+	sessions := runtimebridge.RuntimeLogSessions()
+	resources := make([]map[string]any, 0, len(sessions))
+	for _, sessionID := range sessions {
+		resources = append(resources, map[string]any{
+			"uri":      runtimebridge.RuntimeLogResourceURI(sessionID),
+			"name":     "Runtime Log " + sessionID,
+			"mimeType": "application/x-ndjson",
+		})
+	}
+	return resources
+}
+
+// ReadRuntimeLogResource resolves a godot://runtime/logs/<session_id> export.
+// The boolean is false when uri is not a runtime log resource.
+func ReadRuntimeLogResource(uri string) (any, bool, error) {
+	sessionID, ok := runtimebridge.ParseRuntimeLogResourceURI(uri)
+	if !ok {
+		return nil, false, nil
+	}
+	data, err := runtimebridge.ExportRuntimeLog(sessionID)
+	if err != nil {
+		if errors.Is(err, runtimebridge.ErrRuntimeLogMissing) {
+			return nil, true, fmt.Errorf("runtime log not found for session: %s", sessionID)
+		}
+		return nil, true, fmt.Errorf("failed to export runtime log for session %s: %w", sessionID, err)
+	}
+	return TextResource{MimeType: "application/x-ndjson", Text: string(data)}, true, nil
+}
+
 // ParseJSONRPCFrame validates and parses one JSON-RPC message frame.
 // Both stdio and streamable HTTP currently require a single message per frame.
 func ParseJSONRPCFrame(frame []byte) ([]jsonrpc.Request, []any, bool, error) {
@@ -0,0 +1,86 @@
+package shared
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slighter12/godot-mcp-go/runtimebridge"
+)
+
+func TestRuntimeLogResource_ListedAndReadAsJSONL(t *testing.T) {
+	runtimebridge.ResetDefaultRuntimeLogArchiveForTests(t.TempDir(), runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	t.Cleanup(func() {
+		runtimebridge.ResetDefaultRuntimeLogArchiveForTests("", runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	})
+	runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{
+		{Level: "error", Message: "boom"},
+	}, time.Now().UTC())
+	runtimebridge.DefaultRuntimeLogStore().RemoveSession("game_1")
+
+	listResp := BuildResourcesListResponse(mustRequest(t, "resources/list", map[string]any{}))
+	resources := listResp.Result.(map[string]any)["resources"].([]map[string]any)
+	found := false
+	for _, resource := range resources {
+		if resource["uri"] == "godot://runtime/logs/game_1" {
+			found = resource["mimeType"] == "application/x-ndjson"
+		}
+	}
+	if !found {
+		t.Fatalf("expected runtime log resource in list, got %v", resources)
+	}
+
+	readResource := func(uri string) (any, error) {
+		result, _, err := ReadRuntimeLogResource(uri)
+		return result, err
+	}
+	readResp := BuildResourcesReadResponse(mustRequest(t, "resources/read", map[string]any{"uri": "godot://runtime/logs/game_1"}), readResource)
+	if readResp.Error != nil {
+		t.Fatalf("unexpected error: %+v", readResp.Error)
+	}
+	content := readResp.Result.(map[string]any)["contents"].([]map[string]any)[0]
+	if content["mimeType"] != "application/x-ndjson" || !strings.Contains(content["text"].(string), `"message":"boom"`) {
+		t.Fatalf("unexpected resource content: %v", content)
+	}
+
+	missing := BuildResourcesReadResponse(mustRequest(t, "resources/read", map[string]any{"uri": "godot://runtime/logs/game_2"}), readResource)
+	if missing.Error == nil || !strings.Contains(missing.Error.Message, "runtime log not found") {
+		t.Fatalf("expected missing runtime log error, got %+v", missing)
+	}
+}
+
+func TestRuntimeLogResource_ReadUsesRuntimeLogToolGating(t *testing.T) {
+	runtimebridge.ResetDefaultRuntimeLogArchiveForTests(t.TempDir(), runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	runtimebridge.ResetDefaultRuntimeLogStoreForTests(100)
+	t.Cleanup(func() {
+		runtimebridge.ResetDefaultRuntimeLogArchiveForTests("", runtimebridge.DefaultRuntimeLogMaxFileBytes, runtimebridge.DefaultRuntimeLogMaxFiles)
+	})
+	runtimebridge.DefaultRuntimeLogStore().Append("game_1", []runtimebridge.RuntimeLogAppendEntry{
+		{Level: "error", Message: "boom"},
+	}, time.Now().UTC())
+
+	readResource := func(uri string) (any, error) {
+		result, _, err := ReadRuntimeLogResource(uri)
+		return result, err
+	}
+	request := mustRequest(t, "resources/read", map[string]any{"uri": "godot://runtime/logs/game_1"})
+
+	scoped := BuildResourcesReadResponseWithContextAndOptions(request, readResource, ToolCallContext{AuthRequired: true, AuthScopes: []string{"bridge"}}, DefaultToolCallOptions())
+	if scoped.Error == nil || !strings.Contains(scoped.Error.Message, "not allowed for this credential") {
+		t.Fatalf("expected scope denial, got %+v", scoped)
+	}
+
+	options := DefaultToolCallOptions()
+	options.PermissionMode = ToolPermissionAllowList
+	options.AllowedTools = []string{"godot.project.info"}
+	blocked := BuildResourcesReadResponseWithContextAndOptions(request, readResource, ToolCallContext{}, options)
+	if blocked.Error == nil || !strings.Contains(blocked.Error.Message, "blocked by permission policy") {
+		t.Fatalf("expected permission policy denial, got %+v", blocked)
+	}
+
+	allowed := BuildResourcesReadResponseWithContextAndOptions(request, readResource, ToolCallContext{AuthRequired: true, AuthScopes: []string{"read"}}, DefaultToolCallOptions())
+	if allowed.Error != nil {
+		t.Fatalf("unexpected error: %+v", allowed.Error)
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/slighter12/godot-mcp-go/internal/domain/toolspec"
 	"github.com/slighter12/godot-mcp-go/internal/protocol/mcpv20251125"
 	"github.com/slighter12/godot-mcp-go/logger"
 	"github.com/slighter12/godot-mcp-go/mcp"
@@ -14,6 +16,7 @@ import (
 	"github.com/slighter12/godot-mcp-go/promptcatalog"
 	"github.com/slighter12/godot-mcp-go/runtimebridge"
 	"github.com/slighter12/godot-mcp-go/tools"
+	tooltypes "github.com/slighter12/godot-mcp-go/tools/types"
 	"github.com/slighter12/godot-mcp-go/transport/shared"
 )
 
@@ -23,6 +26,9 @@ type StdioServer struct {
 	promptCatalog       *promptcatalog.Registry
 	promptRenderOptions shared.PromptRenderOptions
 	toolCallOptions     shared.ToolCallOptions
+	permissionProfile   *toolspec.PermissionProfile
+	clientName          string
+	clientVersion       string
 	initializeAccepted  bool
 	initialized         bool
 }
@@ -171,7 +177,12 @@ func (s *StdioServer) handleMessage(msg jsonrpc.Request) (any, error) {
 		if !s.initializeAccepted || !s.initialized {
 			return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidRequest), "Session is not initialized", nil), nil
 		}
-		return shared.DispatchStandardMethodWithOptions(msg, s.toolManager, s.promptCatalog, readGodotResource, s.promptRenderOptions, s.toolCallOptions), nil
+		callContext := shared.ToolCallContext{
+			PermissionProfile: s.permissionProfile,
+			ClientName:        s.clientName,
+			ClientVersion:     s.clientVersion,
+		}
+		return shared.DispatchStandardMethodWithContextAndOptions(msg, s.toolManager, s.promptCatalog, readGodotResource, s.promptRenderOptions, callContext, s.toolCallOptions), nil
 	}
 }
 
@@ -182,6 +193,22 @@ func (s *StdioServer) handleInit(msg jsonrpc.Request) (*jsonrpc.Response, error)
 		}
 		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), mcpv20251125.ErrInvalidProtocolVersion.Error(), nil), nil
 	}
+	profile, err := shared.SelectPermissionProfile(s.toolCallOptions.PermissionPolicy, msg.Params, "")
+	if err != nil {
+		return jsonrpc.NewErrorResponse(msg.ID, int(jsonrpc.ErrInvalidParams), err.Error(), map[string]any{
+			"field": "capabilities.godot.permission_profile",
+		}), nil
+	}
+	var params struct {
+		ClientInfo struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"clientInfo"`
+	}
+	_ = json.Unmarshal(msg.Params, &params)
+	s.clientName = strings.TrimSpace(params.ClientInfo.Name)
+	s.clientVersion = strings.TrimSpace(params.ClientInfo.Version)
+	s.permissionProfile = profile
 	s.initializeAccepted = true
 	s.initialized = false
 
@@ -197,11 +224,17 @@ func (s *StdioServer) handleInit(msg jsonrpc.Request) (*jsonrpc.Response, error)
 			"version": "0.2.0",
 		},
 	}
+	if profile != nil {
+		response["godot"] = map[string]any{"permission_profile": profile.Name}
+	}
 
 	return jsonrpc.NewResponse(msg.ID, response), nil
 }
 
 func readGodotResource(path string) (any, error) {
+	if result, ok, err := shared.ReadRuntimeLogResource(path); ok {
+		return result, err
+	}
 	switch path {
 	case "godot://script/current":
 		return map[string]any{"type": "script", "path": "current"}, nil
@@ -211,6 +244,8 @@ func readGodotResource(path string) (any, error) {
 		return map[string]any{"name": "godot-mcp", "version": "0.2.0", "type": "godot"}, nil
 	case "godot://policy/godot-checks":
 		return map[string]any{"policy": "policy-godot", "checks": promptcatalog.GodotPolicyChecks()}, nil
+	case "godot://policy/path-sandbox":
+		return map[string]any{"policy": "path-sandbox", "rules": tooltypes.ActivePathSandboxRules()}, nil
 	case "godot://runtime/metrics":
 		return runtimebridge.HealthSnapshot(time.Now().UTC()), nil
 	default: